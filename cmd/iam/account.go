@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/namsral/flag"
+	"github.com/pkg/errors"
+
+	pb "github.com/json-multiplex/iam-service/generated/v0"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/service"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+const accountUsage = "usage: iam account export|import"
+
+var importConflicts = map[string]models.ImportConflict{
+	"fail":      models.ImportConflictFail,
+	"skip":      models.ImportConflictSkip,
+	"overwrite": models.ImportConflictOverwrite,
+	"rename":    models.ImportConflictRename,
+}
+
+func runAccount(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(accountUsage)
+	}
+
+	switch args[0] {
+	case "export":
+		return runAccountExport(ctx, args[1:])
+	case "import":
+		return runAccountImport(ctx, args[1:])
+	default:
+		return errors.New(accountUsage)
+	}
+}
+
+func runAccountExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSetWithEnvPrefix("iam account export", "IAM", 0)
+
+	var dbAddr string
+	fs.StringVar(&dbAddr, "db_addr", "", "db connection string")
+
+	var account string
+	fs.StringVar(&account, "account", "", "account to export, as accounts/{account}")
+
+	var format string
+	fs.StringVar(&format, "format", "json", "bundle format: json or proto")
+
+	var out string
+	fs.StringVar(&out, "out", "", "file to write the bundle to; stdout if empty")
+
+	fs.Parse(args)
+
+	accountID := strings.TrimPrefix(account, "accounts/")
+	if accountID == "" || accountID == account {
+		return errors.New("account is required, as accounts/{account}")
+	}
+
+	db, sqlite, err := openDB(dbAddr)
+	if err != nil {
+		return err
+	}
+
+	s := sqlStore(db, sqlite, nil)
+	bundle, err := s.ExportAccount(ctx, store.ExportAccountRequest{AccountID: accountID})
+	if err != nil {
+		return err
+	}
+
+	pbBundle, err := serializeAccountBundle(bundle)
+	if err != nil {
+		return err
+	}
+
+	data, err := marshalAccountBundle(pbBundle, format)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	return ioutil.WriteFile(out, data, 0600)
+}
+
+func runAccountImport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSetWithEnvPrefix("iam account import", "IAM", 0)
+
+	var dbAddr string
+	fs.StringVar(&dbAddr, "db_addr", "", "db connection string")
+
+	var account string
+	fs.StringVar(&account, "account", "", "account to import into, as accounts/{account}; a new account is created if empty")
+
+	var keepID bool
+	fs.BoolVar(&keepID, "keep_id", false, "when creating an account, reuse the bundle's account ID")
+
+	var conflict string
+	fs.StringVar(&conflict, "conflict", "fail", "what to do with users that already exist: fail, skip, overwrite or rename")
+
+	var format string
+	fs.StringVar(&format, "format", "json", "bundle format: json or proto")
+
+	var in string
+	fs.StringVar(&in, "in", "", "file to read the bundle from; stdin if empty")
+
+	fs.Parse(args)
+
+	strategy, ok := importConflicts[conflict]
+	if !ok {
+		return errors.Errorf("invalid conflict strategy: %q", conflict)
+	}
+
+	var data []byte
+	var err error
+	if in == "" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(in)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to read bundle")
+	}
+
+	pbBundle, err := unmarshalAccountBundle(data, format)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := deserializeAccountBundle(pbBundle)
+	if err != nil {
+		return errors.Wrap(err, "invalid bundle")
+	}
+
+	if err := service.ValidateAccountBundle(bundle); err != nil {
+		return err
+	}
+
+	req := store.ImportAccountRequest{
+		Bundle:   bundle,
+		Conflict: strategy,
+	}
+
+	if account == "" {
+		req.CreateAccount = true
+		if keepID {
+			req.AccountID = strings.TrimPrefix(bundle.Account.Name, "accounts/")
+		}
+	} else {
+		req.AccountID = strings.TrimPrefix(account, "accounts/")
+		if req.AccountID == "" || req.AccountID == account {
+			return errors.New("account must be of the form accounts/{account}")
+		}
+	}
+
+	db, sqlite, err := openDB(dbAddr)
+	if err != nil {
+		return err
+	}
+
+	s := sqlStore(db, sqlite, nil)
+	res, err := s.ImportAccount(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "imported into %s\n", res.Account.Name)
+	for _, name := range res.Created {
+		fmt.Fprintf(os.Stdout, "created %s\n", name)
+	}
+
+	for _, name := range res.Overwritten {
+		fmt.Fprintf(os.Stdout, "overwrote %s\n", name)
+	}
+
+	for _, name := range res.Skipped {
+		fmt.Fprintf(os.Stdout, "skipped %s\n", name)
+	}
+
+	for from, to := range res.Renamed {
+		fmt.Fprintf(os.Stdout, "renamed %s to %s\n", from, to)
+	}
+
+	return nil
+}
+
+func marshalAccountBundle(bundle *pb.AccountBundle, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		var buf bytes.Buffer
+		m := jsonpb.Marshaler{Indent: "  "}
+		if err := m.Marshal(&buf, bundle); err != nil {
+			return nil, err
+		}
+
+		buf.WriteString("\n")
+		return buf.Bytes(), nil
+	case "proto":
+		return proto.Marshal(bundle)
+	default:
+		return nil, errors.Errorf("invalid format: %q", format)
+	}
+}
+
+func unmarshalAccountBundle(data []byte, format string) (*pb.AccountBundle, error) {
+	var bundle pb.AccountBundle
+	switch format {
+	case "json":
+		if err := jsonpb.Unmarshal(bytes.NewReader(data), &bundle); err != nil {
+			return nil, errors.Wrap(err, "failed to parse bundle")
+		}
+	case "proto":
+		if err := proto.Unmarshal(data, &bundle); err != nil {
+			return nil, errors.Wrap(err, "failed to parse bundle")
+		}
+	default:
+		return nil, errors.Errorf("invalid format: %q", format)
+	}
+
+	return &bundle, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/namsral/flag"
+	"github.com/pkg/errors"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+)
+
+func runAudit(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "verify" {
+		return errors.New("usage: iam audit verify")
+	}
+
+	fs := flag.NewFlagSetWithEnvPrefix("iam audit verify", "IAM", 0)
+
+	var dbAddr string
+	fs.StringVar(&dbAddr, "db_addr", "", "db connection string")
+
+	var tokenVerifyKeyPEM string
+	fs.StringVar(&tokenVerifyKeyPEM, "token_verify_key", "", "PEM-encoded key for verifying tokens")
+
+	fs.Parse(args[1:])
+
+	db, sqlite, err := openDB(dbAddr)
+	if err != nil {
+		return err
+	}
+
+	tokenVerifyKey, err := parseTokenVerifyKey(tokenVerifyKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	if tokenVerifyKey == nil {
+		return errors.New("token_verify_key is required to verify audit checkpoints")
+	}
+
+	verifier := audit.Verifier{
+		Store:     sqlStore(db, sqlite, nil),
+		VerifyKey: tokenVerifyKey,
+	}
+
+	breaks, err := verifier.Verify(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range breaks {
+		fmt.Fprintln(os.Stdout, b)
+	}
+
+	if len(breaks) > 0 {
+		return errors.Errorf("audit log verification failed with %d breaks", len(breaks))
+	}
+
+	fmt.Fprintln(os.Stdout, "audit log verified")
+	return nil
+}
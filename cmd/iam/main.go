@@ -5,23 +5,40 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"log"
+	"math"
 	"net"
 	"net/http"
+	"net/smtp"
 	"os"
+	"runtime/debug"
+	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/namsral/flag"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/json-multiplex/iam-service/generated/v0"
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/metrics"
+	"github.com/json-multiplex/iam-service/internal/notify"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+	"github.com/json-multiplex/iam-service/internal/purge"
+	"github.com/json-multiplex/iam-service/internal/ratelimit"
 	"github.com/json-multiplex/iam-service/internal/service"
 	"github.com/json-multiplex/iam-service/internal/store"
+	"github.com/json-multiplex/iam-service/internal/webhook"
 )
 
 func main() {
@@ -29,18 +46,29 @@ func main() {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	if err := run(ctx); err != nil {
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "audit" {
+		err = runAudit(ctx, os.Args[2:])
+	} else if len(os.Args) > 1 && os.Args[1] == "account" {
+		err = runAccount(ctx, os.Args[2:])
+	} else if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = runMigrate(ctx, os.Args[2:])
+	} else {
+		err = run(ctx)
+	}
+
+	if err != nil {
 		log.Fatal(err)
 	}
 }
 
 func run(ctx context.Context) error {
-	srv, err := newServer()
+	srv, err := newServer(ctx)
 	if err != nil {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(chainUnary(metrics.Unary, recoverPanic, readSession, srv.RateLimit.Unary)))
 	pb.RegisterIAMServer(grpcServer, &srv)
 	reflection.Register(grpcServer)
 
@@ -51,18 +79,109 @@ func run(ctx context.Context) error {
 
 	go grpcServer.Serve(l)
 
-	mux := runtime.NewServeMux()
+	if srv.Checkpointer.SignKey != nil {
+		go srv.Checkpointer.Run(ctx, srv.CheckpointInterval)
+	}
+
+	go srv.Dispatcher.Run(ctx, srv.WebhookPollInterval)
+
+	if srv.PurgeInterval > 0 {
+		go srv.Purger.Run(ctx, srv.PurgeInterval)
+	}
+
+	if srv.Cache != nil && srv.CacheListenAddr != "" {
+		go func() {
+			if err := srv.Cache.Listen(ctx, srv.CacheListenAddr); err != nil && ctx.Err() == nil {
+				log.Printf("error listening for cache invalidations: %v", err)
+			}
+		}()
+	}
+
+	if srv.Cache != nil {
+		metrics.RegisterCache("store", srv.Cache.Stats)
+	}
+
+	if srv.Service.TokenCache != nil {
+		metrics.RegisterCache("token", srv.Service.TokenCache.Stats)
+	}
+
+	gateway := runtime.NewServeMux(runtime.WithForwardResponseOption(setETag))
 	opts := []grpc.DialOption{grpc.WithInsecure()}
-	pb.RegisterIAMHandlerFromEndpoint(ctx, mux, ":3000", opts)
+	pb.RegisterIAMHandlerFromEndpoint(ctx, gateway, ":3000", opts)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/", gateway)
 
 	return http.ListenAndServe(":4000", mux)
 }
 
-func newServer() (server, error) {
+// chainUnary combines interceptors into one that runs them in order, since a
+// grpc server takes a single unary interceptor.
+func chainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// recoverPanic turns a panic in a handler or a later interceptor into an
+// Internal error, so that one bad request can't take down the server.
+func recoverPanic(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// readSession lets each request's reads go to the database's read replica
+// until the request writes to the primary.
+func readSession(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(store.WithReadSession(ctx), req)
+}
+
+// setETag copies the etag of a resource returned through the gateway into the
+// ETag header, so that HTTP clients can send it back in If-Match.
+func setETag(ctx context.Context, w http.ResponseWriter, msg proto.Message) error {
+	if resource, ok := msg.(interface{ GetEtag() string }); ok && resource.GetEtag() != "" {
+		w.Header().Set("ETag", fmt.Sprintf("%q", resource.GetEtag()))
+	}
+
+	return nil
+}
+
+func newServer(ctx context.Context) (server, error) {
 	fs := flag.NewFlagSetWithEnvPrefix(os.Args[0], "IAM", 0)
 
-	var dbAddr string
-	fs.StringVar(&dbAddr, "db_addr", "", "db connection string")
+	var dbConf dbConfig
+	fs.StringVar(&dbConf.Addr, "db_addr", "", "db connection string: Postgres, sqlite:PATH for a SQLite database file, or memory: for tests and local development")
+	fs.StringVar(&dbConf.ReplicaAddr, "db_replica_addr", "", "optional Postgres read replica for user lookups, password checks and listing")
+	fs.IntVar(&dbConf.MaxOpenConns, "db_max_open_conns", 0, "maximum open connections to each database, or 0 for no limit")
+	fs.IntVar(&dbConf.MaxIdleConns, "db_max_idle_conns", 2, "maximum idle connections kept open to each database")
+	fs.DurationVar(&dbConf.ConnMaxLifetime, "db_conn_max_lifetime", 0, "how long a connection is reused before being closed, or 0 for no limit")
+	fs.DurationVar(&dbConf.ConnectTimeout, "db_connect_timeout", 30*time.Second, "how long to retry connecting to the database at startup")
+
+	var cacheTTL time.Duration
+	fs.DurationVar(&cacheTTL, "cache_ttl", 30*time.Second, "how long users and identities are cached for, or 0 to disable the cache")
+
+	var cacheSize int
+	fs.IntVar(&cacheSize, "cache_size", 10000, "maximum users and identity lists cached")
+
+	var tokenCacheSize int
+	fs.IntVar(&tokenCacheSize, "token_cache_size", 10000, "maximum verified tokens cached, or 0 to disable the token cache")
+
+	var autoMigrate bool
+	fs.BoolVar(&autoMigrate, "auto_migrate", false, "apply pending database migrations at startup")
 
 	var tokenSignKeyPEM string
 	fs.StringVar(&tokenSignKeyPEM, "token_sign_key", "", "PEM-encoded key for signing tokens")
@@ -70,44 +189,438 @@ func newServer() (server, error) {
 	var tokenVerifyKeyPEM string
 	fs.StringVar(&tokenVerifyKeyPEM, "token_verify_key", "", "PEM-encoded key for verifying tokens")
 
+	var checkpointInterval time.Duration
+	fs.DurationVar(&checkpointInterval, "audit_checkpoint_interval", time.Hour, "how often to sign a checkpoint of the audit log")
+
+	var webhookPollInterval time.Duration
+	fs.DurationVar(&webhookPollInterval, "webhook_poll_interval", 5*time.Second, "how often to poll for pending webhook deliveries")
+
+	var webhookMaxAttempts int
+	fs.IntVar(&webhookMaxAttempts, "webhook_max_attempts", 10, "attempts before a webhook delivery is dead-lettered")
+
+	var lockout service.LockoutPolicy
+	fs.IntVar(&lockout.MaxUserFailures, "lockout_max_user_failures", 5, "failed logins before a user is locked out")
+	fs.IntVar(&lockout.MaxIPFailures, "lockout_max_ip_failures", 50, "failed logins before a source ip is locked out")
+	fs.DurationVar(&lockout.FailureWindow, "lockout_failure_window", 15*time.Minute, "how long failed logins count towards a lockout")
+	fs.DurationVar(&lockout.LockoutDuration, "lockout_duration", time.Minute, "how long the first lockout lasts")
+	fs.DurationVar(&lockout.MaxLockoutDuration, "lockout_max_duration", time.Hour, "upper bound on lockout duration")
+
+	var rateLimit ratelimit.Limit
+	fs.Float64Var(&rateLimit.Rate, "rate_limit", 10, "requests per second allowed per method for each client ip and principal, or 0 for no limit")
+	fs.IntVar(&rateLimit.Burst, "rate_limit_burst", 20, "requests allowed in a burst above rate_limit")
+
+	var rateLimitMethods string
+	fs.StringVar(&rateLimitMethods, "rate_limit_methods", "Authenticate=1:5,CreateAccount=0.1:3,RequestPasswordReset=0.1:3", "per-method overrides of rate_limit, as method=rate:burst pairs")
+
+	var notifierKind string
+	fs.StringVar(&notifierKind, "notifier", "log", "how to send emails to users: log, file or smtp")
+
+	var notifierFile string
+	fs.StringVar(&notifierFile, "notifier_file", "", "file to append emails to when notifier is file")
+
+	var smtpAddr, smtpUsername, smtpPassword, smtpFrom string
+	fs.StringVar(&smtpAddr, "smtp_addr", "", "smtp server host:port")
+	fs.StringVar(&smtpUsername, "smtp_username", "", "smtp username")
+	fs.StringVar(&smtpPassword, "smtp_password", "", "smtp password")
+	fs.StringVar(&smtpFrom, "smtp_from", "", "from address for emails")
+
+	var userTokens service.UserTokenConfig
+	fs.DurationVar(&userTokens.PasswordResetTTL, "password_reset_ttl", time.Hour, "how long password reset tokens are valid")
+	fs.DurationVar(&userTokens.EmailVerificationTTL, "email_verification_ttl", 48*time.Hour, "how long email verification tokens are valid")
+	fs.StringVar(&userTokens.PasswordResetURL, "password_reset_url", "", "password reset link sent to users, with {token} in place of the token")
+	fs.StringVar(&userTokens.EmailVerificationURL, "email_verification_url", "", "email verification link sent to users, with {token} in place of the token")
+
+	var invitations service.InvitationConfig
+	fs.DurationVar(&invitations.TTL, "invitation_ttl", 7*24*time.Hour, "how long invitations are valid by default")
+	fs.StringVar(&invitations.URL, "invitation_url", "", "invitation link sent to invitees, with {code} in place of the acceptance code")
+
+	var deletionRetention time.Duration
+	fs.DurationVar(&deletionRetention, "deletion_retention", 30*24*time.Hour, "how long deleted accounts and users can be undeleted before they are purged")
+
+	var purgeInterval time.Duration
+	fs.DurationVar(&purgeInterval, "purge_interval", time.Hour, "how often to purge deleted resources past deletion_retention, or 0 to never purge")
+
+	var tokenClaims string
+	fs.StringVar(&tokenClaims, "token_claims", "", "comma-separated profile attributes to include in tokens: email, phone, locale, labels")
+
+	var hashAlgorithm string
+	fs.StringVar(&hashAlgorithm, "password_hash_algorithm", "bcrypt", "algorithm for new password hashes: bcrypt, scrypt or argon2id")
+
+	bcryptParams := passhash.DefaultBcrypt
+	fs.IntVar(&bcryptParams.Cost, "bcrypt_cost", bcryptParams.Cost, "bcrypt cost")
+
+	scryptParams := passhash.DefaultScrypt
+	fs.IntVar(&scryptParams.N, "scrypt_n", scryptParams.N, "scrypt CPU/memory cost, a power of two")
+	fs.IntVar(&scryptParams.R, "scrypt_r", scryptParams.R, "scrypt block size")
+	fs.IntVar(&scryptParams.P, "scrypt_p", scryptParams.P, "scrypt parallelism")
+
+	argon2Params := passhash.DefaultArgon2id
+	var argon2Time, argon2Memory, argon2Threads int
+	fs.IntVar(&argon2Time, "argon2_time", int(argon2Params.Time), "argon2id iterations")
+	fs.IntVar(&argon2Memory, "argon2_memory", int(argon2Params.Memory), "argon2id memory in KiB")
+	fs.IntVar(&argon2Threads, "argon2_threads", int(argon2Params.Threads), "argon2id parallelism")
+
 	fs.Parse(os.Args[1:])
 
-	db, err := sqlx.Open("postgres", dbAddr)
+	// passhash.New checks the argon2id parameters, but only after they're
+	// narrowed, so reject values that would wrap around first.
+	if argon2Time < 0 || int64(argon2Time) > math.MaxUint32 ||
+		argon2Memory < 0 || int64(argon2Memory) > math.MaxUint32 ||
+		argon2Threads < 0 || argon2Threads > math.MaxUint8 {
+		return server{}, fmt.Errorf("argon2id parameters out of range: time %d, memory %d, threads %d", argon2Time, argon2Memory, argon2Threads)
+	}
+
+	argon2Params.Time = uint32(argon2Time)
+	argon2Params.Memory = uint32(argon2Memory)
+	argon2Params.Threads = uint8(argon2Threads)
+
+	notifier, err := newNotifier(notifierKind, notifierFile, smtpAddr, smtpUsername, smtpPassword, smtpFrom)
 	if err != nil {
-		return server{}, errors.Wrap(err, "failed to open database connection")
+		return server{}, err
 	}
 
-	var tokenSignKey *rsa.PrivateKey
-	var tokenVerifyKey *rsa.PublicKey
+	hashers, err := passhash.New(hashAlgorithm, bcryptParams, scryptParams, argon2Params)
+	if err != nil {
+		return server{}, err
+	}
 
-	if block, _ := pem.Decode([]byte(tokenSignKeyPEM)); block != nil {
-		tokenSignKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
-		if err != nil {
-			return server{}, errors.Wrap(err, "error parsing token sign key")
-		}
+	hasher := metrics.Hasher{Hasher: hashers}
+
+	profileClaims, err := parseTokenClaims(tokenClaims)
+	if err != nil {
+		return server{}, err
 	}
 
-	if block, _ := pem.Decode([]byte(tokenVerifyKeyPEM)); block != nil {
-		key, err := x509.ParsePKIXPublicKey(block.Bytes)
-		if err != nil {
-			return server{}, errors.Wrap(err, "error parsing token verify key")
-		}
+	methodLimits, err := ratelimit.ParseMethods("iam.IAM", rateLimitMethods)
+	if err != nil {
+		return server{}, err
+	}
+
+	st, err := newStore(ctx, dbConf, autoMigrate, hasher)
+	if err != nil {
+		return server{}, err
+	}
+
+	// Other servers sharing the database can only be told about changes
+	// through Postgres.
+	var cacheDB *sqlx.DB
+	if db, ok := st.(*store.DBStore); ok {
+		cacheDB = db.DB
+	}
 
-		var ok bool
-		tokenVerifyKey, ok = key.(*rsa.PublicKey)
-		if !ok {
-			return server{}, errors.New("verify key must be RSA")
+	st = instrumentStore(st)
+
+	var cache *store.CachingStore
+	var cacheListenAddr string
+	if cacheTTL > 0 {
+		cache = store.NewCachingStore(st, cacheTTL, cacheSize)
+		if cacheDB != nil {
+			cache.DB = cacheDB
+			cacheListenAddr = dbConf.Addr
 		}
+
+		st = cache
+	}
+
+	var tokenCache *service.TokenCache
+	if tokenCacheSize > 0 {
+		tokenCache = service.NewTokenCache(tokenCacheSize)
+	}
+
+	tokenSignKey, err := parseTokenSignKey(tokenSignKeyPEM)
+	if err != nil {
+		return server{}, err
+	}
+
+	tokenVerifyKey, err := parseTokenVerifyKey(tokenVerifyKeyPEM)
+	if err != nil {
+		return server{}, err
+	}
+
+	svc := service.Service{
+		Store: st,
+		Audit: audit.Logger{
+			Store: st,
+		},
+		TokenSignKey:          tokenSignKey,
+		TokenVerifyKey:        tokenVerifyKey,
+		TokenExpirationPeriod: 24 * time.Hour,
+		Lockout:               lockout,
+		Notifier:              notifier,
+		UserTokens:            userTokens,
+		Invitations:           invitations,
+		TokenClaims:           profileClaims,
+		DeletionRetention:     deletionRetention,
+		TokenCache:            tokenCache,
+		OnAuthenticate:        metrics.Authenticated,
 	}
 
 	return server{
-		Service: service.Service{
-			Store: &store.DBStore{
-				DB: db,
+		Service: svc,
+		Checkpointer: audit.Checkpointer{
+			Store:   st,
+			SignKey: tokenSignKey,
+		},
+		CheckpointInterval: checkpointInterval,
+		Dispatcher: webhook.Dispatcher{
+			Store:       st,
+			Client:      &http.Client{Timeout: 10 * time.Second},
+			MaxAttempts: webhookMaxAttempts,
+			BatchSize:   100,
+		},
+		WebhookPollInterval: webhookPollInterval,
+		Purger: purge.Purger{
+			Store:     st,
+			Retention: deletionRetention,
+		},
+		PurgeInterval: purgeInterval,
+		RateLimit: ratelimit.Interceptor{
+			Limiter: &ratelimit.MemoryLimiter{},
+			Config: ratelimit.Config{
+				Default: rateLimit,
+				Methods: methodLimits,
+			},
+			Principal: func(ctx context.Context) string {
+				return svc.Principal(getToken(ctx))
 			},
-			TokenSignKey:          tokenSignKey,
-			TokenVerifyKey:        tokenVerifyKey,
-			TokenExpirationPeriod: 24 * time.Hour,
 		},
+		Cache:           cache,
+		CacheListenAddr: cacheListenAddr,
 	}, nil
 }
+
+type dbConfig struct {
+	Addr            string
+	ReplicaAddr     string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnectTimeout  time.Duration
+}
+
+func newStore(ctx context.Context, conf dbConfig, autoMigrate bool, hasher passhash.Hasher) (store.Store, error) {
+	if conf.Addr == memoryScheme {
+		return store.NewMemoryStore(hasher), nil
+	}
+
+	db, sqlite, err := connectDB(ctx, conf, conf.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if autoMigrate {
+		migrator, err := newMigrator(db.DB, sqlite)
+		if err != nil {
+			return nil, err
+		}
+
+		applied, err := migrator.Up(ctx)
+		printMigrations("applied", applied)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to migrate database")
+		}
+	}
+
+	if conf.ReplicaAddr == "" {
+		return sqlStore(db, sqlite, hasher), nil
+	}
+
+	if sqlite || strings.HasPrefix(conf.ReplicaAddr, sqliteScheme) {
+		return nil, errors.New("db_replica_addr is only supported with Postgres")
+	}
+
+	replica, _, err := connectDB(ctx, conf, conf.ReplicaAddr)
+	if err != nil {
+		return nil, errors.Wrap(err, "read replica")
+	}
+
+	return &store.DBStore{DB: db, Replica: replica, Hasher: hasher}, nil
+}
+
+// instrumentStore times the calls to a database store and exports its
+// connection pools' stats. The memory store is returned as is.
+func instrumentStore(st store.Store) store.Store {
+	switch st := st.(type) {
+	case *store.DBStore:
+		metrics.RegisterDB("primary", st.DB.DB)
+		if st.Replica != nil {
+			metrics.RegisterDB("replica", st.Replica.DB)
+		}
+	case *store.SQLiteStore:
+		metrics.RegisterDB("primary", st.DB.DB)
+	default:
+		return st
+	}
+
+	return &metrics.Store{Store: st}
+}
+
+// connectDB opens the database at addr with the configured pool settings and
+// waits for it to accept connections.
+func connectDB(ctx context.Context, conf dbConfig, addr string) (*sqlx.DB, bool, error) {
+	db, sqlite, err := openDB(addr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	db.SetMaxOpenConns(conf.MaxOpenConns)
+	db.SetMaxIdleConns(conf.MaxIdleConns)
+	db.SetConnMaxLifetime(conf.ConnMaxLifetime)
+
+	if err := pingDB(ctx, db, conf.ConnectTimeout); err != nil {
+		db.Close()
+		return nil, false, err
+	}
+
+	return db, sqlite, nil
+}
+
+// pingDB retries connecting to db with exponential backoff until it succeeds
+// or timeout passes, so that the server can start before its database is up.
+func pingDB(ctx context.Context, db *sqlx.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	delay := 100 * time.Millisecond
+	for {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		log.Printf("waiting for database: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(err, "failed to connect to database")
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > 5*time.Second {
+			delay = 5 * time.Second
+		}
+	}
+}
+
+const (
+	sqliteScheme = "sqlite:"
+	memoryScheme = "memory:"
+)
+
+// openDB opens the database in a db_addr flag, which is a SQLite database if
+// it has the sqlite: scheme and Postgres otherwise.
+func openDB(dbAddr string) (*sqlx.DB, bool, error) {
+	if strings.HasPrefix(dbAddr, sqliteScheme) {
+		db, err := sqlx.Open("sqlite3", sqliteDSN(strings.TrimPrefix(dbAddr, sqliteScheme)))
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to open database connection")
+		}
+
+		return db, true, nil
+	}
+
+	db, err := sqlx.Open("postgres", dbAddr)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to open database connection")
+	}
+
+	return db, false, nil
+}
+
+// sqliteDSN returns the go-sqlite3 DSN for a database file. SQLiteStore
+// relies on foreign keys being enforced and on transactions taking the write
+// lock when they begin, so that they never fail to upgrade a read lock.
+// Connections wait for the lock rather than failing immediately, and WAL mode
+// lets reads continue while a write is in progress.
+func sqliteDSN(path string) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+
+	return "file:" + path + sep + "_foreign_keys=1&_busy_timeout=5000&_txlock=immediate&_journal_mode=WAL"
+}
+
+func sqlStore(db *sqlx.DB, sqlite bool, hasher passhash.Hasher) store.Store {
+	if sqlite {
+		return &store.SQLiteStore{DB: db, Hasher: hasher}
+	}
+
+	return &store.DBStore{DB: db, Hasher: hasher}
+}
+
+func newNotifier(kind, file, smtpAddr, smtpUsername, smtpPassword, smtpFrom string) (notify.Notifier, error) {
+	switch kind {
+	case "log":
+		return notify.Log{}, nil
+	case "file":
+		if file == "" {
+			return nil, errors.New("notifier_file is required for the file notifier")
+		}
+
+		return &notify.File{Path: file}, nil
+	case "smtp":
+		host, _, err := net.SplitHostPort(smtpAddr)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid smtp_addr")
+		}
+
+		var auth smtp.Auth
+		if smtpUsername != "" {
+			auth = smtp.PlainAuth("", smtpUsername, smtpPassword, host)
+		}
+
+		return &notify.SMTP{Addr: smtpAddr, From: smtpFrom, Auth: auth}, nil
+	}
+
+	return nil, fmt.Errorf("unknown notifier: %s", kind)
+}
+
+func parseTokenSignKey(keyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, nil
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing token sign key")
+	}
+
+	return key, nil
+}
+
+func parseTokenVerifyKey(keyPEM string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing token verify key")
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("verify key must be RSA")
+	}
+
+	return rsaKey, nil
+}
+
+func parseTokenClaims(s string) ([]string, error) {
+	var claims []string
+	for _, claim := range strings.Split(s, ",") {
+		claim = strings.TrimSpace(claim)
+		switch claim {
+		case "":
+		case service.TokenClaimEmail, service.TokenClaimPhone, service.TokenClaimLocale, service.TokenClaimLabels:
+			claims = append(claims, claim)
+		default:
+			return nil, fmt.Errorf("unknown token claim: %q", claim)
+		}
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization []string
+		want          string
+	}{
+		{name: "none", want: ""},
+		{name: "bearer", authorization: []string{"Bearer abc.def"}, want: "abc.def"},
+		{name: "no space", authorization: []string{"Bearer"}, want: ""},
+		{name: "empty", authorization: []string{""}, want: ""},
+		{name: "other scheme", authorization: []string{"Basic dXNlcjpwYXNz"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{"authorization": tt.authorization})
+			}
+
+			if got := getToken(ctx); got != tt.want {
+				t.Errorf("getToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChainUnary(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		handler  grpc.UnaryHandler
+		wantCode codes.Code
+	}{
+		{
+			name:     "ok",
+			handler:  func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil },
+			wantCode: codes.OK,
+		},
+		{
+			name:     "panic",
+			handler:  func(ctx context.Context, req interface{}) (interface{}, error) { panic("boom") },
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			chain := chainUnary(interceptor("a"), recoverPanic, interceptor("b"))
+
+			_, err := chain(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/iam.IAM/Test"}, tt.handler)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("got %v, want %v", got, tt.wantCode)
+			}
+
+			if len(calls) != 2 || calls[0] != "a" || calls[1] != "b" {
+				t.Errorf("interceptors ran as %v, want [a b]", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/namsral/flag"
+	"github.com/pkg/errors"
+
+	"github.com/json-multiplex/iam-service/internal/migrate"
+	"github.com/json-multiplex/iam-service/migrations"
+	sqlitemigrations "github.com/json-multiplex/iam-service/migrations/sqlite"
+)
+
+const migrateUsage = "usage: iam migrate up|down|status|to VERSION"
+
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	command, args := args[0], args[1:]
+
+	var version int64
+	switch command {
+	case "up", "down", "status":
+	case "to":
+		if len(args) == 0 {
+			return errors.New(migrateUsage)
+		}
+
+		v, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return errors.Errorf("invalid migration version: %s", args[0])
+		}
+
+		version, args = v, args[1:]
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	fs := flag.NewFlagSetWithEnvPrefix("iam migrate "+command, "IAM", 0)
+
+	var dbAddr string
+	fs.StringVar(&dbAddr, "db_addr", "", "db connection string")
+
+	fs.Parse(args)
+
+	db, sqlite, err := openDB(dbAddr)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	migrator, err := newMigrator(db.DB, sqlite)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations("applied", applied)
+		return err
+	case "down":
+		migration, err := migrator.Down(ctx)
+		if migration != nil {
+			printMigrations("rolled back", []migrate.Migration{*migration})
+		}
+
+		return err
+	case "to":
+		done, err := migrator.To(ctx, version)
+		for _, migration := range done {
+			if migration.Version > version {
+				printMigrations("rolled back", []migrate.Migration{migration})
+			} else {
+				printMigrations("applied", []migrate.Migration{migration})
+			}
+		}
+
+		return err
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for _, s := range statuses {
+		state := "pending"
+		if s.ApplyTime != nil {
+			state = fmt.Sprintf("applied %s", s.ApplyTime.Format(time.RFC3339))
+		}
+
+		name := s.Name
+		if name == "" {
+			name = "(unknown)"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, name, state)
+	}
+
+	return w.Flush()
+}
+
+// newMigrator returns a Migrator with the Postgres migrations, or the SQLite
+// ones if sqlite is set.
+func newMigrator(db *sql.DB, sqlite bool) (*migrate.Migrator, error) {
+	fsys := migrations.FS
+	if sqlite {
+		fsys = sqlitemigrations.FS
+	}
+
+	all, err := migrate.Load(fsys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load migrations")
+	}
+
+	return &migrate.Migrator{DB: db, Migrations: all, SQLite: sqlite}, nil
+}
+
+func printMigrations(verb string, done []migrate.Migration) {
+	for _, migration := range done {
+		fmt.Fprintf(os.Stdout, "%s %d_%s\n", verb, migration.Version, migration.Name)
+	}
+}
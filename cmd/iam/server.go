@@ -3,26 +3,54 @@ package main
 import (
 	"context"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
 
 	"github.com/golang/protobuf/ptypes"
 	pb "github.com/json-multiplex/iam-service/generated/v0"
+	"github.com/json-multiplex/iam-service/internal/audit"
 	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/purge"
+	"github.com/json-multiplex/iam-service/internal/ratelimit"
 	"github.com/json-multiplex/iam-service/internal/service"
+	"github.com/json-multiplex/iam-service/internal/store"
+	"github.com/json-multiplex/iam-service/internal/webhook"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type server struct {
-	Service service.Service
+	Service            service.Service
+	Checkpointer       audit.Checkpointer
+	CheckpointInterval time.Duration
+
+	Dispatcher          webhook.Dispatcher
+	WebhookPollInterval time.Duration
+
+	Purger        purge.Purger
+	PurgeInterval time.Duration
+
+	RateLimit ratelimit.Interceptor
+
+	// Cache is the store's cache, if enabled. CacheListenAddr, if set, is
+	// the Postgres database to listen on for invalidations from other
+	// servers.
+	Cache           *store.CachingStore
+	CacheListenAddr string
 }
 
 func (s *server) Authenticate(ctx context.Context, req *pb.AuthenticateRequest) (*pb.AuthenticateResponse, error) {
 	res, err := s.Service.Authenticate(ctx, service.AuthenticateRequest{
-		Account:  req.Account,
-		User:     req.User,
-		Password: req.Password,
+		Account:   req.Account,
+		User:      req.User,
+		Principal: req.Principal,
+		Password:  req.Password,
 	})
 
 	if err != nil {
@@ -34,8 +62,88 @@ func (s *server) Authenticate(ctx context.Context, req *pb.AuthenticateRequest)
 	}, nil
 }
 
+func (s *server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*empty.Empty, error) {
+	if err := s.Service.RequestPasswordReset(ctx, service.RequestPasswordResetRequest{
+		Account: req.Account,
+		User:    req.User,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *server) ConfirmPasswordReset(ctx context.Context, req *pb.ConfirmPasswordResetRequest) (*empty.Empty, error) {
+	if err := s.Service.ConfirmPasswordReset(ctx, service.ConfirmPasswordResetRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*empty.Empty, error) {
+	if err := s.Service.VerifyEmail(ctx, service.VerifyEmailRequest{
+		Token: req.Token,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *server) AcceptInvitation(ctx context.Context, req *pb.AcceptInvitationRequest) (*pb.User, error) {
+	resultUser, err := s.Service.AcceptInvitation(ctx, service.AcceptInvitationRequest{
+		Code:     req.Code,
+		Password: req.Password,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeUser(resultUser)
+}
+
 func (s *server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.Account, error) {
-	return nil, nil
+	resultAccount, err := s.Service.GetAccount(ctx, service.GetAccountRequest{
+		Token:       getToken(ctx),
+		Name:        req.Name,
+		ShowDeleted: req.ShowDeleted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeAccount(resultAccount)
+}
+
+func (s *server) ListMyAccounts(ctx context.Context, req *pb.ListMyAccountsRequest) (*pb.ListMyAccountsResponse, error) {
+	res, err := s.Service.ListMyAccounts(ctx, service.ListMyAccountsRequest{
+		Token: getToken(ctx),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var memberships []*pb.AccountMembership
+	for _, membership := range res.Memberships {
+		account, err := serializeAccount(membership.Account)
+		if err != nil {
+			return nil, err
+		}
+
+		memberships = append(memberships, &pb.AccountMembership{
+			Account: account,
+			User:    membership.User,
+		})
+	}
+
+	return &pb.ListMyAccountsResponse{Memberships: memberships}, nil
 }
 
 func (s *server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.Account, error) {
@@ -56,19 +164,28 @@ func (s *server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest
 }
 
 func (s *server) UpdateAccount(ctx context.Context, req *pb.UpdateAccountRequest) (*pb.Account, error) {
-	return nil, nil
-}
+	if req.Account == nil {
+		return nil, status.Error(codes.InvalidArgument, "account is required")
+	}
 
-func (s *server) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*empty.Empty, error) {
-	return nil, nil
-}
+	inAccount := deserializeAccount(req.Account)
+	inAccount.Etag = ifMatch(ctx, inAccount.Etag)
 
-func (s *server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
-	return nil, nil
+	resultAccount, err := s.Service.UpdateAccount(ctx, service.UpdateAccountRequest{
+		Token:      getToken(ctx),
+		Account:    inAccount,
+		UpdateMask: updateMaskPaths(req.UpdateMask),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeAccount(resultAccount)
 }
 
-func (s *server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
-	resultUser, err := s.Service.GetUser(ctx, service.GetUserRequest{
+func (s *server) GetPasswordPolicy(ctx context.Context, req *pb.GetPasswordPolicyRequest) (*pb.PasswordPolicy, error) {
+	resultPolicy, err := s.Service.GetPasswordPolicy(ctx, service.GetPasswordPolicyRequest{
 		Token: getToken(ctx),
 		Name:  req.Name,
 	})
@@ -77,192 +194,1298 @@ func (s *server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User,
 		return nil, err
 	}
 
-	outUser, err := serializeUser(resultUser)
+	return serializePasswordPolicy(resultPolicy)
+}
+
+func (s *server) UpdatePasswordPolicy(ctx context.Context, req *pb.UpdatePasswordPolicyRequest) (*pb.PasswordPolicy, error) {
+	if req.PasswordPolicy == nil {
+		return nil, status.Error(codes.InvalidArgument, "password_policy is required")
+	}
+
+	inPolicy, err := deserializePasswordPolicy(req.PasswordPolicy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resultPolicy, err := s.Service.UpdatePasswordPolicy(ctx, service.UpdatePasswordPolicyRequest{
+		Token:          getToken(ctx),
+		PasswordPolicy: inPolicy,
+		UpdateMask:     updateMaskPaths(req.UpdateMask),
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	return outUser, nil
+	return serializePasswordPolicy(resultPolicy)
 }
 
-func (s *server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
-	inUser := deserializeUser(req.User)
-
-	resultUser, err := s.Service.CreateUser(ctx, service.CreateUserRequest{
-		Token: getToken(ctx),
-		User:  inUser,
+func (s *server) MoveAccount(ctx context.Context, req *pb.MoveAccountRequest) (*pb.Account, error) {
+	resultAccount, err := s.Service.MoveAccount(ctx, service.MoveAccountRequest{
+		Token:        getToken(ctx),
+		Name:         req.Name,
+		Organization: req.Organization,
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	outUser, err := serializeUser(resultUser)
+	return serializeAccount(resultAccount)
+}
+
+func (s *server) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.Organization, error) {
+	if req.Organization == nil {
+		return nil, status.Error(codes.InvalidArgument, "organization is required")
+	}
+
+	resultOrganization, err := s.Service.CreateOrganization(ctx, service.CreateOrganizationRequest{
+		Token:        getToken(ctx),
+		Organization: deserializeOrganization(req.Organization),
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	return outUser, nil
-}
-
-func (s *server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
-	return nil, nil
+	return serializeOrganization(resultOrganization)
 }
 
-func (s *server) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*empty.Empty, error) {
-	return nil, nil
-}
+func (s *server) GetOrganization(ctx context.Context, req *pb.GetOrganizationRequest) (*pb.Organization, error) {
+	resultOrganization, err := s.Service.GetOrganization(ctx, service.GetOrganizationRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+	})
 
-func (s *server) ListIdentities(ctx context.Context, req *pb.ListIdentitiesRequest) (*pb.ListIdentitiesResponse, error) {
-	return nil, nil
-}
+	if err != nil {
+		return nil, err
+	}
 
-func (s *server) GetIdentity(ctx context.Context, req *pb.GetIdentityRequest) (*pb.Identity, error) {
-	return nil, nil
+	return serializeOrganization(resultOrganization)
 }
 
-func (s *server) CreateIdentity(ctx context.Context, req *pb.CreateIdentityRequest) (*pb.Identity, error) {
-	inIdentity, err := deserializeIdentity(req.Identity)
-	if err != nil {
-		return nil, errors.Wrap(err, "error deserializing identity")
+func (s *server) UpdateOrganization(ctx context.Context, req *pb.UpdateOrganizationRequest) (*pb.Organization, error) {
+	if req.Organization == nil {
+		return nil, status.Error(codes.InvalidArgument, "organization is required")
 	}
 
-	resultIdentity, err := s.Service.CreateIdentity(ctx, service.CreateIdentityRequest{
-		Token:    getToken(ctx),
-		Identity: inIdentity,
-		Parent:   req.Parent,
+	inOrganization := deserializeOrganization(req.Organization)
+	inOrganization.Etag = ifMatch(ctx, inOrganization.Etag)
+
+	resultOrganization, err := s.Service.UpdateOrganization(ctx, service.UpdateOrganizationRequest{
+		Token:        getToken(ctx),
+		Organization: inOrganization,
+		UpdateMask:   updateMaskPaths(req.UpdateMask),
 	})
 
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating identity")
+		return nil, err
 	}
 
-	outIdentity, err := serializeIdentity(resultIdentity)
+	return serializeOrganization(resultOrganization)
+}
+
+func (s *server) AddOrganizationAdmin(ctx context.Context, req *pb.AddOrganizationAdminRequest) (*pb.Organization, error) {
+	resultOrganization, err := s.Service.AddOrganizationAdmin(ctx, service.AddOrganizationAdminRequest{
+		Token:     getToken(ctx),
+		Name:      req.Name,
+		Principal: req.Principal,
+	})
+
 	if err != nil {
-		return nil, errors.Wrap(err, "error serializing identity")
+		return nil, err
 	}
 
-	return outIdentity, nil
+	return serializeOrganization(resultOrganization)
 }
 
-func (s *server) UpdateIdentity(ctx context.Context, req *pb.UpdateIdentityRequest) (*pb.Identity, error) {
-	return nil, nil
-}
+func (s *server) RemoveOrganizationAdmin(ctx context.Context, req *pb.RemoveOrganizationAdminRequest) (*pb.Organization, error) {
+	resultOrganization, err := s.Service.RemoveOrganizationAdmin(ctx, service.RemoveOrganizationAdminRequest{
+		Token:     getToken(ctx),
+		Name:      req.Name,
+		Principal: req.Principal,
+	})
 
-func (s *server) DeleteIdentity(ctx context.Context, req *pb.DeleteIdentityRequest) (*empty.Empty, error) {
-	return nil, nil
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeOrganization(resultOrganization)
 }
 
-func getToken(ctx context.Context) string {
-	if mdata, ok := metadata.FromIncomingContext(ctx); ok {
-		if auth, ok := mdata["authorization"]; ok {
-			if len(auth) > 0 {
-				idx := strings.Index(auth[0], " ")
-				if auth[0][:idx] == "Bearer" {
-					return auth[0][idx+1:]
-				}
-			}
-		}
+func (s *server) GetOrganizationPasswordPolicy(ctx context.Context, req *pb.GetOrganizationPasswordPolicyRequest) (*pb.PasswordPolicy, error) {
+	resultPolicy, err := s.Service.GetOrganizationPasswordPolicy(ctx, service.GetOrganizationPasswordPolicyRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return ""
+	return serializePasswordPolicy(resultPolicy)
 }
 
-func deserializeAccount(a *pb.Account) models.Account {
-	return models.Account{
-		Name:        a.Name,
-		DisplayName: a.DisplayName,
+func (s *server) UpdateOrganizationPasswordPolicy(ctx context.Context, req *pb.UpdateOrganizationPasswordPolicyRequest) (*pb.PasswordPolicy, error) {
+	if req.PasswordPolicy == nil {
+		return nil, status.Error(codes.InvalidArgument, "password_policy is required")
 	}
-}
 
-func serializeAccount(a models.Account) (*pb.Account, error) {
-	createTime, err := ptypes.TimestampProto(a.CreateTime)
+	inPolicy, err := deserializePasswordPolicy(req.PasswordPolicy)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	updateTime, err := ptypes.TimestampProto(a.UpdateTime)
+	resultPolicy, err := s.Service.UpdateOrganizationPasswordPolicy(ctx, service.UpdateOrganizationPasswordPolicyRequest{
+		Token:          getToken(ctx),
+		PasswordPolicy: inPolicy,
+		UpdateMask:     updateMaskPaths(req.UpdateMask),
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.Account{
-		Name:        a.Name,
-		CreateTime:  createTime,
-		UpdateTime:  updateTime,
-		DisplayName: a.DisplayName,
-		Root:        a.Root,
-	}, nil
+	return serializePasswordPolicy(resultPolicy)
 }
 
-func deserializeUser(u *pb.User) models.User {
-	return models.User{
-		Name:        u.Name,
-		IsRoot:      u.IsRoot,
-		DisplayName: u.DisplayName,
+func (s *server) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*empty.Empty, error) {
+	if err := s.Service.DeleteAccount(ctx, service.DeleteAccountRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+		Etag:  ifMatch(ctx, req.Etag),
+	}); err != nil {
+		return nil, err
 	}
+
+	return &empty.Empty{}, nil
 }
 
-func serializeUser(u models.User) (*pb.User, error) {
-	createTime, err := ptypes.TimestampProto(u.CreateTime)
+func (s *server) UndeleteAccount(ctx context.Context, req *pb.UndeleteAccountRequest) (*pb.Account, error) {
+	resultAccount, err := s.Service.UndeleteAccount(ctx, service.UndeleteAccountRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	updateTime, err := ptypes.TimestampProto(u.UpdateTime)
+	return serializeAccount(resultAccount)
+}
+
+func (s *server) ExportAccount(ctx context.Context, req *pb.ExportAccountRequest) (*pb.AccountBundle, error) {
+	bundle, err := s.Service.ExportAccount(ctx, service.ExportAccountRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.User{
-		Name:        u.Name,
-		CreateTime:  createTime,
-		UpdateTime:  updateTime,
-		IsRoot:      u.IsRoot,
-		DisplayName: u.DisplayName,
-	}, nil
+	return serializeAccountBundle(bundle)
 }
 
-func serializeIdentity(i models.Identity) (*pb.Identity, error) {
-	createTime, err := ptypes.TimestampProto(i.CreateTime)
+func (s *server) ImportAccount(ctx context.Context, req *pb.ImportAccountRequest) (*pb.ImportAccountResponse, error) {
+	if req.Bundle == nil {
+		return nil, status.Error(codes.InvalidArgument, "bundle is required")
+	}
+
+	bundle, err := deserializeAccountBundle(req.Bundle)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res, err := s.Service.ImportAccount(ctx, service.ImportAccountRequest{
+		Token:    getToken(ctx),
+		Name:     req.Name,
+		Bundle:   bundle,
+		Conflict: deserializeImportConflict(req.ConflictStrategy),
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	updateTime, err := ptypes.TimestampProto(i.UpdateTime)
+	outAccount, err := serializeAccount(res.Account)
 	if err != nil {
 		return nil, err
 	}
 
-	identity := &pb.Identity{
-		Name:       i.Name,
-		CreateTime: createTime,
-		UpdateTime: updateTime,
+	return &pb.ImportAccountResponse{
+		Account:          outAccount,
+		CreatedUsers:     res.Created,
+		OverwrittenUsers: res.Overwritten,
+		SkippedUsers:     res.Skipped,
+		RenamedUsers:     res.Renamed,
+	}, nil
+}
+
+func (s *server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	res, err := s.Service.ListUsers(ctx, service.ListUsersRequest{
+		Token:       getToken(ctx),
+		ShowDeleted: req.ShowDeleted,
+		Filter:      req.Filter,
+		OrderBy:     req.OrderBy,
+		PageSize:    req.PageSize,
+		PageToken:   req.PageToken,
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	switch i.AuthMethod {
-	case models.AuthMethodPassword:
-		identity.AuthMethod = pb.Identity_AUTH_METHOD_PASSWORD
-		identity.AuthDetails = &pb.Identity_Password{
-			Password: i.Password,
+	out := &pb.ListUsersResponse{NextPageToken: res.NextPageToken}
+	for _, user := range res.Users {
+		outUser, err := serializeUser(user)
+		if err != nil {
+			return nil, err
 		}
+
+		out.Users = append(out.Users, outUser)
 	}
 
-	return identity, nil
+	return out, nil
 }
 
-func deserializeIdentity(u *pb.Identity) (models.Identity, error) {
-	identity := models.Identity{
-		Name: u.Name,
+func (s *server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	resultUser, err := s.Service.GetUser(ctx, service.GetUserRequest{
+		Token:       getToken(ctx),
+		Name:        req.Name,
+		ShowDeleted: req.ShowDeleted,
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	switch u.AuthMethod {
-	case pb.Identity_AUTH_METHOD_UNSPECIFIED:
-		return models.Identity{}, errors.New("auth_method is required")
-	case pb.Identity_AUTH_METHOD_PASSWORD:
-		identity.AuthMethod = models.AuthMethodPassword
-		identity.Password = u.GetPassword()
+	outUser, err := serializeUser(resultUser)
+	if err != nil {
+		return nil, err
 	}
 
-	return identity, nil
+	return outUser, nil
+}
+
+func (s *server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	inUser := deserializeUser(req.User)
+
+	resultUser, err := s.Service.CreateUser(ctx, service.CreateUserRequest{
+		Token: getToken(ctx),
+		User:  inUser,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	outUser, err := serializeUser(resultUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return outUser, nil
+}
+
+func (s *server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+
+	inUser := deserializeUser(req.User)
+	inUser.Etag = ifMatch(ctx, inUser.Etag)
+
+	resultUser, err := s.Service.UpdateUser(ctx, service.UpdateUserRequest{
+		Token:      getToken(ctx),
+		User:       inUser,
+		UpdateMask: updateMaskPaths(req.UpdateMask),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeUser(resultUser)
+}
+
+func (s *server) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*empty.Empty, error) {
+	if err := s.Service.DeleteUser(ctx, service.DeleteUserRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+		Etag:  ifMatch(ctx, req.Etag),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *server) UndeleteUser(ctx context.Context, req *pb.UndeleteUserRequest) (*pb.User, error) {
+	resultUser, err := s.Service.UndeleteUser(ctx, service.UndeleteUserRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeUser(resultUser)
+}
+
+func (s *server) LinkPrincipal(ctx context.Context, req *pb.LinkPrincipalRequest) (*pb.User, error) {
+	resultUser, err := s.Service.LinkPrincipal(ctx, service.LinkPrincipalRequest{
+		Token:     getToken(ctx),
+		Name:      req.Name,
+		Principal: req.Principal,
+		Password:  req.Password,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeUser(resultUser)
+}
+
+func (s *server) UnlinkPrincipal(ctx context.Context, req *pb.UnlinkPrincipalRequest) (*pb.User, error) {
+	resultUser, err := s.Service.UnlinkPrincipal(ctx, service.UnlinkPrincipalRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeUser(resultUser)
+}
+
+func (s *server) CreatePrincipal(ctx context.Context, req *pb.CreatePrincipalRequest) (*pb.Principal, error) {
+	if req.Principal == nil {
+		return nil, status.Error(codes.InvalidArgument, "principal is required")
+	}
+
+	principal, err := s.Service.CreatePrincipal(ctx, service.CreatePrincipalRequest{
+		Principal: models.Principal{
+			DisplayName: req.Principal.DisplayName,
+			Email:       req.Principal.Email,
+		},
+		Password: req.Password,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializePrincipal(principal)
+}
+
+func (s *server) EraseUser(ctx context.Context, req *pb.EraseUserRequest) (*pb.EraseUserResponse, error) {
+	res, err := s.Service.EraseUser(ctx, service.EraseUserRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+		Etag:  ifMatch(ctx, req.Etag),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	outUser, err := serializeUser(res.User)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EraseUserResponse{
+		User:    outUser,
+		Receipt: res.Receipt,
+	}, nil
+}
+
+func (s *server) UnlockUser(ctx context.Context, req *pb.UnlockUserRequest) (*empty.Empty, error) {
+	if err := s.Service.UnlockUser(ctx, service.UnlockUserRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *server) ListIdentities(ctx context.Context, req *pb.ListIdentitiesRequest) (*pb.ListIdentitiesResponse, error) {
+	res, err := s.Service.ListIdentities(ctx, service.ListIdentitiesRequest{
+		Token:       getToken(ctx),
+		Parent:      req.Parent,
+		ShowDeleted: req.ShowDeleted,
+		Filter:      req.Filter,
+		OrderBy:     req.OrderBy,
+		PageSize:    req.PageSize,
+		PageToken:   req.PageToken,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.ListIdentitiesResponse{NextPageToken: res.NextPageToken}
+	for _, identity := range res.Identities {
+		outIdentity, err := serializeIdentity(identity)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Identities = append(out.Identities, outIdentity)
+	}
+
+	return out, nil
+}
+
+func (s *server) GetIdentity(ctx context.Context, req *pb.GetIdentityRequest) (*pb.Identity, error) {
+	return nil, nil
+}
+
+func (s *server) CreateIdentity(ctx context.Context, req *pb.CreateIdentityRequest) (*pb.Identity, error) {
+	inIdentity, err := deserializeIdentity(req.Identity)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deserializing identity")
+	}
+
+	resultIdentity, err := s.Service.CreateIdentity(ctx, service.CreateIdentityRequest{
+		Token:    getToken(ctx),
+		Identity: inIdentity,
+		Parent:   req.Parent,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	outIdentity, err := serializeIdentity(resultIdentity)
+	if err != nil {
+		return nil, errors.Wrap(err, "error serializing identity")
+	}
+
+	return outIdentity, nil
+}
+
+func (s *server) UpdateIdentity(ctx context.Context, req *pb.UpdateIdentityRequest) (*pb.Identity, error) {
+	if req.Identity == nil {
+		return nil, status.Error(codes.InvalidArgument, "identity is required")
+	}
+
+	resultIdentity, err := s.Service.UpdateIdentity(ctx, service.UpdateIdentityRequest{
+		Token: getToken(ctx),
+		Identity: models.Identity{
+			Name:       req.Identity.Name,
+			Etag:       ifMatch(ctx, req.Identity.Etag),
+			AuthMethod: models.AuthMethodPassword,
+			Password:   req.Identity.GetPassword(),
+		},
+		UpdateMask: updateMaskPaths(req.UpdateMask),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeIdentity(resultIdentity)
+}
+
+func (s *server) DeleteIdentity(ctx context.Context, req *pb.DeleteIdentityRequest) (*empty.Empty, error) {
+	return nil, nil
+}
+
+func (s *server) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	var startTime, endTime time.Time
+	if req.StartTime != nil {
+		t, err := ptypes.Timestamp(req.StartTime)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		startTime = t
+	}
+
+	if req.EndTime != nil {
+		t, err := ptypes.Timestamp(req.EndTime)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		endTime = t
+	}
+
+	res, err := s.Service.ListAuditEvents(ctx, service.ListAuditEventsRequest{
+		Token:     getToken(ctx),
+		StartTime: startTime,
+		EndTime:   endTime,
+		Filter:    req.Filter,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.ListAuditEventsResponse{
+		NextPageToken: res.NextPageToken,
+	}
+
+	for _, event := range res.AuditEvents {
+		outEvent, err := serializeAuditEvent(event)
+		if err != nil {
+			return nil, err
+		}
+
+		out.AuditEvents = append(out.AuditEvents, outEvent)
+	}
+
+	return out, nil
+}
+
+func (s *server) ListInvitations(ctx context.Context, req *pb.ListInvitationsRequest) (*pb.ListInvitationsResponse, error) {
+	res, err := s.Service.ListInvitations(ctx, service.ListInvitationsRequest{
+		Token:     getToken(ctx),
+		Parent:    req.Parent,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.ListInvitationsResponse{
+		NextPageToken: res.NextPageToken,
+	}
+
+	for _, invitation := range res.Invitations {
+		outInvitation, err := serializeInvitation(invitation)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Invitations = append(out.Invitations, outInvitation)
+	}
+
+	return out, nil
+}
+
+func (s *server) CreateInvitation(ctx context.Context, req *pb.CreateInvitationRequest) (*pb.Invitation, error) {
+	if req.Invitation == nil {
+		return nil, status.Error(codes.InvalidArgument, "invitation is required")
+	}
+
+	invitation, err := deserializeInvitation(req.Invitation)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resultInvitation, err := s.Service.CreateInvitation(ctx, service.CreateInvitationRequest{
+		Token:      getToken(ctx),
+		Parent:     req.Parent,
+		Invitation: invitation,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeInvitation(resultInvitation)
+}
+
+func (s *server) DeleteInvitation(ctx context.Context, req *pb.DeleteInvitationRequest) (*empty.Empty, error) {
+	if err := s.Service.DeleteInvitation(ctx, service.DeleteInvitationRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *server) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	res, err := s.Service.ListWebhooks(ctx, service.ListWebhooksRequest{
+		Token:     getToken(ctx),
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.ListWebhooksResponse{
+		NextPageToken: res.NextPageToken,
+	}
+
+	for _, webhook := range res.Webhooks {
+		outWebhook, err := serializeWebhook(webhook)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Webhooks = append(out.Webhooks, outWebhook)
+	}
+
+	return out, nil
+}
+
+func (s *server) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.Webhook, error) {
+	if req.Webhook == nil {
+		return nil, status.Error(codes.InvalidArgument, "webhook is required")
+	}
+
+	resultWebhook, err := s.Service.CreateWebhook(ctx, service.CreateWebhookRequest{
+		Token:   getToken(ctx),
+		Webhook: deserializeWebhook(req.Webhook),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeWebhook(resultWebhook)
+}
+
+func (s *server) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*empty.Empty, error) {
+	if err := s.Service.DeleteWebhook(ctx, service.DeleteWebhookRequest{
+		Token: getToken(ctx),
+		Name:  req.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *server) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	res, err := s.Service.ListDeadLetters(ctx, service.ListDeadLettersRequest{
+		Token:     getToken(ctx),
+		Parent:    req.Parent,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.ListDeadLettersResponse{
+		NextPageToken: res.NextPageToken,
+	}
+
+	for _, delivery := range res.DeadLetters {
+		outDelivery, err := serializeWebhookDelivery(delivery)
+		if err != nil {
+			return nil, err
+		}
+
+		out.DeadLetters = append(out.DeadLetters, outDelivery)
+	}
+
+	return out, nil
+}
+
+func getToken(ctx context.Context) string {
+	if mdata, ok := metadata.FromIncomingContext(ctx); ok {
+		if auth, ok := mdata["authorization"]; ok {
+			if len(auth) > 0 {
+				idx := strings.Index(auth[0], " ")
+				if idx >= 0 && auth[0][:idx] == "Bearer" {
+					return auth[0][idx+1:]
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// ifMatch returns etag, or if it is empty the etag in the request's If-Match
+// header. The gateway forwards If-Match with a grpcgateway- prefix.
+func ifMatch(ctx context.Context, etag string) string {
+	if etag != "" {
+		return etag
+	}
+
+	if mdata, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range []string{"if-match", "grpcgateway-if-match"} {
+			if values := mdata[key]; len(values) > 0 {
+				value := strings.TrimPrefix(strings.TrimSpace(values[0]), "W/")
+				if value == "*" {
+					return ""
+				}
+
+				return strings.Trim(value, `"`)
+			}
+		}
+	}
+
+	return ""
+}
+
+func deserializeAccount(a *pb.Account) models.Account {
+	return models.Account{
+		Name:        a.Name,
+		Etag:        a.Etag,
+		DisplayName: a.DisplayName,
+	}
+}
+
+func serializeAccount(a models.Account) (*pb.Account, error) {
+	createTime, err := ptypes.TimestampProto(a.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	updateTime, err := ptypes.TimestampProto(a.UpdateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteTime, err := optionalTimeProto(a.DeleteTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Account{
+		Name:         a.Name,
+		CreateTime:   createTime,
+		UpdateTime:   updateTime,
+		DeleteTime:   deleteTime,
+		Etag:         a.Etag,
+		DisplayName:  a.DisplayName,
+		Root:         a.Root,
+		Organization: a.Organization,
+	}, nil
+}
+
+func deserializeOrganization(o *pb.Organization) models.Organization {
+	return models.Organization{
+		Name:        o.Name,
+		Etag:        o.Etag,
+		DisplayName: o.DisplayName,
+	}
+}
+
+func serializeOrganization(o models.Organization) (*pb.Organization, error) {
+	createTime, err := ptypes.TimestampProto(o.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	updateTime, err := ptypes.TimestampProto(o.UpdateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteTime, err := optionalTimeProto(o.DeleteTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Organization{
+		Name:        o.Name,
+		CreateTime:  createTime,
+		UpdateTime:  updateTime,
+		DeleteTime:  deleteTime,
+		Etag:        o.Etag,
+		DisplayName: o.DisplayName,
+		Admins:      o.Admins,
+	}, nil
+}
+
+func deserializeUser(u *pb.User) models.User {
+	return models.User{
+		Name:        u.Name,
+		Etag:        u.Etag,
+		IsRoot:      u.IsRoot,
+		DisplayName: u.DisplayName,
+		Email:       u.Email,
+		Phone:       u.Phone,
+		Locale:      u.Locale,
+		Labels:      u.Labels,
+		Annotations: u.Annotations,
+	}
+}
+
+func serializeUser(u models.User) (*pb.User, error) {
+	createTime, err := ptypes.TimestampProto(u.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	updateTime, err := ptypes.TimestampProto(u.UpdateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteTime, err := optionalTimeProto(u.DeleteTime)
+	if err != nil {
+		return nil, err
+	}
+
+	eraseTime, err := optionalTimeProto(u.EraseTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.User{
+		Name:          u.Name,
+		CreateTime:    createTime,
+		UpdateTime:    updateTime,
+		DeleteTime:    deleteTime,
+		EraseTime:     eraseTime,
+		Etag:          u.Etag,
+		IsRoot:        u.IsRoot,
+		DisplayName:   u.DisplayName,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Phone:         u.Phone,
+		Locale:        u.Locale,
+		Labels:        u.Labels,
+		Annotations:   u.Annotations,
+		Principal:     u.Principal,
+	}, nil
+}
+
+func serializePrincipal(p models.Principal) (*pb.Principal, error) {
+	createTime, err := ptypes.TimestampProto(p.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	updateTime, err := ptypes.TimestampProto(p.UpdateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteTime, err := optionalTimeProto(p.DeleteTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Principal{
+		Name:        p.Name,
+		CreateTime:  createTime,
+		UpdateTime:  updateTime,
+		DeleteTime:  deleteTime,
+		Etag:        p.Etag,
+		DisplayName: p.DisplayName,
+		Email:       p.Email,
+	}, nil
+}
+
+func serializeIdentity(i models.Identity) (*pb.Identity, error) {
+	createTime, err := ptypes.TimestampProto(i.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	updateTime, err := ptypes.TimestampProto(i.UpdateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteTime, err := optionalTimeProto(i.DeleteTime)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &pb.Identity{
+		Name:       i.Name,
+		CreateTime: createTime,
+		UpdateTime: updateTime,
+		DeleteTime: deleteTime,
+		Etag:       i.Etag,
+	}
+
+	switch i.AuthMethod {
+	case models.AuthMethodPassword:
+		identity.AuthMethod = pb.Identity_AUTH_METHOD_PASSWORD
+		identity.AuthDetails = &pb.Identity_Password{
+			Password: i.Password,
+		}
+	}
+
+	return identity, nil
+}
+
+func deserializeIdentity(u *pb.Identity) (models.Identity, error) {
+	identity := models.Identity{
+		Name: u.Name,
+		Etag: u.Etag,
+	}
+
+	switch u.AuthMethod {
+	case pb.Identity_AUTH_METHOD_UNSPECIFIED:
+		return models.Identity{}, errors.New("auth_method is required")
+	case pb.Identity_AUTH_METHOD_PASSWORD:
+		identity.AuthMethod = models.AuthMethodPassword
+		identity.Password = u.GetPassword()
+	}
+
+	return identity, nil
+}
+
+func serializeAuditEvent(e models.AuditEvent) (*pb.AuditEvent, error) {
+	createTime, err := ptypes.TimestampProto(e.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &pb.AuditEvent{
+		Name:       e.Name,
+		CreateTime: createTime,
+		Actor:      e.Actor,
+		Account:    e.Account,
+		Resource:   e.Resource,
+		Method:     e.Method,
+		SourceIp:   e.SourceIP,
+	}
+
+	switch e.Outcome {
+	case models.AuditOutcomeSuccess:
+		event.Outcome = pb.AuditEvent_OUTCOME_SUCCESS
+	case models.AuditOutcomeFailure:
+		event.Outcome = pb.AuditEvent_OUTCOME_FAILURE
+	}
+
+	return event, nil
+}
+
+func optionalTimeProto(t *time.Time) (*timestamp.Timestamp, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	return ptypes.TimestampProto(*t)
+}
+
+func serializePasswordPolicy(p models.PasswordPolicy) (*pb.PasswordPolicy, error) {
+	var updateTime *timestamp.Timestamp
+	if !p.UpdateTime.IsZero() {
+		t, err := ptypes.TimestampProto(p.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+
+		updateTime = t
+	}
+
+	return &pb.PasswordPolicy{
+		Name:             p.Name,
+		UpdateTime:       updateTime,
+		MinLength:        int32(p.MinLength),
+		RequireUppercase: p.RequireUppercase,
+		RequireLowercase: p.RequireLowercase,
+		RequireDigit:     p.RequireDigit,
+		RequireSymbol:    p.RequireSymbol,
+		HistorySize:      int32(p.HistorySize),
+		MaxAge:           ptypes.DurationProto(p.MaxAge),
+	}, nil
+}
+
+func deserializePasswordPolicy(p *pb.PasswordPolicy) (models.PasswordPolicy, error) {
+	policy := models.PasswordPolicy{
+		Name:             p.Name,
+		MinLength:        int(p.MinLength),
+		RequireUppercase: p.RequireUppercase,
+		RequireLowercase: p.RequireLowercase,
+		RequireDigit:     p.RequireDigit,
+		RequireSymbol:    p.RequireSymbol,
+		HistorySize:      int(p.HistorySize),
+	}
+
+	if p.MaxAge != nil {
+		maxAge, err := ptypes.Duration(p.MaxAge)
+		if err != nil {
+			return models.PasswordPolicy{}, err
+		}
+
+		policy.MaxAge = maxAge
+	}
+
+	return policy, nil
+}
+
+func serializeAccountBundle(b models.AccountBundle) (*pb.AccountBundle, error) {
+	exportTime, err := ptypes.TimestampProto(b.ExportTime)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := serializeAccount(b.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.AccountBundle{
+		Version:    int32(b.Version),
+		ExportTime: exportTime,
+		Account:    account,
+	}
+
+	if b.PasswordPolicy != nil {
+		if out.PasswordPolicy, err = serializePasswordPolicy(*b.PasswordPolicy); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, u := range b.Users {
+		user, err := serializeUser(u.User)
+		if err != nil {
+			return nil, err
+		}
+
+		bundled := &pb.AccountBundle_BundledUser{User: user}
+		for _, i := range u.Identities {
+			identity, err := serializeIdentity(i.Identity)
+			if err != nil {
+				return nil, err
+			}
+
+			bundled.Identities = append(bundled.Identities, &pb.AccountBundle_BundledIdentity{
+				Identity:     identity,
+				PasswordHash: i.PasswordHash,
+			})
+		}
+
+		out.Users = append(out.Users, bundled)
+	}
+
+	return out, nil
+}
+
+func deserializeAccountBundle(b *pb.AccountBundle) (models.AccountBundle, error) {
+	bundle := models.AccountBundle{
+		Version: int(b.Version),
+	}
+
+	var err error
+	if bundle.ExportTime, err = optionalTime(b.ExportTime); err != nil {
+		return models.AccountBundle{}, err
+	}
+
+	if b.Account != nil {
+		bundle.Account = deserializeAccount(b.Account)
+		if bundle.Account.CreateTime, err = optionalTime(b.Account.CreateTime); err != nil {
+			return models.AccountBundle{}, err
+		}
+	}
+
+	if b.PasswordPolicy != nil {
+		policy, err := deserializePasswordPolicy(b.PasswordPolicy)
+		if err != nil {
+			return models.AccountBundle{}, err
+		}
+
+		bundle.PasswordPolicy = &policy
+	}
+
+	for _, u := range b.Users {
+		if u.User == nil {
+			return models.AccountBundle{}, errors.New("bundled user is required")
+		}
+
+		user := deserializeUser(u.User)
+		user.EmailVerified = u.User.EmailVerified
+		if user.CreateTime, err = optionalTime(u.User.CreateTime); err != nil {
+			return models.AccountBundle{}, err
+		}
+
+		bundled := models.BundledUser{User: user}
+		for _, i := range u.Identities {
+			if i.Identity == nil {
+				return models.AccountBundle{}, errors.New("bundled identity is required")
+			}
+
+			identity, err := deserializeIdentity(i.Identity)
+			if err != nil {
+				return models.AccountBundle{}, err
+			}
+
+			if identity.CreateTime, err = optionalTime(i.Identity.CreateTime); err != nil {
+				return models.AccountBundle{}, err
+			}
+
+			bundled.Identities = append(bundled.Identities, models.BundledIdentity{
+				Identity:     identity,
+				PasswordHash: i.PasswordHash,
+			})
+		}
+
+		bundle.Users = append(bundle.Users, bundled)
+	}
+
+	return bundle, nil
+}
+
+func deserializeImportConflict(c pb.ImportAccountRequest_ConflictStrategy) models.ImportConflict {
+	switch c {
+	case pb.ImportAccountRequest_CONFLICT_STRATEGY_SKIP:
+		return models.ImportConflictSkip
+	case pb.ImportAccountRequest_CONFLICT_STRATEGY_OVERWRITE:
+		return models.ImportConflictOverwrite
+	case pb.ImportAccountRequest_CONFLICT_STRATEGY_RENAME:
+		return models.ImportConflictRename
+	default:
+		return models.ImportConflictFail
+	}
+}
+
+// optionalTime converts a timestamp that may be unset, returning the zero
+// time for nil.
+func optionalTime(ts *timestamp.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+
+	return ptypes.Timestamp(ts)
+}
+
+func serializeInvitation(i models.Invitation) (*pb.Invitation, error) {
+	createTime, err := ptypes.TimestampProto(i.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	updateTime, err := ptypes.TimestampProto(i.UpdateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteTime, err := optionalTimeProto(i.DeleteTime)
+	if err != nil {
+		return nil, err
+	}
+
+	expireTime, err := ptypes.TimestampProto(i.ExpireTime)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptTime, err := optionalTimeProto(i.AcceptTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Invitation{
+		Name:       i.Name,
+		CreateTime: createTime,
+		UpdateTime: updateTime,
+		DeleteTime: deleteTime,
+		Email:      i.Email,
+		User: &pb.User{
+			Name:        i.User.Name,
+			DisplayName: i.User.DisplayName,
+			Labels:      i.User.Labels,
+		},
+		ExpireTime: expireTime,
+		AcceptTime: acceptTime,
+		Inviter:    i.Inviter,
+	}, nil
+}
+
+func deserializeInvitation(i *pb.Invitation) (models.Invitation, error) {
+	expireTime, err := optionalTime(i.ExpireTime)
+	if err != nil {
+		return models.Invitation{}, err
+	}
+
+	invitation := models.Invitation{
+		Name:       i.Name,
+		Email:      i.Email,
+		ExpireTime: expireTime,
+	}
+
+	if i.User != nil {
+		invitation.User = models.User{
+			Name:        i.User.Name,
+			DisplayName: i.User.DisplayName,
+			Labels:      i.User.Labels,
+		}
+	}
+
+	return invitation, nil
+}
+
+func serializeWebhook(w models.Webhook) (*pb.Webhook, error) {
+	createTime, err := ptypes.TimestampProto(w.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	updateTime, err := ptypes.TimestampProto(w.UpdateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Webhook{
+		Name:       w.Name,
+		CreateTime: createTime,
+		UpdateTime: updateTime,
+		Url:        w.URL,
+		EventTypes: w.EventTypes,
+		Secret:     w.Secret,
+	}, nil
+}
+
+func deserializeWebhook(w *pb.Webhook) models.Webhook {
+	return models.Webhook{
+		Name:       w.Name,
+		URL:        w.Url,
+		EventTypes: w.EventTypes,
+		Secret:     w.Secret,
+	}
+}
+
+func serializeWebhookDelivery(d models.WebhookDelivery) (*pb.WebhookDelivery, error) {
+	createTime, err := ptypes.TimestampProto(d.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.WebhookDelivery{
+		Name:       d.Name,
+		CreateTime: createTime,
+		EventType:  d.EventType,
+		Payload:    string(d.Payload),
+		Attempts:   int32(d.Attempts),
+		LastError:  d.LastError,
+	}, nil
+}
+
+// updateMaskPaths returns the paths of mask in snake_case. Masks built by the
+// gateway from a request body use the CamelCase Go field names.
+func updateMaskPaths(mask *field_mask.FieldMask) []string {
+	if mask == nil {
+		return nil
+	}
+
+	var paths []string
+	for _, path := range mask.Paths {
+		var b strings.Builder
+		for i, r := range path {
+			if unicode.IsUpper(r) {
+				if i > 0 {
+					b.WriteByte('_')
+				}
+
+				r = unicode.ToLower(r)
+			}
+
+			b.WriteRune(r)
+		}
+
+		paths = append(paths, b.String())
+	}
+
+	return paths
 }
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
@@ -50,7 +51,69 @@ func (x Identity_AuthMethod) String() string {
 }
 
 func (Identity_AuthMethod) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{2, 0}
+	return fileDescriptor_0a2c201915207782, []int{5, 0}
+}
+
+type AuditEvent_Outcome int32
+
+const (
+	AuditEvent_OUTCOME_UNSPECIFIED AuditEvent_Outcome = 0
+	AuditEvent_OUTCOME_SUCCESS     AuditEvent_Outcome = 1
+	AuditEvent_OUTCOME_FAILURE     AuditEvent_Outcome = 2
+)
+
+var AuditEvent_Outcome_name = map[int32]string{
+	0: "OUTCOME_UNSPECIFIED",
+	1: "OUTCOME_SUCCESS",
+	2: "OUTCOME_FAILURE",
+}
+
+var AuditEvent_Outcome_value = map[string]int32{
+	"OUTCOME_UNSPECIFIED": 0,
+	"OUTCOME_SUCCESS":     1,
+	"OUTCOME_FAILURE":     2,
+}
+
+func (x AuditEvent_Outcome) String() string {
+	return proto.EnumName(AuditEvent_Outcome_name, int32(x))
+}
+
+func (AuditEvent_Outcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{9, 0}
+}
+
+type ImportAccountRequest_ConflictStrategy int32
+
+const (
+	ImportAccountRequest_CONFLICT_STRATEGY_UNSPECIFIED ImportAccountRequest_ConflictStrategy = 0
+	ImportAccountRequest_CONFLICT_STRATEGY_FAIL        ImportAccountRequest_ConflictStrategy = 1
+	ImportAccountRequest_CONFLICT_STRATEGY_SKIP        ImportAccountRequest_ConflictStrategy = 2
+	ImportAccountRequest_CONFLICT_STRATEGY_OVERWRITE   ImportAccountRequest_ConflictStrategy = 3
+	ImportAccountRequest_CONFLICT_STRATEGY_RENAME      ImportAccountRequest_ConflictStrategy = 4
+)
+
+var ImportAccountRequest_ConflictStrategy_name = map[int32]string{
+	0: "CONFLICT_STRATEGY_UNSPECIFIED",
+	1: "CONFLICT_STRATEGY_FAIL",
+	2: "CONFLICT_STRATEGY_SKIP",
+	3: "CONFLICT_STRATEGY_OVERWRITE",
+	4: "CONFLICT_STRATEGY_RENAME",
+}
+
+var ImportAccountRequest_ConflictStrategy_value = map[string]int32{
+	"CONFLICT_STRATEGY_UNSPECIFIED": 0,
+	"CONFLICT_STRATEGY_FAIL":        1,
+	"CONFLICT_STRATEGY_SKIP":        2,
+	"CONFLICT_STRATEGY_OVERWRITE":   3,
+	"CONFLICT_STRATEGY_RENAME":      4,
+}
+
+func (x ImportAccountRequest_ConflictStrategy) String() string {
+	return proto.EnumName(ImportAccountRequest_ConflictStrategy_name, int32(x))
+}
+
+func (ImportAccountRequest_ConflictStrategy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{25, 0}
 }
 
 type Account struct {
@@ -60,6 +123,8 @@ type Account struct {
 	DeleteTime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
 	DisplayName          string               `protobuf:"bytes,5,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
 	Root                 string               `protobuf:"bytes,6,opt,name=root,proto3" json:"root,omitempty"`
+	Etag                 string               `protobuf:"bytes,7,opt,name=etag,proto3" json:"etag,omitempty"`
+	Organization         string               `protobuf:"bytes,8,opt,name=organization,proto3" json:"organization,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -132,6 +197,109 @@ func (m *Account) GetRoot() string {
 	return ""
 }
 
+func (m *Account) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *Account) GetOrganization() string {
+	if m != nil {
+		return m.Organization
+	}
+	return ""
+}
+
+type Organization struct {
+	Name        string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreateTime  *timestamp.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	DeleteTime  *timestamp.Timestamp `protobuf:"bytes,4,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	DisplayName string               `protobuf:"bytes,5,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// The principals that administer the organization. They have the root
+	// user's permissions in each of its accounts.
+	Admins               []string `protobuf:"bytes,7,rep,name=admins,proto3" json:"admins,omitempty"`
+	Etag                 string   `protobuf:"bytes,8,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Organization) Reset()         { *m = Organization{} }
+func (m *Organization) String() string { return proto.CompactTextString(m) }
+func (*Organization) ProtoMessage()    {}
+func (*Organization) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{1}
+}
+
+func (m *Organization) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Organization.Unmarshal(m, b)
+}
+func (m *Organization) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Organization.Marshal(b, m, deterministic)
+}
+func (m *Organization) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Organization.Merge(m, src)
+}
+func (m *Organization) XXX_Size() int {
+	return xxx_messageInfo_Organization.Size(m)
+}
+func (m *Organization) XXX_DiscardUnknown() {
+	xxx_messageInfo_Organization.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Organization proto.InternalMessageInfo
+
+func (m *Organization) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Organization) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Organization) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+func (m *Organization) GetDeleteTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeleteTime
+	}
+	return nil
+}
+
+func (m *Organization) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *Organization) GetAdmins() []string {
+	if m != nil {
+		return m.Admins
+	}
+	return nil
+}
+
+func (m *Organization) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
 type User struct {
 	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	CreateTime           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
@@ -139,6 +307,15 @@ type User struct {
 	DeleteTime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
 	IsRoot               bool                 `protobuf:"varint,5,opt,name=is_root,json=isRoot,proto3" json:"is_root,omitempty"`
 	DisplayName          string               `protobuf:"bytes,6,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Email                string               `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	EmailVerified        bool                 `protobuf:"varint,8,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	Phone                string               `protobuf:"bytes,9,opt,name=phone,proto3" json:"phone,omitempty"`
+	Locale               string               `protobuf:"bytes,10,opt,name=locale,proto3" json:"locale,omitempty"`
+	Labels               map[string]string    `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations          map[string]string    `protobuf:"bytes,12,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Etag                 string               `protobuf:"bytes,13,opt,name=etag,proto3" json:"etag,omitempty"`
+	EraseTime            *timestamp.Timestamp `protobuf:"bytes,14,opt,name=erase_time,json=eraseTime,proto3" json:"erase_time,omitempty"`
+	Principal            string               `protobuf:"bytes,15,opt,name=principal,proto3" json:"principal,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -148,7 +325,7 @@ func (m *User) Reset()         { *m = User{} }
 func (m *User) String() string { return proto.CompactTextString(m) }
 func (*User) ProtoMessage()    {}
 func (*User) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{1}
+	return fileDescriptor_0a2c201915207782, []int{2}
 }
 
 func (m *User) XXX_Unmarshal(b []byte) error {
@@ -211,6 +388,203 @@ func (m *User) GetDisplayName() string {
 	return ""
 }
 
+func (m *User) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *User) GetEmailVerified() bool {
+	if m != nil {
+		return m.EmailVerified
+	}
+	return false
+}
+
+func (m *User) GetPhone() string {
+	if m != nil {
+		return m.Phone
+	}
+	return ""
+}
+
+func (m *User) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *User) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *User) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *User) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *User) GetEraseTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EraseTime
+	}
+	return nil
+}
+
+func (m *User) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+type Principal struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,3,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	DeleteTime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	DisplayName          string               `protobuf:"bytes,5,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Email                string               `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
+	Etag                 string               `protobuf:"bytes,7,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Principal) Reset()         { *m = Principal{} }
+func (m *Principal) String() string { return proto.CompactTextString(m) }
+func (*Principal) ProtoMessage()    {}
+func (*Principal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{3}
+}
+
+func (m *Principal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Principal.Unmarshal(m, b)
+}
+func (m *Principal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Principal.Marshal(b, m, deterministic)
+}
+func (m *Principal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Principal.Merge(m, src)
+}
+func (m *Principal) XXX_Size() int {
+	return xxx_messageInfo_Principal.Size(m)
+}
+func (m *Principal) XXX_DiscardUnknown() {
+	xxx_messageInfo_Principal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Principal proto.InternalMessageInfo
+
+func (m *Principal) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Principal) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Principal) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+func (m *Principal) GetDeleteTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeleteTime
+	}
+	return nil
+}
+
+func (m *Principal) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *Principal) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Principal) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type AccountMembership struct {
+	Account              *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	User                 string   `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountMembership) Reset()         { *m = AccountMembership{} }
+func (m *AccountMembership) String() string { return proto.CompactTextString(m) }
+func (*AccountMembership) ProtoMessage()    {}
+func (*AccountMembership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{4}
+}
+
+func (m *AccountMembership) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountMembership.Unmarshal(m, b)
+}
+func (m *AccountMembership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountMembership.Marshal(b, m, deterministic)
+}
+func (m *AccountMembership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountMembership.Merge(m, src)
+}
+func (m *AccountMembership) XXX_Size() int {
+	return xxx_messageInfo_AccountMembership.Size(m)
+}
+func (m *AccountMembership) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountMembership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountMembership proto.InternalMessageInfo
+
+func (m *AccountMembership) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *AccountMembership) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
 type Identity struct {
 	Name       string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	CreateTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
@@ -220,6 +594,7 @@ type Identity struct {
 	// Types that are valid to be assigned to AuthDetails:
 	//	*Identity_Password
 	AuthDetails          isIdentity_AuthDetails `protobuf_oneof:"auth_details"`
+	Etag                 string                 `protobuf:"bytes,7,opt,name=etag,proto3" json:"etag,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
 	XXX_sizecache        int32                  `json:"-"`
@@ -229,7 +604,7 @@ func (m *Identity) Reset()         { *m = Identity{} }
 func (m *Identity) String() string { return proto.CompactTextString(m) }
 func (*Identity) ProtoMessage()    {}
 func (*Identity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{2}
+	return fileDescriptor_0a2c201915207782, []int{5}
 }
 
 func (m *Identity) XXX_Unmarshal(b []byte) error {
@@ -309,6 +684,13 @@ func (m *Identity) GetPassword() string {
 	return ""
 }
 
+func (m *Identity) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Identity) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -316,1338 +698,4962 @@ func (*Identity) XXX_OneofWrappers() []interface{} {
 	}
 }
 
-type AuthenticateRequest struct {
-	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	User                 string   `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	Password             string   `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Invitation struct {
+	Name       string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	DeleteTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	Email      string               `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	// The user to create when the invitation is accepted. Only its name,
+	// display name and labels are used.
+	User *User `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`
+	// Defaults to the server's invitation TTL from now.
+	ExpireTime           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	AcceptTime           *timestamp.Timestamp `protobuf:"bytes,8,opt,name=accept_time,json=acceptTime,proto3" json:"accept_time,omitempty"`
+	Inviter              string               `protobuf:"bytes,9,opt,name=inviter,proto3" json:"inviter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *AuthenticateRequest) Reset()         { *m = AuthenticateRequest{} }
-func (m *AuthenticateRequest) String() string { return proto.CompactTextString(m) }
-func (*AuthenticateRequest) ProtoMessage()    {}
-func (*AuthenticateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{3}
+func (m *Invitation) Reset()         { *m = Invitation{} }
+func (m *Invitation) String() string { return proto.CompactTextString(m) }
+func (*Invitation) ProtoMessage()    {}
+func (*Invitation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{6}
 }
 
-func (m *AuthenticateRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AuthenticateRequest.Unmarshal(m, b)
+func (m *Invitation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Invitation.Unmarshal(m, b)
 }
-func (m *AuthenticateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AuthenticateRequest.Marshal(b, m, deterministic)
+func (m *Invitation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Invitation.Marshal(b, m, deterministic)
 }
-func (m *AuthenticateRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AuthenticateRequest.Merge(m, src)
+func (m *Invitation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Invitation.Merge(m, src)
 }
-func (m *AuthenticateRequest) XXX_Size() int {
-	return xxx_messageInfo_AuthenticateRequest.Size(m)
+func (m *Invitation) XXX_Size() int {
+	return xxx_messageInfo_Invitation.Size(m)
 }
-func (m *AuthenticateRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AuthenticateRequest.DiscardUnknown(m)
+func (m *Invitation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Invitation.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AuthenticateRequest proto.InternalMessageInfo
+var xxx_messageInfo_Invitation proto.InternalMessageInfo
 
-func (m *AuthenticateRequest) GetAccount() string {
+func (m *Invitation) GetName() string {
 	if m != nil {
-		return m.Account
+		return m.Name
 	}
 	return ""
 }
 
-func (m *AuthenticateRequest) GetUser() string {
+func (m *Invitation) GetCreateTime() *timestamp.Timestamp {
 	if m != nil {
-		return m.User
+		return m.CreateTime
 	}
-	return ""
+	return nil
 }
 
-func (m *AuthenticateRequest) GetPassword() string {
+func (m *Invitation) GetUpdateTime() *timestamp.Timestamp {
 	if m != nil {
-		return m.Password
+		return m.UpdateTime
 	}
-	return ""
+	return nil
 }
 
-type AuthenticateResponse struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Invitation) GetDeleteTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeleteTime
+	}
+	return nil
 }
 
-func (m *AuthenticateResponse) Reset()         { *m = AuthenticateResponse{} }
-func (m *AuthenticateResponse) String() string { return proto.CompactTextString(m) }
-func (*AuthenticateResponse) ProtoMessage()    {}
-func (*AuthenticateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{4}
+func (m *Invitation) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
 }
 
-func (m *AuthenticateResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AuthenticateResponse.Unmarshal(m, b)
-}
-func (m *AuthenticateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AuthenticateResponse.Marshal(b, m, deterministic)
-}
-func (m *AuthenticateResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AuthenticateResponse.Merge(m, src)
-}
-func (m *AuthenticateResponse) XXX_Size() int {
-	return xxx_messageInfo_AuthenticateResponse.Size(m)
+func (m *Invitation) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
 }
-func (m *AuthenticateResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AuthenticateResponse.DiscardUnknown(m)
+
+func (m *Invitation) GetExpireTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return nil
 }
 
-var xxx_messageInfo_AuthenticateResponse proto.InternalMessageInfo
+func (m *Invitation) GetAcceptTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.AcceptTime
+	}
+	return nil
+}
 
-func (m *AuthenticateResponse) GetToken() string {
+func (m *Invitation) GetInviter() string {
 	if m != nil {
-		return m.Token
+		return m.Inviter
 	}
 	return ""
 }
 
-type GetAccountRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type AccountBundle struct {
+	Version              int32                        `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	ExportTime           *timestamp.Timestamp         `protobuf:"bytes,2,opt,name=export_time,json=exportTime,proto3" json:"export_time,omitempty"`
+	Account              *Account                     `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	PasswordPolicy       *PasswordPolicy              `protobuf:"bytes,4,opt,name=password_policy,json=passwordPolicy,proto3" json:"password_policy,omitempty"`
+	Users                []*AccountBundle_BundledUser `protobuf:"bytes,5,rep,name=users,proto3" json:"users,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
-func (m *GetAccountRequest) Reset()         { *m = GetAccountRequest{} }
-func (m *GetAccountRequest) String() string { return proto.CompactTextString(m) }
-func (*GetAccountRequest) ProtoMessage()    {}
-func (*GetAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{5}
+func (m *AccountBundle) Reset()         { *m = AccountBundle{} }
+func (m *AccountBundle) String() string { return proto.CompactTextString(m) }
+func (*AccountBundle) ProtoMessage()    {}
+func (*AccountBundle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{7}
 }
 
-func (m *GetAccountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetAccountRequest.Unmarshal(m, b)
+func (m *AccountBundle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountBundle.Unmarshal(m, b)
 }
-func (m *GetAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetAccountRequest.Marshal(b, m, deterministic)
+func (m *AccountBundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountBundle.Marshal(b, m, deterministic)
 }
-func (m *GetAccountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetAccountRequest.Merge(m, src)
+func (m *AccountBundle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountBundle.Merge(m, src)
 }
-func (m *GetAccountRequest) XXX_Size() int {
-	return xxx_messageInfo_GetAccountRequest.Size(m)
+func (m *AccountBundle) XXX_Size() int {
+	return xxx_messageInfo_AccountBundle.Size(m)
 }
-func (m *GetAccountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetAccountRequest.DiscardUnknown(m)
+func (m *AccountBundle) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountBundle.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetAccountRequest proto.InternalMessageInfo
+var xxx_messageInfo_AccountBundle proto.InternalMessageInfo
 
-func (m *GetAccountRequest) GetName() string {
+func (m *AccountBundle) GetVersion() int32 {
 	if m != nil {
-		return m.Name
+		return m.Version
 	}
-	return ""
-}
-
-type CreateAccountRequest struct {
-	Account              *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	Root                 *User    `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
-	RootPassword         string   `protobuf:"bytes,3,opt,name=root_password,json=rootPassword,proto3" json:"root_password,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *CreateAccountRequest) Reset()         { *m = CreateAccountRequest{} }
-func (m *CreateAccountRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateAccountRequest) ProtoMessage()    {}
-func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{6}
+	return 0
 }
 
-func (m *CreateAccountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateAccountRequest.Unmarshal(m, b)
-}
-func (m *CreateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateAccountRequest.Marshal(b, m, deterministic)
-}
-func (m *CreateAccountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateAccountRequest.Merge(m, src)
-}
-func (m *CreateAccountRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateAccountRequest.Size(m)
-}
-func (m *CreateAccountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateAccountRequest.DiscardUnknown(m)
+func (m *AccountBundle) GetExportTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExportTime
+	}
+	return nil
 }
 
-var xxx_messageInfo_CreateAccountRequest proto.InternalMessageInfo
-
-func (m *CreateAccountRequest) GetAccount() *Account {
+func (m *AccountBundle) GetAccount() *Account {
 	if m != nil {
 		return m.Account
 	}
 	return nil
 }
 
-func (m *CreateAccountRequest) GetRoot() *User {
+func (m *AccountBundle) GetPasswordPolicy() *PasswordPolicy {
 	if m != nil {
-		return m.Root
+		return m.PasswordPolicy
 	}
 	return nil
 }
 
-func (m *CreateAccountRequest) GetRootPassword() string {
+func (m *AccountBundle) GetUsers() []*AccountBundle_BundledUser {
 	if m != nil {
-		return m.RootPassword
+		return m.Users
 	}
-	return ""
+	return nil
 }
 
-type UpdateAccountRequest struct {
-	Account              *Account              `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+type AccountBundle_BundledUser struct {
+	User                 *User                            `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Identities           []*AccountBundle_BundledIdentity `protobuf:"bytes,2,rep,name=identities,proto3" json:"identities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
 }
 
-func (m *UpdateAccountRequest) Reset()         { *m = UpdateAccountRequest{} }
-func (m *UpdateAccountRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateAccountRequest) ProtoMessage()    {}
-func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{7}
+func (m *AccountBundle_BundledUser) Reset()         { *m = AccountBundle_BundledUser{} }
+func (m *AccountBundle_BundledUser) String() string { return proto.CompactTextString(m) }
+func (*AccountBundle_BundledUser) ProtoMessage()    {}
+func (*AccountBundle_BundledUser) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{7, 0}
 }
 
-func (m *UpdateAccountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateAccountRequest.Unmarshal(m, b)
+func (m *AccountBundle_BundledUser) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountBundle_BundledUser.Unmarshal(m, b)
 }
-func (m *UpdateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateAccountRequest.Marshal(b, m, deterministic)
+func (m *AccountBundle_BundledUser) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountBundle_BundledUser.Marshal(b, m, deterministic)
 }
-func (m *UpdateAccountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateAccountRequest.Merge(m, src)
+func (m *AccountBundle_BundledUser) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountBundle_BundledUser.Merge(m, src)
 }
-func (m *UpdateAccountRequest) XXX_Size() int {
-	return xxx_messageInfo_UpdateAccountRequest.Size(m)
+func (m *AccountBundle_BundledUser) XXX_Size() int {
+	return xxx_messageInfo_AccountBundle_BundledUser.Size(m)
 }
-func (m *UpdateAccountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateAccountRequest.DiscardUnknown(m)
+func (m *AccountBundle_BundledUser) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountBundle_BundledUser.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateAccountRequest proto.InternalMessageInfo
+var xxx_messageInfo_AccountBundle_BundledUser proto.InternalMessageInfo
 
-func (m *UpdateAccountRequest) GetAccount() *Account {
+func (m *AccountBundle_BundledUser) GetUser() *User {
 	if m != nil {
-		return m.Account
+		return m.User
 	}
 	return nil
 }
 
-func (m *UpdateAccountRequest) GetUpdateMask() *field_mask.FieldMask {
+func (m *AccountBundle_BundledUser) GetIdentities() []*AccountBundle_BundledIdentity {
 	if m != nil {
-		return m.UpdateMask
+		return m.Identities
 	}
 	return nil
 }
 
-type DeleteAccountRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type AccountBundle_BundledIdentity struct {
+	Identity             *Identity `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	PasswordHash         string    `protobuf:"bytes,2,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *DeleteAccountRequest) Reset()         { *m = DeleteAccountRequest{} }
-func (m *DeleteAccountRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteAccountRequest) ProtoMessage()    {}
-func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{8}
+func (m *AccountBundle_BundledIdentity) Reset()         { *m = AccountBundle_BundledIdentity{} }
+func (m *AccountBundle_BundledIdentity) String() string { return proto.CompactTextString(m) }
+func (*AccountBundle_BundledIdentity) ProtoMessage()    {}
+func (*AccountBundle_BundledIdentity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{7, 1}
 }
 
-func (m *DeleteAccountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteAccountRequest.Unmarshal(m, b)
+func (m *AccountBundle_BundledIdentity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountBundle_BundledIdentity.Unmarshal(m, b)
 }
-func (m *DeleteAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteAccountRequest.Marshal(b, m, deterministic)
+func (m *AccountBundle_BundledIdentity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountBundle_BundledIdentity.Marshal(b, m, deterministic)
 }
-func (m *DeleteAccountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteAccountRequest.Merge(m, src)
+func (m *AccountBundle_BundledIdentity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountBundle_BundledIdentity.Merge(m, src)
 }
-func (m *DeleteAccountRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteAccountRequest.Size(m)
+func (m *AccountBundle_BundledIdentity) XXX_Size() int {
+	return xxx_messageInfo_AccountBundle_BundledIdentity.Size(m)
 }
-func (m *DeleteAccountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteAccountRequest.DiscardUnknown(m)
+func (m *AccountBundle_BundledIdentity) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountBundle_BundledIdentity.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteAccountRequest proto.InternalMessageInfo
+var xxx_messageInfo_AccountBundle_BundledIdentity proto.InternalMessageInfo
 
-func (m *DeleteAccountRequest) GetName() string {
+func (m *AccountBundle_BundledIdentity) GetIdentity() *Identity {
 	if m != nil {
-		return m.Name
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *AccountBundle_BundledIdentity) GetPasswordHash() string {
+	if m != nil {
+		return m.PasswordHash
 	}
 	return ""
 }
 
-type ListUsersRequest struct {
-	PageSize             int32    `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type PasswordPolicy struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	MinLength            int32                `protobuf:"varint,3,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
+	RequireUppercase     bool                 `protobuf:"varint,4,opt,name=require_uppercase,json=requireUppercase,proto3" json:"require_uppercase,omitempty"`
+	RequireLowercase     bool                 `protobuf:"varint,5,opt,name=require_lowercase,json=requireLowercase,proto3" json:"require_lowercase,omitempty"`
+	RequireDigit         bool                 `protobuf:"varint,6,opt,name=require_digit,json=requireDigit,proto3" json:"require_digit,omitempty"`
+	RequireSymbol        bool                 `protobuf:"varint,7,opt,name=require_symbol,json=requireSymbol,proto3" json:"require_symbol,omitempty"`
+	HistorySize          int32                `protobuf:"varint,8,opt,name=history_size,json=historySize,proto3" json:"history_size,omitempty"`
+	MaxAge               *duration.Duration   `protobuf:"bytes,9,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ListUsersRequest) Reset()         { *m = ListUsersRequest{} }
-func (m *ListUsersRequest) String() string { return proto.CompactTextString(m) }
-func (*ListUsersRequest) ProtoMessage()    {}
-func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{9}
+func (m *PasswordPolicy) Reset()         { *m = PasswordPolicy{} }
+func (m *PasswordPolicy) String() string { return proto.CompactTextString(m) }
+func (*PasswordPolicy) ProtoMessage()    {}
+func (*PasswordPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{8}
 }
 
-func (m *ListUsersRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListUsersRequest.Unmarshal(m, b)
+func (m *PasswordPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PasswordPolicy.Unmarshal(m, b)
 }
-func (m *ListUsersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListUsersRequest.Marshal(b, m, deterministic)
+func (m *PasswordPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PasswordPolicy.Marshal(b, m, deterministic)
 }
-func (m *ListUsersRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListUsersRequest.Merge(m, src)
+func (m *PasswordPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PasswordPolicy.Merge(m, src)
 }
-func (m *ListUsersRequest) XXX_Size() int {
-	return xxx_messageInfo_ListUsersRequest.Size(m)
+func (m *PasswordPolicy) XXX_Size() int {
+	return xxx_messageInfo_PasswordPolicy.Size(m)
 }
-func (m *ListUsersRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListUsersRequest.DiscardUnknown(m)
+func (m *PasswordPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_PasswordPolicy.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListUsersRequest proto.InternalMessageInfo
+var xxx_messageInfo_PasswordPolicy proto.InternalMessageInfo
 
-func (m *ListUsersRequest) GetPageSize() int32 {
+func (m *PasswordPolicy) GetName() string {
 	if m != nil {
-		return m.PageSize
+		return m.Name
 	}
-	return 0
+	return ""
 }
 
-func (m *ListUsersRequest) GetPageToken() string {
+func (m *PasswordPolicy) GetUpdateTime() *timestamp.Timestamp {
 	if m != nil {
-		return m.PageToken
+		return m.UpdateTime
 	}
-	return ""
+	return nil
 }
 
-type ListUsersResponse struct {
-	Users                []*User  `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *PasswordPolicy) GetMinLength() int32 {
+	if m != nil {
+		return m.MinLength
+	}
+	return 0
 }
 
-func (m *ListUsersResponse) Reset()         { *m = ListUsersResponse{} }
-func (m *ListUsersResponse) String() string { return proto.CompactTextString(m) }
-func (*ListUsersResponse) ProtoMessage()    {}
-func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{10}
+func (m *PasswordPolicy) GetRequireUppercase() bool {
+	if m != nil {
+		return m.RequireUppercase
+	}
+	return false
 }
 
-func (m *ListUsersResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListUsersResponse.Unmarshal(m, b)
-}
-func (m *ListUsersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListUsersResponse.Marshal(b, m, deterministic)
+func (m *PasswordPolicy) GetRequireLowercase() bool {
+	if m != nil {
+		return m.RequireLowercase
+	}
+	return false
 }
-func (m *ListUsersResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListUsersResponse.Merge(m, src)
-}
-func (m *ListUsersResponse) XXX_Size() int {
-	return xxx_messageInfo_ListUsersResponse.Size(m)
-}
-func (m *ListUsersResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListUsersResponse.DiscardUnknown(m)
+
+func (m *PasswordPolicy) GetRequireDigit() bool {
+	if m != nil {
+		return m.RequireDigit
+	}
+	return false
 }
 
-var xxx_messageInfo_ListUsersResponse proto.InternalMessageInfo
+func (m *PasswordPolicy) GetRequireSymbol() bool {
+	if m != nil {
+		return m.RequireSymbol
+	}
+	return false
+}
 
-func (m *ListUsersResponse) GetUsers() []*User {
+func (m *PasswordPolicy) GetHistorySize() int32 {
 	if m != nil {
-		return m.Users
+		return m.HistorySize
 	}
-	return nil
+	return 0
 }
 
-func (m *ListUsersResponse) GetNextPageToken() string {
+func (m *PasswordPolicy) GetMaxAge() *duration.Duration {
 	if m != nil {
-		return m.NextPageToken
+		return m.MaxAge
 	}
-	return ""
+	return nil
 }
 
-type GetUserRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type AuditEvent struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	Actor                string               `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Account              string               `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`
+	Resource             string               `protobuf:"bytes,5,opt,name=resource,proto3" json:"resource,omitempty"`
+	Method               string               `protobuf:"bytes,6,opt,name=method,proto3" json:"method,omitempty"`
+	SourceIp             string               `protobuf:"bytes,7,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	Outcome              AuditEvent_Outcome   `protobuf:"varint,8,opt,name=outcome,proto3,enum=iam.AuditEvent_Outcome" json:"outcome,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *GetUserRequest) Reset()         { *m = GetUserRequest{} }
-func (m *GetUserRequest) String() string { return proto.CompactTextString(m) }
-func (*GetUserRequest) ProtoMessage()    {}
-func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{11}
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{9}
 }
 
-func (m *GetUserRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetUserRequest.Unmarshal(m, b)
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
 }
-func (m *GetUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetUserRequest.Marshal(b, m, deterministic)
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
 }
-func (m *GetUserRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetUserRequest.Merge(m, src)
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
 }
-func (m *GetUserRequest) XXX_Size() int {
-	return xxx_messageInfo_GetUserRequest.Size(m)
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
 }
-func (m *GetUserRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetUserRequest.DiscardUnknown(m)
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetUserRequest proto.InternalMessageInfo
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
 
-func (m *GetUserRequest) GetName() string {
+func (m *AuditEvent) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-type CreateUserRequest struct {
-	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *CreateUserRequest) Reset()         { *m = CreateUserRequest{} }
-func (m *CreateUserRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateUserRequest) ProtoMessage()    {}
-func (*CreateUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{12}
-}
-
-func (m *CreateUserRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateUserRequest.Unmarshal(m, b)
-}
-func (m *CreateUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateUserRequest.Marshal(b, m, deterministic)
-}
-func (m *CreateUserRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateUserRequest.Merge(m, src)
-}
-func (m *CreateUserRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateUserRequest.Size(m)
-}
-func (m *CreateUserRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateUserRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_CreateUserRequest proto.InternalMessageInfo
-
-func (m *CreateUserRequest) GetUser() *User {
+func (m *AuditEvent) GetCreateTime() *timestamp.Timestamp {
 	if m != nil {
-		return m.User
+		return m.CreateTime
 	}
 	return nil
 }
 
-type UpdateUserRequest struct {
-	User                 *User                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+func (m *AuditEvent) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
 }
 
-func (m *UpdateUserRequest) Reset()         { *m = UpdateUserRequest{} }
-func (m *UpdateUserRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateUserRequest) ProtoMessage()    {}
-func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{13}
+func (m *AuditEvent) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
 }
 
-func (m *UpdateUserRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateUserRequest.Unmarshal(m, b)
-}
-func (m *UpdateUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateUserRequest.Marshal(b, m, deterministic)
-}
-func (m *UpdateUserRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateUserRequest.Merge(m, src)
-}
-func (m *UpdateUserRequest) XXX_Size() int {
-	return xxx_messageInfo_UpdateUserRequest.Size(m)
-}
-func (m *UpdateUserRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateUserRequest.DiscardUnknown(m)
+func (m *AuditEvent) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
 }
 
-var xxx_messageInfo_UpdateUserRequest proto.InternalMessageInfo
+func (m *AuditEvent) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
 
-func (m *UpdateUserRequest) GetUser() *User {
+func (m *AuditEvent) GetSourceIp() string {
 	if m != nil {
-		return m.User
+		return m.SourceIp
 	}
-	return nil
+	return ""
 }
 
-func (m *UpdateUserRequest) GetUpdateMask() *field_mask.FieldMask {
+func (m *AuditEvent) GetOutcome() AuditEvent_Outcome {
 	if m != nil {
-		return m.UpdateMask
+		return m.Outcome
 	}
-	return nil
+	return AuditEvent_OUTCOME_UNSPECIFIED
 }
 
-type DeleteUserRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Webhook struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,3,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	DeleteTime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	Url                  string               `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes           []string             `protobuf:"bytes,6,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Secret               string               `protobuf:"bytes,7,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *DeleteUserRequest) Reset()         { *m = DeleteUserRequest{} }
-func (m *DeleteUserRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteUserRequest) ProtoMessage()    {}
-func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{14}
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{10}
 }
 
-func (m *DeleteUserRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteUserRequest.Unmarshal(m, b)
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
 }
-func (m *DeleteUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteUserRequest.Marshal(b, m, deterministic)
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
 }
-func (m *DeleteUserRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteUserRequest.Merge(m, src)
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
 }
-func (m *DeleteUserRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteUserRequest.Size(m)
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
 }
-func (m *DeleteUserRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteUserRequest.DiscardUnknown(m)
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteUserRequest proto.InternalMessageInfo
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
 
-func (m *DeleteUserRequest) GetName() string {
+func (m *Webhook) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-type ListIdentitiesRequest struct {
-	Parent               string   `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
-	PageSize             int32    `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Webhook) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
 }
 
-func (m *ListIdentitiesRequest) Reset()         { *m = ListIdentitiesRequest{} }
-func (m *ListIdentitiesRequest) String() string { return proto.CompactTextString(m) }
-func (*ListIdentitiesRequest) ProtoMessage()    {}
-func (*ListIdentitiesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{15}
+func (m *Webhook) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
 }
 
-func (m *ListIdentitiesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListIdentitiesRequest.Unmarshal(m, b)
-}
-func (m *ListIdentitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListIdentitiesRequest.Marshal(b, m, deterministic)
-}
-func (m *ListIdentitiesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListIdentitiesRequest.Merge(m, src)
-}
-func (m *ListIdentitiesRequest) XXX_Size() int {
-	return xxx_messageInfo_ListIdentitiesRequest.Size(m)
-}
-func (m *ListIdentitiesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListIdentitiesRequest.DiscardUnknown(m)
+func (m *Webhook) GetDeleteTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeleteTime
+	}
+	return nil
 }
 
-var xxx_messageInfo_ListIdentitiesRequest proto.InternalMessageInfo
-
-func (m *ListIdentitiesRequest) GetParent() string {
+func (m *Webhook) GetUrl() string {
 	if m != nil {
-		return m.Parent
+		return m.Url
 	}
 	return ""
 }
 
-func (m *ListIdentitiesRequest) GetPageSize() int32 {
+func (m *Webhook) GetEventTypes() []string {
 	if m != nil {
-		return m.PageSize
+		return m.EventTypes
 	}
-	return 0
+	return nil
 }
 
-func (m *ListIdentitiesRequest) GetPageToken() string {
+func (m *Webhook) GetSecret() string {
 	if m != nil {
-		return m.PageToken
+		return m.Secret
 	}
 	return ""
 }
 
-type ListIdentitiesResponse struct {
-	Identities           []*Identity `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
-	NextPageToken        string      `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+type WebhookDelivery struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	EventType            string               `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Payload              string               `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Attempts             int32                `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError            string               `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ListIdentitiesResponse) Reset()         { *m = ListIdentitiesResponse{} }
-func (m *ListIdentitiesResponse) String() string { return proto.CompactTextString(m) }
-func (*ListIdentitiesResponse) ProtoMessage()    {}
-func (*ListIdentitiesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{16}
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{11}
 }
 
-func (m *ListIdentitiesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListIdentitiesResponse.Unmarshal(m, b)
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
 }
-func (m *ListIdentitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListIdentitiesResponse.Marshal(b, m, deterministic)
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
 }
-func (m *ListIdentitiesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListIdentitiesResponse.Merge(m, src)
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
 }
-func (m *ListIdentitiesResponse) XXX_Size() int {
-	return xxx_messageInfo_ListIdentitiesResponse.Size(m)
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
 }
-func (m *ListIdentitiesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListIdentitiesResponse.DiscardUnknown(m)
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListIdentitiesResponse proto.InternalMessageInfo
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
 
-func (m *ListIdentitiesResponse) GetIdentities() []*Identity {
+func (m *WebhookDelivery) GetName() string {
 	if m != nil {
-		return m.Identities
+		return m.Name
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
 	}
 	return nil
 }
 
-func (m *ListIdentitiesResponse) GetNextPageToken() string {
+func (m *WebhookDelivery) GetEventType() string {
 	if m != nil {
-		return m.NextPageToken
+		return m.EventType
 	}
 	return ""
 }
 
-type GetIdentityRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
+func (m *WebhookDelivery) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+type AuthenticateRequest struct {
+	Account  string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	User     string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// Authenticates as a principal instead of a user. account picks which of
+	// the principal's accounts to authenticate to, and may be left empty if it
+	// has only one.
+	Principal            string   `protobuf:"bytes,4,opt,name=principal,proto3" json:"principal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetIdentityRequest) Reset()         { *m = GetIdentityRequest{} }
-func (m *GetIdentityRequest) String() string { return proto.CompactTextString(m) }
-func (*GetIdentityRequest) ProtoMessage()    {}
-func (*GetIdentityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{17}
+func (m *AuthenticateRequest) Reset()         { *m = AuthenticateRequest{} }
+func (m *AuthenticateRequest) String() string { return proto.CompactTextString(m) }
+func (*AuthenticateRequest) ProtoMessage()    {}
+func (*AuthenticateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{12}
 }
 
-func (m *GetIdentityRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetIdentityRequest.Unmarshal(m, b)
+func (m *AuthenticateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthenticateRequest.Unmarshal(m, b)
 }
-func (m *GetIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetIdentityRequest.Marshal(b, m, deterministic)
+func (m *AuthenticateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthenticateRequest.Marshal(b, m, deterministic)
 }
-func (m *GetIdentityRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetIdentityRequest.Merge(m, src)
+func (m *AuthenticateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthenticateRequest.Merge(m, src)
 }
-func (m *GetIdentityRequest) XXX_Size() int {
-	return xxx_messageInfo_GetIdentityRequest.Size(m)
+func (m *AuthenticateRequest) XXX_Size() int {
+	return xxx_messageInfo_AuthenticateRequest.Size(m)
 }
-func (m *GetIdentityRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetIdentityRequest.DiscardUnknown(m)
+func (m *AuthenticateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthenticateRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetIdentityRequest proto.InternalMessageInfo
+var xxx_messageInfo_AuthenticateRequest proto.InternalMessageInfo
 
-func (m *GetIdentityRequest) GetName() string {
+func (m *AuthenticateRequest) GetAccount() string {
 	if m != nil {
-		return m.Name
+		return m.Account
 	}
 	return ""
 }
 
-type CreateIdentityRequest struct {
-	Parent               string    `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
-	Identity             *Identity `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *AuthenticateRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
 }
 
-func (m *CreateIdentityRequest) Reset()         { *m = CreateIdentityRequest{} }
-func (m *CreateIdentityRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateIdentityRequest) ProtoMessage()    {}
-func (*CreateIdentityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{18}
+func (m *AuthenticateRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
 }
 
-func (m *CreateIdentityRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateIdentityRequest.Unmarshal(m, b)
+func (m *AuthenticateRequest) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
 }
-func (m *CreateIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateIdentityRequest.Marshal(b, m, deterministic)
+
+type AuthenticateResponse struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *CreateIdentityRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateIdentityRequest.Merge(m, src)
+
+func (m *AuthenticateResponse) Reset()         { *m = AuthenticateResponse{} }
+func (m *AuthenticateResponse) String() string { return proto.CompactTextString(m) }
+func (*AuthenticateResponse) ProtoMessage()    {}
+func (*AuthenticateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{13}
 }
-func (m *CreateIdentityRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateIdentityRequest.Size(m)
+
+func (m *AuthenticateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthenticateResponse.Unmarshal(m, b)
 }
-func (m *CreateIdentityRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateIdentityRequest.DiscardUnknown(m)
+func (m *AuthenticateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthenticateResponse.Marshal(b, m, deterministic)
+}
+func (m *AuthenticateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthenticateResponse.Merge(m, src)
+}
+func (m *AuthenticateResponse) XXX_Size() int {
+	return xxx_messageInfo_AuthenticateResponse.Size(m)
+}
+func (m *AuthenticateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthenticateResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateIdentityRequest proto.InternalMessageInfo
+var xxx_messageInfo_AuthenticateResponse proto.InternalMessageInfo
 
-func (m *CreateIdentityRequest) GetParent() string {
+func (m *AuthenticateResponse) GetToken() string {
 	if m != nil {
-		return m.Parent
+		return m.Token
 	}
 	return ""
 }
 
-func (m *CreateIdentityRequest) GetIdentity() *Identity {
-	if m != nil {
-		return m.Identity
-	}
-	return nil
-}
-
-type UpdateIdentityRequest struct {
-	Identity             *Identity             `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
-	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+type RequestPasswordResetRequest struct {
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	User                 string   `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateIdentityRequest) Reset()         { *m = UpdateIdentityRequest{} }
-func (m *UpdateIdentityRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateIdentityRequest) ProtoMessage()    {}
-func (*UpdateIdentityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{19}
+func (m *RequestPasswordResetRequest) Reset()         { *m = RequestPasswordResetRequest{} }
+func (m *RequestPasswordResetRequest) String() string { return proto.CompactTextString(m) }
+func (*RequestPasswordResetRequest) ProtoMessage()    {}
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{14}
 }
 
-func (m *UpdateIdentityRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateIdentityRequest.Unmarshal(m, b)
+func (m *RequestPasswordResetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestPasswordResetRequest.Unmarshal(m, b)
 }
-func (m *UpdateIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateIdentityRequest.Marshal(b, m, deterministic)
+func (m *RequestPasswordResetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestPasswordResetRequest.Marshal(b, m, deterministic)
 }
-func (m *UpdateIdentityRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateIdentityRequest.Merge(m, src)
+func (m *RequestPasswordResetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestPasswordResetRequest.Merge(m, src)
 }
-func (m *UpdateIdentityRequest) XXX_Size() int {
-	return xxx_messageInfo_UpdateIdentityRequest.Size(m)
+func (m *RequestPasswordResetRequest) XXX_Size() int {
+	return xxx_messageInfo_RequestPasswordResetRequest.Size(m)
 }
-func (m *UpdateIdentityRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateIdentityRequest.DiscardUnknown(m)
+func (m *RequestPasswordResetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestPasswordResetRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateIdentityRequest proto.InternalMessageInfo
+var xxx_messageInfo_RequestPasswordResetRequest proto.InternalMessageInfo
 
-func (m *UpdateIdentityRequest) GetIdentity() *Identity {
+func (m *RequestPasswordResetRequest) GetAccount() string {
 	if m != nil {
-		return m.Identity
+		return m.Account
 	}
-	return nil
+	return ""
 }
 
-func (m *UpdateIdentityRequest) GetUpdateMask() *field_mask.FieldMask {
+func (m *RequestPasswordResetRequest) GetUser() string {
 	if m != nil {
-		return m.UpdateMask
+		return m.User
 	}
-	return nil
+	return ""
 }
 
-type DeleteIdentityRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+type ConfirmPasswordResetRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	NewPassword          string   `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteIdentityRequest) Reset()         { *m = DeleteIdentityRequest{} }
-func (m *DeleteIdentityRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteIdentityRequest) ProtoMessage()    {}
-func (*DeleteIdentityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a2c201915207782, []int{20}
+func (m *ConfirmPasswordResetRequest) Reset()         { *m = ConfirmPasswordResetRequest{} }
+func (m *ConfirmPasswordResetRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfirmPasswordResetRequest) ProtoMessage()    {}
+func (*ConfirmPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{15}
 }
 
-func (m *DeleteIdentityRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteIdentityRequest.Unmarshal(m, b)
+func (m *ConfirmPasswordResetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmPasswordResetRequest.Unmarshal(m, b)
 }
-func (m *DeleteIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteIdentityRequest.Marshal(b, m, deterministic)
+func (m *ConfirmPasswordResetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmPasswordResetRequest.Marshal(b, m, deterministic)
 }
-func (m *DeleteIdentityRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteIdentityRequest.Merge(m, src)
+func (m *ConfirmPasswordResetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmPasswordResetRequest.Merge(m, src)
 }
-func (m *DeleteIdentityRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteIdentityRequest.Size(m)
+func (m *ConfirmPasswordResetRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfirmPasswordResetRequest.Size(m)
 }
-func (m *DeleteIdentityRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteIdentityRequest.DiscardUnknown(m)
+func (m *ConfirmPasswordResetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmPasswordResetRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteIdentityRequest proto.InternalMessageInfo
+var xxx_messageInfo_ConfirmPasswordResetRequest proto.InternalMessageInfo
 
-func (m *DeleteIdentityRequest) GetName() string {
+func (m *ConfirmPasswordResetRequest) GetToken() string {
 	if m != nil {
-		return m.Name
+		return m.Token
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterEnum("iam.Identity_AuthMethod", Identity_AuthMethod_name, Identity_AuthMethod_value)
-	proto.RegisterType((*Account)(nil), "iam.Account")
-	proto.RegisterType((*User)(nil), "iam.User")
-	proto.RegisterType((*Identity)(nil), "iam.Identity")
-	proto.RegisterType((*AuthenticateRequest)(nil), "iam.AuthenticateRequest")
-	proto.RegisterType((*AuthenticateResponse)(nil), "iam.AuthenticateResponse")
-	proto.RegisterType((*GetAccountRequest)(nil), "iam.GetAccountRequest")
-	proto.RegisterType((*CreateAccountRequest)(nil), "iam.CreateAccountRequest")
-	proto.RegisterType((*UpdateAccountRequest)(nil), "iam.UpdateAccountRequest")
-	proto.RegisterType((*DeleteAccountRequest)(nil), "iam.DeleteAccountRequest")
-	proto.RegisterType((*ListUsersRequest)(nil), "iam.ListUsersRequest")
-	proto.RegisterType((*ListUsersResponse)(nil), "iam.ListUsersResponse")
-	proto.RegisterType((*GetUserRequest)(nil), "iam.GetUserRequest")
-	proto.RegisterType((*CreateUserRequest)(nil), "iam.CreateUserRequest")
-	proto.RegisterType((*UpdateUserRequest)(nil), "iam.UpdateUserRequest")
-	proto.RegisterType((*DeleteUserRequest)(nil), "iam.DeleteUserRequest")
-	proto.RegisterType((*ListIdentitiesRequest)(nil), "iam.ListIdentitiesRequest")
-	proto.RegisterType((*ListIdentitiesResponse)(nil), "iam.ListIdentitiesResponse")
-	proto.RegisterType((*GetIdentityRequest)(nil), "iam.GetIdentityRequest")
-	proto.RegisterType((*CreateIdentityRequest)(nil), "iam.CreateIdentityRequest")
-	proto.RegisterType((*UpdateIdentityRequest)(nil), "iam.UpdateIdentityRequest")
-	proto.RegisterType((*DeleteIdentityRequest)(nil), "iam.DeleteIdentityRequest")
+func (m *ConfirmPasswordResetRequest) GetNewPassword() string {
+	if m != nil {
+		return m.NewPassword
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("iam.proto", fileDescriptor_0a2c201915207782) }
-
-var fileDescriptor_0a2c201915207782 = []byte{
-	// 1173 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0x5b, 0x6f, 0x1b, 0x45,
-	0x14, 0xae, 0xe3, 0xdc, 0x7c, 0x7c, 0x21, 0x9e, 0xda, 0xb1, 0xd9, 0x34, 0x4a, 0xba, 0x40, 0x63,
-	0x5c, 0x58, 0x83, 0xe1, 0x05, 0x23, 0x1e, 0x4c, 0xe2, 0x26, 0x91, 0x70, 0x9a, 0x6e, 0x12, 0x40,
-	0x08, 0xb1, 0x4c, 0xe3, 0x69, 0xb2, 0x8a, 0xbd, 0xbb, 0x78, 0xc6, 0x94, 0xb4, 0x42, 0x48, 0xe5,
-	0x11, 0xf1, 0xc4, 0x0b, 0xff, 0x8b, 0xbf, 0xc0, 0x0f, 0x41, 0x73, 0xd9, 0xab, 0xd7, 0x71, 0x44,
-	0xdf, 0xfa, 0x94, 0xdd, 0x99, 0x73, 0xbe, 0xf3, 0x9d, 0xf3, 0x9d, 0xfd, 0x62, 0xc8, 0xd9, 0x78,
-	0x64, 0x78, 0x63, 0x97, 0xb9, 0x28, 0x6b, 0xe3, 0x91, 0x76, 0xef, 0xc2, 0x75, 0x2f, 0x86, 0xa4,
-	0x85, 0x3d, 0xbb, 0x85, 0x1d, 0xc7, 0x65, 0x98, 0xd9, 0xae, 0x43, 0x65, 0x88, 0xb6, 0xa5, 0x6e,
-	0xc5, 0xdb, 0xd3, 0xc9, 0xb3, 0x16, 0xb3, 0x47, 0x84, 0x32, 0x3c, 0xf2, 0x54, 0xc0, 0x46, 0x32,
-	0x80, 0x8c, 0x3c, 0x76, 0xad, 0x2e, 0xb7, 0x93, 0x97, 0xcf, 0x6c, 0x32, 0x1c, 0x58, 0x23, 0x4c,
-	0xaf, 0x64, 0x84, 0xfe, 0xc7, 0x02, 0xac, 0x74, 0xcf, 0xcf, 0xdd, 0x89, 0xc3, 0x10, 0x82, 0x45,
-	0x07, 0x8f, 0x48, 0x3d, 0xb3, 0x9d, 0x69, 0xe4, 0x4c, 0xf1, 0x8c, 0x3e, 0x87, 0xfc, 0xf9, 0x98,
-	0x60, 0x46, 0x2c, 0x5e, 0xb8, 0xbe, 0xb0, 0x9d, 0x69, 0xe4, 0xdb, 0x9a, 0x21, 0x71, 0x0d, 0x1f,
-	0xd7, 0x38, 0xf5, 0x59, 0x99, 0x20, 0xc3, 0xf9, 0x01, 0x4f, 0x9e, 0x78, 0x83, 0x20, 0x39, 0x3b,
-	0x3f, 0x59, 0x86, 0xfb, 0xc9, 0x03, 0x32, 0x24, 0x7e, 0xf2, 0xe2, 0xfc, 0x64, 0x19, 0x2e, 0x92,
-	0xef, 0x43, 0x61, 0x60, 0x53, 0x6f, 0x88, 0xaf, 0x2d, 0xd1, 0xd2, 0x92, 0x68, 0x29, 0xaf, 0xce,
-	0x8e, 0x78, 0x67, 0x08, 0x16, 0xc7, 0xae, 0xcb, 0xea, 0xcb, 0xb2, 0x5b, 0xfe, 0xac, 0xff, 0xb9,
-	0x00, 0x8b, 0x67, 0x94, 0x8c, 0xdf, 0xa4, 0x51, 0xd4, 0x60, 0xc5, 0xa6, 0x96, 0x68, 0x95, 0x4f,
-	0x61, 0xd5, 0x5c, 0xb6, 0xa9, 0xe9, 0xba, 0x6c, 0x6a, 0x46, 0xcb, 0x53, 0x33, 0xd2, 0x7f, 0xcf,
-	0xc2, 0xea, 0xe1, 0x80, 0x38, 0xcc, 0x66, 0xd7, 0x6f, 0xd2, 0x4c, 0x3e, 0x83, 0x3c, 0x9e, 0xb0,
-	0x4b, 0x6b, 0x44, 0xd8, 0xa5, 0x3b, 0x10, 0x73, 0x29, 0xb5, 0xeb, 0x06, 0xff, 0x32, 0xfd, 0x76,
-	0x8d, 0xee, 0x84, 0x5d, 0xf6, 0xc5, 0xbd, 0x09, 0x38, 0x78, 0x46, 0xf7, 0x60, 0xd5, 0xc3, 0x94,
-	0x3e, 0x77, 0xc7, 0x03, 0x39, 0xb1, 0x83, 0x3b, 0x66, 0x70, 0xa2, 0xef, 0x02, 0x84, 0x79, 0x68,
-	0x03, 0x6a, 0xdd, 0xb3, 0xd3, 0x03, 0xab, 0xdf, 0x3b, 0x3d, 0x78, 0xbc, 0x67, 0x9d, 0x1d, 0x9d,
-	0x1c, 0xf7, 0x76, 0x0f, 0x1f, 0x1d, 0xf6, 0xf6, 0xd6, 0xee, 0xa0, 0x3a, 0x54, 0xa2, 0x97, 0xc7,
-	0xdd, 0x93, 0x93, 0x6f, 0x1e, 0x9b, 0x7b, 0x6b, 0x99, 0x2f, 0x4b, 0x50, 0x10, 0xec, 0x06, 0x84,
-	0x61, 0x7b, 0x48, 0x75, 0x0b, 0xee, 0x72, 0x50, 0xce, 0xeb, 0x1c, 0x33, 0x62, 0x92, 0x9f, 0x26,
-	0x84, 0x32, 0x54, 0x87, 0x15, 0x2c, 0xbf, 0x5c, 0x25, 0x89, 0xff, 0xca, 0x95, 0x9a, 0x50, 0x32,
-	0x16, 0x72, 0xe4, 0x4c, 0xf1, 0x8c, 0xb4, 0x08, 0xef, 0xac, 0x38, 0x0f, 0x59, 0x7f, 0x00, 0x95,
-	0x78, 0x01, 0xea, 0xb9, 0x0e, 0x25, 0xa8, 0x02, 0x4b, 0xcc, 0xbd, 0x22, 0x8e, 0xc2, 0x97, 0x2f,
-	0xfa, 0x0e, 0x94, 0xf7, 0x09, 0x53, 0xa6, 0xe1, 0x93, 0x49, 0x59, 0x0e, 0xfd, 0x55, 0x06, 0x2a,
-	0xbb, 0x42, 0xee, 0x44, 0xf0, 0x83, 0x38, 0xf3, 0x7c, 0xbb, 0x20, 0x46, 0xef, 0x47, 0x05, 0x7d,
-	0x6c, 0xaa, 0x4f, 0x54, 0xae, 0x55, 0x4e, 0x04, 0xf1, 0xcf, 0x53, 0x7e, 0xad, 0xe8, 0x1d, 0x28,
-	0xf2, 0xbf, 0x56, 0xa2, 0xaf, 0x02, 0x3f, 0x3c, 0xf6, 0x7b, 0x7b, 0x09, 0x95, 0x33, 0xb1, 0x35,
-	0xff, 0x93, 0x43, 0xb8, 0xa4, 0xdc, 0x35, 0x67, 0x6e, 0xf8, 0x23, 0x6e, 0xac, 0x7d, 0x4c, 0xaf,
-	0xfc, 0x25, 0xe5, 0xcf, 0x7a, 0x13, 0x2a, 0x7b, 0x62, 0xeb, 0x6e, 0x31, 0xad, 0x23, 0x58, 0xfb,
-	0xca, 0xa6, 0x8c, 0xf7, 0x47, 0xfd, 0xb8, 0x0d, 0xc8, 0x79, 0xf8, 0x82, 0x58, 0xd4, 0x7e, 0x21,
-	0x83, 0x97, 0xb8, 0x6a, 0x17, 0xe4, 0xc4, 0x7e, 0x41, 0xd0, 0x26, 0x80, 0xb8, 0x94, 0x12, 0x49,
-	0xad, 0x45, 0xf8, 0xa9, 0x90, 0xe9, 0x7b, 0x28, 0x47, 0xf0, 0x94, 0xa2, 0x5b, 0xb0, 0xc4, 0xb7,
-	0x81, 0xd6, 0x33, 0xdb, 0xd9, 0xf8, 0x48, 0xe5, 0x39, 0x7a, 0x00, 0x6f, 0x39, 0xe4, 0x17, 0x3e,
-	0xd3, 0x04, 0x72, 0x91, 0x1f, 0x1f, 0x07, 0xe8, 0xef, 0x42, 0x69, 0x9f, 0x08, 0xf0, 0x9b, 0x7a,
-	0x6a, 0x43, 0x59, 0x2e, 0x40, 0x34, 0x70, 0x53, 0x6d, 0x67, 0x66, 0x4a, 0x55, 0x7e, 0xac, 0xbb,
-	0x50, 0x96, 0x82, 0xdd, 0x3e, 0xe7, 0xf5, 0x44, 0xda, 0x81, 0xb2, 0x14, 0x69, 0x5e, 0x37, 0x57,
-	0x50, 0xe5, 0x13, 0x55, 0x0e, 0x61, 0x93, 0x40, 0xa6, 0x75, 0x58, 0xf6, 0xf0, 0x98, 0x04, 0x1f,
-	0xa2, 0x7a, 0x8b, 0xcb, 0xb7, 0x70, 0xa3, 0x7c, 0xd9, 0xa4, 0x7c, 0x2e, 0xac, 0x27, 0x8b, 0x29,
-	0x0d, 0x3f, 0x04, 0xb0, 0x83, 0x53, 0x25, 0x64, 0x31, 0xe6, 0x5d, 0x66, 0x24, 0xe0, 0xd6, 0x8a,
-	0x36, 0x00, 0xed, 0x13, 0x16, 0x40, 0xdc, 0x30, 0x87, 0xef, 0xa0, 0x2a, 0x55, 0x4d, 0x06, 0xcf,
-	0x9a, 0xc3, 0xfb, 0xb0, 0xaa, 0x08, 0x5d, 0x2b, 0x6d, 0x12, 0x7c, 0x83, 0x6b, 0xfd, 0x37, 0xa8,
-	0x4a, 0xf5, 0x93, 0xd8, 0x51, 0x8c, 0xcc, 0x8d, 0x18, 0xaf, 0xb7, 0x0d, 0x0f, 0xa1, 0x2a, 0xb7,
-	0xe1, 0x16, 0x93, 0x68, 0xff, 0x9d, 0x87, 0xec, 0x61, 0xb7, 0x8f, 0x7e, 0x84, 0x42, 0xd4, 0x40,
-	0x91, 0xfc, 0x57, 0x92, 0x62, 0xda, 0xda, 0xdb, 0x29, 0x37, 0x52, 0x57, 0x7d, 0xe3, 0xd5, 0x3f,
-	0xff, 0xfe, 0xb5, 0x50, 0xd5, 0xd7, 0x5a, 0x3f, 0x7f, 0xd4, 0xc2, 0x91, 0x88, 0x4e, 0xa6, 0x89,
-	0x9e, 0x00, 0x84, 0xa6, 0x8b, 0xd6, 0x05, 0xca, 0x94, 0x0b, 0x6b, 0x31, 0x0f, 0xd3, 0x37, 0x05,
-	0x60, 0x0d, 0x55, 0x39, 0xe0, 0x4b, 0x4e, 0xf8, 0x0b, 0xe5, 0x6a, 0xb4, 0xd5, 0xfc, 0x15, 0x3d,
-	0x81, 0x62, 0xcc, 0x9d, 0x91, 0xe4, 0x96, 0xe6, 0xd8, 0x09, 0xe0, 0x9a, 0x00, 0x2e, 0xeb, 0x05,
-	0xc1, 0x54, 0x41, 0x72, 0x96, 0x97, 0x50, 0x8c, 0x99, 0xad, 0x82, 0x4c, 0x33, 0xe0, 0x04, 0xa4,
-	0x21, 0x20, 0x1b, 0xed, 0x4d, 0xc1, 0x55, 0x61, 0x1a, 0x49, 0xce, 0x9d, 0xc0, 0x96, 0x31, 0x14,
-	0x63, 0xce, 0xaa, 0x2a, 0xa5, 0xb9, 0xad, 0xb6, 0x3e, 0x25, 0x7d, 0x8f, 0xff, 0x46, 0xf6, 0xe7,
-	0xd3, 0x9c, 0x31, 0x9f, 0x3e, 0xe4, 0x02, 0x03, 0x45, 0x55, 0x01, 0x9f, 0x34, 0x68, 0x6d, 0x3d,
-	0x79, 0xac, 0xb4, 0x2c, 0x0b, 0xe8, 0x3c, 0xca, 0x71, 0x68, 0xe9, 0xac, 0x3d, 0x58, 0x51, 0x8e,
-	0x89, 0xee, 0xfa, 0xf2, 0x45, 0x1c, 0x47, 0x0b, 0x4d, 0x4d, 0xd7, 0x44, 0x76, 0x05, 0xa1, 0x90,
-	0x98, 0xc0, 0xe0, 0xac, 0x0e, 0x00, 0x42, 0x4b, 0x55, 0x8b, 0x30, 0xe5, 0xb1, 0x51, 0x30, 0x5f,
-	0xac, 0x90, 0x4a, 0x47, 0x9a, 0xe6, 0xd7, 0x00, 0xa1, 0xd1, 0x2a, 0xa4, 0x29, 0xe7, 0x8d, 0x22,
-	0xbd, 0x27, 0x90, 0xb6, 0xda, 0x35, 0x41, 0x8b, 0x63, 0x18, 0x31, 0x6e, 0x0a, 0xf7, 0x5b, 0x80,
-	0xd0, 0x4f, 0x15, 0xee, 0x94, 0xc1, 0xce, 0x14, 0x45, 0xf5, 0xde, 0x4c, 0xeb, 0x7d, 0x0c, 0xa5,
-	0xb8, 0x27, 0x22, 0x2d, 0x98, 0xff, 0x94, 0x2b, 0x6b, 0x1b, 0xa9, 0x77, 0x4a, 0xa0, 0x1d, 0x51,
-	0xe6, 0x3e, 0xda, 0x12, 0x65, 0xa4, 0x4f, 0x05, 0x85, 0x5a, 0x11, 0xfb, 0xfc, 0x01, 0xf2, 0x11,
-	0x5b, 0x44, 0x35, 0x5f, 0xba, 0x84, 0x3d, 0x68, 0x71, 0x37, 0x4a, 0xe0, 0x47, 0xdb, 0x88, 0x80,
-	0xf3, 0x9e, 0x1c, 0x28, 0xc5, 0xcd, 0x54, 0xf5, 0x94, 0xea, 0xb0, 0xc9, 0x2a, 0x1f, 0x8b, 0x2a,
-	0x0f, 0xf5, 0x79, 0x5d, 0x74, 0x42, 0x73, 0x7c, 0x0e, 0xa5, 0xb8, 0xc1, 0xaa, 0x7a, 0xa9, 0xae,
-	0x9b, 0xac, 0xd7, 0x11, 0xf5, 0x3e, 0x6d, 0x37, 0x44, 0x3d, 0x1f, 0xd3, 0x98, 0xdd, 0x5e, 0xa4,
-	0xf0, 0x08, 0x4a, 0x71, 0x63, 0x55, 0x85, 0x53, 0xdd, 0x76, 0xe6, 0x7a, 0xa8, 0xb9, 0x36, 0xe7,
-	0xcd, 0xf5, 0xe9, 0xb2, 0x48, 0xfc, 0xe4, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x08, 0x7c, 0x3f,
-	0xeb, 0x6f, 0x0f, 0x00, 0x00,
+type VerifyEmailRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// IAMClient is the client API for IAM service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type IAMClient interface {
-	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
-	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error)
-	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error)
-	UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*Account, error)
-	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
-	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
-	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error)
-	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error)
-	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesResponse, error)
-	GetIdentity(ctx context.Context, in *GetIdentityRequest, opts ...grpc.CallOption) (*Identity, error)
-	CreateIdentity(ctx context.Context, in *CreateIdentityRequest, opts ...grpc.CallOption) (*Identity, error)
-	UpdateIdentity(ctx context.Context, in *UpdateIdentityRequest, opts ...grpc.CallOption) (*Identity, error)
-	DeleteIdentity(ctx context.Context, in *DeleteIdentityRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+func (m *VerifyEmailRequest) Reset()         { *m = VerifyEmailRequest{} }
+func (m *VerifyEmailRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyEmailRequest) ProtoMessage()    {}
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{16}
 }
 
-type iAMClient struct {
-	cc *grpc.ClientConn
+func (m *VerifyEmailRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyEmailRequest.Unmarshal(m, b)
 }
-
-func NewIAMClient(cc *grpc.ClientConn) IAMClient {
-	return &iAMClient{cc}
+func (m *VerifyEmailRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyEmailRequest.Marshal(b, m, deterministic)
+}
+func (m *VerifyEmailRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyEmailRequest.Merge(m, src)
+}
+func (m *VerifyEmailRequest) XXX_Size() int {
+	return xxx_messageInfo_VerifyEmailRequest.Size(m)
+}
+func (m *VerifyEmailRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyEmailRequest.DiscardUnknown(m)
 }
 
-func (c *iAMClient) Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error) {
-	out := new(AuthenticateResponse)
-	err := c.cc.Invoke(ctx, "/iam.IAM/Authenticate", in, out, opts...)
+var xxx_messageInfo_VerifyEmailRequest proto.InternalMessageInfo
+
+func (m *VerifyEmailRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type GetAccountRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ShowDeleted          bool     `protobuf:"varint,2,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccountRequest) Reset()         { *m = GetAccountRequest{} }
+func (m *GetAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountRequest) ProtoMessage()    {}
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{17}
+}
+
+func (m *GetAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountRequest.Unmarshal(m, b)
+}
+func (m *GetAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountRequest.Merge(m, src)
+}
+func (m *GetAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAccountRequest.Size(m)
+}
+func (m *GetAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountRequest proto.InternalMessageInfo
+
+func (m *GetAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetAccountRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+type ListMyAccountsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMyAccountsRequest) Reset()         { *m = ListMyAccountsRequest{} }
+func (m *ListMyAccountsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMyAccountsRequest) ProtoMessage()    {}
+func (*ListMyAccountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{18}
+}
+
+func (m *ListMyAccountsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMyAccountsRequest.Unmarshal(m, b)
+}
+func (m *ListMyAccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMyAccountsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListMyAccountsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMyAccountsRequest.Merge(m, src)
+}
+func (m *ListMyAccountsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMyAccountsRequest.Size(m)
+}
+func (m *ListMyAccountsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMyAccountsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMyAccountsRequest proto.InternalMessageInfo
+
+type ListMyAccountsResponse struct {
+	Memberships          []*AccountMembership `protobuf:"bytes,1,rep,name=memberships,proto3" json:"memberships,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListMyAccountsResponse) Reset()         { *m = ListMyAccountsResponse{} }
+func (m *ListMyAccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMyAccountsResponse) ProtoMessage()    {}
+func (*ListMyAccountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{19}
+}
+
+func (m *ListMyAccountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMyAccountsResponse.Unmarshal(m, b)
+}
+func (m *ListMyAccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMyAccountsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListMyAccountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMyAccountsResponse.Merge(m, src)
+}
+func (m *ListMyAccountsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMyAccountsResponse.Size(m)
+}
+func (m *ListMyAccountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMyAccountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMyAccountsResponse proto.InternalMessageInfo
+
+func (m *ListMyAccountsResponse) GetMemberships() []*AccountMembership {
+	if m != nil {
+		return m.Memberships
+	}
+	return nil
+}
+
+type CreateAccountRequest struct {
+	Account              *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Root                 *User    `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+	RootPassword         string   `protobuf:"bytes,3,opt,name=root_password,json=rootPassword,proto3" json:"root_password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAccountRequest) Reset()         { *m = CreateAccountRequest{} }
+func (m *CreateAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAccountRequest) ProtoMessage()    {}
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{20}
+}
+
+func (m *CreateAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAccountRequest.Unmarshal(m, b)
+}
+func (m *CreateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccountRequest.Merge(m, src)
+}
+func (m *CreateAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAccountRequest.Size(m)
+}
+func (m *CreateAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccountRequest proto.InternalMessageInfo
+
+func (m *CreateAccountRequest) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *CreateAccountRequest) GetRoot() *User {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *CreateAccountRequest) GetRootPassword() string {
+	if m != nil {
+		return m.RootPassword
+	}
+	return ""
+}
+
+type UpdateAccountRequest struct {
+	Account              *Account              `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateAccountRequest) Reset()         { *m = UpdateAccountRequest{} }
+func (m *UpdateAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateAccountRequest) ProtoMessage()    {}
+func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{21}
+}
+
+func (m *UpdateAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAccountRequest.Unmarshal(m, b)
+}
+func (m *UpdateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAccountRequest.Merge(m, src)
+}
+func (m *UpdateAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateAccountRequest.Size(m)
+}
+func (m *UpdateAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAccountRequest proto.InternalMessageInfo
+
+func (m *UpdateAccountRequest) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *UpdateAccountRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type DeleteAccountRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Etag                 string   `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteAccountRequest) Reset()         { *m = DeleteAccountRequest{} }
+func (m *DeleteAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteAccountRequest) ProtoMessage()    {}
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{22}
+}
+
+func (m *DeleteAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAccountRequest.Unmarshal(m, b)
+}
+func (m *DeleteAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAccountRequest.Merge(m, src)
+}
+func (m *DeleteAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteAccountRequest.Size(m)
+}
+func (m *DeleteAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAccountRequest proto.InternalMessageInfo
+
+func (m *DeleteAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteAccountRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type UndeleteAccountRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndeleteAccountRequest) Reset()         { *m = UndeleteAccountRequest{} }
+func (m *UndeleteAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*UndeleteAccountRequest) ProtoMessage()    {}
+func (*UndeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{23}
+}
+
+func (m *UndeleteAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndeleteAccountRequest.Unmarshal(m, b)
+}
+func (m *UndeleteAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndeleteAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *UndeleteAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteAccountRequest.Merge(m, src)
+}
+func (m *UndeleteAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_UndeleteAccountRequest.Size(m)
+}
+func (m *UndeleteAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndeleteAccountRequest proto.InternalMessageInfo
+
+func (m *UndeleteAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ExportAccountRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportAccountRequest) Reset()         { *m = ExportAccountRequest{} }
+func (m *ExportAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportAccountRequest) ProtoMessage()    {}
+func (*ExportAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{24}
+}
+
+func (m *ExportAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportAccountRequest.Unmarshal(m, b)
+}
+func (m *ExportAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportAccountRequest.Merge(m, src)
+}
+func (m *ExportAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportAccountRequest.Size(m)
+}
+func (m *ExportAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportAccountRequest proto.InternalMessageInfo
+
+func (m *ExportAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ImportAccountRequest struct {
+	Name                 string                                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Bundle               *AccountBundle                        `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	ConflictStrategy     ImportAccountRequest_ConflictStrategy `protobuf:"varint,3,opt,name=conflict_strategy,json=conflictStrategy,proto3,enum=iam.ImportAccountRequest_ConflictStrategy" json:"conflict_strategy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
+}
+
+func (m *ImportAccountRequest) Reset()         { *m = ImportAccountRequest{} }
+func (m *ImportAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountRequest) ProtoMessage()    {}
+func (*ImportAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{25}
+}
+
+func (m *ImportAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAccountRequest.Unmarshal(m, b)
+}
+func (m *ImportAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAccountRequest.Merge(m, src)
+}
+func (m *ImportAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportAccountRequest.Size(m)
+}
+func (m *ImportAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAccountRequest proto.InternalMessageInfo
+
+func (m *ImportAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ImportAccountRequest) GetBundle() *AccountBundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+func (m *ImportAccountRequest) GetConflictStrategy() ImportAccountRequest_ConflictStrategy {
+	if m != nil {
+		return m.ConflictStrategy
+	}
+	return ImportAccountRequest_CONFLICT_STRATEGY_UNSPECIFIED
+}
+
+type ImportAccountResponse struct {
+	Account              *Account          `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	CreatedUsers         []string          `protobuf:"bytes,2,rep,name=created_users,json=createdUsers,proto3" json:"created_users,omitempty"`
+	OverwrittenUsers     []string          `protobuf:"bytes,3,rep,name=overwritten_users,json=overwrittenUsers,proto3" json:"overwritten_users,omitempty"`
+	SkippedUsers         []string          `protobuf:"bytes,4,rep,name=skipped_users,json=skippedUsers,proto3" json:"skipped_users,omitempty"`
+	RenamedUsers         map[string]string `protobuf:"bytes,5,rep,name=renamed_users,json=renamedUsers,proto3" json:"renamed_users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ImportAccountResponse) Reset()         { *m = ImportAccountResponse{} }
+func (m *ImportAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountResponse) ProtoMessage()    {}
+func (*ImportAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{26}
+}
+
+func (m *ImportAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAccountResponse.Unmarshal(m, b)
+}
+func (m *ImportAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAccountResponse.Merge(m, src)
+}
+func (m *ImportAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportAccountResponse.Size(m)
+}
+func (m *ImportAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAccountResponse proto.InternalMessageInfo
+
+func (m *ImportAccountResponse) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *ImportAccountResponse) GetCreatedUsers() []string {
+	if m != nil {
+		return m.CreatedUsers
+	}
+	return nil
+}
+
+func (m *ImportAccountResponse) GetOverwrittenUsers() []string {
+	if m != nil {
+		return m.OverwrittenUsers
+	}
+	return nil
+}
+
+func (m *ImportAccountResponse) GetSkippedUsers() []string {
+	if m != nil {
+		return m.SkippedUsers
+	}
+	return nil
+}
+
+func (m *ImportAccountResponse) GetRenamedUsers() map[string]string {
+	if m != nil {
+		return m.RenamedUsers
+	}
+	return nil
+}
+
+type ListUsersRequest struct {
+	PageSize             int32    `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter               string   `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy              string   `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	ShowDeleted          bool     `protobuf:"varint,5,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUsersRequest) Reset()         { *m = ListUsersRequest{} }
+func (m *ListUsersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUsersRequest) ProtoMessage()    {}
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{27}
+}
+
+func (m *ListUsersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUsersRequest.Unmarshal(m, b)
+}
+func (m *ListUsersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUsersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListUsersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUsersRequest.Merge(m, src)
+}
+func (m *ListUsersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUsersRequest.Size(m)
+}
+func (m *ListUsersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUsersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUsersRequest proto.InternalMessageInfo
+
+func (m *ListUsersRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListUsersRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListUsersRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *ListUsersRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *ListUsersRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+type ListUsersResponse struct {
+	Users                []*User  `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUsersResponse) Reset()         { *m = ListUsersResponse{} }
+func (m *ListUsersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListUsersResponse) ProtoMessage()    {}
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{28}
+}
+
+func (m *ListUsersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUsersResponse.Unmarshal(m, b)
+}
+func (m *ListUsersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUsersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListUsersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUsersResponse.Merge(m, src)
+}
+func (m *ListUsersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListUsersResponse.Size(m)
+}
+func (m *ListUsersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUsersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUsersResponse proto.InternalMessageInfo
+
+func (m *ListUsersResponse) GetUsers() []*User {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+func (m *ListUsersResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ShowDeleted          bool     `protobuf:"varint,2,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetUserRequest) Reset()         { *m = GetUserRequest{} }
+func (m *GetUserRequest) String() string { return proto.CompactTextString(m) }
+func (*GetUserRequest) ProtoMessage()    {}
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{29}
+}
+
+func (m *GetUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUserRequest.Unmarshal(m, b)
+}
+func (m *GetUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUserRequest.Marshal(b, m, deterministic)
+}
+func (m *GetUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUserRequest.Merge(m, src)
+}
+func (m *GetUserRequest) XXX_Size() int {
+	return xxx_messageInfo_GetUserRequest.Size(m)
+}
+func (m *GetUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUserRequest proto.InternalMessageInfo
+
+func (m *GetUserRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetUserRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+type CreateUserRequest struct {
+	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateUserRequest) Reset()         { *m = CreateUserRequest{} }
+func (m *CreateUserRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateUserRequest) ProtoMessage()    {}
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{30}
+}
+
+func (m *CreateUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateUserRequest.Unmarshal(m, b)
+}
+func (m *CreateUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateUserRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateUserRequest.Merge(m, src)
+}
+func (m *CreateUserRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateUserRequest.Size(m)
+}
+func (m *CreateUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateUserRequest proto.InternalMessageInfo
+
+func (m *CreateUserRequest) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type UpdateUserRequest struct {
+	User                 *User                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateUserRequest) Reset()         { *m = UpdateUserRequest{} }
+func (m *UpdateUserRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateUserRequest) ProtoMessage()    {}
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{31}
+}
+
+func (m *UpdateUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateUserRequest.Unmarshal(m, b)
+}
+func (m *UpdateUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateUserRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateUserRequest.Merge(m, src)
+}
+func (m *UpdateUserRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateUserRequest.Size(m)
+}
+func (m *UpdateUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateUserRequest proto.InternalMessageInfo
+
+func (m *UpdateUserRequest) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Etag                 string   `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteUserRequest) Reset()         { *m = DeleteUserRequest{} }
+func (m *DeleteUserRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteUserRequest) ProtoMessage()    {}
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{32}
+}
+
+func (m *DeleteUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteUserRequest.Unmarshal(m, b)
+}
+func (m *DeleteUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteUserRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteUserRequest.Merge(m, src)
+}
+func (m *DeleteUserRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteUserRequest.Size(m)
+}
+func (m *DeleteUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteUserRequest proto.InternalMessageInfo
+
+func (m *DeleteUserRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteUserRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type UndeleteUserRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndeleteUserRequest) Reset()         { *m = UndeleteUserRequest{} }
+func (m *UndeleteUserRequest) String() string { return proto.CompactTextString(m) }
+func (*UndeleteUserRequest) ProtoMessage()    {}
+func (*UndeleteUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{33}
+}
+
+func (m *UndeleteUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndeleteUserRequest.Unmarshal(m, b)
+}
+func (m *UndeleteUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndeleteUserRequest.Marshal(b, m, deterministic)
+}
+func (m *UndeleteUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteUserRequest.Merge(m, src)
+}
+func (m *UndeleteUserRequest) XXX_Size() int {
+	return xxx_messageInfo_UndeleteUserRequest.Size(m)
+}
+func (m *UndeleteUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndeleteUserRequest proto.InternalMessageInfo
+
+func (m *UndeleteUserRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type LinkPrincipalRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Principal            string   `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	Password             string   `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LinkPrincipalRequest) Reset()         { *m = LinkPrincipalRequest{} }
+func (m *LinkPrincipalRequest) String() string { return proto.CompactTextString(m) }
+func (*LinkPrincipalRequest) ProtoMessage()    {}
+func (*LinkPrincipalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{34}
+}
+
+func (m *LinkPrincipalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LinkPrincipalRequest.Unmarshal(m, b)
+}
+func (m *LinkPrincipalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LinkPrincipalRequest.Marshal(b, m, deterministic)
+}
+func (m *LinkPrincipalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LinkPrincipalRequest.Merge(m, src)
+}
+func (m *LinkPrincipalRequest) XXX_Size() int {
+	return xxx_messageInfo_LinkPrincipalRequest.Size(m)
+}
+func (m *LinkPrincipalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LinkPrincipalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LinkPrincipalRequest proto.InternalMessageInfo
+
+func (m *LinkPrincipalRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LinkPrincipalRequest) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *LinkPrincipalRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type UnlinkPrincipalRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlinkPrincipalRequest) Reset()         { *m = UnlinkPrincipalRequest{} }
+func (m *UnlinkPrincipalRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlinkPrincipalRequest) ProtoMessage()    {}
+func (*UnlinkPrincipalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{35}
+}
+
+func (m *UnlinkPrincipalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlinkPrincipalRequest.Unmarshal(m, b)
+}
+func (m *UnlinkPrincipalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlinkPrincipalRequest.Marshal(b, m, deterministic)
+}
+func (m *UnlinkPrincipalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlinkPrincipalRequest.Merge(m, src)
+}
+func (m *UnlinkPrincipalRequest) XXX_Size() int {
+	return xxx_messageInfo_UnlinkPrincipalRequest.Size(m)
+}
+func (m *UnlinkPrincipalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlinkPrincipalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlinkPrincipalRequest proto.InternalMessageInfo
+
+func (m *UnlinkPrincipalRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CreatePrincipalRequest struct {
+	Principal            *Principal `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	Password             string     `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreatePrincipalRequest) Reset()         { *m = CreatePrincipalRequest{} }
+func (m *CreatePrincipalRequest) String() string { return proto.CompactTextString(m) }
+func (*CreatePrincipalRequest) ProtoMessage()    {}
+func (*CreatePrincipalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{36}
+}
+
+func (m *CreatePrincipalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreatePrincipalRequest.Unmarshal(m, b)
+}
+func (m *CreatePrincipalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreatePrincipalRequest.Marshal(b, m, deterministic)
+}
+func (m *CreatePrincipalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreatePrincipalRequest.Merge(m, src)
+}
+func (m *CreatePrincipalRequest) XXX_Size() int {
+	return xxx_messageInfo_CreatePrincipalRequest.Size(m)
+}
+func (m *CreatePrincipalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreatePrincipalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreatePrincipalRequest proto.InternalMessageInfo
+
+func (m *CreatePrincipalRequest) GetPrincipal() *Principal {
+	if m != nil {
+		return m.Principal
+	}
+	return nil
+}
+
+func (m *CreatePrincipalRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type EraseUserRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Etag                 string   `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EraseUserRequest) Reset()         { *m = EraseUserRequest{} }
+func (m *EraseUserRequest) String() string { return proto.CompactTextString(m) }
+func (*EraseUserRequest) ProtoMessage()    {}
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{37}
+}
+
+func (m *EraseUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EraseUserRequest.Unmarshal(m, b)
+}
+func (m *EraseUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EraseUserRequest.Marshal(b, m, deterministic)
+}
+func (m *EraseUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EraseUserRequest.Merge(m, src)
+}
+func (m *EraseUserRequest) XXX_Size() int {
+	return xxx_messageInfo_EraseUserRequest.Size(m)
+}
+func (m *EraseUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EraseUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EraseUserRequest proto.InternalMessageInfo
+
+func (m *EraseUserRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EraseUserRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type EraseUserResponse struct {
+	User *User `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// A JWT signed with the token signing key that records the erasure. It
+	// names the user only by its pseudonym.
+	Receipt              string   `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EraseUserResponse) Reset()         { *m = EraseUserResponse{} }
+func (m *EraseUserResponse) String() string { return proto.CompactTextString(m) }
+func (*EraseUserResponse) ProtoMessage()    {}
+func (*EraseUserResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{38}
+}
+
+func (m *EraseUserResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EraseUserResponse.Unmarshal(m, b)
+}
+func (m *EraseUserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EraseUserResponse.Marshal(b, m, deterministic)
+}
+func (m *EraseUserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EraseUserResponse.Merge(m, src)
+}
+func (m *EraseUserResponse) XXX_Size() int {
+	return xxx_messageInfo_EraseUserResponse.Size(m)
+}
+func (m *EraseUserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EraseUserResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EraseUserResponse proto.InternalMessageInfo
+
+func (m *EraseUserResponse) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *EraseUserResponse) GetReceipt() string {
+	if m != nil {
+		return m.Receipt
+	}
+	return ""
+}
+
+type UnlockUserRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockUserRequest) Reset()         { *m = UnlockUserRequest{} }
+func (m *UnlockUserRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlockUserRequest) ProtoMessage()    {}
+func (*UnlockUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{39}
+}
+
+func (m *UnlockUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockUserRequest.Unmarshal(m, b)
+}
+func (m *UnlockUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockUserRequest.Marshal(b, m, deterministic)
+}
+func (m *UnlockUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockUserRequest.Merge(m, src)
+}
+func (m *UnlockUserRequest) XXX_Size() int {
+	return xxx_messageInfo_UnlockUserRequest.Size(m)
+}
+func (m *UnlockUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockUserRequest proto.InternalMessageInfo
+
+func (m *UnlockUserRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListIdentitiesRequest struct {
+	Parent               string   `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	PageSize             int32    `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter               string   `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy              string   `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	ShowDeleted          bool     `protobuf:"varint,6,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListIdentitiesRequest) Reset()         { *m = ListIdentitiesRequest{} }
+func (m *ListIdentitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListIdentitiesRequest) ProtoMessage()    {}
+func (*ListIdentitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{40}
+}
+
+func (m *ListIdentitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListIdentitiesRequest.Unmarshal(m, b)
+}
+func (m *ListIdentitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListIdentitiesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListIdentitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListIdentitiesRequest.Merge(m, src)
+}
+func (m *ListIdentitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListIdentitiesRequest.Size(m)
+}
+func (m *ListIdentitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListIdentitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListIdentitiesRequest proto.InternalMessageInfo
+
+func (m *ListIdentitiesRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *ListIdentitiesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListIdentitiesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListIdentitiesRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *ListIdentitiesRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *ListIdentitiesRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+type ListIdentitiesResponse struct {
+	Identities           []*Identity `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	NextPageToken        string      `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListIdentitiesResponse) Reset()         { *m = ListIdentitiesResponse{} }
+func (m *ListIdentitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListIdentitiesResponse) ProtoMessage()    {}
+func (*ListIdentitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{41}
+}
+
+func (m *ListIdentitiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListIdentitiesResponse.Unmarshal(m, b)
+}
+func (m *ListIdentitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListIdentitiesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListIdentitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListIdentitiesResponse.Merge(m, src)
+}
+func (m *ListIdentitiesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListIdentitiesResponse.Size(m)
+}
+func (m *ListIdentitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListIdentitiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListIdentitiesResponse proto.InternalMessageInfo
+
+func (m *ListIdentitiesResponse) GetIdentities() []*Identity {
+	if m != nil {
+		return m.Identities
+	}
+	return nil
+}
+
+func (m *ListIdentitiesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetIdentityRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetIdentityRequest) Reset()         { *m = GetIdentityRequest{} }
+func (m *GetIdentityRequest) String() string { return proto.CompactTextString(m) }
+func (*GetIdentityRequest) ProtoMessage()    {}
+func (*GetIdentityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{42}
+}
+
+func (m *GetIdentityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIdentityRequest.Unmarshal(m, b)
+}
+func (m *GetIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIdentityRequest.Marshal(b, m, deterministic)
+}
+func (m *GetIdentityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIdentityRequest.Merge(m, src)
+}
+func (m *GetIdentityRequest) XXX_Size() int {
+	return xxx_messageInfo_GetIdentityRequest.Size(m)
+}
+func (m *GetIdentityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIdentityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetIdentityRequest proto.InternalMessageInfo
+
+func (m *GetIdentityRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CreateIdentityRequest struct {
+	Parent               string    `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	Identity             *Identity `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CreateIdentityRequest) Reset()         { *m = CreateIdentityRequest{} }
+func (m *CreateIdentityRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateIdentityRequest) ProtoMessage()    {}
+func (*CreateIdentityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{43}
+}
+
+func (m *CreateIdentityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateIdentityRequest.Unmarshal(m, b)
+}
+func (m *CreateIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateIdentityRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateIdentityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateIdentityRequest.Merge(m, src)
+}
+func (m *CreateIdentityRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateIdentityRequest.Size(m)
+}
+func (m *CreateIdentityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateIdentityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateIdentityRequest proto.InternalMessageInfo
+
+func (m *CreateIdentityRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *CreateIdentityRequest) GetIdentity() *Identity {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+type MoveAccountRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The organization to move the account to, or empty to remove it from its
+	// organization.
+	Organization         string   `protobuf:"bytes,2,opt,name=organization,proto3" json:"organization,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveAccountRequest) Reset()         { *m = MoveAccountRequest{} }
+func (m *MoveAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveAccountRequest) ProtoMessage()    {}
+func (*MoveAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{44}
+}
+
+func (m *MoveAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveAccountRequest.Unmarshal(m, b)
+}
+func (m *MoveAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *MoveAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveAccountRequest.Merge(m, src)
+}
+func (m *MoveAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveAccountRequest.Size(m)
+}
+func (m *MoveAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveAccountRequest proto.InternalMessageInfo
+
+func (m *MoveAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MoveAccountRequest) GetOrganization() string {
+	if m != nil {
+		return m.Organization
+	}
+	return ""
+}
+
+type CreateOrganizationRequest struct {
+	Organization         *Organization `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CreateOrganizationRequest) Reset()         { *m = CreateOrganizationRequest{} }
+func (m *CreateOrganizationRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateOrganizationRequest) ProtoMessage()    {}
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{45}
+}
+
+func (m *CreateOrganizationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateOrganizationRequest.Unmarshal(m, b)
+}
+func (m *CreateOrganizationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateOrganizationRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateOrganizationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateOrganizationRequest.Merge(m, src)
+}
+func (m *CreateOrganizationRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateOrganizationRequest.Size(m)
+}
+func (m *CreateOrganizationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateOrganizationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateOrganizationRequest proto.InternalMessageInfo
+
+func (m *CreateOrganizationRequest) GetOrganization() *Organization {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+type GetOrganizationRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrganizationRequest) Reset()         { *m = GetOrganizationRequest{} }
+func (m *GetOrganizationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrganizationRequest) ProtoMessage()    {}
+func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{46}
+}
+
+func (m *GetOrganizationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrganizationRequest.Unmarshal(m, b)
+}
+func (m *GetOrganizationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrganizationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrganizationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrganizationRequest.Merge(m, src)
+}
+func (m *GetOrganizationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrganizationRequest.Size(m)
+}
+func (m *GetOrganizationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrganizationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrganizationRequest proto.InternalMessageInfo
+
+func (m *GetOrganizationRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type UpdateOrganizationRequest struct {
+	Organization         *Organization         `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateOrganizationRequest) Reset()         { *m = UpdateOrganizationRequest{} }
+func (m *UpdateOrganizationRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateOrganizationRequest) ProtoMessage()    {}
+func (*UpdateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{47}
+}
+
+func (m *UpdateOrganizationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateOrganizationRequest.Unmarshal(m, b)
+}
+func (m *UpdateOrganizationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateOrganizationRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateOrganizationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateOrganizationRequest.Merge(m, src)
+}
+func (m *UpdateOrganizationRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateOrganizationRequest.Size(m)
+}
+func (m *UpdateOrganizationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateOrganizationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateOrganizationRequest proto.InternalMessageInfo
+
+func (m *UpdateOrganizationRequest) GetOrganization() *Organization {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+func (m *UpdateOrganizationRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type AddOrganizationAdminRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Principal            string   `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddOrganizationAdminRequest) Reset()         { *m = AddOrganizationAdminRequest{} }
+func (m *AddOrganizationAdminRequest) String() string { return proto.CompactTextString(m) }
+func (*AddOrganizationAdminRequest) ProtoMessage()    {}
+func (*AddOrganizationAdminRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{48}
+}
+
+func (m *AddOrganizationAdminRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddOrganizationAdminRequest.Unmarshal(m, b)
+}
+func (m *AddOrganizationAdminRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddOrganizationAdminRequest.Marshal(b, m, deterministic)
+}
+func (m *AddOrganizationAdminRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddOrganizationAdminRequest.Merge(m, src)
+}
+func (m *AddOrganizationAdminRequest) XXX_Size() int {
+	return xxx_messageInfo_AddOrganizationAdminRequest.Size(m)
+}
+func (m *AddOrganizationAdminRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddOrganizationAdminRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddOrganizationAdminRequest proto.InternalMessageInfo
+
+func (m *AddOrganizationAdminRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AddOrganizationAdminRequest) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+type RemoveOrganizationAdminRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Principal            string   `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveOrganizationAdminRequest) Reset()         { *m = RemoveOrganizationAdminRequest{} }
+func (m *RemoveOrganizationAdminRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveOrganizationAdminRequest) ProtoMessage()    {}
+func (*RemoveOrganizationAdminRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{49}
+}
+
+func (m *RemoveOrganizationAdminRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveOrganizationAdminRequest.Unmarshal(m, b)
+}
+func (m *RemoveOrganizationAdminRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveOrganizationAdminRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveOrganizationAdminRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveOrganizationAdminRequest.Merge(m, src)
+}
+func (m *RemoveOrganizationAdminRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveOrganizationAdminRequest.Size(m)
+}
+func (m *RemoveOrganizationAdminRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveOrganizationAdminRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveOrganizationAdminRequest proto.InternalMessageInfo
+
+func (m *RemoveOrganizationAdminRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RemoveOrganizationAdminRequest) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+type GetOrganizationPasswordPolicyRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrganizationPasswordPolicyRequest) Reset()         { *m = GetOrganizationPasswordPolicyRequest{} }
+func (m *GetOrganizationPasswordPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrganizationPasswordPolicyRequest) ProtoMessage()    {}
+func (*GetOrganizationPasswordPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{50}
+}
+
+func (m *GetOrganizationPasswordPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrganizationPasswordPolicyRequest.Unmarshal(m, b)
+}
+func (m *GetOrganizationPasswordPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrganizationPasswordPolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrganizationPasswordPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrganizationPasswordPolicyRequest.Merge(m, src)
+}
+func (m *GetOrganizationPasswordPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrganizationPasswordPolicyRequest.Size(m)
+}
+func (m *GetOrganizationPasswordPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrganizationPasswordPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrganizationPasswordPolicyRequest proto.InternalMessageInfo
+
+func (m *GetOrganizationPasswordPolicyRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type UpdateOrganizationPasswordPolicyRequest struct {
+	PasswordPolicy       *PasswordPolicy       `protobuf:"bytes,1,opt,name=password_policy,json=passwordPolicy,proto3" json:"password_policy,omitempty"`
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateOrganizationPasswordPolicyRequest) Reset() {
+	*m = UpdateOrganizationPasswordPolicyRequest{}
+}
+func (m *UpdateOrganizationPasswordPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateOrganizationPasswordPolicyRequest) ProtoMessage()    {}
+func (*UpdateOrganizationPasswordPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{51}
+}
+
+func (m *UpdateOrganizationPasswordPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateOrganizationPasswordPolicyRequest.Unmarshal(m, b)
+}
+func (m *UpdateOrganizationPasswordPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateOrganizationPasswordPolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateOrganizationPasswordPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateOrganizationPasswordPolicyRequest.Merge(m, src)
+}
+func (m *UpdateOrganizationPasswordPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateOrganizationPasswordPolicyRequest.Size(m)
+}
+func (m *UpdateOrganizationPasswordPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateOrganizationPasswordPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateOrganizationPasswordPolicyRequest proto.InternalMessageInfo
+
+func (m *UpdateOrganizationPasswordPolicyRequest) GetPasswordPolicy() *PasswordPolicy {
+	if m != nil {
+		return m.PasswordPolicy
+	}
+	return nil
+}
+
+func (m *UpdateOrganizationPasswordPolicyRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type GetPasswordPolicyRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPasswordPolicyRequest) Reset()         { *m = GetPasswordPolicyRequest{} }
+func (m *GetPasswordPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPasswordPolicyRequest) ProtoMessage()    {}
+func (*GetPasswordPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{52}
+}
+
+func (m *GetPasswordPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPasswordPolicyRequest.Unmarshal(m, b)
+}
+func (m *GetPasswordPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPasswordPolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPasswordPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPasswordPolicyRequest.Merge(m, src)
+}
+func (m *GetPasswordPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPasswordPolicyRequest.Size(m)
+}
+func (m *GetPasswordPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPasswordPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPasswordPolicyRequest proto.InternalMessageInfo
+
+func (m *GetPasswordPolicyRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type UpdatePasswordPolicyRequest struct {
+	PasswordPolicy       *PasswordPolicy       `protobuf:"bytes,1,opt,name=password_policy,json=passwordPolicy,proto3" json:"password_policy,omitempty"`
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdatePasswordPolicyRequest) Reset()         { *m = UpdatePasswordPolicyRequest{} }
+func (m *UpdatePasswordPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdatePasswordPolicyRequest) ProtoMessage()    {}
+func (*UpdatePasswordPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{53}
+}
+
+func (m *UpdatePasswordPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePasswordPolicyRequest.Unmarshal(m, b)
+}
+func (m *UpdatePasswordPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePasswordPolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdatePasswordPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePasswordPolicyRequest.Merge(m, src)
+}
+func (m *UpdatePasswordPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdatePasswordPolicyRequest.Size(m)
+}
+func (m *UpdatePasswordPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePasswordPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePasswordPolicyRequest proto.InternalMessageInfo
+
+func (m *UpdatePasswordPolicyRequest) GetPasswordPolicy() *PasswordPolicy {
+	if m != nil {
+		return m.PasswordPolicy
+	}
+	return nil
+}
+
+func (m *UpdatePasswordPolicyRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateIdentityRequest struct {
+	Identity             *Identity             `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateIdentityRequest) Reset()         { *m = UpdateIdentityRequest{} }
+func (m *UpdateIdentityRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateIdentityRequest) ProtoMessage()    {}
+func (*UpdateIdentityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{54}
+}
+
+func (m *UpdateIdentityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateIdentityRequest.Unmarshal(m, b)
+}
+func (m *UpdateIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateIdentityRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateIdentityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateIdentityRequest.Merge(m, src)
+}
+func (m *UpdateIdentityRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateIdentityRequest.Size(m)
+}
+func (m *UpdateIdentityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateIdentityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateIdentityRequest proto.InternalMessageInfo
+
+func (m *UpdateIdentityRequest) GetIdentity() *Identity {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *UpdateIdentityRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type DeleteIdentityRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Etag                 string   `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteIdentityRequest) Reset()         { *m = DeleteIdentityRequest{} }
+func (m *DeleteIdentityRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteIdentityRequest) ProtoMessage()    {}
+func (*DeleteIdentityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{55}
+}
+
+func (m *DeleteIdentityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteIdentityRequest.Unmarshal(m, b)
+}
+func (m *DeleteIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteIdentityRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteIdentityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteIdentityRequest.Merge(m, src)
+}
+func (m *DeleteIdentityRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteIdentityRequest.Size(m)
+}
+func (m *DeleteIdentityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteIdentityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteIdentityRequest proto.InternalMessageInfo
+
+func (m *DeleteIdentityRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteIdentityRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type ListInvitationsRequest struct {
+	Parent               string   `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	PageSize             int32    `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListInvitationsRequest) Reset()         { *m = ListInvitationsRequest{} }
+func (m *ListInvitationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListInvitationsRequest) ProtoMessage()    {}
+func (*ListInvitationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{56}
+}
+
+func (m *ListInvitationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListInvitationsRequest.Unmarshal(m, b)
+}
+func (m *ListInvitationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListInvitationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListInvitationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListInvitationsRequest.Merge(m, src)
+}
+func (m *ListInvitationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListInvitationsRequest.Size(m)
+}
+func (m *ListInvitationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListInvitationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListInvitationsRequest proto.InternalMessageInfo
+
+func (m *ListInvitationsRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *ListInvitationsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListInvitationsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListInvitationsResponse struct {
+	Invitations          []*Invitation `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	NextPageToken        string        `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListInvitationsResponse) Reset()         { *m = ListInvitationsResponse{} }
+func (m *ListInvitationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListInvitationsResponse) ProtoMessage()    {}
+func (*ListInvitationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{57}
+}
+
+func (m *ListInvitationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListInvitationsResponse.Unmarshal(m, b)
+}
+func (m *ListInvitationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListInvitationsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListInvitationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListInvitationsResponse.Merge(m, src)
+}
+func (m *ListInvitationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListInvitationsResponse.Size(m)
+}
+func (m *ListInvitationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListInvitationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListInvitationsResponse proto.InternalMessageInfo
+
+func (m *ListInvitationsResponse) GetInvitations() []*Invitation {
+	if m != nil {
+		return m.Invitations
+	}
+	return nil
+}
+
+func (m *ListInvitationsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type CreateInvitationRequest struct {
+	Parent               string      `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	Invitation           *Invitation `protobuf:"bytes,2,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CreateInvitationRequest) Reset()         { *m = CreateInvitationRequest{} }
+func (m *CreateInvitationRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateInvitationRequest) ProtoMessage()    {}
+func (*CreateInvitationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{58}
+}
+
+func (m *CreateInvitationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateInvitationRequest.Unmarshal(m, b)
+}
+func (m *CreateInvitationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateInvitationRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateInvitationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateInvitationRequest.Merge(m, src)
+}
+func (m *CreateInvitationRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateInvitationRequest.Size(m)
+}
+func (m *CreateInvitationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateInvitationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateInvitationRequest proto.InternalMessageInfo
+
+func (m *CreateInvitationRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *CreateInvitationRequest) GetInvitation() *Invitation {
+	if m != nil {
+		return m.Invitation
+	}
+	return nil
+}
+
+type DeleteInvitationRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteInvitationRequest) Reset()         { *m = DeleteInvitationRequest{} }
+func (m *DeleteInvitationRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteInvitationRequest) ProtoMessage()    {}
+func (*DeleteInvitationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{59}
+}
+
+func (m *DeleteInvitationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteInvitationRequest.Unmarshal(m, b)
+}
+func (m *DeleteInvitationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteInvitationRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteInvitationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteInvitationRequest.Merge(m, src)
+}
+func (m *DeleteInvitationRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteInvitationRequest.Size(m)
+}
+func (m *DeleteInvitationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteInvitationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteInvitationRequest proto.InternalMessageInfo
+
+func (m *DeleteInvitationRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type AcceptInvitationRequest struct {
+	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcceptInvitationRequest) Reset()         { *m = AcceptInvitationRequest{} }
+func (m *AcceptInvitationRequest) String() string { return proto.CompactTextString(m) }
+func (*AcceptInvitationRequest) ProtoMessage()    {}
+func (*AcceptInvitationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{60}
+}
+
+func (m *AcceptInvitationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcceptInvitationRequest.Unmarshal(m, b)
+}
+func (m *AcceptInvitationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcceptInvitationRequest.Marshal(b, m, deterministic)
+}
+func (m *AcceptInvitationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcceptInvitationRequest.Merge(m, src)
+}
+func (m *AcceptInvitationRequest) XXX_Size() int {
+	return xxx_messageInfo_AcceptInvitationRequest.Size(m)
+}
+func (m *AcceptInvitationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcceptInvitationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcceptInvitationRequest proto.InternalMessageInfo
+
+func (m *AcceptInvitationRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *AcceptInvitationRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type ListAuditEventsRequest struct {
+	PageSize             int32                `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string               `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Filter               string               `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{61}
+}
+
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
+}
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
+}
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
+
+func (m *ListAuditEventsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *ListAuditEventsRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *ListAuditEventsRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	AuditEvents          []*AuditEvent `protobuf:"bytes,1,rep,name=audit_events,json=auditEvents,proto3" json:"audit_events,omitempty"`
+	NextPageToken        string        `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListAuditEventsResponse) Reset()         { *m = ListAuditEventsResponse{} }
+func (m *ListAuditEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsResponse) ProtoMessage()    {}
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{62}
+}
+
+func (m *ListAuditEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsResponse.Unmarshal(m, b)
+}
+func (m *ListAuditEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsResponse.Merge(m, src)
+}
+func (m *ListAuditEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsResponse.Size(m)
+}
+func (m *ListAuditEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsResponse proto.InternalMessageInfo
+
+func (m *ListAuditEventsResponse) GetAuditEvents() []*AuditEvent {
+	if m != nil {
+		return m.AuditEvents
+	}
+	return nil
+}
+
+func (m *ListAuditEventsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ListWebhooksRequest struct {
+	PageSize             int32    `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksRequest) Reset()         { *m = ListWebhooksRequest{} }
+func (m *ListWebhooksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRequest) ProtoMessage()    {}
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{63}
+}
+
+func (m *ListWebhooksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRequest.Unmarshal(m, b)
+}
+func (m *ListWebhooksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRequest.Merge(m, src)
+}
+func (m *ListWebhooksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRequest.Size(m)
+}
+func (m *ListWebhooksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRequest proto.InternalMessageInfo
+
+func (m *ListWebhooksRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListWebhooksRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListWebhooksResponse struct {
+	Webhooks             []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	NextPageToken        string     `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListWebhooksResponse) Reset()         { *m = ListWebhooksResponse{} }
+func (m *ListWebhooksResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksResponse) ProtoMessage()    {}
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{64}
+}
+
+func (m *ListWebhooksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksResponse.Unmarshal(m, b)
+}
+func (m *ListWebhooksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksResponse.Merge(m, src)
+}
+func (m *ListWebhooksResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksResponse.Size(m)
+}
+func (m *ListWebhooksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksResponse proto.InternalMessageInfo
+
+func (m *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+func (m *ListWebhooksResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type CreateWebhookRequest struct {
+	Webhook              *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookRequest) Reset()         { *m = CreateWebhookRequest{} }
+func (m *CreateWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookRequest) ProtoMessage()    {}
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{65}
+}
+
+func (m *CreateWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookRequest.Unmarshal(m, b)
+}
+func (m *CreateWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookRequest.Merge(m, src)
+}
+func (m *CreateWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookRequest.Size(m)
+}
+func (m *CreateWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookRequest proto.InternalMessageInfo
+
+func (m *CreateWebhookRequest) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookRequest) Reset()         { *m = DeleteWebhookRequest{} }
+func (m *DeleteWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookRequest) ProtoMessage()    {}
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{66}
+}
+
+func (m *DeleteWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookRequest.Unmarshal(m, b)
+}
+func (m *DeleteWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookRequest.Merge(m, src)
+}
+func (m *DeleteWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookRequest.Size(m)
+}
+func (m *DeleteWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookRequest proto.InternalMessageInfo
+
+func (m *DeleteWebhookRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListDeadLettersRequest struct {
+	Parent               string   `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	PageSize             int32    `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeadLettersRequest) Reset()         { *m = ListDeadLettersRequest{} }
+func (m *ListDeadLettersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersRequest) ProtoMessage()    {}
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{67}
+}
+
+func (m *ListDeadLettersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersRequest.Unmarshal(m, b)
+}
+func (m *ListDeadLettersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLettersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersRequest.Merge(m, src)
+}
+func (m *ListDeadLettersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersRequest.Size(m)
+}
+func (m *ListDeadLettersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersRequest proto.InternalMessageInfo
+
+func (m *ListDeadLettersRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *ListDeadLettersRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListDeadLettersRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListDeadLettersResponse struct {
+	DeadLetters          []*WebhookDelivery `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	NextPageToken        string             `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListDeadLettersResponse) Reset()         { *m = ListDeadLettersResponse{} }
+func (m *ListDeadLettersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersResponse) ProtoMessage()    {}
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a2c201915207782, []int{68}
+}
+
+func (m *ListDeadLettersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersResponse.Unmarshal(m, b)
+}
+func (m *ListDeadLettersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLettersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersResponse.Merge(m, src)
+}
+func (m *ListDeadLettersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersResponse.Size(m)
+}
+func (m *ListDeadLettersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersResponse proto.InternalMessageInfo
+
+func (m *ListDeadLettersResponse) GetDeadLetters() []*WebhookDelivery {
+	if m != nil {
+		return m.DeadLetters
+	}
+	return nil
+}
+
+func (m *ListDeadLettersResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("iam.Identity_AuthMethod", Identity_AuthMethod_name, Identity_AuthMethod_value)
+	proto.RegisterEnum("iam.AuditEvent_Outcome", AuditEvent_Outcome_name, AuditEvent_Outcome_value)
+	proto.RegisterEnum("iam.ImportAccountRequest_ConflictStrategy", ImportAccountRequest_ConflictStrategy_name, ImportAccountRequest_ConflictStrategy_value)
+	proto.RegisterType((*Account)(nil), "iam.Account")
+	proto.RegisterType((*Organization)(nil), "iam.Organization")
+	proto.RegisterType((*User)(nil), "iam.User")
+	proto.RegisterMapType((map[string]string)(nil), "iam.User.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "iam.User.LabelsEntry")
+	proto.RegisterType((*Principal)(nil), "iam.Principal")
+	proto.RegisterType((*AccountMembership)(nil), "iam.AccountMembership")
+	proto.RegisterType((*Identity)(nil), "iam.Identity")
+	proto.RegisterType((*Invitation)(nil), "iam.Invitation")
+	proto.RegisterType((*AccountBundle)(nil), "iam.AccountBundle")
+	proto.RegisterType((*AccountBundle_BundledUser)(nil), "iam.AccountBundle.BundledUser")
+	proto.RegisterType((*AccountBundle_BundledIdentity)(nil), "iam.AccountBundle.BundledIdentity")
+	proto.RegisterType((*PasswordPolicy)(nil), "iam.PasswordPolicy")
+	proto.RegisterType((*AuditEvent)(nil), "iam.AuditEvent")
+	proto.RegisterType((*Webhook)(nil), "iam.Webhook")
+	proto.RegisterType((*WebhookDelivery)(nil), "iam.WebhookDelivery")
+	proto.RegisterType((*AuthenticateRequest)(nil), "iam.AuthenticateRequest")
+	proto.RegisterType((*AuthenticateResponse)(nil), "iam.AuthenticateResponse")
+	proto.RegisterType((*RequestPasswordResetRequest)(nil), "iam.RequestPasswordResetRequest")
+	proto.RegisterType((*ConfirmPasswordResetRequest)(nil), "iam.ConfirmPasswordResetRequest")
+	proto.RegisterType((*VerifyEmailRequest)(nil), "iam.VerifyEmailRequest")
+	proto.RegisterType((*GetAccountRequest)(nil), "iam.GetAccountRequest")
+	proto.RegisterType((*ListMyAccountsRequest)(nil), "iam.ListMyAccountsRequest")
+	proto.RegisterType((*ListMyAccountsResponse)(nil), "iam.ListMyAccountsResponse")
+	proto.RegisterType((*CreateAccountRequest)(nil), "iam.CreateAccountRequest")
+	proto.RegisterType((*UpdateAccountRequest)(nil), "iam.UpdateAccountRequest")
+	proto.RegisterType((*DeleteAccountRequest)(nil), "iam.DeleteAccountRequest")
+	proto.RegisterType((*UndeleteAccountRequest)(nil), "iam.UndeleteAccountRequest")
+	proto.RegisterType((*ExportAccountRequest)(nil), "iam.ExportAccountRequest")
+	proto.RegisterType((*ImportAccountRequest)(nil), "iam.ImportAccountRequest")
+	proto.RegisterType((*ImportAccountResponse)(nil), "iam.ImportAccountResponse")
+	proto.RegisterMapType((map[string]string)(nil), "iam.ImportAccountResponse.RenamedUsersEntry")
+	proto.RegisterType((*ListUsersRequest)(nil), "iam.ListUsersRequest")
+	proto.RegisterType((*ListUsersResponse)(nil), "iam.ListUsersResponse")
+	proto.RegisterType((*GetUserRequest)(nil), "iam.GetUserRequest")
+	proto.RegisterType((*CreateUserRequest)(nil), "iam.CreateUserRequest")
+	proto.RegisterType((*UpdateUserRequest)(nil), "iam.UpdateUserRequest")
+	proto.RegisterType((*DeleteUserRequest)(nil), "iam.DeleteUserRequest")
+	proto.RegisterType((*UndeleteUserRequest)(nil), "iam.UndeleteUserRequest")
+	proto.RegisterType((*LinkPrincipalRequest)(nil), "iam.LinkPrincipalRequest")
+	proto.RegisterType((*UnlinkPrincipalRequest)(nil), "iam.UnlinkPrincipalRequest")
+	proto.RegisterType((*CreatePrincipalRequest)(nil), "iam.CreatePrincipalRequest")
+	proto.RegisterType((*EraseUserRequest)(nil), "iam.EraseUserRequest")
+	proto.RegisterType((*EraseUserResponse)(nil), "iam.EraseUserResponse")
+	proto.RegisterType((*UnlockUserRequest)(nil), "iam.UnlockUserRequest")
+	proto.RegisterType((*ListIdentitiesRequest)(nil), "iam.ListIdentitiesRequest")
+	proto.RegisterType((*ListIdentitiesResponse)(nil), "iam.ListIdentitiesResponse")
+	proto.RegisterType((*GetIdentityRequest)(nil), "iam.GetIdentityRequest")
+	proto.RegisterType((*CreateIdentityRequest)(nil), "iam.CreateIdentityRequest")
+	proto.RegisterType((*MoveAccountRequest)(nil), "iam.MoveAccountRequest")
+	proto.RegisterType((*CreateOrganizationRequest)(nil), "iam.CreateOrganizationRequest")
+	proto.RegisterType((*GetOrganizationRequest)(nil), "iam.GetOrganizationRequest")
+	proto.RegisterType((*UpdateOrganizationRequest)(nil), "iam.UpdateOrganizationRequest")
+	proto.RegisterType((*AddOrganizationAdminRequest)(nil), "iam.AddOrganizationAdminRequest")
+	proto.RegisterType((*RemoveOrganizationAdminRequest)(nil), "iam.RemoveOrganizationAdminRequest")
+	proto.RegisterType((*GetOrganizationPasswordPolicyRequest)(nil), "iam.GetOrganizationPasswordPolicyRequest")
+	proto.RegisterType((*UpdateOrganizationPasswordPolicyRequest)(nil), "iam.UpdateOrganizationPasswordPolicyRequest")
+	proto.RegisterType((*GetPasswordPolicyRequest)(nil), "iam.GetPasswordPolicyRequest")
+	proto.RegisterType((*UpdatePasswordPolicyRequest)(nil), "iam.UpdatePasswordPolicyRequest")
+	proto.RegisterType((*UpdateIdentityRequest)(nil), "iam.UpdateIdentityRequest")
+	proto.RegisterType((*DeleteIdentityRequest)(nil), "iam.DeleteIdentityRequest")
+	proto.RegisterType((*ListInvitationsRequest)(nil), "iam.ListInvitationsRequest")
+	proto.RegisterType((*ListInvitationsResponse)(nil), "iam.ListInvitationsResponse")
+	proto.RegisterType((*CreateInvitationRequest)(nil), "iam.CreateInvitationRequest")
+	proto.RegisterType((*DeleteInvitationRequest)(nil), "iam.DeleteInvitationRequest")
+	proto.RegisterType((*AcceptInvitationRequest)(nil), "iam.AcceptInvitationRequest")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "iam.ListAuditEventsRequest")
+	proto.RegisterType((*ListAuditEventsResponse)(nil), "iam.ListAuditEventsResponse")
+	proto.RegisterType((*ListWebhooksRequest)(nil), "iam.ListWebhooksRequest")
+	proto.RegisterType((*ListWebhooksResponse)(nil), "iam.ListWebhooksResponse")
+	proto.RegisterType((*CreateWebhookRequest)(nil), "iam.CreateWebhookRequest")
+	proto.RegisterType((*DeleteWebhookRequest)(nil), "iam.DeleteWebhookRequest")
+	proto.RegisterType((*ListDeadLettersRequest)(nil), "iam.ListDeadLettersRequest")
+	proto.RegisterType((*ListDeadLettersResponse)(nil), "iam.ListDeadLettersResponse")
+}
+
+func init() { proto.RegisterFile("iam.proto", fileDescriptor_0a2c201915207782) }
+
+var fileDescriptor_0a2c201915207782 = []byte{
+	// 3797 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x3a, 0x5d, 0x6f, 0x1b, 0xc7,
+	0x76, 0x21, 0xf5, 0x45, 0x1e, 0x52, 0x14, 0x35, 0xa2, 0x24, 0x6a, 0x25, 0xd9, 0xf2, 0x2a, 0xb1,
+	0x65, 0xc5, 0xa6, 0x6a, 0x36, 0x41, 0x62, 0xe5, 0x0b, 0xb2, 0x44, 0xcb, 0x4a, 0x24, 0xcb, 0x59,
+	0x49, 0x76, 0x93, 0x14, 0x61, 0x57, 0xdc, 0xb1, 0xb4, 0x10, 0xb9, 0xcb, 0xec, 0x2e, 0x25, 0xd3,
+	0x41, 0x50, 0x20, 0x28, 0xfa, 0x5a, 0xa0, 0x7d, 0x68, 0x1f, 0x0a, 0xb4, 0x40, 0xf3, 0xd8, 0x3f,
+	0x50, 0xf4, 0xa9, 0xe8, 0x7d, 0xba, 0xc0, 0x7d, 0xba, 0xc0, 0xbd, 0x6f, 0xb9, 0x2f, 0xf7, 0x6f,
+	0x5c, 0xe0, 0x62, 0x3e, 0x76, 0x39, 0xbb, 0x3b, 0xfc, 0x90, 0x3f, 0x80, 0x0b, 0xe7, 0x89, 0x3b,
+	0x67, 0xce, 0x9c, 0x73, 0xe6, 0xcc, 0x99, 0x33, 0xe7, 0x83, 0x90, 0x36, 0xf5, 0x46, 0xa9, 0xe9,
+	0xd8, 0x9e, 0x8d, 0x86, 0x4c, 0xbd, 0xa1, 0x2c, 0x9c, 0xd8, 0xf6, 0x49, 0x1d, 0xaf, 0xe9, 0x4d,
+	0x73, 0x4d, 0xb7, 0x2c, 0xdb, 0xd3, 0x3d, 0xd3, 0xb6, 0x5c, 0x86, 0xa2, 0x5c, 0xe5, 0xb3, 0x74,
+	0x74, 0xdc, 0x7a, 0xba, 0xe6, 0x99, 0x0d, 0xec, 0x7a, 0x7a, 0xa3, 0xc9, 0x11, 0xae, 0x44, 0x11,
+	0x8c, 0x96, 0x43, 0x29, 0xf0, 0xf9, 0xf9, 0xe8, 0x3c, 0x6e, 0x34, 0xbd, 0x36, 0x9f, 0x5c, 0x8a,
+	0x4e, 0x3e, 0x35, 0x71, 0xdd, 0xa8, 0x36, 0x74, 0xf7, 0x8c, 0x61, 0xa8, 0xff, 0x9f, 0x84, 0xb1,
+	0x8d, 0x5a, 0xcd, 0x6e, 0x59, 0x1e, 0x42, 0x30, 0x6c, 0xe9, 0x0d, 0x5c, 0x4c, 0x2c, 0x25, 0x56,
+	0xd2, 0x1a, 0xfd, 0x46, 0x1f, 0x41, 0xa6, 0xe6, 0x60, 0xdd, 0xc3, 0x55, 0x22, 0x58, 0x31, 0xb9,
+	0x94, 0x58, 0xc9, 0x94, 0x95, 0x12, 0xa3, 0x5b, 0xf2, 0xe9, 0x96, 0x0e, 0x7d, 0xa9, 0x35, 0x60,
+	0xe8, 0x87, 0x26, 0x5b, 0xdc, 0x6a, 0x1a, 0xc1, 0xe2, 0xa1, 0xfe, 0x8b, 0x19, 0xba, 0xbf, 0xd8,
+	0xc0, 0x75, 0xec, 0x2f, 0x1e, 0xee, 0xbf, 0x98, 0xa1, 0xd3, 0xc5, 0xd7, 0x20, 0x6b, 0x98, 0x6e,
+	0xb3, 0xae, 0xb7, 0xab, 0x74, 0x4b, 0x23, 0x74, 0x4b, 0x19, 0x0e, 0x7b, 0x48, 0x76, 0x86, 0x60,
+	0xd8, 0xb1, 0x6d, 0xaf, 0x38, 0xca, 0x76, 0x4b, 0xbe, 0x09, 0x0c, 0x7b, 0xfa, 0x49, 0x71, 0x8c,
+	0xc1, 0xc8, 0x37, 0x52, 0x21, 0x6b, 0x3b, 0x27, 0xba, 0x65, 0x3e, 0xa7, 0x6a, 0x2f, 0xa6, 0xe8,
+	0x5c, 0x08, 0xa6, 0xfe, 0x6f, 0x12, 0xb2, 0xfb, 0x02, 0xe0, 0x17, 0xa6, 0xca, 0x19, 0x18, 0xd5,
+	0x8d, 0x86, 0x69, 0xb9, 0xc5, 0xb1, 0xa5, 0xa1, 0x95, 0xb4, 0xc6, 0x47, 0x81, 0x3a, 0x53, 0x1d,
+	0x75, 0x7e, 0x3e, 0x9c, 0x1a, 0xcd, 0x8f, 0x69, 0x19, 0x07, 0x7f, 0xd7, 0x32, 0x1d, 0x5c, 0x6d,
+	0x3c, 0xd5, 0xd5, 0xdf, 0x8c, 0xc0, 0xf0, 0x91, 0x8b, 0x9d, 0x37, 0x49, 0x6b, 0xb3, 0x30, 0x66,
+	0xba, 0x55, 0x6a, 0x60, 0x44, 0x61, 0x29, 0x6d, 0xd4, 0x74, 0x35, 0x62, 0x62, 0x51, 0x75, 0x8e,
+	0xc6, 0xd5, 0x59, 0x80, 0x11, 0xdc, 0xd0, 0xcd, 0x3a, 0x37, 0x43, 0x36, 0x40, 0xef, 0x40, 0x8e,
+	0x7e, 0x54, 0xcf, 0xb1, 0x63, 0x3e, 0x35, 0xb1, 0x41, 0xd5, 0x9a, 0xd2, 0xc6, 0x29, 0xf4, 0x31,
+	0x07, 0x92, 0xc5, 0xcd, 0x53, 0xdb, 0xc2, 0xc5, 0x34, 0x5b, 0x4c, 0x07, 0xe4, 0x84, 0xea, 0x76,
+	0x4d, 0xaf, 0xe3, 0x22, 0x50, 0x30, 0x1f, 0xa1, 0xdb, 0x30, 0x5a, 0xd7, 0x8f, 0x71, 0xdd, 0x2d,
+	0x66, 0x96, 0x86, 0x56, 0x32, 0xe5, 0xe9, 0x12, 0xf1, 0x5e, 0xe4, 0x30, 0x4a, 0xbb, 0x14, 0x5e,
+	0xb1, 0x3c, 0xa7, 0xad, 0x71, 0x24, 0xf4, 0x31, 0x64, 0x04, 0x17, 0x56, 0xcc, 0xd2, 0x35, 0x4a,
+	0x67, 0xcd, 0x46, 0x67, 0x92, 0x2d, 0x14, 0xd1, 0x03, 0x73, 0x18, 0x17, 0x6e, 0xd7, 0x5d, 0x00,
+	0xec, 0xe8, 0x2e, 0xd7, 0x71, 0xae, 0xaf, 0x8e, 0xd3, 0x14, 0x9b, 0xaa, 0x78, 0x01, 0xd2, 0x4d,
+	0xc7, 0xb4, 0x6a, 0x66, 0x53, 0xaf, 0x17, 0x27, 0x28, 0xcd, 0x0e, 0x40, 0xb9, 0x0b, 0x19, 0x61,
+	0x07, 0x28, 0x0f, 0x43, 0x67, 0xb8, 0xcd, 0x2d, 0x8b, 0x7c, 0x12, 0x45, 0x9d, 0xeb, 0xf5, 0x16,
+	0x33, 0xa9, 0xb4, 0xc6, 0x06, 0xeb, 0xc9, 0x0f, 0x13, 0xca, 0xa7, 0x90, 0x8f, 0x6e, 0xe4, 0x32,
+	0xeb, 0xd5, 0xff, 0x4a, 0x42, 0xfa, 0x91, 0x2f, 0xc8, 0x2f, 0xcc, 0x15, 0x04, 0xb6, 0x3b, 0x2a,
+	0xda, 0xae, 0xc4, 0xaf, 0xaa, 0xfb, 0x30, 0xc9, 0x1f, 0x9e, 0x3d, 0xdc, 0x38, 0xc6, 0x8e, 0x7b,
+	0x6a, 0x36, 0xd1, 0x75, 0x18, 0xd3, 0x19, 0x90, 0xea, 0x2b, 0x53, 0xce, 0x52, 0xe3, 0xe2, 0x88,
+	0x9a, 0x3f, 0x49, 0x08, 0xb6, 0x5c, 0xec, 0x70, 0xdd, 0xd3, 0x6f, 0xf5, 0x5f, 0x87, 0x20, 0xb5,
+	0x63, 0x60, 0xcb, 0x33, 0xbd, 0xf6, 0x9b, 0xa4, 0xf5, 0xbb, 0x90, 0xd1, 0x5b, 0xde, 0x69, 0xb5,
+	0x81, 0xbd, 0x53, 0xdb, 0xa0, 0x4a, 0xcf, 0x95, 0x8b, 0x54, 0x2f, 0xfe, 0x76, 0x4b, 0x1b, 0x2d,
+	0xef, 0x74, 0x8f, 0xce, 0x6b, 0xa0, 0x07, 0xdf, 0x68, 0x01, 0x52, 0x4d, 0xdd, 0x75, 0x2f, 0x6c,
+	0xc7, 0x60, 0x07, 0xf2, 0xe0, 0x2d, 0x2d, 0x80, 0x48, 0x4f, 0x65, 0x13, 0xa0, 0x43, 0x0b, 0xcd,
+	0xc3, 0xec, 0xc6, 0xd1, 0xe1, 0x83, 0xea, 0x5e, 0xe5, 0xf0, 0xc1, 0xfe, 0x56, 0xf5, 0xe8, 0xe1,
+	0xc1, 0xa3, 0xca, 0xe6, 0xce, 0xfd, 0x9d, 0xca, 0x56, 0xfe, 0x2d, 0x54, 0x84, 0x82, 0x38, 0xf9,
+	0x68, 0xe3, 0xe0, 0xe0, 0xc9, 0xbe, 0xb6, 0x95, 0x4f, 0xdc, 0xcb, 0x41, 0x96, 0x4a, 0x6c, 0x60,
+	0x4f, 0x37, 0xeb, 0xae, 0xfa, 0x9f, 0x43, 0x00, 0x3b, 0xd6, 0xb9, 0xe9, 0xbd, 0x71, 0x8f, 0x63,
+	0x60, 0xee, 0x23, 0xa2, 0xb9, 0x2f, 0x72, 0xeb, 0x1c, 0xa5, 0xb4, 0xd2, 0x81, 0x7f, 0x64, 0x86,
+	0x4a, 0x38, 0xe2, 0x67, 0x4d, 0xd3, 0xe1, 0x1c, 0xc7, 0xfa, 0x73, 0x64, 0xe8, 0xbe, 0xb8, 0x7a,
+	0xad, 0x86, 0x9b, 0x1e, 0x5b, 0x9c, 0xea, 0xbf, 0x98, 0xa1, 0xd3, 0xc5, 0x45, 0x18, 0x33, 0xc9,
+	0x39, 0x60, 0x87, 0x3f, 0x0f, 0xfe, 0x50, 0xfd, 0xfd, 0x10, 0x8c, 0xf3, 0x5b, 0x76, 0xaf, 0x65,
+	0x19, 0x75, 0x8a, 0x7b, 0x8e, 0x1d, 0x97, 0x84, 0x3c, 0xe4, 0xa0, 0x46, 0x34, 0x7f, 0xc8, 0xe5,
+	0xb7, 0x1d, 0x6f, 0xe0, 0xb3, 0x62, 0xe8, 0x54, 0x04, 0xe1, 0x86, 0x0f, 0xf5, 0xba, 0xe1, 0x1f,
+	0xc3, 0x84, 0x6f, 0xa8, 0xd5, 0xa6, 0x5d, 0x37, 0x6b, 0x6d, 0x7e, 0x34, 0x53, 0x14, 0xff, 0x11,
+	0x9f, 0x7b, 0x44, 0xa7, 0xb4, 0x5c, 0x33, 0x34, 0x46, 0xef, 0xc1, 0x08, 0x51, 0xb5, 0x5b, 0x1c,
+	0xa1, 0x4f, 0xd4, 0x15, 0x91, 0x07, 0xdb, 0x5f, 0x89, 0xfd, 0x18, 0xf4, 0x5c, 0x18, 0xb2, 0xd2,
+	0x84, 0x8c, 0x00, 0x0d, 0x8e, 0x31, 0x21, 0x3f, 0xc6, 0x7b, 0x00, 0x26, 0xbb, 0x7f, 0x26, 0x76,
+	0x8b, 0x49, 0xca, 0x48, 0xed, 0xce, 0xc8, 0xbf, 0xab, 0x9a, 0xb0, 0x4a, 0xd1, 0x61, 0x22, 0x32,
+	0x8d, 0x6e, 0x42, 0x8a, 0x23, 0xb4, 0x39, 0xe7, 0xf1, 0xd0, 0x5d, 0xd7, 0x82, 0x69, 0xb4, 0x0c,
+	0xe3, 0x81, 0x8e, 0x4e, 0x75, 0xf7, 0x94, 0xbb, 0xc3, 0xac, 0x0f, 0x7c, 0xa0, 0xbb, 0xa7, 0xea,
+	0x9f, 0x92, 0x90, 0x0b, 0x6b, 0xab, 0xdb, 0x05, 0x14, 0xef, 0x50, 0xf2, 0x52, 0x77, 0x68, 0x11,
+	0xa0, 0x61, 0x5a, 0xd5, 0x3a, 0xb6, 0x4e, 0xbc, 0x53, 0x7a, 0xae, 0x23, 0x5a, 0xba, 0x61, 0x5a,
+	0xbb, 0x14, 0x80, 0xde, 0x85, 0x49, 0x3f, 0xde, 0x6b, 0x35, 0x9b, 0xd8, 0xa9, 0xe9, 0x2e, 0xbb,
+	0x68, 0x29, 0x2d, 0xcf, 0x27, 0x8e, 0x7c, 0xb8, 0x88, 0x5c, 0xb7, 0x2f, 0x38, 0xf2, 0x48, 0x08,
+	0x79, 0xd7, 0x87, 0x13, 0x0d, 0xf8, 0xc8, 0x86, 0x79, 0x62, 0xb2, 0x68, 0x3e, 0xa5, 0x65, 0x39,
+	0x70, 0x8b, 0xc0, 0x48, 0xe4, 0xe4, 0x23, 0xb9, 0xed, 0xc6, 0xb1, 0xcd, 0x02, 0xab, 0x94, 0xe6,
+	0x2f, 0x3d, 0xa0, 0x40, 0xf2, 0xba, 0x9d, 0x9a, 0xae, 0x67, 0x3b, 0xed, 0xaa, 0x6b, 0x3e, 0x67,
+	0x57, 0x6b, 0x44, 0xcb, 0x70, 0xd8, 0x81, 0xf9, 0x1c, 0xa3, 0x32, 0x8c, 0x35, 0xf4, 0x67, 0x55,
+	0xfd, 0x84, 0x85, 0x57, 0x99, 0xf2, 0x5c, 0x4c, 0x41, 0x5b, 0x3c, 0x3d, 0xd3, 0x46, 0x1b, 0xfa,
+	0xb3, 0x8d, 0x13, 0xac, 0xfe, 0x21, 0x49, 0x5c, 0xaa, 0x61, 0x7a, 0x95, 0x73, 0xfc, 0x3a, 0x92,
+	0xac, 0x02, 0x8c, 0xe8, 0x35, 0xcf, 0x76, 0xa8, 0xda, 0xd3, 0x1a, 0x1b, 0x90, 0xdb, 0xeb, 0x5f,
+	0xb3, 0x61, 0x76, 0xd3, 0xf9, 0x10, 0x29, 0x90, 0x72, 0xb0, 0x6b, 0xb7, 0x9c, 0x9a, 0xff, 0x80,
+	0x07, 0x63, 0x12, 0x26, 0xf2, 0x57, 0x86, 0x3d, 0xdf, 0x7c, 0x84, 0xe6, 0x21, 0xcd, 0x30, 0xaa,
+	0x66, 0x93, 0x3f, 0x17, 0x29, 0x06, 0xd8, 0x69, 0xa2, 0x3b, 0x30, 0x66, 0xb7, 0xbc, 0x9a, 0xcd,
+	0xbd, 0x51, 0xae, 0x3c, 0xcb, 0x2e, 0x41, 0xb0, 0xe7, 0xd2, 0x3e, 0x9b, 0xd6, 0x7c, 0x3c, 0x75,
+	0x17, 0xc6, 0x38, 0x0c, 0xcd, 0xc2, 0xd4, 0xfe, 0xd1, 0xe1, 0xe6, 0xfe, 0x5e, 0x25, 0xf2, 0xbc,
+	0x4c, 0xc1, 0x84, 0x3f, 0x71, 0x70, 0xb4, 0xb9, 0x59, 0x39, 0x38, 0xc8, 0x27, 0x44, 0xe0, 0xfd,
+	0x8d, 0x9d, 0xdd, 0x23, 0xad, 0x92, 0x4f, 0xaa, 0xff, 0x91, 0x84, 0xb1, 0x27, 0xf8, 0xf8, 0xd4,
+	0xb6, 0xcf, 0xde, 0xa4, 0xb7, 0x25, 0x0f, 0x43, 0x2d, 0xc7, 0x7f, 0x59, 0xc8, 0x27, 0xba, 0x0a,
+	0x19, 0x4c, 0x14, 0x5a, 0xf5, 0xda, 0x4d, 0xec, 0x16, 0x47, 0x69, 0xb2, 0x05, 0x14, 0x74, 0x48,
+	0x20, 0xe4, 0xfc, 0x5c, 0x5c, 0x73, 0xb0, 0xc7, 0x0f, 0x89, 0x8f, 0xd4, 0xdf, 0x25, 0x60, 0x82,
+	0x6b, 0x68, 0x0b, 0xd7, 0xcd, 0x73, 0xec, 0xbc, 0x86, 0x08, 0x69, 0x11, 0xa0, 0x23, 0x1d, 0xb7,
+	0xc6, 0x74, 0x20, 0x1c, 0xb1, 0xc8, 0xa6, 0xde, 0xae, 0xdb, 0xba, 0xe1, 0x5b, 0x24, 0x1f, 0x12,
+	0x8b, 0xd4, 0x3d, 0x0f, 0x37, 0x9a, 0x9e, 0x4b, 0x77, 0x3b, 0xa2, 0x05, 0x63, 0x42, 0xb4, 0xae,
+	0xbb, 0x5e, 0x15, 0x3b, 0x8e, 0xed, 0x70, 0xab, 0x4c, 0x13, 0x48, 0x85, 0x00, 0xd4, 0x1f, 0x60,
+	0x8a, 0x84, 0x2b, 0xc4, 0x1f, 0xd6, 0x74, 0x0f, 0x6b, 0xf8, 0xbb, 0x16, 0x76, 0x3d, 0xd1, 0xfa,
+	0x13, 0x61, 0xeb, 0x97, 0x04, 0x8e, 0x84, 0x7f, 0x10, 0x25, 0x31, 0xb1, 0x83, 0x71, 0x38, 0xc9,
+	0x18, 0x8e, 0x24, 0x19, 0xea, 0x2d, 0x28, 0x84, 0xd9, 0xbb, 0x4d, 0xdb, 0x72, 0xe9, 0x9d, 0xf4,
+	0xec, 0x33, 0x6c, 0x71, 0xee, 0x6c, 0xa0, 0x7e, 0x01, 0xf3, 0x5c, 0x40, 0xdf, 0x1f, 0x6b, 0xd8,
+	0xc5, 0xde, 0x0b, 0x09, 0xad, 0x3e, 0x86, 0xf9, 0x4d, 0xdb, 0x7a, 0x6a, 0x3a, 0x0d, 0x29, 0x31,
+	0xa9, 0x04, 0xc4, 0xc5, 0x59, 0xf8, 0xa2, 0x1a, 0xec, 0x96, 0x11, 0xcc, 0x58, 0xf8, 0xc2, 0x27,
+	0xa2, 0xae, 0x02, 0xa2, 0xb9, 0x64, 0xbb, 0x42, 0x42, 0x99, 0x9e, 0xe4, 0xd4, 0xcf, 0x61, 0x72,
+	0x1b, 0x7b, 0xfe, 0xd3, 0xcd, 0x51, 0x65, 0x76, 0x75, 0x0d, 0xb2, 0xee, 0xa9, 0x7d, 0x51, 0x65,
+	0xd6, 0xcd, 0xf8, 0xa6, 0xb4, 0x0c, 0x81, 0x6d, 0x31, 0x90, 0x3a, 0x0b, 0xd3, 0xbb, 0xa6, 0xeb,
+	0xed, 0xb5, 0x39, 0x39, 0x97, 0xd3, 0x53, 0x35, 0x98, 0x89, 0x4e, 0x70, 0x2d, 0x7f, 0x08, 0x99,
+	0x46, 0x90, 0x3a, 0xb8, 0xc5, 0x04, 0x7d, 0x81, 0x67, 0xc4, 0x17, 0xb8, 0x93, 0x59, 0x68, 0x22,
+	0xaa, 0xfa, 0x63, 0x02, 0x0a, 0x9b, 0xd4, 0x72, 0x23, 0xc2, 0x0f, 0x9a, 0x7f, 0x2c, 0xf2, 0xe2,
+	0x51, 0x32, 0x16, 0x1a, 0x10, 0x30, 0x7d, 0x96, 0x6c, 0xdb, 0xab, 0x46, 0xcc, 0x2a, 0x4b, 0x80,
+	0x81, 0xa6, 0xbf, 0x87, 0xc2, 0x11, 0x75, 0x15, 0x2f, 0x28, 0x43, 0xc7, 0x33, 0x91, 0x7a, 0x5e,
+	0xd7, 0xcb, 0x7a, 0x9f, 0x94, 0xfc, 0xf6, 0x74, 0xf7, 0xcc, 0xf7, 0x4c, 0xe4, 0x5b, 0xfd, 0x14,
+	0x0a, 0x4c, 0xf3, 0x03, 0x9c, 0x9e, 0x9f, 0x27, 0x24, 0x85, 0x3c, 0xe1, 0x16, 0xcc, 0x1c, 0x59,
+	0xc6, 0x80, 0x14, 0xd4, 0x55, 0x28, 0x54, 0x68, 0x08, 0x38, 0x00, 0xee, 0xcf, 0x49, 0x28, 0xec,
+	0x34, 0x06, 0x43, 0x46, 0xab, 0x30, 0x7a, 0x4c, 0xe3, 0x27, 0xbe, 0x7d, 0x14, 0x8f, 0xbf, 0x34,
+	0x8e, 0x81, 0x9e, 0xc0, 0x64, 0xcd, 0xb6, 0x9e, 0xd6, 0xcd, 0x9a, 0x57, 0x75, 0x3d, 0x47, 0xf7,
+	0xf0, 0x49, 0x9b, 0x1e, 0x4c, 0xae, 0xbc, 0xca, 0x22, 0x2c, 0x09, 0xd7, 0xd2, 0x26, 0x5f, 0x72,
+	0xc0, 0x57, 0x68, 0xf9, 0x5a, 0x04, 0xa2, 0xfe, 0x77, 0x02, 0xf2, 0x51, 0x34, 0x74, 0x0d, 0x16,
+	0x37, 0xf7, 0x1f, 0xde, 0xdf, 0xdd, 0xd9, 0x3c, 0xac, 0x1e, 0x1c, 0x6a, 0x1b, 0x87, 0x95, 0xed,
+	0xaf, 0x22, 0x2f, 0x9c, 0x02, 0x33, 0x71, 0x14, 0xf2, 0xac, 0xe5, 0x13, 0xf2, 0xb9, 0x83, 0x2f,
+	0x76, 0x1e, 0xe5, 0x93, 0xe8, 0x2a, 0xcc, 0xc7, 0xe7, 0xf6, 0x1f, 0x57, 0xb4, 0x27, 0xda, 0xce,
+	0x61, 0x25, 0x3f, 0x84, 0x16, 0xa0, 0x18, 0x47, 0xd0, 0x2a, 0x0f, 0x37, 0xf6, 0x2a, 0xf9, 0x61,
+	0xf5, 0xd7, 0x49, 0x98, 0x8e, 0x6c, 0x95, 0x5f, 0xa8, 0x41, 0x2d, 0x6f, 0x19, 0xc6, 0x99, 0xdf,
+	0x37, 0xaa, 0x2c, 0xca, 0x4e, 0xd2, 0x97, 0x28, 0xcb, 0x81, 0xe4, 0x22, 0xb8, 0x24, 0x8e, 0xb3,
+	0xcf, 0xb1, 0x73, 0xe1, 0x98, 0x9e, 0x87, 0x2d, 0x8e, 0x38, 0x44, 0x11, 0xf3, 0xc2, 0x04, 0x43,
+	0x5e, 0x86, 0x71, 0xf7, 0xcc, 0x6c, 0x36, 0x03, 0x8a, 0xc3, 0x8c, 0x22, 0x07, 0x32, 0xa4, 0x2f,
+	0x49, 0xb0, 0x47, 0x8e, 0xdd, 0xa8, 0x8a, 0xc1, 0xfd, 0x2d, 0xd9, 0xe1, 0xb1, 0x1d, 0x95, 0x34,
+	0x86, 0x4f, 0xd7, 0xb3, 0x8a, 0x54, 0xd6, 0x11, 0x40, 0xca, 0x67, 0x30, 0x19, 0x43, 0xb9, 0x54,
+	0xad, 0xe7, 0xa7, 0x04, 0xe4, 0x89, 0x7b, 0xa2, 0xcb, 0x7d, 0x4b, 0x9d, 0x87, 0x74, 0x53, 0x3f,
+	0xc1, 0x2c, 0x8c, 0x64, 0xc9, 0x53, 0x8a, 0x00, 0x68, 0x0c, 0xb9, 0x08, 0x40, 0x27, 0x99, 0x3f,
+	0x4d, 0xf2, 0x27, 0x45, 0x3f, 0xc1, 0x87, 0x04, 0x40, 0x9e, 0xf0, 0xa7, 0x66, 0x9d, 0x64, 0x68,
+	0xcc, 0x67, 0xf0, 0x11, 0x9a, 0x83, 0x94, 0xed, 0x18, 0xd8, 0xa9, 0x1e, 0xb7, 0xfd, 0xf7, 0x93,
+	0x8e, 0xef, 0xb5, 0x63, 0xde, 0x75, 0x24, 0xee, 0x5d, 0xff, 0x16, 0x26, 0x05, 0x29, 0xf9, 0x71,
+	0x5f, 0xf5, 0x93, 0x24, 0xe6, 0x39, 0x05, 0x2f, 0xc6, 0xe0, 0xe8, 0x3a, 0x4c, 0x58, 0xf8, 0x99,
+	0x57, 0x8d, 0xc9, 0x3b, 0x4e, 0xc0, 0x8f, 0x7c, 0x99, 0xd5, 0x6d, 0xc8, 0x6d, 0x63, 0x4a, 0xfc,
+	0x25, 0x1f, 0x81, 0x32, 0x4c, 0x32, 0xb7, 0x2c, 0xd2, 0xea, 0x9d, 0x86, 0xa9, 0x36, 0x4c, 0x32,
+	0x37, 0x3a, 0xf8, 0x9a, 0x97, 0x73, 0x9d, 0x1f, 0xc1, 0x24, 0x93, 0xb7, 0xdf, 0x86, 0x65, 0x7e,
+	0xf3, 0x26, 0x4c, 0xf9, 0x7e, 0xb3, 0xcf, 0x72, 0xd5, 0x80, 0xc2, 0xae, 0x69, 0x9d, 0x05, 0x95,
+	0xc4, 0x5e, 0xac, 0x42, 0x61, 0x4a, 0x32, 0x12, 0xa6, 0xf4, 0x0a, 0x70, 0x98, 0x23, 0xaf, 0x0f,
+	0xc8, 0x47, 0x3d, 0x86, 0x19, 0x76, 0x40, 0x31, 0xec, 0x5b, 0xa2, 0x04, 0x4c, 0xed, 0x39, 0x96,
+	0xa9, 0x07, 0x98, 0x5d, 0x24, 0x4a, 0x46, 0x24, 0x5a, 0x87, 0x7c, 0xc5, 0xd1, 0xdd, 0x17, 0x52,
+	0xef, 0x2e, 0x4c, 0x0a, 0x6b, 0xb9, 0x9d, 0xf7, 0x31, 0x86, 0x22, 0x8c, 0x39, 0xb8, 0x86, 0xcd,
+	0xa6, 0xc7, 0x49, 0xf9, 0x43, 0xf5, 0x06, 0x4c, 0x1e, 0x59, 0x75, 0xbb, 0x76, 0xd6, 0xef, 0xa8,
+	0xfe, 0x2f, 0xc1, 0xa2, 0x97, 0x9d, 0x20, 0xb3, 0xf7, 0xb1, 0x67, 0x60, 0xb4, 0xa9, 0x3b, 0x38,
+	0x88, 0xe9, 0xf8, 0x28, 0xec, 0x22, 0x92, 0x3d, 0x5d, 0xc4, 0x50, 0x77, 0x17, 0x31, 0xdc, 0xd5,
+	0x45, 0x8c, 0xf4, 0x76, 0x11, 0xa3, 0xf1, 0xbb, 0x67, 0xc3, 0x4c, 0x74, 0x0b, 0x5c, 0x7f, 0xb7,
+	0x43, 0x85, 0x0e, 0xe6, 0x2c, 0x22, 0x35, 0x09, 0x01, 0x61, 0x60, 0xaf, 0xb1, 0x02, 0x68, 0x1b,
+	0x7b, 0x01, 0x89, 0x1e, 0xea, 0xfd, 0x1a, 0xa6, 0x99, 0xd5, 0x45, 0x91, 0xbb, 0x69, 0x57, 0xac,
+	0xa1, 0x24, 0x7b, 0xd6, 0x50, 0xd4, 0x5d, 0x40, 0x7b, 0xf6, 0xf9, 0x20, 0x61, 0x50, 0xb4, 0x11,
+	0x98, 0x94, 0x34, 0x02, 0x35, 0x98, 0x63, 0x92, 0x8a, 0xdd, 0x40, 0x9f, 0xe8, 0xfb, 0x11, 0x02,
+	0xcc, 0x1e, 0x27, 0xa9, 0x64, 0x21, 0xfc, 0x30, 0xcd, 0x5b, 0x30, 0xb3, 0x8d, 0x3d, 0x19, 0x41,
+	0x99, 0xae, 0xfe, 0x29, 0x01, 0x73, 0xcc, 0x1f, 0xbe, 0x3a, 0x11, 0x5e, 0xce, 0x5f, 0xee, 0xc3,
+	0xfc, 0x86, 0x61, 0x88, 0xd4, 0x37, 0x48, 0x7b, 0xf0, 0x85, 0xdd, 0x99, 0xaa, 0xc1, 0x15, 0x0d,
+	0x37, 0xec, 0x73, 0xfc, 0x0a, 0x69, 0xae, 0xc3, 0xdb, 0x11, 0x25, 0x47, 0x2a, 0x8c, 0x3d, 0x54,
+	0xfe, 0x53, 0x02, 0x6e, 0xc4, 0x55, 0x2e, 0x5f, 0x2f, 0x29, 0x6b, 0x26, 0x06, 0x2f, 0x6b, 0xbe,
+	0xd4, 0x39, 0x94, 0xa0, 0xb8, 0x8d, 0xbd, 0xc1, 0xb7, 0xf5, 0x6f, 0x09, 0x98, 0x67, 0xdb, 0xfa,
+	0x8b, 0xdb, 0xca, 0xdf, 0xc3, 0x34, 0x93, 0x2c, 0xea, 0x10, 0x2e, 0x51, 0x3c, 0x7d, 0x29, 0x01,
+	0x3e, 0x83, 0x69, 0xe6, 0x37, 0x07, 0x70, 0x5f, 0xd2, 0x87, 0xaa, 0xce, 0xbd, 0x6d, 0xd0, 0x15,
+	0x79, 0x9d, 0x2f, 0x86, 0xea, 0xc1, 0x6c, 0x8c, 0x1b, 0x77, 0xee, 0x77, 0x20, 0x63, 0x76, 0xc0,
+	0xdc, 0xbb, 0x4f, 0x30, 0xa5, 0x05, 0x70, 0x4d, 0xc4, 0x19, 0xd8, 0xc1, 0x1f, 0xc3, 0x2c, 0x77,
+	0xdb, 0x1d, 0x42, 0x7d, 0x36, 0xb9, 0x06, 0xd0, 0xe1, 0xc4, 0xcf, 0x24, 0x26, 0x8c, 0x80, 0xa2,
+	0xde, 0x86, 0x59, 0x7e, 0x10, 0x31, 0x1e, 0x32, 0x9b, 0xde, 0x81, 0xd9, 0x0d, 0xda, 0x0e, 0x91,
+	0xa2, 0xd7, 0x6c, 0x23, 0x40, 0x27, 0xdf, 0x3d, 0xc3, 0x94, 0x9f, 0x13, 0xec, 0x08, 0x3b, 0x75,
+	0xce, 0x57, 0x12, 0xff, 0xdf, 0x05, 0x70, 0x3d, 0xdd, 0xef, 0xad, 0xf4, 0x2f, 0x37, 0xa6, 0x29,
+	0x36, 0x19, 0xa3, 0xf7, 0x21, 0x85, 0x2d, 0x63, 0xd0, 0x52, 0xe3, 0x18, 0xb6, 0x0c, 0xba, 0xac,
+	0x13, 0x4e, 0x8c, 0x88, 0xe1, 0x84, 0xda, 0x82, 0xd9, 0xd8, 0xfe, 0xb8, 0xd1, 0x94, 0x49, 0x83,
+	0xcf, 0x30, 0xbd, 0x2a, 0x2d, 0xef, 0x85, 0xad, 0xa6, 0x83, 0xaf, 0x65, 0xf4, 0xce, 0xda, 0x81,
+	0xad, 0xe6, 0x4b, 0x98, 0x22, 0x6c, 0x79, 0xb9, 0xf2, 0x55, 0xe8, 0x54, 0x3d, 0x85, 0x42, 0x98,
+	0x24, 0xdf, 0xc6, 0x0a, 0xa4, 0x2e, 0x38, 0x8c, 0x6f, 0x81, 0x25, 0xbc, 0x1c, 0x51, 0x0b, 0x66,
+	0x07, 0x16, 0xfe, 0x53, 0xbf, 0xae, 0xe4, 0x93, 0xe8, 0xd4, 0x74, 0x38, 0xad, 0x50, 0x66, 0xed,
+	0x63, 0xf9, 0x93, 0xa4, 0x50, 0xc2, 0xcc, 0x39, 0xb2, 0x5e, 0x66, 0xcb, 0xdc, 0x85, 0x6c, 0x61,
+	0xdd, 0xd8, 0xc5, 0x9e, 0x27, 0xe4, 0x9f, 0xaf, 0xc3, 0x85, 0x3c, 0x87, 0xd9, 0x18, 0x37, 0xae,
+	0xc6, 0x0f, 0x20, 0x6b, 0x60, 0xdd, 0xa8, 0xd6, 0x19, 0x9c, 0xab, 0xb2, 0x20, 0xee, 0xd0, 0xaf,
+	0x3a, 0x6b, 0x19, 0xa3, 0x43, 0x60, 0x50, 0xad, 0x96, 0x7f, 0x75, 0x03, 0x86, 0x76, 0x36, 0xf6,
+	0xd0, 0xdf, 0x41, 0x56, 0x2c, 0xb7, 0xa2, 0x22, 0x37, 0xb8, 0x58, 0x01, 0x58, 0x99, 0x93, 0xcc,
+	0x30, 0x69, 0xd5, 0xf9, 0x1f, 0x7f, 0xfb, 0xc7, 0x7f, 0x49, 0x4e, 0xaf, 0x27, 0x56, 0xd5, 0xfc,
+	0xda, 0xf9, 0x5f, 0xad, 0xe9, 0x22, 0xc5, 0x16, 0x14, 0x64, 0x25, 0x5a, 0xb4, 0x44, 0xe9, 0xf5,
+	0xa8, 0xde, 0x2a, 0x33, 0xb1, 0xab, 0x56, 0x21, 0x7f, 0xc4, 0x53, 0x97, 0x29, 0xbb, 0x45, 0xc2,
+	0xae, 0x48, 0xd8, 0x39, 0x32, 0xf2, 0x2d, 0x28, 0xc8, 0x8a, 0xb9, 0x9c, 0x6d, 0x8f, 0x3a, 0xef,
+	0xc0, 0x6c, 0x6b, 0x32, 0xf2, 0x5f, 0x43, 0x46, 0xa8, 0xf5, 0x22, 0xd6, 0xb7, 0x89, 0x57, 0x7f,
+	0xbb, 0x32, 0x51, 0x28, 0x93, 0x02, 0x61, 0x32, 0x41, 0x98, 0x9c, 0x0b, 0xc4, 0xbe, 0x85, 0x7c,
+	0xd4, 0xd3, 0xa2, 0x05, 0xbf, 0x9c, 0x24, 0x73, 0xc0, 0x4a, 0x27, 0x31, 0x53, 0xaf, 0x51, 0xc2,
+	0xf3, 0x84, 0xf0, 0x0c, 0x21, 0x2c, 0xbc, 0x3e, 0xeb, 0xac, 0x9d, 0x8d, 0x0c, 0xc8, 0x85, 0xcb,
+	0xc2, 0x88, 0xfd, 0x0f, 0x49, 0x5a, 0x44, 0x56, 0xe6, 0xa5, 0x73, 0xdc, 0x22, 0xe6, 0x28, 0xb7,
+	0x29, 0x34, 0x49, 0xcd, 0x81, 0xcf, 0xae, 0x37, 0x4c, 0x0b, 0xa3, 0x2f, 0x01, 0x3a, 0x15, 0x6e,
+	0xc4, 0x6a, 0xcb, 0xb1, 0x92, 0xb7, 0x12, 0x2a, 0x93, 0xa9, 0x8b, 0x94, 0xdc, 0x2c, 0x9a, 0x26,
+	0xe4, 0xbe, 0x27, 0x57, 0xf5, 0x13, 0x9f, 0xe8, 0xda, 0xea, 0x0f, 0xa4, 0x8a, 0x15, 0x2a, 0x3d,
+	0x23, 0x66, 0xab, 0xb2, 0x72, 0x74, 0x84, 0xf0, 0x2c, 0x25, 0x3c, 0x49, 0xb4, 0x92, 0x15, 0x45,
+	0x45, 0xa7, 0x30, 0x1e, 0xaa, 0x24, 0x73, 0x92, 0xb2, 0xea, 0x72, 0x84, 0x64, 0x89, 0x92, 0x5c,
+	0x59, 0xf7, 0x4b, 0x7b, 0xe5, 0x45, 0x2a, 0x34, 0x1f, 0x95, 0x62, 0xc2, 0xb7, 0x68, 0xc5, 0x3f,
+	0xd2, 0x4e, 0x5e, 0xf4, 0xd5, 0x22, 0x8d, 0x13, 0x15, 0x59, 0x38, 0xa8, 0xde, 0xa2, 0x8c, 0xaf,
+	0xa3, 0xb7, 0x65, 0x4a, 0x5a, 0x0b, 0xc7, 0x8a, 0x3f, 0xa0, 0x7f, 0x4f, 0xf8, 0xb5, 0xf2, 0x08,
+	0xeb, 0x25, 0x61, 0xa3, 0x97, 0xe0, 0xbe, 0x47, 0xb9, 0x6f, 0xaf, 0x47, 0x03, 0xd8, 0xf2, 0x7b,
+	0x54, 0x9c, 0x08, 0xb4, 0xd4, 0x4f, 0xbc, 0x6f, 0x20, 0x23, 0xe4, 0x90, 0xfc, 0x1e, 0xc5, 0xb3,
+	0xca, 0x88, 0xee, 0xdf, 0xa1, 0x42, 0x5c, 0x25, 0xc7, 0xa9, 0x48, 0x4d, 0x65, 0x9d, 0xe4, 0x38,
+	0xa8, 0x09, 0x28, 0x9e, 0x52, 0xa2, 0x2b, 0x82, 0xd1, 0x48, 0x12, 0x3d, 0x25, 0x9e, 0xd2, 0xa9,
+	0x37, 0x28, 0xbf, 0x6b, 0xeb, 0xe1, 0xec, 0x92, 0x1a, 0xbd, 0x08, 0x71, 0x11, 0x86, 0x89, 0x48,
+	0x2e, 0x84, 0xe6, 0xfd, 0x23, 0x1e, 0x90, 0x97, 0x4a, 0x79, 0x2d, 0x20, 0x61, 0x63, 0x21, 0x1e,
+	0xc4, 0x96, 0xfe, 0x31, 0x01, 0x28, 0x9e, 0x36, 0xf1, 0x9d, 0x75, 0x4d, 0x61, 0x65, 0xdc, 0x3e,
+	0xa1, 0xdc, 0x3e, 0x08, 0xef, 0xac, 0x7c, 0x83, 0xf2, 0x16, 0x41, 0x25, 0xb9, 0x20, 0xdf, 0x43,
+	0x41, 0x96, 0xa0, 0x72, 0xe3, 0xea, 0x91, 0xbb, 0xca, 0x64, 0xf1, 0x6f, 0x54, 0x62, 0x55, 0x5d,
+	0xee, 0xbe, 0xf9, 0x75, 0xdd, 0x30, 0x18, 0x93, 0x7f, 0x48, 0xc0, 0x6c, 0x97, 0x6c, 0x16, 0x2d,
+	0xf3, 0x57, 0xa7, 0x57, 0xae, 0x2b, 0x93, 0xe1, 0x0e, 0x95, 0xe1, 0x5d, 0x22, 0xc3, 0xf5, 0x1e,
+	0x32, 0x38, 0x94, 0x30, 0x63, 0xf5, 0xcf, 0x09, 0x58, 0xec, 0x99, 0x00, 0xa3, 0x9b, 0x32, 0x13,
+	0xb8, 0xc4, 0x9d, 0xe3, 0x42, 0xa1, 0x9b, 0x5d, 0x25, 0x8a, 0xdd, 0xab, 0xff, 0x49, 0xc0, 0x52,
+	0xbf, 0xc4, 0x1a, 0xdd, 0xea, 0x62, 0x2f, 0x97, 0x10, 0x4d, 0xa3, 0xa2, 0xed, 0xc6, 0xdd, 0xc1,
+	0xdd, 0xee, 0xee, 0xa0, 0x9f, 0xec, 0x3a, 0x8c, 0x87, 0x1a, 0x6c, 0xdc, 0x27, 0xcb, 0x9a, 0x6e,
+	0x5d, 0xdf, 0x57, 0xfe, 0x92, 0xac, 0x76, 0x79, 0x49, 0x4e, 0x60, 0x22, 0xd2, 0x83, 0xe3, 0xf7,
+	0x54, 0xde, 0x99, 0x8b, 0xb8, 0x9f, 0x9b, 0x94, 0xf8, 0x32, 0x31, 0x92, 0x2b, 0x72, 0xf7, 0xd3,
+	0xe2, 0x64, 0x90, 0x01, 0xe3, 0xa1, 0xf6, 0x1d, 0xdf, 0x8b, 0xac, 0xa5, 0xa7, 0x48, 0x3a, 0x70,
+	0xea, 0xdb, 0x94, 0xd5, 0x15, 0xb4, 0x20, 0xe7, 0xc3, 0xfe, 0x1d, 0x86, 0x6c, 0x18, 0xdf, 0x69,
+	0xc4, 0xb9, 0xc8, 0xba, 0x72, 0x8a, 0xd2, 0xbd, 0xe7, 0x13, 0xf8, 0xb9, 0xc4, 0xaa, 0xda, 0x85,
+	0xa1, 0x49, 0xd7, 0xa1, 0x3d, 0x48, 0x07, 0x4d, 0x11, 0x34, 0x1d, 0x44, 0x08, 0x62, 0x2b, 0x47,
+	0x99, 0x89, 0x82, 0x39, 0x93, 0x49, 0xca, 0x24, 0x83, 0xd2, 0x84, 0x03, 0xeb, 0x96, 0x54, 0x60,
+	0x8c, 0x77, 0x41, 0xd0, 0x94, 0x7f, 0x57, 0x84, 0xc2, 0xb1, 0x18, 0xdf, 0xf0, 0xc0, 0x09, 0xa1,
+	0x8e, 0x7c, 0x94, 0x06, 0x39, 0xd5, 0x07, 0x00, 0x9d, 0x1e, 0x08, 0x0f, 0x39, 0x62, 0x4d, 0x11,
+	0x91, 0x98, 0x1f, 0x16, 0xb0, 0x76, 0x88, 0x20, 0xd0, 0x63, 0x80, 0x4e, 0x67, 0x84, 0x53, 0x8a,
+	0xb5, 0x4a, 0x44, 0x4a, 0xfe, 0x8b, 0x44, 0x29, 0x95, 0x67, 0xa9, 0x70, 0xe4, 0xb3, 0x14, 0x96,
+	0xf0, 0x6f, 0x00, 0x3a, 0x0d, 0x10, 0x4e, 0x37, 0xd6, 0x11, 0xe9, 0x17, 0x34, 0xae, 0xca, 0xf6,
+	0xfe, 0x0d, 0x64, 0xc5, 0xee, 0x08, 0x0f, 0xf0, 0x25, 0x0d, 0x13, 0x51, 0xea, 0xeb, 0x94, 0xe0,
+	0x12, 0x39, 0xef, 0xf9, 0x38, 0xcd, 0x8e, 0x15, 0x1f, 0xc3, 0x78, 0xa8, 0x9f, 0xc2, 0xed, 0x4b,
+	0xd6, 0x63, 0x11, 0xc9, 0xbf, 0x4b, 0xc9, 0xbf, 0x43, 0xc8, 0x2f, 0x49, 0xc8, 0x87, 0x5a, 0x27,
+	0xe8, 0x94, 0x5c, 0xc9, 0x30, 0xc8, 0xbf, 0x92, 0xf5, 0x3e, 0x7c, 0x6e, 0x53, 0x3e, 0x37, 0x08,
+	0x1f, 0x55, 0xba, 0x8d, 0x30, 0xd9, 0xaf, 0x60, 0x22, 0xd2, 0x89, 0xe1, 0x9c, 0xe4, 0xfd, 0x19,
+	0x25, 0xd2, 0x8c, 0xf1, 0x83, 0x5e, 0xc2, 0x2e, 0x47, 0xd8, 0x05, 0xa5, 0x50, 0x17, 0x55, 0x21,
+	0x1d, 0x34, 0x51, 0xf8, 0xbd, 0x88, 0x36, 0x64, 0x94, 0x99, 0x28, 0x98, 0xdf, 0x8b, 0x68, 0xde,
+	0x11, 0xd9, 0x05, 0xfd, 0xf7, 0x3e, 0x3a, 0x06, 0xe8, 0xf4, 0x55, 0x7c, 0xc3, 0x8c, 0x36, 0x5a,
+	0xba, 0x1a, 0x10, 0xf7, 0x26, 0x84, 0xc5, 0x9c, 0x5c, 0x51, 0x76, 0xed, 0x0c, 0x39, 0x2c, 0x3f,
+	0xe8, 0xb4, 0x33, 0x84, 0xfc, 0x20, 0xd6, 0xa6, 0x51, 0xe6, 0xa5, 0x73, 0x61, 0x87, 0x82, 0xae,
+	0xf2, 0xd7, 0x80, 0xe4, 0xd2, 0x01, 0xbf, 0x35, 0xa1, 0xf3, 0xf1, 0x2d, 0x64, 0x84, 0x8e, 0x06,
+	0x8f, 0x03, 0xe3, 0x3d, 0x0e, 0x25, 0x5c, 0x93, 0x8c, 0xd0, 0x17, 0x77, 0x23, 0x10, 0x27, 0xd7,
+	0xc3, 0x82, 0x5c, 0xb8, 0x0f, 0xc2, 0xf7, 0x24, 0x6d, 0x8e, 0x44, 0xb9, 0xf8, 0x41, 0x41, 0xa7,
+	0xf5, 0xd1, 0x77, 0x3f, 0x17, 0x90, 0x0b, 0x97, 0x59, 0x39, 0x3f, 0x69, 0xed, 0x35, 0xca, 0x6f,
+	0x9d, 0xf2, 0x7b, 0xaf, 0xc3, 0xaf, 0xbc, 0x42, 0xf9, 0xf9, 0xc3, 0x52, 0x8f, 0x8d, 0x36, 0x20,
+	0x17, 0x2e, 0xaf, 0x72, 0xc6, 0xd2, 0x9a, 0x6b, 0x57, 0x43, 0xe1, 0x7a, 0x5d, 0xed, 0xab, 0xd7,
+	0x36, 0x4c, 0x44, 0xca, 0xa3, 0x48, 0x30, 0x88, 0x58, 0x89, 0x56, 0x59, 0x90, 0x4f, 0x72, 0x73,
+	0xe1, 0x0e, 0x03, 0x2d, 0x8b, 0xea, 0x15, 0x9e, 0x1f, 0x31, 0x9b, 0x45, 0xcf, 0x21, 0x1f, 0xad,
+	0x91, 0xf2, 0x34, 0xb9, 0x4b, 0xe9, 0x54, 0x89, 0x96, 0x43, 0xd5, 0x0f, 0x28, 0xbf, 0x3b, 0xeb,
+	0x62, 0x59, 0x74, 0x20, 0xde, 0x2d, 0xc8, 0x47, 0x6b, 0xa7, 0x9c, 0x77, 0x97, 0x92, 0x6a, 0x57,
+	0x4d, 0xf3, 0x2d, 0xaf, 0x2e, 0x4b, 0xb3, 0x39, 0x81, 0x27, 0xd1, 0x36, 0x66, 0xda, 0x16, 0xea,
+	0x8a, 0x82, 0xb6, 0xe3, 0xd5, 0x54, 0x65, 0x41, 0x3e, 0xc9, 0xb5, 0xcd, 0x5f, 0x3f, 0x34, 0xc1,
+	0x6a, 0x39, 0x1d, 0x9a, 0x5f, 0x41, 0x56, 0x2c, 0xfa, 0xf1, 0xb7, 0x44, 0x52, 0x5a, 0x54, 0xe6,
+	0x24, 0x33, 0x9c, 0x7a, 0x81, 0x52, 0xcf, 0x21, 0x9a, 0x6f, 0x07, 0xd5, 0xc0, 0x27, 0x7e, 0x0a,
+	0xcf, 0xf1, 0x43, 0x29, 0x7c, 0xb8, 0x72, 0xa7, 0x84, 0x0a, 0x7d, 0x7e, 0x44, 0xb7, 0x1e, 0x14,
+	0xfc, 0xc2, 0x84, 0x83, 0xa0, 0x31, 0x4c, 0x58, 0x56, 0x12, 0xbc, 0x4c, 0xd0, 0xe8, 0xd3, 0x17,
+	0x6c, 0x5d, 0xa8, 0xe3, 0x09, 0xda, 0x8f, 0xd7, 0x12, 0x95, 0x05, 0xf9, 0x64, 0x2f, 0x5b, 0x17,
+	0xd8, 0xad, 0x09, 0xe5, 0xbe, 0xe3, 0x51, 0x2a, 0xe9, 0x5f, 0xff, 0x79, 0x00, 0x57, 0xa9, 0xf9,
+	0x56, 0x22, 0x3b, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// IAMClient is the client API for IAM service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IAMClient interface {
+	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
+	RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ConfirmPasswordReset(ctx context.Context, in *ConfirmPasswordResetRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*User, error)
+	ListMyAccounts(ctx context.Context, in *ListMyAccountsRequest, opts ...grpc.CallOption) (*ListMyAccountsResponse, error)
+	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	GetPasswordPolicy(ctx context.Context, in *GetPasswordPolicyRequest, opts ...grpc.CallOption) (*PasswordPolicy, error)
+	UpdatePasswordPolicy(ctx context.Context, in *UpdatePasswordPolicyRequest, opts ...grpc.CallOption) (*PasswordPolicy, error)
+	MoveAccount(ctx context.Context, in *MoveAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	UpdateOrganization(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	AddOrganizationAdmin(ctx context.Context, in *AddOrganizationAdminRequest, opts ...grpc.CallOption) (*Organization, error)
+	RemoveOrganizationAdmin(ctx context.Context, in *RemoveOrganizationAdminRequest, opts ...grpc.CallOption) (*Organization, error)
+	GetOrganizationPasswordPolicy(ctx context.Context, in *GetOrganizationPasswordPolicyRequest, opts ...grpc.CallOption) (*PasswordPolicy, error)
+	UpdateOrganizationPasswordPolicy(ctx context.Context, in *UpdateOrganizationPasswordPolicyRequest, opts ...grpc.CallOption) (*PasswordPolicy, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	UndeleteAccount(ctx context.Context, in *UndeleteAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	ExportAccount(ctx context.Context, in *ExportAccountRequest, opts ...grpc.CallOption) (*AccountBundle, error)
+	ImportAccount(ctx context.Context, in *ImportAccountRequest, opts ...grpc.CallOption) (*ImportAccountResponse, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error)
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error)
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	UndeleteUser(ctx context.Context, in *UndeleteUserRequest, opts ...grpc.CallOption) (*User, error)
+	LinkPrincipal(ctx context.Context, in *LinkPrincipalRequest, opts ...grpc.CallOption) (*User, error)
+	UnlinkPrincipal(ctx context.Context, in *UnlinkPrincipalRequest, opts ...grpc.CallOption) (*User, error)
+	CreatePrincipal(ctx context.Context, in *CreatePrincipalRequest, opts ...grpc.CallOption) (*Principal, error)
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error)
+	UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesResponse, error)
+	GetIdentity(ctx context.Context, in *GetIdentityRequest, opts ...grpc.CallOption) (*Identity, error)
+	CreateIdentity(ctx context.Context, in *CreateIdentityRequest, opts ...grpc.CallOption) (*Identity, error)
+	UpdateIdentity(ctx context.Context, in *UpdateIdentityRequest, opts ...grpc.CallOption) (*Identity, error)
+	DeleteIdentity(ctx context.Context, in *DeleteIdentityRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error)
+	CreateInvitation(ctx context.Context, in *CreateInvitationRequest, opts ...grpc.CallOption) (*Invitation, error)
+	DeleteInvitation(ctx context.Context, in *DeleteInvitationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+}
+
+type iAMClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIAMClient(cc grpc.ClientConnInterface) IAMClient {
+	return &iAMClient{cc}
+}
+
+func (c *iAMClient) Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error) {
+	out := new(AuthenticateResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/Authenticate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iam.IAM/RequestPasswordReset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ConfirmPasswordReset(ctx context.Context, in *ConfirmPasswordResetRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ConfirmPasswordReset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iam.IAM/VerifyEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/iam.IAM/AcceptInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ListMyAccounts(ctx context.Context, in *ListMyAccountsRequest, opts ...grpc.CallOption) (*ListMyAccountsResponse, error) {
+	out := new(ListMyAccountsResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ListMyAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/iam.IAM/GetAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/iam.IAM/CreateAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UpdateAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) GetPasswordPolicy(ctx context.Context, in *GetPasswordPolicyRequest, opts ...grpc.CallOption) (*PasswordPolicy, error) {
+	out := new(PasswordPolicy)
+	err := c.cc.Invoke(ctx, "/iam.IAM/GetPasswordPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UpdatePasswordPolicy(ctx context.Context, in *UpdatePasswordPolicyRequest, opts ...grpc.CallOption) (*PasswordPolicy, error) {
+	out := new(PasswordPolicy)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UpdatePasswordPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) MoveAccount(ctx context.Context, in *MoveAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/iam.IAM/MoveAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, "/iam.IAM/CreateOrganization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, "/iam.IAM/GetOrganization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UpdateOrganization(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UpdateOrganization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) AddOrganizationAdmin(ctx context.Context, in *AddOrganizationAdminRequest, opts ...grpc.CallOption) (*Organization, error) {
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, "/iam.IAM/AddOrganizationAdmin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) RemoveOrganizationAdmin(ctx context.Context, in *RemoveOrganizationAdminRequest, opts ...grpc.CallOption) (*Organization, error) {
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, "/iam.IAM/RemoveOrganizationAdmin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) GetOrganizationPasswordPolicy(ctx context.Context, in *GetOrganizationPasswordPolicyRequest, opts ...grpc.CallOption) (*PasswordPolicy, error) {
+	out := new(PasswordPolicy)
+	err := c.cc.Invoke(ctx, "/iam.IAM/GetOrganizationPasswordPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UpdateOrganizationPasswordPolicy(ctx context.Context, in *UpdateOrganizationPasswordPolicyRequest, opts ...grpc.CallOption) (*PasswordPolicy, error) {
+	out := new(PasswordPolicy)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UpdateOrganizationPasswordPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iam.IAM/DeleteAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UndeleteAccount(ctx context.Context, in *UndeleteAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UndeleteAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ExportAccount(ctx context.Context, in *ExportAccountRequest, opts ...grpc.CallOption) (*AccountBundle, error) {
+	out := new(AccountBundle)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ExportAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ImportAccount(ctx context.Context, in *ImportAccountRequest, opts ...grpc.CallOption) (*ImportAccountResponse, error) {
+	out := new(ImportAccountResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ImportAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ListUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/iam.IAM/GetUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/iam.IAM/CreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UpdateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iam.IAM/DeleteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UndeleteUser(ctx context.Context, in *UndeleteUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UndeleteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) LinkPrincipal(ctx context.Context, in *LinkPrincipalRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/iam.IAM/LinkPrincipal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UnlinkPrincipal(ctx context.Context, in *UnlinkPrincipalRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UnlinkPrincipal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) CreatePrincipal(ctx context.Context, in *CreatePrincipalRequest, opts ...grpc.CallOption) (*Principal, error) {
+	out := new(Principal)
+	err := c.cc.Invoke(ctx, "/iam.IAM/CreatePrincipal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error) {
+	out := new(EraseUserResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/EraseUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UnlockUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesResponse, error) {
+	out := new(ListIdentitiesResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ListIdentities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) GetIdentity(ctx context.Context, in *GetIdentityRequest, opts ...grpc.CallOption) (*Identity, error) {
+	out := new(Identity)
+	err := c.cc.Invoke(ctx, "/iam.IAM/GetIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) CreateIdentity(ctx context.Context, in *CreateIdentityRequest, opts ...grpc.CallOption) (*Identity, error) {
+	out := new(Identity)
+	err := c.cc.Invoke(ctx, "/iam.IAM/CreateIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) UpdateIdentity(ctx context.Context, in *UpdateIdentityRequest, opts ...grpc.CallOption) (*Identity, error) {
+	out := new(Identity)
+	err := c.cc.Invoke(ctx, "/iam.IAM/UpdateIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) DeleteIdentity(ctx context.Context, in *DeleteIdentityRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iam.IAM/DeleteIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error) {
+	out := new(ListInvitationsResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ListInvitations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) CreateInvitation(ctx context.Context, in *CreateInvitationRequest, opts ...grpc.CallOption) (*Invitation, error) {
+	out := new(Invitation)
+	err := c.cc.Invoke(ctx, "/iam.IAM/CreateInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) DeleteInvitation(ctx context.Context, in *DeleteInvitationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iam.IAM/DeleteInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/iam.IAM/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iam.IAM/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iAMClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/iam.IAM/ListDeadLetters", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *iAMClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error) {
-	out := new(Account)
-	err := c.cc.Invoke(ctx, "/iam.IAM/GetAccount", in, out, opts...)
-	if err != nil {
+// IAMServer is the server API for IAM service.
+type IAMServer interface {
+	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
+	RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*empty.Empty, error)
+	ConfirmPasswordReset(context.Context, *ConfirmPasswordResetRequest) (*empty.Empty, error)
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*empty.Empty, error)
+	AcceptInvitation(context.Context, *AcceptInvitationRequest) (*User, error)
+	ListMyAccounts(context.Context, *ListMyAccountsRequest) (*ListMyAccountsResponse, error)
+	GetAccount(context.Context, *GetAccountRequest) (*Account, error)
+	CreateAccount(context.Context, *CreateAccountRequest) (*Account, error)
+	UpdateAccount(context.Context, *UpdateAccountRequest) (*Account, error)
+	GetPasswordPolicy(context.Context, *GetPasswordPolicyRequest) (*PasswordPolicy, error)
+	UpdatePasswordPolicy(context.Context, *UpdatePasswordPolicyRequest) (*PasswordPolicy, error)
+	MoveAccount(context.Context, *MoveAccountRequest) (*Account, error)
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*Organization, error)
+	GetOrganization(context.Context, *GetOrganizationRequest) (*Organization, error)
+	UpdateOrganization(context.Context, *UpdateOrganizationRequest) (*Organization, error)
+	AddOrganizationAdmin(context.Context, *AddOrganizationAdminRequest) (*Organization, error)
+	RemoveOrganizationAdmin(context.Context, *RemoveOrganizationAdminRequest) (*Organization, error)
+	GetOrganizationPasswordPolicy(context.Context, *GetOrganizationPasswordPolicyRequest) (*PasswordPolicy, error)
+	UpdateOrganizationPasswordPolicy(context.Context, *UpdateOrganizationPasswordPolicyRequest) (*PasswordPolicy, error)
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*empty.Empty, error)
+	UndeleteAccount(context.Context, *UndeleteAccountRequest) (*Account, error)
+	ExportAccount(context.Context, *ExportAccountRequest) (*AccountBundle, error)
+	ImportAccount(context.Context, *ImportAccountRequest) (*ImportAccountResponse, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	CreateUser(context.Context, *CreateUserRequest) (*User, error)
+	UpdateUser(context.Context, *UpdateUserRequest) (*User, error)
+	DeleteUser(context.Context, *DeleteUserRequest) (*empty.Empty, error)
+	UndeleteUser(context.Context, *UndeleteUserRequest) (*User, error)
+	LinkPrincipal(context.Context, *LinkPrincipalRequest) (*User, error)
+	UnlinkPrincipal(context.Context, *UnlinkPrincipalRequest) (*User, error)
+	CreatePrincipal(context.Context, *CreatePrincipalRequest) (*Principal, error)
+	EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error)
+	UnlockUser(context.Context, *UnlockUserRequest) (*empty.Empty, error)
+	ListIdentities(context.Context, *ListIdentitiesRequest) (*ListIdentitiesResponse, error)
+	GetIdentity(context.Context, *GetIdentityRequest) (*Identity, error)
+	CreateIdentity(context.Context, *CreateIdentityRequest) (*Identity, error)
+	UpdateIdentity(context.Context, *UpdateIdentityRequest) (*Identity, error)
+	DeleteIdentity(context.Context, *DeleteIdentityRequest) (*empty.Empty, error)
+	ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error)
+	CreateInvitation(context.Context, *CreateInvitationRequest) (*Invitation, error)
+	DeleteInvitation(context.Context, *DeleteInvitationRequest) (*empty.Empty, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*empty.Empty, error)
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+}
+
+// UnimplementedIAMServer can be embedded to have forward compatible implementations.
+type UnimplementedIAMServer struct {
+}
+
+func (*UnimplementedIAMServer) Authenticate(ctx context.Context, req *AuthenticateRequest) (*AuthenticateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
+}
+func (*UnimplementedIAMServer) RequestPasswordReset(ctx context.Context, req *RequestPasswordResetRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (*UnimplementedIAMServer) ConfirmPasswordReset(ctx context.Context, req *ConfirmPasswordResetRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmPasswordReset not implemented")
+}
+func (*UnimplementedIAMServer) VerifyEmail(ctx context.Context, req *VerifyEmailRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (*UnimplementedIAMServer) AcceptInvitation(ctx context.Context, req *AcceptInvitationRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvitation not implemented")
+}
+func (*UnimplementedIAMServer) ListMyAccounts(ctx context.Context, req *ListMyAccountsRequest) (*ListMyAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyAccounts not implemented")
+}
+func (*UnimplementedIAMServer) GetAccount(ctx context.Context, req *GetAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (*UnimplementedIAMServer) CreateAccount(ctx context.Context, req *CreateAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (*UnimplementedIAMServer) UpdateAccount(ctx context.Context, req *UpdateAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccount not implemented")
+}
+func (*UnimplementedIAMServer) GetPasswordPolicy(ctx context.Context, req *GetPasswordPolicyRequest) (*PasswordPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPasswordPolicy not implemented")
+}
+func (*UnimplementedIAMServer) UpdatePasswordPolicy(ctx context.Context, req *UpdatePasswordPolicyRequest) (*PasswordPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePasswordPolicy not implemented")
+}
+func (*UnimplementedIAMServer) MoveAccount(ctx context.Context, req *MoveAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveAccount not implemented")
+}
+func (*UnimplementedIAMServer) CreateOrganization(ctx context.Context, req *CreateOrganizationRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (*UnimplementedIAMServer) GetOrganization(ctx context.Context, req *GetOrganizationRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrganization not implemented")
+}
+func (*UnimplementedIAMServer) UpdateOrganization(ctx context.Context, req *UpdateOrganizationRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrganization not implemented")
+}
+func (*UnimplementedIAMServer) AddOrganizationAdmin(ctx context.Context, req *AddOrganizationAdminRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOrganizationAdmin not implemented")
+}
+func (*UnimplementedIAMServer) RemoveOrganizationAdmin(ctx context.Context, req *RemoveOrganizationAdminRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveOrganizationAdmin not implemented")
+}
+func (*UnimplementedIAMServer) GetOrganizationPasswordPolicy(ctx context.Context, req *GetOrganizationPasswordPolicyRequest) (*PasswordPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrganizationPasswordPolicy not implemented")
+}
+func (*UnimplementedIAMServer) UpdateOrganizationPasswordPolicy(ctx context.Context, req *UpdateOrganizationPasswordPolicyRequest) (*PasswordPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrganizationPasswordPolicy not implemented")
+}
+func (*UnimplementedIAMServer) DeleteAccount(ctx context.Context, req *DeleteAccountRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (*UnimplementedIAMServer) UndeleteAccount(ctx context.Context, req *UndeleteAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteAccount not implemented")
+}
+func (*UnimplementedIAMServer) ExportAccount(ctx context.Context, req *ExportAccountRequest) (*AccountBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAccount not implemented")
+}
+func (*UnimplementedIAMServer) ImportAccount(ctx context.Context, req *ImportAccountRequest) (*ImportAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAccount not implemented")
+}
+func (*UnimplementedIAMServer) ListUsers(ctx context.Context, req *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (*UnimplementedIAMServer) GetUser(ctx context.Context, req *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (*UnimplementedIAMServer) CreateUser(ctx context.Context, req *CreateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (*UnimplementedIAMServer) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (*UnimplementedIAMServer) DeleteUser(ctx context.Context, req *DeleteUserRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (*UnimplementedIAMServer) UndeleteUser(ctx context.Context, req *UndeleteUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteUser not implemented")
+}
+func (*UnimplementedIAMServer) LinkPrincipal(ctx context.Context, req *LinkPrincipalRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkPrincipal not implemented")
+}
+func (*UnimplementedIAMServer) UnlinkPrincipal(ctx context.Context, req *UnlinkPrincipalRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkPrincipal not implemented")
+}
+func (*UnimplementedIAMServer) CreatePrincipal(ctx context.Context, req *CreatePrincipalRequest) (*Principal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePrincipal not implemented")
+}
+func (*UnimplementedIAMServer) EraseUser(ctx context.Context, req *EraseUserRequest) (*EraseUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (*UnimplementedIAMServer) UnlockUser(ctx context.Context, req *UnlockUserRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUser not implemented")
+}
+func (*UnimplementedIAMServer) ListIdentities(ctx context.Context, req *ListIdentitiesRequest) (*ListIdentitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIdentities not implemented")
+}
+func (*UnimplementedIAMServer) GetIdentity(ctx context.Context, req *GetIdentityRequest) (*Identity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdentity not implemented")
+}
+func (*UnimplementedIAMServer) CreateIdentity(ctx context.Context, req *CreateIdentityRequest) (*Identity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIdentity not implemented")
+}
+func (*UnimplementedIAMServer) UpdateIdentity(ctx context.Context, req *UpdateIdentityRequest) (*Identity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateIdentity not implemented")
+}
+func (*UnimplementedIAMServer) DeleteIdentity(ctx context.Context, req *DeleteIdentityRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteIdentity not implemented")
+}
+func (*UnimplementedIAMServer) ListInvitations(ctx context.Context, req *ListInvitationsRequest) (*ListInvitationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvitations not implemented")
+}
+func (*UnimplementedIAMServer) CreateInvitation(ctx context.Context, req *CreateInvitationRequest) (*Invitation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvitation not implemented")
+}
+func (*UnimplementedIAMServer) DeleteInvitation(ctx context.Context, req *DeleteInvitationRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteInvitation not implemented")
+}
+func (*UnimplementedIAMServer) ListAuditEvents(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (*UnimplementedIAMServer) ListWebhooks(ctx context.Context, req *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (*UnimplementedIAMServer) CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (*UnimplementedIAMServer) DeleteWebhook(ctx context.Context, req *DeleteWebhookRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (*UnimplementedIAMServer) ListDeadLetters(ctx context.Context, req *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+
+func RegisterIAMServer(s *grpc.Server, srv IAMServer) {
+	s.RegisterService(&_IAM_serviceDesc, srv)
+}
+
+func _IAM_Authenticate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).Authenticate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/Authenticate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).Authenticate(ctx, req.(*AuthenticateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/RequestPasswordReset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).RequestPasswordReset(ctx, req.(*RequestPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_ConfirmPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).ConfirmPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/ConfirmPasswordReset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).ConfirmPasswordReset(ctx, req.(*ConfirmPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/VerifyEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_AcceptInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).AcceptInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/AcceptInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).AcceptInvitation(ctx, req.(*AcceptInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_ListMyAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).ListMyAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/ListMyAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).ListMyAccounts(ctx, req.(*ListMyAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/GetAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).GetAccount(ctx, req.(*GetAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/CreateAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_UpdateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).UpdateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/UpdateAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).UpdateAccount(ctx, req.(*UpdateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_GetPasswordPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPasswordPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).GetPasswordPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/GetPasswordPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).GetPasswordPolicy(ctx, req.(*GetPasswordPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error) {
-	out := new(Account)
-	err := c.cc.Invoke(ctx, "/iam.IAM/CreateAccount", in, out, opts...)
-	if err != nil {
+func _IAM_UpdatePasswordPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePasswordPolicyRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).UpdatePasswordPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/UpdatePasswordPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).UpdatePasswordPolicy(ctx, req.(*UpdatePasswordPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*Account, error) {
-	out := new(Account)
-	err := c.cc.Invoke(ctx, "/iam.IAM/UpdateAccount", in, out, opts...)
-	if err != nil {
+func _IAM_MoveAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveAccountRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).MoveAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/MoveAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).MoveAccount(ctx, req.(*MoveAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iam.IAM/DeleteAccount", in, out, opts...)
-	if err != nil {
+func _IAM_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).CreateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/CreateOrganization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
-	out := new(ListUsersResponse)
-	err := c.cc.Invoke(ctx, "/iam.IAM/ListUsers", in, out, opts...)
-	if err != nil {
+func _IAM_GetOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).GetOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/GetOrganization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).GetOrganization(ctx, req.(*GetOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
-	out := new(User)
-	err := c.cc.Invoke(ctx, "/iam.IAM/GetUser", in, out, opts...)
-	if err != nil {
+func _IAM_UpdateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrganizationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).UpdateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/UpdateOrganization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).UpdateOrganization(ctx, req.(*UpdateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error) {
-	out := new(User)
-	err := c.cc.Invoke(ctx, "/iam.IAM/CreateUser", in, out, opts...)
-	if err != nil {
+func _IAM_AddOrganizationAdmin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrganizationAdminRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).AddOrganizationAdmin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/AddOrganizationAdmin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).AddOrganizationAdmin(ctx, req.(*AddOrganizationAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error) {
-	out := new(User)
-	err := c.cc.Invoke(ctx, "/iam.IAM/UpdateUser", in, out, opts...)
-	if err != nil {
+func _IAM_RemoveOrganizationAdmin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveOrganizationAdminRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).RemoveOrganizationAdmin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/RemoveOrganizationAdmin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).RemoveOrganizationAdmin(ctx, req.(*RemoveOrganizationAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iam.IAM/DeleteUser", in, out, opts...)
-	if err != nil {
+func _IAM_GetOrganizationPasswordPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizationPasswordPolicyRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).GetOrganizationPasswordPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/GetOrganizationPasswordPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).GetOrganizationPasswordPolicy(ctx, req.(*GetOrganizationPasswordPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesResponse, error) {
-	out := new(ListIdentitiesResponse)
-	err := c.cc.Invoke(ctx, "/iam.IAM/ListIdentities", in, out, opts...)
-	if err != nil {
+func _IAM_UpdateOrganizationPasswordPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrganizationPasswordPolicyRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).UpdateOrganizationPasswordPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/UpdateOrganizationPasswordPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).UpdateOrganizationPasswordPolicy(ctx, req.(*UpdateOrganizationPasswordPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) GetIdentity(ctx context.Context, in *GetIdentityRequest, opts ...grpc.CallOption) (*Identity, error) {
-	out := new(Identity)
-	err := c.cc.Invoke(ctx, "/iam.IAM/GetIdentity", in, out, opts...)
-	if err != nil {
+func _IAM_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/DeleteAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) CreateIdentity(ctx context.Context, in *CreateIdentityRequest, opts ...grpc.CallOption) (*Identity, error) {
-	out := new(Identity)
-	err := c.cc.Invoke(ctx, "/iam.IAM/CreateIdentity", in, out, opts...)
-	if err != nil {
+func _IAM_UndeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteAccountRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).UndeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/UndeleteAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).UndeleteAccount(ctx, req.(*UndeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) UpdateIdentity(ctx context.Context, in *UpdateIdentityRequest, opts ...grpc.CallOption) (*Identity, error) {
-	out := new(Identity)
-	err := c.cc.Invoke(ctx, "/iam.IAM/UpdateIdentity", in, out, opts...)
-	if err != nil {
+func _IAM_ExportAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportAccountRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(IAMServer).ExportAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/ExportAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).ExportAccount(ctx, req.(*ExportAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *iAMClient) DeleteIdentity(ctx context.Context, in *DeleteIdentityRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iam.IAM/DeleteIdentity", in, out, opts...)
-	if err != nil {
+func _IAM_ImportAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAccountRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// IAMServer is the server API for IAM service.
-type IAMServer interface {
-	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
-	GetAccount(context.Context, *GetAccountRequest) (*Account, error)
-	CreateAccount(context.Context, *CreateAccountRequest) (*Account, error)
-	UpdateAccount(context.Context, *UpdateAccountRequest) (*Account, error)
-	DeleteAccount(context.Context, *DeleteAccountRequest) (*empty.Empty, error)
-	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
-	GetUser(context.Context, *GetUserRequest) (*User, error)
-	CreateUser(context.Context, *CreateUserRequest) (*User, error)
-	UpdateUser(context.Context, *UpdateUserRequest) (*User, error)
-	DeleteUser(context.Context, *DeleteUserRequest) (*empty.Empty, error)
-	ListIdentities(context.Context, *ListIdentitiesRequest) (*ListIdentitiesResponse, error)
-	GetIdentity(context.Context, *GetIdentityRequest) (*Identity, error)
-	CreateIdentity(context.Context, *CreateIdentityRequest) (*Identity, error)
-	UpdateIdentity(context.Context, *UpdateIdentityRequest) (*Identity, error)
-	DeleteIdentity(context.Context, *DeleteIdentityRequest) (*empty.Empty, error)
-}
-
-// UnimplementedIAMServer can be embedded to have forward compatible implementations.
-type UnimplementedIAMServer struct {
-}
-
-func (*UnimplementedIAMServer) Authenticate(ctx context.Context, req *AuthenticateRequest) (*AuthenticateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
-}
-func (*UnimplementedIAMServer) GetAccount(ctx context.Context, req *GetAccountRequest) (*Account, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
-}
-func (*UnimplementedIAMServer) CreateAccount(ctx context.Context, req *CreateAccountRequest) (*Account, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
-}
-func (*UnimplementedIAMServer) UpdateAccount(ctx context.Context, req *UpdateAccountRequest) (*Account, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccount not implemented")
-}
-func (*UnimplementedIAMServer) DeleteAccount(ctx context.Context, req *DeleteAccountRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
-}
-func (*UnimplementedIAMServer) ListUsers(ctx context.Context, req *ListUsersRequest) (*ListUsersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
-}
-func (*UnimplementedIAMServer) GetUser(ctx context.Context, req *GetUserRequest) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
-}
-func (*UnimplementedIAMServer) CreateUser(ctx context.Context, req *CreateUserRequest) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
-}
-func (*UnimplementedIAMServer) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
-}
-func (*UnimplementedIAMServer) DeleteUser(ctx context.Context, req *DeleteUserRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
-}
-func (*UnimplementedIAMServer) ListIdentities(ctx context.Context, req *ListIdentitiesRequest) (*ListIdentitiesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListIdentities not implemented")
-}
-func (*UnimplementedIAMServer) GetIdentity(ctx context.Context, req *GetIdentityRequest) (*Identity, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetIdentity not implemented")
-}
-func (*UnimplementedIAMServer) CreateIdentity(ctx context.Context, req *CreateIdentityRequest) (*Identity, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateIdentity not implemented")
-}
-func (*UnimplementedIAMServer) UpdateIdentity(ctx context.Context, req *UpdateIdentityRequest) (*Identity, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateIdentity not implemented")
-}
-func (*UnimplementedIAMServer) DeleteIdentity(ctx context.Context, req *DeleteIdentityRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteIdentity not implemented")
+	if interceptor == nil {
+		return srv.(IAMServer).ImportAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/ImportAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).ImportAccount(ctx, req.(*ImportAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterIAMServer(s *grpc.Server, srv IAMServer) {
-	s.RegisterService(&_IAM_serviceDesc, srv)
+func _IAM_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/ListUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_Authenticate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthenticateRequest)
+func _IAM_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).Authenticate(ctx, in)
+		return srv.(IAMServer).GetUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/Authenticate",
+		FullMethod: "/iam.IAM/GetUser",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).Authenticate(ctx, req.(*AuthenticateRequest))
+		return srv.(IAMServer).GetUser(ctx, req.(*GetUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAccountRequest)
+func _IAM_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).GetAccount(ctx, in)
+		return srv.(IAMServer).CreateUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/GetAccount",
+		FullMethod: "/iam.IAM/CreateUser",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).GetAccount(ctx, req.(*GetAccountRequest))
+		return srv.(IAMServer).CreateUser(ctx, req.(*CreateUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateAccountRequest)
+func _IAM_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).CreateAccount(ctx, in)
+		return srv.(IAMServer).UpdateUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/CreateAccount",
+		FullMethod: "/iam.IAM/UpdateUser",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+		return srv.(IAMServer).UpdateUser(ctx, req.(*UpdateUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_UpdateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateAccountRequest)
+func _IAM_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).UpdateAccount(ctx, in)
+		return srv.(IAMServer).DeleteUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/UpdateAccount",
+		FullMethod: "/iam.IAM/DeleteUser",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).UpdateAccount(ctx, req.(*UpdateAccountRequest))
+		return srv.(IAMServer).DeleteUser(ctx, req.(*DeleteUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteAccountRequest)
+func _IAM_UndeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).DeleteAccount(ctx, in)
+		return srv.(IAMServer).UndeleteUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/DeleteAccount",
+		FullMethod: "/iam.IAM/UndeleteUser",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+		return srv.(IAMServer).UndeleteUser(ctx, req.(*UndeleteUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListUsersRequest)
+func _IAM_LinkPrincipal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkPrincipalRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).ListUsers(ctx, in)
+		return srv.(IAMServer).LinkPrincipal(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/ListUsers",
+		FullMethod: "/iam.IAM/LinkPrincipal",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).ListUsers(ctx, req.(*ListUsersRequest))
+		return srv.(IAMServer).LinkPrincipal(ctx, req.(*LinkPrincipalRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserRequest)
+func _IAM_UnlinkPrincipal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkPrincipalRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).GetUser(ctx, in)
+		return srv.(IAMServer).UnlinkPrincipal(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/GetUser",
+		FullMethod: "/iam.IAM/UnlinkPrincipal",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).GetUser(ctx, req.(*GetUserRequest))
+		return srv.(IAMServer).UnlinkPrincipal(ctx, req.(*UnlinkPrincipalRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateUserRequest)
+func _IAM_CreatePrincipal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePrincipalRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).CreateUser(ctx, in)
+		return srv.(IAMServer).CreatePrincipal(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/CreateUser",
+		FullMethod: "/iam.IAM/CreatePrincipal",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).CreateUser(ctx, req.(*CreateUserRequest))
+		return srv.(IAMServer).CreatePrincipal(ctx, req.(*CreatePrincipalRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
+func _IAM_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).UpdateUser(ctx, in)
+		return srv.(IAMServer).EraseUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/UpdateUser",
+		FullMethod: "/iam.IAM/EraseUser",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+		return srv.(IAMServer).EraseUser(ctx, req.(*EraseUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IAM_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteUserRequest)
+func _IAM_UnlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IAMServer).DeleteUser(ctx, in)
+		return srv.(IAMServer).UnlockUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/iam.IAM/DeleteUser",
+		FullMethod: "/iam.IAM/UnlockUser",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAMServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+		return srv.(IAMServer).UnlockUser(ctx, req.(*UnlockUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1742,6 +5748,150 @@ func _IAM_DeleteIdentity_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IAM_ListInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).ListInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/ListInvitations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).ListInvitations(ctx, req.(*ListInvitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_CreateInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).CreateInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/CreateInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).CreateInvitation(ctx, req.(*CreateInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_DeleteInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).DeleteInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/DeleteInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).DeleteInvitation(ctx, req.(*DeleteInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IAM_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAMServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iam.IAM/ListDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAMServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _IAM_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "iam.IAM",
 	HandlerType: (*IAMServer)(nil),
@@ -1750,6 +5900,26 @@ var _IAM_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Authenticate",
 			Handler:    _IAM_Authenticate_Handler,
 		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _IAM_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ConfirmPasswordReset",
+			Handler:    _IAM_ConfirmPasswordReset_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _IAM_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "AcceptInvitation",
+			Handler:    _IAM_AcceptInvitation_Handler,
+		},
+		{
+			MethodName: "ListMyAccounts",
+			Handler:    _IAM_ListMyAccounts_Handler,
+		},
 		{
 			MethodName: "GetAccount",
 			Handler:    _IAM_GetAccount_Handler,
@@ -1762,10 +5932,62 @@ var _IAM_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAccount",
 			Handler:    _IAM_UpdateAccount_Handler,
 		},
+		{
+			MethodName: "GetPasswordPolicy",
+			Handler:    _IAM_GetPasswordPolicy_Handler,
+		},
+		{
+			MethodName: "UpdatePasswordPolicy",
+			Handler:    _IAM_UpdatePasswordPolicy_Handler,
+		},
+		{
+			MethodName: "MoveAccount",
+			Handler:    _IAM_MoveAccount_Handler,
+		},
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _IAM_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "GetOrganization",
+			Handler:    _IAM_GetOrganization_Handler,
+		},
+		{
+			MethodName: "UpdateOrganization",
+			Handler:    _IAM_UpdateOrganization_Handler,
+		},
+		{
+			MethodName: "AddOrganizationAdmin",
+			Handler:    _IAM_AddOrganizationAdmin_Handler,
+		},
+		{
+			MethodName: "RemoveOrganizationAdmin",
+			Handler:    _IAM_RemoveOrganizationAdmin_Handler,
+		},
+		{
+			MethodName: "GetOrganizationPasswordPolicy",
+			Handler:    _IAM_GetOrganizationPasswordPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateOrganizationPasswordPolicy",
+			Handler:    _IAM_UpdateOrganizationPasswordPolicy_Handler,
+		},
 		{
 			MethodName: "DeleteAccount",
 			Handler:    _IAM_DeleteAccount_Handler,
 		},
+		{
+			MethodName: "UndeleteAccount",
+			Handler:    _IAM_UndeleteAccount_Handler,
+		},
+		{
+			MethodName: "ExportAccount",
+			Handler:    _IAM_ExportAccount_Handler,
+		},
+		{
+			MethodName: "ImportAccount",
+			Handler:    _IAM_ImportAccount_Handler,
+		},
 		{
 			MethodName: "ListUsers",
 			Handler:    _IAM_ListUsers_Handler,
@@ -1786,6 +6008,30 @@ var _IAM_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _IAM_DeleteUser_Handler,
 		},
+		{
+			MethodName: "UndeleteUser",
+			Handler:    _IAM_UndeleteUser_Handler,
+		},
+		{
+			MethodName: "LinkPrincipal",
+			Handler:    _IAM_LinkPrincipal_Handler,
+		},
+		{
+			MethodName: "UnlinkPrincipal",
+			Handler:    _IAM_UnlinkPrincipal_Handler,
+		},
+		{
+			MethodName: "CreatePrincipal",
+			Handler:    _IAM_CreatePrincipal_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _IAM_EraseUser_Handler,
+		},
+		{
+			MethodName: "UnlockUser",
+			Handler:    _IAM_UnlockUser_Handler,
+		},
 		{
 			MethodName: "ListIdentities",
 			Handler:    _IAM_ListIdentities_Handler,
@@ -1806,6 +6052,38 @@ var _IAM_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteIdentity",
 			Handler:    _IAM_DeleteIdentity_Handler,
 		},
+		{
+			MethodName: "ListInvitations",
+			Handler:    _IAM_ListInvitations_Handler,
+		},
+		{
+			MethodName: "CreateInvitation",
+			Handler:    _IAM_CreateInvitation_Handler,
+		},
+		{
+			MethodName: "DeleteInvitation",
+			Handler:    _IAM_DeleteInvitation_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _IAM_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _IAM_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _IAM_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _IAM_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _IAM_ListDeadLetters_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "iam.proto",
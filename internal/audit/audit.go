@@ -0,0 +1,98 @@
+// Package audit records IAM mutations and authentication attempts in an
+// append-only log.
+package audit
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/ratelimit"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type Logger struct {
+	Store store.Store
+}
+
+type Entry struct {
+	Actor    string
+	Account  string
+	Resource string
+	Method   string
+}
+
+// Log records entry with an outcome derived from err. Failures to write the
+// event are logged rather than returned, so that they never mask the result
+// of the operation being audited.
+func (l *Logger) Log(ctx context.Context, entry Entry, err error) {
+	outcome := models.AuditOutcomeSuccess
+	if err != nil {
+		outcome = models.AuditOutcomeFailure
+	}
+
+	if _, err := l.Store.CreateAuditEvent(ctx, store.CreateAuditEventRequest{
+		AuditEvent: models.AuditEvent{
+			Actor:    entry.Actor,
+			Account:  entry.Account,
+			Resource: entry.Resource,
+			Method:   entry.Method,
+			SourceIP: SourceIP(ctx),
+			Outcome:  outcome,
+		},
+	}); err != nil {
+		log.Printf("error recording audit event for %s: %v", entry.Method, err)
+	}
+}
+
+// SourceIP returns the address of the client that made the request in ctx,
+// trusting x-forwarded-for only as far as rate limiting does.
+func SourceIP(ctx context.Context) string {
+	return ratelimit.ClientIP(ctx)
+}
+
+// ParseFilter parses a ListAuditEvents filter of the form
+// `method="CreateUser" AND outcome="failure"`.
+func ParseFilter(filter string) (store.AuditEventFilter, error) {
+	var out store.AuditEventFilter
+	if strings.TrimSpace(filter) == "" {
+		return out, nil
+	}
+
+	for _, term := range strings.Split(filter, " AND ") {
+		parts := strings.SplitN(term, "=", 2)
+		if len(parts) != 2 {
+			return store.AuditEventFilter{}, errors.Errorf("invalid filter term: %q", term)
+		}
+
+		field := strings.TrimSpace(parts[0])
+		value := strings.Trim(strings.TrimSpace(parts[1]), `"`)
+
+		switch field {
+		case "actor":
+			out.Actor = value
+		case "resource":
+			out.Resource = value
+		case "method":
+			out.Method = value
+		case "source_ip":
+			out.SourceIP = value
+		case "outcome":
+			switch value {
+			case "success":
+				out.Outcome = models.AuditOutcomeSuccess
+			case "failure":
+				out.Outcome = models.AuditOutcomeFailure
+			default:
+				return store.AuditEventFilter{}, errors.Errorf("invalid outcome: %q", value)
+			}
+		default:
+			return store.AuditEventFilter{}, errors.Errorf("unsupported filter field: %q", field)
+		}
+	}
+
+	return out, nil
+}
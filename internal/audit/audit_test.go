@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+func TestSourceIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{name: "direct", peer: "203.0.113.7:5000", want: "203.0.113.7"},
+		{name: "gateway", peer: "127.0.0.1:5000", forwarded: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "gateway last hop", peer: "127.0.0.1:5000", forwarded: []string{"10.0.0.1, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "forged header from remote peer", peer: "203.0.113.7:5000", forwarded: []string{"10.0.0.1"}, want: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := net.ResolveTCPAddr("tcp", tt.peer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+			if tt.forwarded != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{"x-forwarded-for": tt.forwarded})
+			}
+
+			if got := SourceIP(ctx); got != tt.want {
+				t.Errorf("SourceIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter  string
+		want    store.AuditEventFilter
+		wantErr bool
+	}{
+		{filter: "", want: store.AuditEventFilter{}},
+		{filter: `method="CreateUser"`, want: store.AuditEventFilter{Method: "CreateUser"}},
+		{
+			filter: `actor="users/root" AND outcome="failure"`,
+			want:   store.AuditEventFilter{Actor: "users/root", Outcome: models.AuditOutcomeFailure},
+		},
+		{filter: `source_ip = "10.0.0.1"`, want: store.AuditEventFilter{SourceIP: "10.0.0.1"}},
+		{filter: `outcome="maybe"`, wantErr: true},
+		{filter: `color="red"`, wantErr: true},
+		{filter: `method`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got, err := ParseFilter(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFilter(%q) error = %v, wantErr %v", tt.filter, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseFilter(%q) = %+v, want %+v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
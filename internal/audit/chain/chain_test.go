@@ -0,0 +1,118 @@
+package chain
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func testEvent() models.AuditEvent {
+	return models.AuditEvent{
+		Name:       "auditEvents/1",
+		CreateTime: time.Date(2019, 4, 1, 12, 0, 0, 0, time.UTC),
+		Actor:      "users/alice",
+		Account:    "accounts/a",
+		Resource:   "users/bob",
+		Method:     "DeleteUser",
+		SourceIP:   "198.51.100.1",
+		Outcome:    models.AuditOutcomeSuccess,
+		Sequence:   1,
+	}
+}
+
+func TestHashCoversEveryField(t *testing.T) {
+	base := Hash(nil, testEvent())
+
+	tests := []struct {
+		name   string
+		prev   []byte
+		change func(*models.AuditEvent)
+	}{
+		{name: "prev hash", prev: []byte("x"), change: func(*models.AuditEvent) {}},
+		{name: "name", change: func(e *models.AuditEvent) { e.Name = "auditEvents/2" }},
+		{name: "create time", change: func(e *models.AuditEvent) { e.CreateTime = e.CreateTime.Add(time.Nanosecond) }},
+		{name: "actor", change: func(e *models.AuditEvent) { e.Actor = "users/mallory" }},
+		{name: "account", change: func(e *models.AuditEvent) { e.Account = "accounts/b" }},
+		{name: "resource", change: func(e *models.AuditEvent) { e.Resource = "users/carol" }},
+		{name: "method", change: func(e *models.AuditEvent) { e.Method = "UndeleteUser" }},
+		{name: "source ip", change: func(e *models.AuditEvent) { e.SourceIP = "10.0.0.1" }},
+		{name: "outcome", change: func(e *models.AuditEvent) { e.Outcome = models.AuditOutcomeFailure }},
+		{name: "sequence", change: func(e *models.AuditEvent) { e.Sequence = 2 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testEvent()
+			tt.change(&e)
+
+			if bytes.Equal(Hash(tt.prev, e), base) {
+				t.Errorf("changing the %s didn't change the hash", tt.name)
+			}
+		})
+	}
+}
+
+func TestHashSurvivesRedaction(t *testing.T) {
+	original := testEvent()
+	want := Hash(nil, original)
+
+	redacted := original
+	redacted.Actor = "users/erased-1"
+	redacted.ActorDigest = FieldDigest(original.Actor)
+	redacted.Resource = "users/erased-2"
+	redacted.ResourceDigest = FieldDigest(original.Resource)
+
+	if !bytes.Equal(Hash(nil, redacted), want) {
+		t.Error("redacting actor and resource changed the hash")
+	}
+
+	redacted.ActorDigest = nil
+	if bytes.Equal(Hash(nil, redacted), want) {
+		t.Error("redacted actor without its digest kept the hash")
+	}
+}
+
+func TestCheckpointSignature(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint := models.AuditCheckpoint{
+		Account:  "accounts/a",
+		Sequence: 10,
+		Hash:     Hash(nil, testEvent()),
+	}
+
+	if checkpoint.Signature, err = SignCheckpoint(key, checkpoint); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyCheckpoint(&key.PublicKey, checkpoint); err != nil {
+		t.Errorf("VerifyCheckpoint: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(*models.AuditCheckpoint)
+	}{
+		{name: "account", change: func(c *models.AuditCheckpoint) { c.Account = "accounts/b" }},
+		{name: "sequence", change: func(c *models.AuditCheckpoint) { c.Sequence = 11 }},
+		{name: "hash", change: func(c *models.AuditCheckpoint) { c.Hash = FieldDigest("other") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := checkpoint
+			tt.change(&tampered)
+
+			if err := VerifyCheckpoint(&key.PublicKey, tampered); err == nil {
+				t.Errorf("VerifyCheckpoint accepted a checkpoint with a changed %s", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"crypto/rsa"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/json-multiplex/iam-service/internal/audit/chain"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type Checkpointer struct {
+	Store   store.Store
+	SignKey *rsa.PrivateKey
+}
+
+// Checkpoint signs the head of every audit chain that has grown since its
+// last checkpoint.
+func (c *Checkpointer) Checkpoint(ctx context.Context) error {
+	heads, err := c.Store.ListAuditChainHeads(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error listing audit chain heads")
+	}
+
+	for _, head := range heads {
+		checkpoints, err := c.Store.ListAuditCheckpoints(ctx, store.ListAuditCheckpointsRequest{
+			AccountID: accountID(head.Account),
+		})
+
+		if err != nil {
+			return errors.Wrap(err, "error listing audit checkpoints")
+		}
+
+		if len(checkpoints) > 0 && checkpoints[len(checkpoints)-1].Sequence >= head.Sequence {
+			continue
+		}
+
+		checkpoint := models.AuditCheckpoint{
+			Account:  head.Account,
+			Sequence: head.Sequence,
+			Hash:     head.Hash,
+		}
+
+		checkpoint.Signature, err = chain.SignCheckpoint(c.SignKey, checkpoint)
+		if err != nil {
+			return errors.Wrap(err, "error signing audit checkpoint")
+		}
+
+		if _, err := c.Store.CreateAuditCheckpoint(ctx, store.CreateAuditCheckpointRequest{
+			AuditCheckpoint: checkpoint,
+		}); err != nil {
+			return errors.Wrap(err, "error creating audit checkpoint")
+		}
+	}
+
+	return nil
+}
+
+// Run calls Checkpoint every interval until ctx is done.
+func (c *Checkpointer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Checkpoint(ctx); err != nil {
+				log.Printf("error checkpointing audit log: %v", err)
+			}
+		}
+	}
+}
+
+func accountID(account string) string {
+	return strings.TrimPrefix(account, "accounts/")
+}
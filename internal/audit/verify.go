@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/json-multiplex/iam-service/internal/audit/chain"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type Verifier struct {
+	Store     store.Store
+	VerifyKey *rsa.PublicKey
+}
+
+type Break struct {
+	Account  string
+	Sequence int64
+	Reason   string
+}
+
+func (b Break) String() string {
+	account := b.Account
+	if account == "" {
+		account = "(no account)"
+	}
+
+	return fmt.Sprintf("%s: sequence %d: %s", account, b.Sequence, b.Reason)
+}
+
+// Verify walks every audit chain, recomputing each event's hash and checking
+// every signed checkpoint against the chain. It returns all of the breaks it
+// finds.
+func (v *Verifier) Verify(ctx context.Context) ([]Break, error) {
+	heads, err := v.Store.ListAuditChainHeads(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing audit chain heads")
+	}
+
+	var breaks []Break
+	for _, head := range heads {
+		chainBreaks, err := v.verifyChain(ctx, head.Account)
+		if err != nil {
+			return nil, err
+		}
+
+		breaks = append(breaks, chainBreaks...)
+	}
+
+	return breaks, nil
+}
+
+func (v *Verifier) verifyChain(ctx context.Context, account string) ([]Break, error) {
+	checkpoints, err := v.Store.ListAuditCheckpoints(ctx, store.ListAuditCheckpointsRequest{
+		AccountID: accountID(account),
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing audit checkpoints")
+	}
+
+	var breaks []Break
+	addBreak := func(sequence int64, format string, args ...interface{}) {
+		breaks = append(breaks, Break{
+			Account:  account,
+			Sequence: sequence,
+			Reason:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	checkpointHashes := map[int64][]byte{}
+	for _, checkpoint := range checkpoints {
+		if err := chain.VerifyCheckpoint(v.VerifyKey, checkpoint); err != nil {
+			addBreak(checkpoint.Sequence, "invalid checkpoint signature")
+			continue
+		}
+
+		checkpointHashes[checkpoint.Sequence] = checkpoint.Hash
+	}
+
+	var sequence int64
+	var prevHash []byte
+	for {
+		events, err := v.Store.ListAuditChain(ctx, store.ListAuditChainRequest{
+			AccountID:     accountID(account),
+			AfterSequence: sequence,
+		})
+
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing audit chain")
+		}
+
+		if len(events) == 0 {
+			break
+		}
+
+		for _, event := range events {
+			if event.Sequence != sequence+1 {
+				addBreak(event.Sequence, "missing events after sequence %d", sequence)
+			}
+
+			if !bytes.Equal(event.PrevHash, prevHash) {
+				addBreak(event.Sequence, "previous hash does not match preceding event")
+			}
+
+			if !bytes.Equal(chain.Hash(event.PrevHash, event), event.Hash) {
+				addBreak(event.Sequence, "event hash does not match event contents")
+			}
+
+			if hash, ok := checkpointHashes[event.Sequence]; ok {
+				if !bytes.Equal(hash, event.Hash) {
+					addBreak(event.Sequence, "event hash does not match signed checkpoint")
+				}
+
+				delete(checkpointHashes, event.Sequence)
+			}
+
+			sequence = event.Sequence
+			prevHash = event.Hash
+		}
+	}
+
+	for checkpointSequence := range checkpointHashes {
+		addBreak(checkpointSequence, "checkpointed event is missing")
+	}
+
+	return breaks, nil
+}
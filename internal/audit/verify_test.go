@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	signKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := store.NewMemoryStore(passhash.Bcrypt{Cost: 4})
+	account, err := st.CreateAccount(ctx, store.CreateAccountRequest{
+		Account:      models.Account{DisplayName: "Test"},
+		Root:         models.User{Name: "users/root", IsRoot: true},
+		RootPassword: "password",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := Logger{Store: st}
+	for i, err := range []error{nil, errors.New("denied"), nil} {
+		logger.Log(ctx, Entry{
+			Actor:    "users/root",
+			Account:  account.Name,
+			Resource: "users/root",
+			Method:   "UpdateUser",
+		}, err)
+
+		if i == 1 {
+			checkpointer := Checkpointer{Store: st, SignKey: signKey}
+			if err := checkpointer.Checkpoint(ctx); err != nil {
+				t.Fatalf("Checkpoint: %v", err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name       string
+		key        *rsa.PublicKey
+		wantBreaks int
+	}{
+		{name: "intact", key: &signKey.PublicKey, wantBreaks: 0},
+		{name: "wrong key", key: &otherKey.PublicKey, wantBreaks: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := Verifier{Store: st, VerifyKey: tt.key}
+			breaks, err := verifier.Verify(ctx)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+
+			if len(breaks) != tt.wantBreaks {
+				t.Errorf("Verify: got breaks %v, want %d", breaks, tt.wantBreaks)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Values returns a resource's value for a schema field, by its API name: a
+// string, bool, int64, time.Time or map[string]string, or nil if the value is
+// null.
+type Values func(field string) interface{}
+
+// Matcher compiles expr to a function reporting whether a resource matches
+// it. It evaluates expr the way Postgres evaluates the condition from SQL,
+// including treating comparisons with a missing map key as unknown, except
+// that strings are compared bytewise, as in the C collation.
+func Matcher(expr Expr, schema Schema) (func(Values) bool, error) {
+	m, err := compileMatcher(expr, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(v Values) bool {
+		return m(v) == yes
+	}, nil
+}
+
+// Comparer compiles orders to a function that compares two resources the way
+// the ORDER BY clause from OrderBySQL orders them. It returns 0 for resources
+// that are equal in every ordered field.
+func Comparer(orders []Order, schema Schema) (func(a, b Values) int, error) {
+	for _, order := range orders {
+		field, _, err := schema.lookup(order.Field)
+		if err != nil {
+			return nil, err
+		}
+
+		if field.Type == Map {
+			return nil, errors.Errorf("cannot order by %q", order.Field)
+		}
+	}
+
+	return func(a, b Values) int {
+		for _, order := range orders {
+			c := compareNullable(a(order.Field), b(order.Field))
+			if order.Desc {
+				c = -c
+			}
+
+			if c != 0 {
+				return c
+			}
+		}
+
+		return 0
+	}, nil
+}
+
+// truth is a value of SQL's three-valued logic.
+type truth int
+
+const (
+	unknown truth = iota
+	no
+	yes
+)
+
+func truthOf(b bool) truth {
+	if b {
+		return yes
+	}
+
+	return no
+}
+
+type matcher func(Values) truth
+
+func compileMatcher(expr Expr, schema Schema) (matcher, error) {
+	switch expr := expr.(type) {
+	case And:
+		l, r, err := compileBinary(expr.Left, expr.Right, schema)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v Values) truth {
+			a, b := l(v), r(v)
+			if a == no || b == no {
+				return no
+			}
+
+			if a == yes && b == yes {
+				return yes
+			}
+
+			return unknown
+		}, nil
+	case Or:
+		l, r, err := compileBinary(expr.Left, expr.Right, schema)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v Values) truth {
+			a, b := l(v), r(v)
+			if a == yes || b == yes {
+				return yes
+			}
+
+			if a == no && b == no {
+				return no
+			}
+
+			return unknown
+		}, nil
+	case Not:
+		m, err := compileMatcher(expr.Expr, schema)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v Values) truth {
+			switch m(v) {
+			case yes:
+				return no
+			case no:
+				return yes
+			}
+
+			return unknown
+		}, nil
+	case Restriction:
+		return compileRestriction(expr, schema)
+	default:
+		return nil, errors.Errorf("unsupported expression: %T", expr)
+	}
+}
+
+func compileBinary(left, right Expr, schema Schema) (matcher, matcher, error) {
+	l, err := compileMatcher(left, schema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := compileMatcher(right, schema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return l, r, nil
+}
+
+func compileRestriction(r Restriction, schema Schema) (matcher, error) {
+	field, key, err := schema.lookup(r.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	name := r.Field
+	if key != "" {
+		name = r.Field[:len(r.Field)-len(key)-1]
+	}
+
+	get := func(v Values) interface{} {
+		return v(name)
+	}
+
+	switch field.Type {
+	case String:
+		return stringMatcher(r, get)
+	case Bool:
+		value, err := strconv.ParseBool(r.Value)
+		if err != nil {
+			return nil, errors.Errorf("invalid boolean for %s: %q", r.Field, r.Value)
+		}
+
+		return comparisonMatcher(r, get, value, "=", "!=")
+	case Int:
+		value, err := strconv.ParseInt(r.Value, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid integer for %s: %q", r.Field, r.Value)
+		}
+
+		return comparisonMatcher(r, get, value, "=", "!=", "<", "<=", ">", ">=")
+	case Timestamp:
+		value, err := parseTimestamp(r.Value)
+		if err != nil {
+			return nil, errors.Errorf("invalid timestamp for %s: %q", r.Field, r.Value)
+		}
+
+		return comparisonMatcher(r, get, value, "=", "!=", "<", "<=", ">", ">=")
+	case Map:
+		if key == "" {
+			if r.Operator != ":" {
+				return nil, errors.Errorf("%s only supports the ':' operator", r.Field)
+			}
+
+			return func(v Values) truth {
+				m, _ := get(v).(map[string]string)
+				_, ok := m[r.Value]
+				return truthOf(ok)
+			}, nil
+		}
+
+		return stringMatcher(r, func(v Values) interface{} {
+			m, _ := get(v).(map[string]string)
+			if value, ok := m[key]; ok {
+				return value
+			}
+
+			return nil
+		})
+	default:
+		return nil, errors.Errorf("unsupported field: %q", r.Field)
+	}
+}
+
+func stringMatcher(r Restriction, get func(Values) interface{}) (matcher, error) {
+	switch r.Operator {
+	case ":":
+		pattern := strings.ToLower(r.Value)
+		return func(v Values) truth {
+			s, ok := get(v).(string)
+			if !ok {
+				return unknown
+			}
+
+			return truthOf(wildcardMatch(strings.ToLower(s), pattern))
+		}, nil
+	case "=", "!=":
+		if strings.Contains(r.Value, "*") {
+			return func(v Values) truth {
+				s, ok := get(v).(string)
+				if !ok {
+					return unknown
+				}
+
+				return truthOf(wildcardMatch(s, r.Value) == (r.Operator == "="))
+			}, nil
+		}
+	}
+
+	return comparisonMatcher(r, get, r.Value, "=", "!=", "<", "<=", ">", ">=")
+}
+
+func comparisonMatcher(r Restriction, get func(Values) interface{}, value interface{}, operators ...string) (matcher, error) {
+	for _, op := range operators {
+		if op != r.Operator {
+			continue
+		}
+
+		return func(v Values) truth {
+			actual := get(v)
+			if actual == nil {
+				return unknown
+			}
+
+			c := compareValues(actual, value)
+			switch op {
+			case "=":
+				return truthOf(c == 0)
+			case "!=":
+				return truthOf(c != 0)
+			case "<":
+				return truthOf(c < 0)
+			case "<=":
+				return truthOf(c <= 0)
+			case ">":
+				return truthOf(c > 0)
+			}
+
+			return truthOf(c >= 0)
+		}, nil
+	}
+
+	return nil, errors.Errorf("%s does not support the %q operator", r.Field, r.Operator)
+}
+
+// wildcardMatch reports whether s matches pattern, in which '*' matches any
+// run of characters.
+func wildcardMatch(s, pattern string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return s == pattern
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+
+		s = s[i+len(part):]
+	}
+
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
+// compareNullable compares values the way ORDER BY does, with nulls after
+// every other value.
+func compareNullable(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	return compareValues(a, b)
+}
+
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case bool:
+		if a == b.(bool) {
+			return 0
+		} else if a {
+			return 1
+		}
+
+		return -1
+	case int64:
+		switch b := b.(int64); {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+
+		return 0
+	case time.Time:
+		switch b := b.(time.Time); {
+		case a.Before(b):
+			return -1
+		case a.After(b):
+			return 1
+		}
+
+		return 0
+	}
+
+	return 0
+}
@@ -0,0 +1,209 @@
+package filter
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type testRow struct {
+	name        string
+	displayName interface{}
+	isRoot      bool
+	size        int64
+	createTime  time.Time
+	labels      map[string]string
+}
+
+var testRows = []testRow{
+	{"ann", "Ann Lee", true, 1, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), map[string]string{"team": "eng"}},
+	{"bob", "bob_50%", false, 10, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), map[string]string{"team": "ops", "tier": "gold"}},
+	{"cat", nil, false, 5, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), map[string]string{}},
+}
+
+func (r testRow) values(field string) interface{} {
+	switch field {
+	case "display_name":
+		return r.displayName
+	case "is_root":
+		return r.isRoot
+	case "size":
+		return r.size
+	case "create_time":
+		return r.createTime
+	case "labels":
+		return r.labels
+	}
+
+	return nil
+}
+
+var matchTests = []struct {
+	filter string
+	want   []string
+}{
+	{`display_name = "Ann Lee"`, []string{"ann"}},
+	{`display_name != "Ann Lee"`, []string{"bob"}},
+	{`display_name:ann*`, []string{"ann"}},
+	{`display_name:"*LEE"`, []string{"ann"}},
+	{`display_name:"*_50%"`, []string{"bob"}},
+	{`display_name:"*_5%"`, nil},
+	{`display_name = "ann*"`, nil},
+	{`display_name = "Ann*"`, []string{"ann"}},
+	{`display_name != "Ann*"`, []string{"bob"}},
+	{`display_name > "B"`, []string{"bob"}},
+	{`is_root = true`, []string{"ann"}},
+	{`is_root != true`, []string{"bob", "cat"}},
+	{`size >= 5`, []string{"bob", "cat"}},
+	{`size < 5 OR size > 5`, []string{"ann", "bob"}},
+	{`create_time >= "2026-01-01"`, []string{"bob", "cat"}},
+	{`create_time < "2026-01-01T00:00:00Z"`, []string{"ann"}},
+	{`labels:team`, []string{"ann", "bob"}},
+	{`labels.team = ops`, []string{"bob"}},
+	{`labels.team:E*`, []string{"ann"}},
+	{`labels.team != ops`, []string{"ann"}},
+	{`NOT labels.team = ops`, []string{"ann"}},
+	{`-labels:tier`, []string{"ann", "cat"}},
+	{`size > 0 labels.tier = gold`, []string{"bob"}},
+	{`NOT (is_root = true OR size = 10)`, []string{"cat"}},
+	// A null display name makes the condition unknown, as in SQL.
+	{`NOT (is_root = true OR display_name = "bob_50%")`, nil},
+}
+
+func TestMatcher(t *testing.T) {
+	for _, tt := range matchTests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			match, err := Matcher(expr, testSchema)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, row := range testRows {
+				if match(row.values) {
+					got = append(got, row.name)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matched %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSQLiteMatchesMatcher runs the same filters through SQLite, whose
+// results the memory store's Matcher is meant to reproduce.
+func TestSQLiteMatchesMatcher(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if _, err := db.Exec(`CREATE TABLE t (name TEXT, display_name TEXT, is_root BOOLEAN, size INTEGER, create_time TIMESTAMP, labels TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, row := range testRows {
+		labels, err := json.Marshal(row.labels)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := db.Exec(`INSERT INTO t VALUES (?, ?, ?, ?, ?, ?)`,
+			row.name, row.displayName, row.isRoot, row.size, row.createTime, string(labels)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tt := range matchTests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cond, args, err := SQLite(expr, testSchema, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rows, err := db.Query("SELECT name FROM t WHERE "+cond, args...)
+			if err != nil {
+				t.Fatalf("%s: %v", cond, err)
+			}
+
+			defer rows.Close()
+
+			var got []string
+			for rows.Next() {
+				var name string
+				if err := rows.Scan(&name); err != nil {
+					t.Fatal(err)
+				}
+
+				got = append(got, name)
+			}
+
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s matched %v, want %v", cond, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparer(t *testing.T) {
+	tests := []struct {
+		orderBy string
+		want    []string
+	}{
+		{"size", []string{"ann", "cat", "bob"}},
+		{"size desc", []string{"bob", "cat", "ann"}},
+		{"create_time", []string{"ann", "cat", "bob"}},
+		// Nulls sort last, as in Postgres.
+		{"display_name", []string{"ann", "bob", "cat"}},
+		{"display_name desc", []string{"cat", "bob", "ann"}},
+		{"is_root, size desc", []string{"bob", "cat", "ann"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			orders, err := ParseOrderBy(tt.orderBy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			compare, err := Comparer(orders, testSchema)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rows := append([]testRow(nil), testRows...)
+			sort.SliceStable(rows, func(i, j int) bool {
+				return compare(rows[i].values, rows[j].values) < 0
+			})
+
+			var got []string
+			for _, row := range rows {
+				got = append(got, row.name)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ordered %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+// Package filter implements the AIP-160 filter and AIP-132 order_by syntax
+// used by list RPCs. It compiles them to parameterized SQL, or to functions
+// that evaluate them in memory.
+package filter
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// Expr is a parsed filter expression: And, Or, Not or Restriction.
+type Expr interface {
+	expr()
+}
+
+type And struct {
+	Left, Right Expr
+}
+
+type Or struct {
+	Left, Right Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+// Restriction compares a field, such as "display_name" or "labels.team", to
+// a value with one of the operators =, !=, <, <=, >, >= or :.
+type Restriction struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+func (And) expr()         {}
+func (Or) expr()          {}
+func (Not) expr()         {}
+func (Restriction) expr() {}
+
+// Order is one field of an order_by string.
+type Order struct {
+	Field string
+	Desc  bool
+}
+
+// Parse parses an AIP-160 filter such as
+// `display_name:"ann*" AND create_time > "2026-01-01"`. It returns nil for an
+// empty filter.
+func Parse(filter string) (Expr, error) {
+	tokens, err := lex(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+
+	expr, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errors.Errorf("unexpected %q at position %d", tok.value, tok.pos)
+	}
+
+	return expr, nil
+}
+
+// ParseOrderBy parses an order_by string such as "display_name, create_time
+// desc".
+func ParseOrderBy(orderBy string) ([]Order, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+
+	var orders []Order
+	for _, part := range strings.Split(orderBy, ",") {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 || !isMember(words[0]) {
+			return nil, errors.Errorf("invalid order_by term: %q", strings.TrimSpace(part))
+		}
+
+		order := Order{Field: words[0]}
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				order.Desc = true
+			default:
+				return nil, errors.Errorf("invalid order_by direction: %q", words[1])
+			}
+		}
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenComparator
+	tokenString
+	tokenText
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func lex(s string) ([]token, error) {
+	var tokens []token
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: i})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, errors.Errorf("unterminated string at position %d", start)
+				}
+
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					b.WriteRune(runes[i])
+					continue
+				}
+
+				if runes[i] == r {
+					i++
+					break
+				}
+
+				b.WriteRune(runes[i])
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: b.String(), pos: start})
+		case strings.ContainsRune("<>=!:", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != '=' && r != ':' {
+				op += "="
+			}
+
+			if op == "!" {
+				return nil, errors.Errorf("unexpected '!' at position %d", i)
+			}
+
+			tokens = append(tokens, token{kind: tokenComparator, value: op, pos: i})
+			i += len(op)
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`()"'<>=!:`, runes[i]) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenText, value: string(runes[start:i]), pos: start})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+// parser is a recursive descent parser for the AIP-160 grammar. As in the
+// AIP, OR binds more tightly than AND, and adjacent terms are ANDed.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) peekKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenText && tok.value == keyword
+}
+
+func (p *parser) expression() (Expr, error) {
+	left, err := p.sequence()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("AND") {
+		p.next()
+
+		right, err := p.sequence()
+		if err != nil {
+			return nil, err
+		}
+
+		left = And{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) sequence() (Expr, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.kind == tokenEOF || tok.kind == tokenRParen || p.peekKeyword("AND") {
+			return left, nil
+		}
+
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+
+		left = And{Left: left, Right: right}
+	}
+}
+
+func (p *parser) factor() (Expr, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("OR") {
+		p.next()
+
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+
+		left = Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) term() (Expr, error) {
+	if p.peekKeyword("NOT") {
+		p.next()
+
+		expr, err := p.simple()
+		if err != nil {
+			return nil, err
+		}
+
+		return Not{Expr: expr}, nil
+	}
+
+	if tok := p.peek(); tok.kind == tokenText && len(tok.value) > 1 && tok.value[0] == '-' {
+		p.tokens[p.pos].value = tok.value[1:]
+
+		expr, err := p.simple()
+		if err != nil {
+			return nil, err
+		}
+
+		return Not{Expr: expr}, nil
+	}
+
+	return p.simple()
+}
+
+func (p *parser) simple() (Expr, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenLParen:
+		expr, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, errors.Errorf("expected ')' at position %d", closing.pos)
+		}
+
+		return expr, nil
+	case tokenText:
+		if !isMember(tok.value) {
+			return nil, errors.Errorf("invalid field name %q at position %d", tok.value, tok.pos)
+		}
+
+		op := p.next()
+		if op.kind != tokenComparator {
+			return nil, errors.Errorf("expected comparator after %q at position %d", tok.value, op.pos)
+		}
+
+		arg := p.next()
+		if arg.kind != tokenString && arg.kind != tokenText {
+			return nil, errors.Errorf("expected value after %q at position %d", op.value, arg.pos)
+		}
+
+		return Restriction{Field: tok.value, Operator: op.value, Value: arg.value}, nil
+	case tokenEOF:
+		return nil, errors.New("unexpected end of filter")
+	default:
+		return nil, errors.Errorf("unexpected %q at position %d", tok.value, tok.pos)
+	}
+}
+
+func isMember(s string) bool {
+	for _, part := range strings.Split(s, ".") {
+		if part == "" {
+			return false
+		}
+
+		for _, r := range part {
+			if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   Expr
+	}{
+		{"", nil},
+		{"   ", nil},
+		{`display_name = "Ann"`, Restriction{"display_name", "=", "Ann"}},
+		{`display_name="Ann Lee"`, Restriction{"display_name", "=", "Ann Lee"}},
+		{`display_name:'ann*'`, Restriction{"display_name", ":", "ann*"}},
+		{`display_name = "say \"hi\""`, Restriction{"display_name", "=", `say "hi"`}},
+		{`labels.team != eng`, Restriction{"labels.team", "!=", "eng"}},
+		{`create_time >= "2026-01-01"`, Restriction{"create_time", ">=", "2026-01-01"}},
+		{`a = 1 AND b = 2`, And{Restriction{"a", "=", "1"}, Restriction{"b", "=", "2"}}},
+		{`a = 1 b = 2`, And{Restriction{"a", "=", "1"}, Restriction{"b", "=", "2"}}},
+		{`a = 1 OR b = 2`, Or{Restriction{"a", "=", "1"}, Restriction{"b", "=", "2"}}},
+		{
+			// OR binds more tightly than AND.
+			`a = 1 AND b = 2 OR c = 3`,
+			And{Restriction{"a", "=", "1"}, Or{Restriction{"b", "=", "2"}, Restriction{"c", "=", "3"}}},
+		},
+		{
+			`(a = 1 AND b = 2) OR c = 3`,
+			Or{And{Restriction{"a", "=", "1"}, Restriction{"b", "=", "2"}}, Restriction{"c", "=", "3"}},
+		},
+		{`NOT a = 1`, Not{Restriction{"a", "=", "1"}}},
+		{`-a = 1`, Not{Restriction{"a", "=", "1"}}},
+		{`NOT (a = 1 OR b = 2)`, Not{Or{Restriction{"a", "=", "1"}, Restriction{"b", "=", "2"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		`display_name`,
+		`display_name =`,
+		`display_name = "Ann`,
+		`display_name ! "Ann"`,
+		`(a = 1`,
+		`a = 1)`,
+		`a = 1 AND`,
+		`"a" = 1`,
+		`= 1`,
+	}
+
+	for _, filter := range tests {
+		t.Run(filter, func(t *testing.T) {
+			if expr, err := Parse(filter); err == nil {
+				t.Errorf("Parse() = %#v, want an error", expr)
+			}
+		})
+	}
+}
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		orderBy string
+		want    []Order
+		wantErr bool
+	}{
+		{orderBy: "", want: nil},
+		{orderBy: "display_name", want: []Order{{Field: "display_name"}}},
+		{orderBy: "display_name asc, create_time DESC", want: []Order{{Field: "display_name"}, {Field: "create_time", Desc: true}}},
+		{orderBy: "display_name sideways", wantErr: true},
+		{orderBy: "display_name desc extra", wantErr: true},
+		{orderBy: "display_name,", wantErr: true},
+		{orderBy: "'display_name'", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			got, err := ParseOrderBy(tt.orderBy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOrderBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOrderBy() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
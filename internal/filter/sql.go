@@ -0,0 +1,263 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type Type int
+
+const (
+	String Type = iota
+	Bool
+	Int
+	Timestamp
+
+	// Map is a JSONB object of strings. "labels.team" compares the value of
+	// the "team" key, and "labels:team" checks that the key is present.
+	Map
+)
+
+type Field struct {
+	Column string
+	Type   Type
+}
+
+// Schema is the whitelist of fields that can be filtered and ordered on for
+// a resource, keyed by their API names.
+type Schema map[string]Field
+
+func (s Schema) lookup(name string) (Field, string, error) {
+	if field, ok := s[name]; ok {
+		return field, "", nil
+	}
+
+	if i := strings.Index(name, "."); i > 0 {
+		if field, ok := s[name[:i]]; ok && field.Type == Map {
+			return field, name[i+1:], nil
+		}
+	}
+
+	return Field{}, "", errors.Errorf("unsupported field: %q", name)
+}
+
+// SQL compiles expr to a SQL condition. Values are appended to args and
+// referenced as $n placeholders, so the condition can be added to a query
+// that already uses the first len(args) placeholders.
+func SQL(expr Expr, schema Schema, args []interface{}) (string, []interface{}, error) {
+	c := compiler{schema: schema, args: args}
+	cond, err := c.compile(expr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return cond, c.args, nil
+}
+
+// SQLite is like SQL, but compiles expr for SQLite, where Map fields are JSON
+// text and values are referenced as ?n placeholders.
+func SQLite(expr Expr, schema Schema, args []interface{}) (string, []interface{}, error) {
+	c := compiler{schema: schema, args: args, sqlite: true}
+	cond, err := c.compile(expr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return cond, c.args, nil
+}
+
+// OrderBySQL compiles orders to the contents of an ORDER BY clause.
+func OrderBySQL(orders []Order, schema Schema) (string, error) {
+	var terms []string
+	for _, order := range orders {
+		field, _, err := schema.lookup(order.Field)
+		if err != nil {
+			return "", err
+		}
+
+		if field.Type == Map {
+			return "", errors.Errorf("cannot order by %q", order.Field)
+		}
+
+		term := field.Column
+		if order.Desc {
+			term += " DESC"
+		}
+
+		terms = append(terms, term)
+	}
+
+	return strings.Join(terms, ", "), nil
+}
+
+type compiler struct {
+	schema Schema
+	args   []interface{}
+	sqlite bool
+}
+
+func (c *compiler) arg(v interface{}) string {
+	c.args = append(c.args, v)
+	if c.sqlite {
+		return fmt.Sprintf("?%d", len(c.args))
+	}
+
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *compiler) compile(expr Expr) (string, error) {
+	switch expr := expr.(type) {
+	case And:
+		return c.binary(expr.Left, "AND", expr.Right)
+	case Or:
+		return c.binary(expr.Left, "OR", expr.Right)
+	case Not:
+		cond, err := c.compile(expr.Expr)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("NOT (%s)", cond), nil
+	case Restriction:
+		return c.restriction(expr)
+	default:
+		return "", errors.Errorf("unsupported expression: %T", expr)
+	}
+}
+
+func (c *compiler) binary(left Expr, op string, right Expr) (string, error) {
+	l, err := c.compile(left)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := c.compile(right)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("(%s %s %s)", l, op, r), nil
+}
+
+func (c *compiler) restriction(r Restriction) (string, error) {
+	field, key, err := c.schema.lookup(r.Field)
+	if err != nil {
+		return "", err
+	}
+
+	switch field.Type {
+	case String:
+		return c.stringRestriction(field.Column, r)
+	case Bool:
+		value, err := strconv.ParseBool(r.Value)
+		if err != nil {
+			return "", errors.Errorf("invalid boolean for %s: %q", r.Field, r.Value)
+		}
+
+		return c.comparison(field.Column, r, value, "=", "!=")
+	case Int:
+		value, err := strconv.ParseInt(r.Value, 10, 64)
+		if err != nil {
+			return "", errors.Errorf("invalid integer for %s: %q", r.Field, r.Value)
+		}
+
+		return c.comparison(field.Column, r, value, "=", "!=", "<", "<=", ">", ">=")
+	case Timestamp:
+		value, err := parseTimestamp(r.Value)
+		if err != nil {
+			return "", errors.Errorf("invalid timestamp for %s: %q", r.Field, r.Value)
+		}
+
+		return c.comparison(field.Column, r, value, "=", "!=", "<", "<=", ">", ">=")
+	case Map:
+		if key == "" {
+			if r.Operator != ":" {
+				return "", errors.Errorf("%s only supports the ':' operator", r.Field)
+			}
+
+			if c.sqlite {
+				return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) WHERE key = %s)", field.Column, c.arg(r.Value)), nil
+			}
+
+			return fmt.Sprintf("(%s ->> %s) IS NOT NULL", field.Column, c.arg(r.Value)), nil
+		}
+
+		// SQLite's ->> would treat a key starting with '$' as a JSON path.
+		if c.sqlite {
+			return c.stringRestriction(fmt.Sprintf("(SELECT value FROM json_each(%s) WHERE key = %s)", field.Column, c.arg(key)), r)
+		}
+
+		return c.stringRestriction(fmt.Sprintf("(%s ->> %s)", field.Column, c.arg(key)), r)
+	default:
+		return "", errors.Errorf("unsupported field: %q", r.Field)
+	}
+}
+
+// stringRestriction compares strings. "=" and "!=" are exact unless the value
+// contains a '*' wildcard, and ":" is a case-insensitive match that also
+// allows wildcards.
+func (c *compiler) stringRestriction(column string, r Restriction) (string, error) {
+	switch r.Operator {
+	case ":":
+		// SQLite's LIKE is already case-insensitive, but has no default escape
+		// character.
+		if c.sqlite {
+			return fmt.Sprintf(`%s LIKE %s ESCAPE '\'`, column, c.arg(likePattern(r.Value))), nil
+		}
+
+		return fmt.Sprintf("%s ILIKE %s", column, c.arg(likePattern(r.Value))), nil
+	case "=", "!=":
+		if strings.Contains(r.Value, "*") {
+			op, pattern := "LIKE", likePattern(r.Value)
+			if c.sqlite {
+				op, pattern = "GLOB", globPattern(r.Value)
+			}
+
+			if r.Operator == "!=" {
+				op = "NOT " + op
+			}
+
+			return fmt.Sprintf("%s %s %s", column, op, c.arg(pattern)), nil
+		}
+	}
+
+	return c.comparison(column, r, r.Value, "=", "!=", "<", "<=", ">", ">=")
+}
+
+func (c *compiler) comparison(column string, r Restriction, value interface{}, operators ...string) (string, error) {
+	for _, op := range operators {
+		if op == r.Operator {
+			if op == "!=" {
+				op = "<>"
+			}
+
+			return fmt.Sprintf("%s %s %s", column, op, c.arg(value)), nil
+		}
+	}
+
+	return "", errors.Errorf("%s does not support the %q operator", r.Field, r.Operator)
+}
+
+func likePattern(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+	return strings.Replace(value, "*", "%", -1)
+}
+
+// globPattern is likePattern for SQLite's GLOB, which is case-sensitive like
+// Postgres's LIKE. GLOB has no escape character, so special characters are
+// matched with single-character classes instead.
+func globPattern(value string) string {
+	return strings.NewReplacer("?", "[?]", "[", "[[]").Replace(value)
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse("2006-01-02", value)
+}
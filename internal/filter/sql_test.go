@@ -0,0 +1,212 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{
+	"display_name": {Column: "display_name", Type: String},
+	"is_root":      {Column: "is_root", Type: Bool},
+	"size":         {Column: "size", Type: Int},
+	"create_time":  {Column: "create_time", Type: Timestamp},
+	"labels":       {Column: "labels", Type: Map},
+}
+
+func TestSQL(t *testing.T) {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		filter     string
+		want       string
+		wantSQLite string
+		wantArgs   []interface{}
+	}{
+		{
+			filter:     `display_name = "Ann"`,
+			want:       "display_name = $2",
+			wantSQLite: "display_name = ?2",
+			wantArgs:   []interface{}{"Ann"},
+		},
+		{
+			filter:     `display_name != "Ann"`,
+			want:       "display_name <> $2",
+			wantSQLite: "display_name <> ?2",
+			wantArgs:   []interface{}{"Ann"},
+		},
+		{
+			filter:     `display_name:"an_n*"`,
+			want:       "display_name ILIKE $2",
+			wantSQLite: `display_name LIKE ?2 ESCAPE '\'`,
+			wantArgs:   []interface{}{`an\_n%`},
+		},
+		{
+			filter:     `display_name = "A[n]?*"`,
+			want:       "display_name LIKE $2",
+			wantSQLite: "display_name GLOB ?2",
+			wantArgs:   nil, // The patterns differ; see TestSQLPatterns.
+		},
+		{
+			filter:     `is_root = true`,
+			want:       "is_root = $2",
+			wantSQLite: "is_root = ?2",
+			wantArgs:   []interface{}{true},
+		},
+		{
+			filter:     `size >= 10`,
+			want:       "size >= $2",
+			wantSQLite: "size >= ?2",
+			wantArgs:   []interface{}{int64(10)},
+		},
+		{
+			filter:     `create_time < "2026-01-01"`,
+			want:       "create_time < $2",
+			wantSQLite: "create_time < ?2",
+			wantArgs:   []interface{}{day},
+		},
+		{
+			filter:     `labels:team`,
+			want:       "(labels ->> $2) IS NOT NULL",
+			wantSQLite: "EXISTS (SELECT 1 FROM json_each(labels) WHERE key = ?2)",
+			wantArgs:   []interface{}{"team"},
+		},
+		{
+			filter:     `labels.team = eng`,
+			want:       "(labels ->> $2) = $3",
+			wantSQLite: "(SELECT value FROM json_each(labels) WHERE key = ?2) = ?3",
+			wantArgs:   []interface{}{"team", "eng"},
+		},
+		{
+			filter:     `NOT is_root = true AND (size < 1 OR size > 9)`,
+			want:       "(NOT (is_root = $2) AND (size < $3 OR size > $4))",
+			wantSQLite: "(NOT (is_root = ?2) AND (size < ?3 OR size > ?4))",
+			wantArgs:   []interface{}{true, int64(1), int64(9)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The conditions are appended to queries that already use $1.
+			for _, compile := range []struct {
+				name string
+				fn   func(Expr, Schema, []interface{}) (string, []interface{}, error)
+				want string
+			}{
+				{"SQL", SQL, tt.want},
+				{"SQLite", SQLite, tt.wantSQLite},
+			} {
+				got, args, err := compile.fn(expr, testSchema, []interface{}{"account"})
+				if err != nil {
+					t.Fatalf("%s() error = %v", compile.name, err)
+				}
+
+				if got != compile.want {
+					t.Errorf("%s() = %q, want %q", compile.name, got, compile.want)
+				}
+
+				if args[0] != "account" {
+					t.Errorf("%s() replaced the existing args: %v", compile.name, args)
+				}
+
+				if tt.wantArgs != nil && !reflect.DeepEqual(args[1:], tt.wantArgs) {
+					t.Errorf("%s() args = %#v, want %#v", compile.name, args[1:], tt.wantArgs)
+				}
+			}
+		})
+	}
+}
+
+func TestSQLPatterns(t *testing.T) {
+	tests := []struct {
+		value, like, glob string
+	}{
+		{"ann*", "ann%", "ann*"},
+		{"*ann*", "%ann%", "*ann*"},
+		{`50%_off*`, `50\%\_off%`, "50%_off*"},
+		{`a\b*`, `a\\b%`, `a\b*`},
+		{"what?*", "what?%", "what[?]*"},
+		{"[x]*", "[x]%", "[[]x]*"},
+	}
+
+	for _, tt := range tests {
+		if got := likePattern(tt.value); got != tt.like {
+			t.Errorf("likePattern(%q) = %q, want %q", tt.value, got, tt.like)
+		}
+
+		if got := globPattern(tt.value); got != tt.glob {
+			t.Errorf("globPattern(%q) = %q, want %q", tt.value, got, tt.glob)
+		}
+	}
+}
+
+func TestSQLErrors(t *testing.T) {
+	tests := []string{
+		`unknown = 1`,
+		`labels = team`,
+		`labels.team < 1 AND unknown = 2`,
+		`is_root = maybe`,
+		`is_root > true`,
+		`size = ten`,
+		`size : 1`,
+		`create_time > yesterday`,
+	}
+
+	for _, filter := range tests {
+		t.Run(filter, func(t *testing.T) {
+			expr, err := Parse(filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err := SQL(expr, testSchema, nil); err == nil {
+				t.Error("SQL() succeeded, want an error")
+			}
+
+			if _, _, err := SQLite(expr, testSchema, nil); err == nil {
+				t.Error("SQLite() succeeded, want an error")
+			}
+
+			if _, err := Matcher(expr, testSchema); err == nil {
+				t.Error("Matcher() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestOrderBySQL(t *testing.T) {
+	tests := []struct {
+		orderBy string
+		want    string
+		wantErr bool
+	}{
+		{orderBy: "display_name", want: "display_name"},
+		{orderBy: "size desc, create_time", want: "size DESC, create_time"},
+		{orderBy: "labels", wantErr: true},
+		{orderBy: "labels.team", wantErr: true},
+		{orderBy: "unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			orders, err := ParseOrderBy(tt.orderBy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := OrderBySQL(orders, testSchema)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OrderBySQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("OrderBySQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
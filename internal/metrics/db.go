@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dbCollector reports a connection pool's sql.DBStats.
+type dbCollector struct {
+	db *sql.DB
+
+	open, inUse, idle                               *prometheus.Desc
+	waits, waitDuration, idleClosed, lifetimeClosed *prometheus.Desc
+}
+
+// RegisterDB exports the pool stats of db under the given name, such as
+// "primary" or "replica".
+func RegisterDB(name string, db *sql.DB) {
+	labels := prometheus.Labels{"db": name}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(name, help, nil, labels)
+	}
+
+	prometheus.MustRegister(&dbCollector{
+		db:             db,
+		open:           desc("iam_db_open_connections", "Open connections to the database."),
+		inUse:          desc("iam_db_in_use_connections", "Connections currently in use."),
+		idle:           desc("iam_db_idle_connections", "Idle connections."),
+		waits:          desc("iam_db_waits_total", "Times a connection had to be waited for."),
+		waitDuration:   desc("iam_db_wait_seconds_total", "Time spent waiting for connections."),
+		idleClosed:     desc("iam_db_max_idle_closed_total", "Connections closed because of db_max_idle_conns."),
+		lifetimeClosed: desc("iam_db_max_lifetime_closed_total", "Connections closed because of db_conn_max_lifetime."),
+	})
+}
+
+func (c *dbCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{c.open, c.inUse, c.idle, c.waits, c.waitDuration, c.idleClosed, c.lifetimeClosed} {
+		ch <- desc
+	}
+}
+
+func (c *dbCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.db.Stats()
+
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waits, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.idleClosed, prometheus.CounterValue, float64(stats.MaxIdleClosed))
+	ch <- prometheus.MustNewConstMetric(c.lifetimeClosed, prometheus.CounterValue, float64(stats.MaxLifetimeClosed))
+}
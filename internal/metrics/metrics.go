@@ -0,0 +1,83 @@
+// Package metrics collects Prometheus metrics about the service's RPCs,
+// authentication, password hashing, database and caches. They are
+// registered with the default registry, which promhttp.Handler serves.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "iam_rpc_duration_seconds",
+		Help:    "Latency of gRPC calls, by method.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{"method"})
+
+	rpcs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iam_rpcs_total",
+		Help: "gRPC calls, by method and status code.",
+	}, []string{"method", "code"})
+
+	authentications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iam_authentications_total",
+		Help: "Authenticate calls, by result and, for failures, reason.",
+	}, []string{"result", "reason"})
+)
+
+func init() {
+	prometheus.MustRegister(rpcDuration, rpcs, authentications, hashDuration, storeDuration)
+}
+
+// Unary records the latency and status code of each call. It should run
+// before any other interceptor, so that calls they reject are counted too.
+func Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+
+	rpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+	rpcs.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+	return res, err
+}
+
+// Authenticated counts an Authenticate call, which failed for reason unless
+// reason is empty. It suits service.Service's OnAuthenticate.
+func Authenticated(reason string) {
+	if reason == "" {
+		authentications.WithLabelValues("success", "").Inc()
+		return
+	}
+
+	authentications.WithLabelValues("failure", reason).Inc()
+}
+
+// RegisterCache exports the hits and misses that stats reports for the named
+// cache.
+func RegisterCache(name string, stats func() (hits, misses int64)) {
+	labels := prometheus.Labels{"cache": name}
+
+	prometheus.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name:        "iam_cache_hits_total",
+			Help:        "Lookups served from a cache.",
+			ConstLabels: labels,
+		}, func() float64 {
+			hits, _ := stats()
+			return float64(hits)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name:        "iam_cache_misses_total",
+			Help:        "Lookups that a cache couldn't serve.",
+			ConstLabels: labels,
+		}, func() float64 {
+			_, misses := stats()
+			return float64(misses)
+		}),
+	)
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAlgorithm(t *testing.T) {
+	tests := []struct {
+		encoded string
+		want    string
+	}{
+		{"$2a$10$abcdefghijklmnopqrstuv", "bcrypt"},
+		{"$2b$10$abcdefghijklmnopqrstuv", "bcrypt"},
+		{"$2y$10$abcdefghijklmnopqrstuv", "bcrypt"},
+		{"$argon2id$v=19$m=65536,t=1,p=4$c2FsdA$aGFzaA", "argon2id"},
+		{"$scrypt$ln=15,r=8,p=1$c2FsdA$aGFzaA", "scrypt"},
+		{"$argon2i$v=19$m=65536,t=1,p=4$c2FsdA$aGFzaA", "unknown"},
+		{"$md5$salt$hash", "unknown"},
+		{"x$2a$10$abcdefghijklmnopqrstuv", "unknown"},
+		{"$2a", "unknown"},
+		{"plaintext", "unknown"},
+		{"", "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := algorithm(tt.encoded); got != tt.want {
+			t.Errorf("algorithm(%q) = %q, want %q", tt.encoded, got, tt.want)
+		}
+	}
+}
+
+func TestUnary(t *testing.T) {
+	const method = "/test.Service/TestUnary"
+
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{nil, codes.OK},
+		{status.Error(codes.PermissionDenied, "denied"), codes.PermissionDenied},
+		{context.Canceled, codes.Unknown},
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	for _, tt := range tests {
+		counter := rpcs.WithLabelValues(method, tt.code.String())
+		before := testutil.ToFloat64(counter)
+
+		_, err := Unary(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, tt.err
+		})
+
+		if err != tt.err {
+			t.Errorf("Unary() error = %v, want %v", err, tt.err)
+		}
+
+		if got := testutil.ToFloat64(counter) - before; got != 1 {
+			t.Errorf("iam_rpcs_total{code=%q} grew by %v, want 1", tt.code, got)
+		}
+	}
+}
+
+func TestAuthenticated(t *testing.T) {
+	tests := []struct {
+		reason string
+		result string
+	}{
+		{"", "success"},
+		{"invalid_credentials", "failure"},
+		{"locked_out", "failure"},
+	}
+
+	for _, tt := range tests {
+		counter := authentications.WithLabelValues(tt.result, tt.reason)
+		before := testutil.ToFloat64(counter)
+
+		Authenticated(tt.reason)
+
+		if got := testutil.ToFloat64(counter) - before; got != 1 {
+			t.Errorf("Authenticated(%q): iam_authentications_total{result=%q} grew by %v, want 1", tt.reason, tt.result, got)
+		}
+	}
+}
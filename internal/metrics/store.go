@@ -0,0 +1,355 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+var storeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "iam_store_duration_seconds",
+	Help:    "Latency of calls to the database store, by method.",
+	Buckets: prometheus.ExponentialBuckets(0.0005, 2, 16),
+}, []string{"method"})
+
+// Store times each call to the wrapped Store, including the calls made inside
+// WithTx. Calls that hash or verify passwords include the time spent doing
+// so, which Hasher also measures separately.
+type Store struct {
+	store.Store
+}
+
+func observeStore(method string, start time.Time) {
+	storeDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func (s *Store) WithTx(ctx context.Context, fn func(store.Store) error) error {
+	defer observeStore("WithTx", time.Now())
+	return s.Store.WithTx(ctx, func(tx store.Store) error {
+		return fn(&Store{Store: tx})
+	})
+}
+
+func (s *Store) CheckPassword(ctx context.Context, req store.CheckPasswordRequest) (store.CheckPasswordResponse, error) {
+	defer observeStore("CheckPassword", time.Now())
+	return s.Store.CheckPassword(ctx, req)
+}
+
+func (s *Store) GetAccount(ctx context.Context, req store.GetAccountRequest) (models.Account, error) {
+	defer observeStore("GetAccount", time.Now())
+	return s.Store.GetAccount(ctx, req)
+}
+
+func (s *Store) CreateAccount(ctx context.Context, req store.CreateAccountRequest) (models.Account, error) {
+	defer observeStore("CreateAccount", time.Now())
+	return s.Store.CreateAccount(ctx, req)
+}
+
+func (s *Store) UpdateAccount(ctx context.Context, req store.UpdateAccountRequest) (models.Account, error) {
+	defer observeStore("UpdateAccount", time.Now())
+	return s.Store.UpdateAccount(ctx, req)
+}
+
+func (s *Store) DeleteAccount(ctx context.Context, req store.DeleteAccountRequest) error {
+	defer observeStore("DeleteAccount", time.Now())
+	return s.Store.DeleteAccount(ctx, req)
+}
+
+func (s *Store) UndeleteAccount(ctx context.Context, req store.UndeleteAccountRequest) (models.Account, error) {
+	defer observeStore("UndeleteAccount", time.Now())
+	return s.Store.UndeleteAccount(ctx, req)
+}
+
+func (s *Store) ExportAccount(ctx context.Context, req store.ExportAccountRequest) (models.AccountBundle, error) {
+	defer observeStore("ExportAccount", time.Now())
+	return s.Store.ExportAccount(ctx, req)
+}
+
+func (s *Store) ImportAccount(ctx context.Context, req store.ImportAccountRequest) (store.ImportAccountResponse, error) {
+	defer observeStore("ImportAccount", time.Now())
+	return s.Store.ImportAccount(ctx, req)
+}
+
+func (s *Store) GetPasswordPolicy(ctx context.Context, req store.GetPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	defer observeStore("GetPasswordPolicy", time.Now())
+	return s.Store.GetPasswordPolicy(ctx, req)
+}
+
+func (s *Store) UpdatePasswordPolicy(ctx context.Context, req store.UpdatePasswordPolicyRequest) (models.PasswordPolicy, error) {
+	defer observeStore("UpdatePasswordPolicy", time.Now())
+	return s.Store.UpdatePasswordPolicy(ctx, req)
+}
+
+func (s *Store) GetUser(ctx context.Context, req store.GetUserRequest) (models.User, error) {
+	defer observeStore("GetUser", time.Now())
+	return s.Store.GetUser(ctx, req)
+}
+
+func (s *Store) ListUsers(ctx context.Context, req store.ListUsersRequest) (store.ListUsersResponse, error) {
+	defer observeStore("ListUsers", time.Now())
+	return s.Store.ListUsers(ctx, req)
+}
+
+func (s *Store) CreateUser(ctx context.Context, req store.CreateUserRequest) (models.User, error) {
+	defer observeStore("CreateUser", time.Now())
+	return s.Store.CreateUser(ctx, req)
+}
+
+func (s *Store) UpdateUser(ctx context.Context, req store.UpdateUserRequest) (models.User, error) {
+	defer observeStore("UpdateUser", time.Now())
+	return s.Store.UpdateUser(ctx, req)
+}
+
+func (s *Store) DeleteUser(ctx context.Context, req store.DeleteUserRequest) error {
+	defer observeStore("DeleteUser", time.Now())
+	return s.Store.DeleteUser(ctx, req)
+}
+
+func (s *Store) UndeleteUser(ctx context.Context, req store.UndeleteUserRequest) (models.User, error) {
+	defer observeStore("UndeleteUser", time.Now())
+	return s.Store.UndeleteUser(ctx, req)
+}
+
+func (s *Store) EraseUser(ctx context.Context, req store.EraseUserRequest) (store.EraseUserResponse, error) {
+	defer observeStore("EraseUser", time.Now())
+	return s.Store.EraseUser(ctx, req)
+}
+
+func (s *Store) PurgeDeleted(ctx context.Context, req store.PurgeDeletedRequest) (store.PurgeDeletedResponse, error) {
+	defer observeStore("PurgeDeleted", time.Now())
+	return s.Store.PurgeDeleted(ctx, req)
+}
+
+func (s *Store) CreatePrincipal(ctx context.Context, req store.CreatePrincipalRequest) (models.Principal, error) {
+	defer observeStore("CreatePrincipal", time.Now())
+	return s.Store.CreatePrincipal(ctx, req)
+}
+
+func (s *Store) GetPrincipal(ctx context.Context, req store.GetPrincipalRequest) (models.Principal, error) {
+	defer observeStore("GetPrincipal", time.Now())
+	return s.Store.GetPrincipal(ctx, req)
+}
+
+func (s *Store) CheckPrincipalPassword(ctx context.Context, req store.CheckPrincipalPasswordRequest) (store.CheckPasswordResponse, error) {
+	defer observeStore("CheckPrincipalPassword", time.Now())
+	return s.Store.CheckPrincipalPassword(ctx, req)
+}
+
+func (s *Store) ListAccountMemberships(ctx context.Context, req store.ListAccountMembershipsRequest) ([]models.AccountMembership, error) {
+	defer observeStore("ListAccountMemberships", time.Now())
+	return s.Store.ListAccountMemberships(ctx, req)
+}
+
+func (s *Store) LinkPrincipal(ctx context.Context, req store.LinkPrincipalRequest) (models.User, error) {
+	defer observeStore("LinkPrincipal", time.Now())
+	return s.Store.LinkPrincipal(ctx, req)
+}
+
+func (s *Store) UnlinkPrincipal(ctx context.Context, req store.UnlinkPrincipalRequest) (models.User, error) {
+	defer observeStore("UnlinkPrincipal", time.Now())
+	return s.Store.UnlinkPrincipal(ctx, req)
+}
+
+func (s *Store) CreateOrganization(ctx context.Context, req store.CreateOrganizationRequest) (models.Organization, error) {
+	defer observeStore("CreateOrganization", time.Now())
+	return s.Store.CreateOrganization(ctx, req)
+}
+
+func (s *Store) GetOrganization(ctx context.Context, req store.GetOrganizationRequest) (models.Organization, error) {
+	defer observeStore("GetOrganization", time.Now())
+	return s.Store.GetOrganization(ctx, req)
+}
+
+func (s *Store) UpdateOrganization(ctx context.Context, req store.UpdateOrganizationRequest) (models.Organization, error) {
+	defer observeStore("UpdateOrganization", time.Now())
+	return s.Store.UpdateOrganization(ctx, req)
+}
+
+func (s *Store) AddOrganizationAdmin(ctx context.Context, req store.AddOrganizationAdminRequest) (models.Organization, error) {
+	defer observeStore("AddOrganizationAdmin", time.Now())
+	return s.Store.AddOrganizationAdmin(ctx, req)
+}
+
+func (s *Store) RemoveOrganizationAdmin(ctx context.Context, req store.RemoveOrganizationAdminRequest) (models.Organization, error) {
+	defer observeStore("RemoveOrganizationAdmin", time.Now())
+	return s.Store.RemoveOrganizationAdmin(ctx, req)
+}
+
+func (s *Store) IsOrganizationAdmin(ctx context.Context, req store.IsOrganizationAdminRequest) (bool, error) {
+	defer observeStore("IsOrganizationAdmin", time.Now())
+	return s.Store.IsOrganizationAdmin(ctx, req)
+}
+
+func (s *Store) GetOrganizationPasswordPolicy(ctx context.Context, req store.GetOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	defer observeStore("GetOrganizationPasswordPolicy", time.Now())
+	return s.Store.GetOrganizationPasswordPolicy(ctx, req)
+}
+
+func (s *Store) UpdateOrganizationPasswordPolicy(ctx context.Context, req store.UpdateOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	defer observeStore("UpdateOrganizationPasswordPolicy", time.Now())
+	return s.Store.UpdateOrganizationPasswordPolicy(ctx, req)
+}
+
+func (s *Store) MoveAccount(ctx context.Context, req store.MoveAccountRequest) (models.Account, error) {
+	defer observeStore("MoveAccount", time.Now())
+	return s.Store.MoveAccount(ctx, req)
+}
+
+func (s *Store) ListIdentities(ctx context.Context, req store.ListIdentitiesRequest) (store.ListIdentitiesResponse, error) {
+	defer observeStore("ListIdentities", time.Now())
+	return s.Store.ListIdentities(ctx, req)
+}
+
+func (s *Store) CreateIdentity(ctx context.Context, req store.CreateIdentityRequest) (models.Identity, error) {
+	defer observeStore("CreateIdentity", time.Now())
+	return s.Store.CreateIdentity(ctx, req)
+}
+
+func (s *Store) UpdateIdentity(ctx context.Context, req store.UpdateIdentityRequest) (models.Identity, error) {
+	defer observeStore("UpdateIdentity", time.Now())
+	return s.Store.UpdateIdentity(ctx, req)
+}
+
+func (s *Store) PasswordReused(ctx context.Context, req store.PasswordReusedRequest) (bool, error) {
+	defer observeStore("PasswordReused", time.Now())
+	return s.Store.PasswordReused(ctx, req)
+}
+
+func (s *Store) CreateAuditEvent(ctx context.Context, req store.CreateAuditEventRequest) (models.AuditEvent, error) {
+	defer observeStore("CreateAuditEvent", time.Now())
+	return s.Store.CreateAuditEvent(ctx, req)
+}
+
+func (s *Store) ListAuditEvents(ctx context.Context, req store.ListAuditEventsRequest) (store.ListAuditEventsResponse, error) {
+	defer observeStore("ListAuditEvents", time.Now())
+	return s.Store.ListAuditEvents(ctx, req)
+}
+
+func (s *Store) ListAuditChainHeads(ctx context.Context) ([]models.AuditEvent, error) {
+	defer observeStore("ListAuditChainHeads", time.Now())
+	return s.Store.ListAuditChainHeads(ctx)
+}
+
+func (s *Store) ListAuditChain(ctx context.Context, req store.ListAuditChainRequest) ([]models.AuditEvent, error) {
+	defer observeStore("ListAuditChain", time.Now())
+	return s.Store.ListAuditChain(ctx, req)
+}
+
+func (s *Store) CreateAuditCheckpoint(ctx context.Context, req store.CreateAuditCheckpointRequest) (models.AuditCheckpoint, error) {
+	defer observeStore("CreateAuditCheckpoint", time.Now())
+	return s.Store.CreateAuditCheckpoint(ctx, req)
+}
+
+func (s *Store) ListAuditCheckpoints(ctx context.Context, req store.ListAuditCheckpointsRequest) ([]models.AuditCheckpoint, error) {
+	defer observeStore("ListAuditCheckpoints", time.Now())
+	return s.Store.ListAuditCheckpoints(ctx, req)
+}
+
+func (s *Store) ListWebhooks(ctx context.Context, req store.ListWebhooksRequest) (store.ListWebhooksResponse, error) {
+	defer observeStore("ListWebhooks", time.Now())
+	return s.Store.ListWebhooks(ctx, req)
+}
+
+func (s *Store) CreateWebhook(ctx context.Context, req store.CreateWebhookRequest) (models.Webhook, error) {
+	defer observeStore("CreateWebhook", time.Now())
+	return s.Store.CreateWebhook(ctx, req)
+}
+
+func (s *Store) DeleteWebhook(ctx context.Context, req store.DeleteWebhookRequest) error {
+	defer observeStore("DeleteWebhook", time.Now())
+	return s.Store.DeleteWebhook(ctx, req)
+}
+
+func (s *Store) EnqueueWebhookEvent(ctx context.Context, req store.EnqueueWebhookEventRequest) error {
+	defer observeStore("EnqueueWebhookEvent", time.Now())
+	return s.Store.EnqueueWebhookEvent(ctx, req)
+}
+
+func (s *Store) ClaimWebhookDeliveries(ctx context.Context, req store.ClaimWebhookDeliveriesRequest) ([]models.WebhookDelivery, error) {
+	defer observeStore("ClaimWebhookDeliveries", time.Now())
+	return s.Store.ClaimWebhookDeliveries(ctx, req)
+}
+
+func (s *Store) CompleteWebhookDelivery(ctx context.Context, req store.CompleteWebhookDeliveryRequest) error {
+	defer observeStore("CompleteWebhookDelivery", time.Now())
+	return s.Store.CompleteWebhookDelivery(ctx, req)
+}
+
+func (s *Store) FailWebhookDelivery(ctx context.Context, req store.FailWebhookDeliveryRequest) error {
+	defer observeStore("FailWebhookDelivery", time.Now())
+	return s.Store.FailWebhookDelivery(ctx, req)
+}
+
+func (s *Store) ListDeadLetters(ctx context.Context, req store.ListDeadLettersRequest) (store.ListDeadLettersResponse, error) {
+	defer observeStore("ListDeadLetters", time.Now())
+	return s.Store.ListDeadLetters(ctx, req)
+}
+
+func (s *Store) GetLoginThrottles(ctx context.Context, req store.GetLoginThrottlesRequest) ([]models.LoginThrottle, error) {
+	defer observeStore("GetLoginThrottles", time.Now())
+	return s.Store.GetLoginThrottles(ctx, req)
+}
+
+func (s *Store) RecordLoginFailure(ctx context.Context, req store.RecordLoginFailureRequest) (models.LoginThrottle, error) {
+	defer observeStore("RecordLoginFailure", time.Now())
+	return s.Store.RecordLoginFailure(ctx, req)
+}
+
+func (s *Store) LockLogin(ctx context.Context, req store.LockLoginRequest) error {
+	defer observeStore("LockLogin", time.Now())
+	return s.Store.LockLogin(ctx, req)
+}
+
+func (s *Store) ResetLoginThrottles(ctx context.Context, req store.ResetLoginThrottlesRequest) error {
+	defer observeStore("ResetLoginThrottles", time.Now())
+	return s.Store.ResetLoginThrottles(ctx, req)
+}
+
+func (s *Store) CreateUserToken(ctx context.Context, req store.CreateUserTokenRequest) error {
+	defer observeStore("CreateUserToken", time.Now())
+	return s.Store.CreateUserToken(ctx, req)
+}
+
+func (s *Store) GetUserToken(ctx context.Context, req store.GetUserTokenRequest) (models.UserToken, error) {
+	defer observeStore("GetUserToken", time.Now())
+	return s.Store.GetUserToken(ctx, req)
+}
+
+func (s *Store) ResetPassword(ctx context.Context, req store.ResetPasswordRequest) error {
+	defer observeStore("ResetPassword", time.Now())
+	return s.Store.ResetPassword(ctx, req)
+}
+
+func (s *Store) VerifyEmail(ctx context.Context, req store.VerifyEmailRequest) error {
+	defer observeStore("VerifyEmail", time.Now())
+	return s.Store.VerifyEmail(ctx, req)
+}
+
+func (s *Store) ListInvitations(ctx context.Context, req store.ListInvitationsRequest) (store.ListInvitationsResponse, error) {
+	defer observeStore("ListInvitations", time.Now())
+	return s.Store.ListInvitations(ctx, req)
+}
+
+func (s *Store) CreateInvitation(ctx context.Context, req store.CreateInvitationRequest) (models.Invitation, error) {
+	defer observeStore("CreateInvitation", time.Now())
+	return s.Store.CreateInvitation(ctx, req)
+}
+
+func (s *Store) DeleteInvitation(ctx context.Context, req store.DeleteInvitationRequest) error {
+	defer observeStore("DeleteInvitation", time.Now())
+	return s.Store.DeleteInvitation(ctx, req)
+}
+
+func (s *Store) GetPendingInvitation(ctx context.Context, req store.GetPendingInvitationRequest) (models.Invitation, error) {
+	defer observeStore("GetPendingInvitation", time.Now())
+	return s.Store.GetPendingInvitation(ctx, req)
+}
+
+func (s *Store) AcceptInvitation(ctx context.Context, req store.AcceptInvitationRequest) (models.User, error) {
+	defer observeStore("AcceptInvitation", time.Now())
+	return s.Store.AcceptInvitation(ctx, req)
+}
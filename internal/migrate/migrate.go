@@ -0,0 +1,410 @@
+// Package migrate applies database schema migrations, recording the applied
+// versions in the iam_schema_migrations table. Databases migrated with
+// golang-migrate, which uses a schema_migrations table, are adopted the first
+// time the runner sees them.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and when it was applied, if it has been.
+type Status struct {
+	Migration
+	ApplyTime *time.Time
+}
+
+var fileNamePattern = regexp.MustCompile(`^([0-9]+)_([A-Za-z0-9_]+)\.(up|down)\.sql$`)
+
+// lockKey identifies the advisory lock held while migrating, so that replicas
+// starting at the same time don't apply the same migrations.
+const lockKey int64 = 0x69616d6d6967
+
+// Load reads the migrations in fsys, ordered by version. Every migration must
+// have both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	paths, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, path := range paths {
+		match := fileNamePattern.FindStringSubmatch(path)
+		if match == nil {
+			return nil, errors.Errorf("invalid migration file name: %s", path)
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid migration version: %s", path)
+		}
+
+		contents, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, errors.Errorf("migration %d has files with different names: %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(contents)
+		} else {
+			migration.Down = string(contents)
+		}
+	}
+
+	var migrations []Migration
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, errors.Errorf("migration %d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+
+	// SQLite is set if DB is a SQLite database rather than Postgres. SQLite
+	// has no advisory locks, so migrations are only serialized by SQLite's own
+	// write lock, and a process that loses a race to apply a migration fails
+	// instead of waiting for it.
+	SQLite bool
+}
+
+// Status returns every migration and when it was applied. Applied versions
+// that aren't among Migrations, such as from a newer release, are included
+// with no name or SQL.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]time.Time) error {
+		for _, migration := range m.Migrations {
+			status := Status{Migration: migration}
+			if t, ok := applied[migration.Version]; ok {
+				status.ApplyTime = &t
+				delete(applied, migration.Version)
+			}
+
+			statuses = append(statuses, status)
+		}
+
+		for version, t := range applied {
+			t := t
+			statuses = append(statuses, Status{
+				Migration: Migration{Version: version},
+				ApplyTime: &t,
+			})
+		}
+
+		return nil
+	})
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, err
+}
+
+// Up applies every pending migration, returning the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if len(m.Migrations) == 0 {
+		return nil, nil
+	}
+
+	return m.To(ctx, m.Migrations[len(m.Migrations)-1].Version)
+}
+
+// Down rolls back the most recently applied migration, returning it, or nil
+// if none are applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var rolledBack *Migration
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]time.Time) error {
+		for i := len(m.Migrations) - 1; i >= 0; i-- {
+			migration := m.Migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			if err := m.unknownApplied(applied, migration.Version); err != nil {
+				return err
+			}
+
+			if err := rollBack(ctx, conn, migration); err != nil {
+				return err
+			}
+
+			rolledBack = &migration
+			return nil
+		}
+
+		return m.unknownApplied(applied, 0)
+	})
+
+	return rolledBack, err
+}
+
+// To applies or rolls back migrations until exactly those up to and including
+// version are applied, returning the ones it applied or rolled back in the
+// order it did so. Version 0 rolls back every migration.
+func (m *Migrator) To(ctx context.Context, version int64) ([]Migration, error) {
+	if version != 0 && !m.known(version) {
+		return nil, errors.Errorf("unknown migration version: %d", version)
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]time.Time) error {
+		if err := m.unknownApplied(applied, version); err != nil {
+			return err
+		}
+
+		for i := len(m.Migrations) - 1; i >= 0; i-- {
+			migration := m.Migrations[i]
+			if _, ok := applied[migration.Version]; !ok || migration.Version <= version {
+				continue
+			}
+
+			if err := rollBack(ctx, conn, migration); err != nil {
+				return err
+			}
+
+			done = append(done, migration)
+		}
+
+		for _, migration := range m.Migrations {
+			if _, ok := applied[migration.Version]; ok || migration.Version > version {
+				continue
+			}
+
+			if err := apply(ctx, conn, migration); err != nil {
+				return err
+			}
+
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+func (m *Migrator) known(version int64) bool {
+	for _, migration := range m.Migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unknownApplied returns an error if a migration newer than version has been
+// applied that this binary doesn't have, since it can't be rolled back and
+// the schema may be ahead of what the binary expects.
+func (m *Migrator) unknownApplied(applied map[int64]time.Time, version int64) error {
+	for v := range applied {
+		if v > version && !m.known(v) {
+			return errors.Errorf("database has unknown migration %d applied; it may be from a newer release", v)
+		}
+	}
+
+	return nil
+}
+
+// withLock calls fn holding the migration lock, with the applied migration
+// versions and their apply times.
+func (m *Migrator) withLock(ctx context.Context, fn func(*sql.Conn, map[int64]time.Time) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	// SQLite only parses apply_time as a time if it's declared as TIMESTAMP.
+	applyTimeType := "TIMESTAMP WITH TIME ZONE"
+	if m.SQLite {
+		applyTimeType = "TIMESTAMP"
+	} else {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+			return errors.Wrap(err, "error acquiring migration lock")
+		}
+
+		defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+	}
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS iam_schema_migrations (
+		  version BIGINT NOT NULL PRIMARY KEY,
+		  name TEXT NOT NULL,
+		  apply_time `+applyTimeType+` NOT NULL
+		)
+	`); err != nil {
+		return err
+	}
+
+	if err := m.adoptGolangMigrate(ctx, conn); err != nil {
+		return err
+	}
+
+	rows, err := conn.QueryContext(ctx, `SELECT version, apply_time FROM iam_schema_migrations`)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var applyTime time.Time
+		if err := rows.Scan(&version, &applyTime); err != nil {
+			return err
+		}
+
+		applied[version] = applyTime
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return fn(conn, applied)
+}
+
+// adoptGolangMigrate records the migrations that golang-migrate applied, if
+// the database has its schema_migrations table and the runner hasn't recorded
+// any migrations yet. golang-migrate only keeps the latest version, so every
+// migration up to it counts as applied.
+func (m *Migrator) adoptGolangMigrate(ctx context.Context, conn *sql.Conn) error {
+	var recorded bool
+	if err := conn.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM iam_schema_migrations)`).Scan(&recorded); err != nil {
+		return err
+	}
+
+	if recorded {
+		return nil
+	}
+
+	columns := `
+		SELECT count(*) FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'schema_migrations' AND column_name IN ('version', 'dirty')
+	`
+	if m.SQLite {
+		columns = `SELECT count(*) FROM pragma_table_info('schema_migrations') WHERE name IN ('version', 'dirty')`
+	}
+
+	var n int
+	if err := conn.QueryRowContext(ctx, columns).Scan(&n); err != nil {
+		return err
+	}
+
+	if n != 2 {
+		return nil
+	}
+
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations`).Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "error reading golang-migrate's schema_migrations table")
+	}
+
+	if dirty {
+		return errors.Errorf("golang-migrate left migration %d dirty; fix the schema and clear schema_migrations.dirty before migrating", version)
+	}
+
+	// An unknown version is recorded without a name, so that it's reported
+	// like any other migration from a newer release.
+	adopted := []Migration{{Version: version}}
+	for _, migration := range m.Migrations {
+		if migration.Version < version {
+			adopted = append(adopted, migration)
+		} else if migration.Version == version {
+			adopted[0] = migration
+		}
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, migration := range adopted {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO iam_schema_migrations (version, name, apply_time) VALUES ($1, $2, $3)
+		`, migration.Version, migration.Name, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	return inTx(ctx, conn, migration.Up, `
+		INSERT INTO iam_schema_migrations (version, name, apply_time) VALUES ($1, $2, $3)
+	`, migration.Version, migration.Name, time.Now())
+}
+
+func rollBack(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	return inTx(ctx, conn, migration.Down, `
+		DELETE FROM iam_schema_migrations WHERE version = $1
+	`, migration.Version)
+}
+
+// inTx runs a migration's SQL and the query recording it in one transaction.
+func inTx(ctx context.Context, conn *sql.Conn, migration, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
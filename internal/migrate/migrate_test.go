@@ -0,0 +1,263 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_a", Up: "CREATE TABLE a (id INTEGER);", Down: "DROP TABLE a;"},
+	{Version: 2, Name: "create_b", Up: "CREATE TABLE b (id INTEGER);", Down: "DROP TABLE b;"},
+	{Version: 3, Name: "create_c", Up: "CREATE TABLE c (id INTEGER);", Down: "DROP TABLE c;"},
+}
+
+func file(s string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(s)}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []int64
+		wantErr bool
+	}{
+		{
+			name: "ordered by version",
+			fsys: fstest.MapFS{
+				"10_b.up.sql": file("up"), "10_b.down.sql": file("down"),
+				"9_a.up.sql": file("up"), "9_a.down.sql": file("down"),
+			},
+			want: []int64{9, 10},
+		},
+		{
+			name:    "missing down",
+			fsys:    fstest.MapFS{"1_a.up.sql": file("up")},
+			wantErr: true,
+		},
+		{
+			name:    "different names",
+			fsys:    fstest.MapFS{"1_a.up.sql": file("up"), "1_b.down.sql": file("down")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			fsys:    fstest.MapFS{"a.up.sql": file("up")},
+			wantErr: true,
+		},
+		{
+			name: "other files ignored",
+			fsys: fstest.MapFS{"1_a.up.sql": file("up"), "1_a.down.sql": file("down"), "README": file("")},
+			want: []int64{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.fsys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []int64
+			for _, migration := range migrations {
+				got = append(got, migration.Version)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() versions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestDB returns a SQLite database in a file, since each connection to an
+// in-memory database would get its own.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func applied(t *testing.T, m *Migrator) []int64 {
+	t.Helper()
+
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+
+	var versions []int64
+	for _, status := range statuses {
+		if status.ApplyTime != nil {
+			versions = append(versions, status.Version)
+		}
+	}
+
+	return versions
+}
+
+func versions(migrations []Migration) []int64 {
+	var out []int64
+	for _, migration := range migrations {
+		out = append(out, migration.Version)
+	}
+
+	return out
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	m := &Migrator{DB: newTestDB(t), Migrations: testMigrations, SQLite: true}
+
+	steps := []struct {
+		name        string
+		run         func() ([]Migration, error)
+		wantDone    []int64
+		wantApplied []int64
+	}{
+		{"up", func() ([]Migration, error) { return m.Up(ctx) }, []int64{1, 2, 3}, []int64{1, 2, 3}},
+		{"up again", func() ([]Migration, error) { return m.Up(ctx) }, nil, []int64{1, 2, 3}},
+		{"down", func() ([]Migration, error) {
+			migration, err := m.Down(ctx)
+			if migration == nil {
+				return nil, err
+			}
+
+			return []Migration{*migration}, err
+		}, []int64{3}, []int64{1, 2}},
+		{"to 1", func() ([]Migration, error) { return m.To(ctx, 1) }, []int64{2}, []int64{1}},
+		{"to 3", func() ([]Migration, error) { return m.To(ctx, 3) }, []int64{2, 3}, []int64{1, 2, 3}},
+		{"to 0", func() ([]Migration, error) { return m.To(ctx, 0) }, []int64{3, 2, 1}, nil},
+	}
+
+	for _, step := range steps {
+		done, err := step.run()
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		if got := versions(done); !reflect.DeepEqual(got, step.wantDone) {
+			t.Errorf("%s: did %v, want %v", step.name, got, step.wantDone)
+		}
+
+		if got := applied(t, m); !reflect.DeepEqual(got, step.wantApplied) {
+			t.Errorf("%s: applied %v, want %v", step.name, got, step.wantApplied)
+		}
+	}
+
+	if _, err := m.To(ctx, 4); err == nil {
+		t.Error("To(unknown version) succeeded")
+	}
+}
+
+func TestMigratorRollsBackFailedMigration(t *testing.T) {
+	ctx := context.Background()
+	broken := append([]Migration(nil), testMigrations[:2]...)
+	broken[1].Up = "CREATE TABLE b (id INTEGER); INSERT INTO missing VALUES (1);"
+
+	m := &Migrator{DB: newTestDB(t), Migrations: broken, SQLite: true}
+	if _, err := m.Up(ctx); err == nil {
+		t.Fatal("Up succeeded")
+	}
+
+	if got := applied(t, m); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("applied %v, want [1]", got)
+	}
+
+	// The failed migration's table must not survive it.
+	m.Migrations = testMigrations[:2]
+	if _, err := m.Up(ctx); err != nil {
+		t.Errorf("Up after fixing the migration: %v", err)
+	}
+}
+
+func TestMigratorRefusesUnknownVersions(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	newer := &Migrator{DB: db, Migrations: testMigrations, SQLite: true}
+	if _, err := newer.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	older := &Migrator{DB: db, Migrations: testMigrations[:2], SQLite: true}
+	if _, err := older.Up(ctx); err == nil {
+		t.Error("Up succeeded with a newer migration applied")
+	}
+
+	if _, err := older.Down(ctx); err == nil {
+		t.Error("Down succeeded with a newer migration applied")
+	}
+}
+
+func TestAdoptGolangMigrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     int64
+		dirty       bool
+		wantApplied []int64
+		wantErr     bool
+	}{
+		{name: "clean", version: 2, wantApplied: []int64{1, 2}},
+		{name: "dirty", version: 2, dirty: true, wantErr: true},
+		{name: "newer release", version: 4, wantApplied: []int64{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			if _, err := db.Exec(`CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := db.Exec(`INSERT INTO schema_migrations VALUES ($1, $2)`, tt.version, tt.dirty); err != nil {
+				t.Fatal(err)
+			}
+
+			m := &Migrator{DB: db, Migrations: testMigrations, SQLite: true}
+			statuses, err := m.Status(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Status() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []int64
+			for _, status := range statuses {
+				if status.ApplyTime != nil {
+					got = append(got, status.Version)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.wantApplied) {
+				t.Errorf("applied %v, want %v", got, tt.wantApplied)
+			}
+
+			// golang-migrate's table is left alone.
+			var version int64
+			if err := db.QueryRow(`SELECT version FROM schema_migrations`).Scan(&version); err != nil || version != tt.version {
+				t.Errorf("schema_migrations version = %d, %v, want %d", version, err, tt.version)
+			}
+		})
+	}
+}
@@ -7,7 +7,9 @@ type Account struct {
 	CreateTime time.Time
 	UpdateTime time.Time
 	DeleteTime *time.Time
+	Etag       string
 
-	DisplayName string
-	Root        string
+	DisplayName  string
+	Root         string
+	Organization string
 }
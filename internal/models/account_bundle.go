@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// AccountBundleVersion is the version of the AccountBundle format that
+// ExportAccount writes and ImportAccount reads.
+const AccountBundleVersion = 1
+
+// AccountBundle is a portable copy of an account, used to clone it into
+// another deployment or to hand its data to its owner.
+type AccountBundle struct {
+	Version        int
+	ExportTime     time.Time
+	Account        Account
+	PasswordPolicy *PasswordPolicy
+	Users          []BundledUser
+}
+
+type BundledUser struct {
+	User       User
+	Identities []BundledIdentity
+}
+
+type BundledIdentity struct {
+	Identity     Identity
+	PasswordHash string
+}
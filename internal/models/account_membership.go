@@ -0,0 +1,8 @@
+package models
+
+// AccountMembership is an account that a principal is linked to, and the user
+// it is linked to there.
+type AccountMembership struct {
+	Account Account
+	User    string
+}
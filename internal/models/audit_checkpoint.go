@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type AuditCheckpoint struct {
+	Name       string
+	CreateTime time.Time
+
+	Account   string
+	Sequence  int64
+	Hash      []byte
+	Signature []byte
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type AuditEvent struct {
+	Name       string
+	CreateTime time.Time
+
+	Actor    string
+	Account  string
+	Resource string
+	Method   string
+	SourceIP string
+	Outcome  AuditOutcome
+
+	// ActorDigest and ResourceDigest are set when Actor or Resource has been
+	// pseudonymized since the event was chained. They hold the digest of the
+	// original value, which the chain hash is computed over.
+	ActorDigest    []byte
+	ResourceDigest []byte
+
+	Sequence int64
+	PrevHash []byte
+	Hash     []byte
+}
@@ -0,0 +1,8 @@
+package models
+
+type AuditOutcome int
+
+const (
+	AuditOutcomeSuccess AuditOutcome = 1
+	AuditOutcomeFailure AuditOutcome = 2
+)
@@ -7,6 +7,7 @@ type Identity struct {
 	CreateTime time.Time
 	UpdateTime time.Time
 	DeleteTime *time.Time
+	Etag       string
 
 	AuthMethod AuthMethod
 	Password   string
@@ -0,0 +1,12 @@
+package models
+
+// ImportConflict says what ImportAccount does with a bundled user whose name
+// is already taken in the target account.
+type ImportConflict int
+
+const (
+	ImportConflictFail      ImportConflict = 1
+	ImportConflictSkip      ImportConflict = 2
+	ImportConflictOverwrite ImportConflict = 3
+	ImportConflictRename    ImportConflict = 4
+)
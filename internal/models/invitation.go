@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Invitation invites someone to join an account as a new user. The invitee
+// accepts it with a single-use code emailed to them, choosing their own
+// password. Only a hash of the code is stored.
+type Invitation struct {
+	Name       string
+	CreateTime time.Time
+	UpdateTime time.Time
+	DeleteTime *time.Time
+	ExpireTime time.Time
+	AcceptTime *time.Time
+
+	Email string
+
+	// User is the user to create when the invitation is accepted. Only its
+	// name, display name and labels are used.
+	User User
+
+	Inviter  string
+	CodeHash []byte
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type LoginThrottle struct {
+	Key             string
+	Failures        int
+	LastFailureTime time.Time
+	LockedUntil     *time.Time
+}
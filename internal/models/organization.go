@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Organization owns a group of accounts. Its admins are principals, and have
+// the same permissions as the root user in each of its accounts.
+type Organization struct {
+	Name       string
+	CreateTime time.Time
+	UpdateTime time.Time
+	DeleteTime *time.Time
+	Etag       string
+
+	DisplayName string
+	Admins      []string
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type PasswordPolicy struct {
+	Name       string
+	UpdateTime time.Time
+
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	HistorySize      int
+	MaxAge           time.Duration
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Principal is a person who can be linked to users in several accounts, and
+// authenticate as any of them with one set of credentials.
+type Principal struct {
+	Name       string
+	CreateTime time.Time
+	UpdateTime time.Time
+	DeleteTime *time.Time
+	Etag       string
+
+	DisplayName string
+	Email       string
+}
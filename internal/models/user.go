@@ -7,7 +7,16 @@ type User struct {
 	CreateTime time.Time
 	UpdateTime time.Time
 	DeleteTime *time.Time
+	EraseTime  *time.Time
+	Etag       string
 
-	IsRoot      bool
-	DisplayName string
+	IsRoot        bool
+	DisplayName   string
+	Email         string
+	EmailVerified bool
+	Phone         string
+	Locale        string
+	Labels        map[string]string
+	Annotations   map[string]string
+	Principal     string
 }
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// UserToken is a single-use secret sent to a user's email address. Only a hash
+// of the secret is stored.
+type UserToken struct {
+	Account    string
+	User       string
+	Purpose    UserTokenPurpose
+	TokenHash  []byte
+	Email      string
+	CreateTime time.Time
+	ExpireTime time.Time
+}
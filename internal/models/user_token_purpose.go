@@ -0,0 +1,8 @@
+package models
+
+type UserTokenPurpose int
+
+const (
+	UserTokenPurposePasswordReset     UserTokenPurpose = 1
+	UserTokenPurposeEmailVerification UserTokenPurpose = 2
+)
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Webhook struct {
+	Name       string
+	CreateTime time.Time
+	UpdateTime time.Time
+	DeleteTime *time.Time
+
+	URL        string
+	EventTypes []string
+	Secret     string
+}
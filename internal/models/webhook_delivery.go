@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type WebhookDelivery struct {
+	Name       string
+	CreateTime time.Time
+
+	EventType string
+	Payload   []byte
+	Attempts  int
+	LastError string
+
+	URL    string
+	Secret string
+}
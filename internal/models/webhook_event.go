@@ -0,0 +1,25 @@
+package models
+
+const (
+	WebhookEventUserCreated      = "user.created"
+	WebhookEventUserDeleted      = "user.deleted"
+	WebhookEventUserUndeleted    = "user.undeleted"
+	WebhookEventUserErased       = "user.erased"
+	WebhookEventIdentityCreated  = "identity.created"
+	WebhookEventAccountUpdated   = "account.updated"
+	WebhookEventAccountDeleted   = "account.deleted"
+	WebhookEventAccountUndeleted = "account.undeleted"
+	WebhookEventLoginFailed      = "login.failed"
+)
+
+var WebhookEventTypes = []string{
+	WebhookEventUserCreated,
+	WebhookEventUserDeleted,
+	WebhookEventUserUndeleted,
+	WebhookEventUserErased,
+	WebhookEventIdentityCreated,
+	WebhookEventAccountUpdated,
+	WebhookEventAccountDeleted,
+	WebhookEventAccountUndeleted,
+	WebhookEventLoginFailed,
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+)
+
+// File appends each message to Path as a line of JSON. It's meant for
+// development and tests, where a real mail server isn't available.
+type File struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func (f *File) Notify(ctx context.Context, m Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewEncoder(file).Encode(m); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Log writes each message to the standard logger.
+type Log struct{}
+
+func (Log) Notify(ctx context.Context, m Message) error {
+	log.Printf("notify: to=%s subject=%q body=%q", m.To, m.Subject, m.Body)
+	return nil
+}
@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notify")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	f := &File{Path: filepath.Join(dir, "messages.jsonl")}
+	want := []Message{
+		{To: "alice@example.com", Subject: "Reset your password", Body: "token-1\n"},
+		{To: "bob@example.com", Subject: "Verify your email address", Body: "token-2\n"},
+	}
+
+	for _, m := range want {
+		if err := f.Notify(context.Background(), m); err != nil {
+			t.Fatalf("Notify: %v", err)
+		}
+	}
+
+	b, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Message
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var m Message
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("line %q: %v", line, err)
+		}
+
+		got = append(got, m)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
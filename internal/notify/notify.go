@@ -0,0 +1,14 @@
+// Package notify sends messages, such as password reset links, to users.
+package notify
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Notify(context.Context, Message) error
+}
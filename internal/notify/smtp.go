@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type SMTP struct {
+	Addr string
+	From string
+	Auth smtp.Auth
+}
+
+func (s *SMTP) Notify(ctx context.Context, m Message) error {
+	if strings.ContainsAny(m.To, "\r\n") || strings.ContainsAny(m.Subject, "\r\n") {
+		return fmt.Errorf("invalid message header")
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", m.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", m.Subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&msg, "\r\n%s\r\n", strings.Replace(m.Body, "\n", "\r\n", -1))
+
+	return smtp.SendMail(s.Addr, s.Auth, s.From, []string{m.To}, msg.Bytes())
+}
@@ -0,0 +1,101 @@
+package passhash
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var DefaultArgon2id = Argon2id{Time: 3, Memory: 64 * 1024, Threads: 4, SaltLength: 16, KeyLength: 32}
+
+// Bounds on argon2id parameters. They apply to stored hashes as well as to
+// new ones, so that a hash can't make Verify use unbounded memory or time.
+const (
+	maxArgon2Time    = 16
+	maxArgon2Memory  = 1 << 18 // KiB, or 256 MiB
+	maxArgon2Threads = 255
+)
+
+// Argon2id stores hashes as "$argon2id$v=19$m=65536,t=3,p=4$salt$hash", where m
+// is in KiB.
+type Argon2id struct {
+	Time       uint32
+	Memory     uint32
+	Threads    uint8
+	SaltLength int
+	KeyLength  uint32
+}
+
+// Validate reports whether a's parameters are within bounds.
+func (a Argon2id) Validate() error {
+	if err := checkArgon2id(int(a.Time), int(a.Memory), int(a.Threads), int(a.KeyLength)); err != nil {
+		return err
+	}
+
+	return checkSaltLength(a.SaltLength)
+}
+
+func (a Argon2id) Hash(password string) (string, error) {
+	if err := a.Validate(); err != nil {
+		return "", err
+	}
+
+	salt, err := salt(a.SaltLength)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.Time, a.Memory, a.Threads, a.KeyLength)
+	encoded := phc{
+		id:     "argon2id",
+		params: map[string]int{"m": int(a.Memory), "t": int(a.Time), "p": int(a.Threads)},
+		salt:   salt,
+		hash:   key,
+	}.String()
+
+	// Insert the version field that other argon2 implementations expect.
+	return "$argon2id$v=19" + encoded[len("$argon2id"):], nil
+}
+
+func (a Argon2id) Verify(encoded, password string) (bool, error) {
+	p, err := parsePHC("argon2id", encoded)
+	if err != nil {
+		return false, err
+	}
+
+	if err := checkArgon2id(p.params["t"], p.params["m"], p.params["p"], len(p.hash)); err != nil {
+		return false, err
+	}
+
+	key := argon2.IDKey([]byte(password), p.salt, uint32(p.params["t"]), uint32(p.params["m"]),
+		uint8(p.params["p"]), uint32(len(p.hash)))
+
+	return equal(key, p.hash), nil
+}
+
+func (a Argon2id) NeedsRehash(encoded string) bool {
+	p, err := parsePHC("argon2id", encoded)
+	if err != nil {
+		return true
+	}
+
+	return uint32(p.params["m"]) != a.Memory || uint32(p.params["t"]) != a.Time ||
+		uint8(p.params["p"]) != a.Threads || len(p.salt) != a.SaltLength ||
+		uint32(len(p.hash)) != a.KeyLength
+}
+
+func checkArgon2id(time, memory, threads, keyLength int) error {
+	if time < 1 || time > maxArgon2Time {
+		return fmt.Errorf("argon2id time must be between 1 and %d, got %d", maxArgon2Time, time)
+	}
+
+	if threads < 1 || threads > maxArgon2Threads {
+		return fmt.Errorf("argon2id threads must be between 1 and %d, got %d", maxArgon2Threads, threads)
+	}
+
+	if memory < 8*threads || memory > maxArgon2Memory {
+		return fmt.Errorf("argon2id memory must be between 8 KiB per thread and %d KiB, got %d", maxArgon2Memory, memory)
+	}
+
+	return checkKeyLength(keyLength)
+}
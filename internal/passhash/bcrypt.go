@@ -0,0 +1,78 @@
+package passhash
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var DefaultBcrypt = Bcrypt{Cost: bcrypt.DefaultCost}
+
+// maxBcryptCost bounds the cost of new and stored bcrypt hashes. Each step
+// doubles the time a hash takes.
+const maxBcryptCost = 16
+
+// Bcrypt stores hashes in bcrypt's own modular crypt format, such as
+// "$2a$10$...", which is what the service has always stored.
+type Bcrypt struct {
+	Cost int
+}
+
+// Validate reports whether b's cost is within bounds.
+func (b Bcrypt) Validate() error {
+	return checkBcryptCost(b.Cost)
+}
+
+func (b Bcrypt) Hash(password string) (string, error) {
+	if err := b.Validate(); err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	return string(hash), err
+}
+
+func (b Bcrypt) Verify(encoded, password string) (bool, error) {
+	if !isBcrypt(encoded) {
+		return false, ErrUnsupported
+	}
+
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return false, err
+	}
+
+	if err := checkBcryptCost(cost); err != nil {
+		return false, err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (b Bcrypt) NeedsRehash(encoded string) bool {
+	if !isBcrypt(encoded) {
+		return true
+	}
+
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != b.Cost
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+func checkBcryptCost(cost int) error {
+	if cost < bcrypt.MinCost || cost > maxBcryptCost {
+		return fmt.Errorf("bcrypt cost must be between %d and %d, got %d", bcrypt.MinCost, maxBcryptCost, cost)
+	}
+
+	return nil
+}
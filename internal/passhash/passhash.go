@@ -0,0 +1,233 @@
+// Package passhash hashes passwords into self-describing PHC strings, so that
+// hashes made with different algorithms or parameters can coexist.
+package passhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrUnsupported is returned by Verify for hashes in a format the hasher
+// doesn't recognize.
+var ErrUnsupported = errors.New("unsupported password hash format")
+
+type Hasher interface {
+	Hash(password string) (string, error)
+	Verify(encoded, password string) (bool, error)
+
+	// NeedsRehash reports whether encoded was made with a different algorithm
+	// or different parameters than Hash would use now.
+	NeedsRehash(encoded string) bool
+}
+
+// Set hashes new passwords with Preferred, and verifies hashes made by
+// Preferred or any of Accepted.
+type Set struct {
+	Preferred Hasher
+	Accepted  []Hasher
+}
+
+func (s Set) Hash(password string) (string, error) {
+	return s.Preferred.Hash(password)
+}
+
+func (s Set) Verify(encoded, password string) (bool, error) {
+	for _, h := range append([]Hasher{s.Preferred}, s.Accepted...) {
+		ok, err := h.Verify(encoded, password)
+		if err == ErrUnsupported {
+			continue
+		}
+
+		return ok, err
+	}
+
+	return false, ErrUnsupported
+}
+
+func (s Set) NeedsRehash(encoded string) bool {
+	return s.Preferred.NeedsRehash(encoded)
+}
+
+// New returns a Set that hashes with the named algorithm and accepts hashes
+// from all of bcrypt, scrypt and argon2id.
+func New(algorithm string, b Bcrypt, s Scrypt, a Argon2id) (Set, error) {
+	hashers := map[string]Hasher{
+		"bcrypt":   b,
+		"scrypt":   s,
+		"argon2id": a,
+	}
+
+	preferred, ok := hashers[algorithm]
+	if !ok {
+		return Set{}, fmt.Errorf("unknown password hash algorithm: %s", algorithm)
+	}
+
+	for _, err := range []error{b.Validate(), s.Validate(), a.Validate()} {
+		if err != nil {
+			return Set{}, err
+		}
+	}
+
+	set := Set{Preferred: preferred}
+	for _, name := range []string{"bcrypt", "scrypt", "argon2id"} {
+		if name != algorithm {
+			set.Accepted = append(set.Accepted, hashers[name])
+		}
+	}
+
+	return set, nil
+}
+
+// Check reports whether encoded is a well-formed bcrypt, scrypt or argon2id
+// hash with parameters that Verify would accept. It suits hashes that come
+// from elsewhere, such as an imported account.
+func Check(encoded string) error {
+	if isBcrypt(encoded) {
+		cost, err := bcrypt.Cost([]byte(encoded))
+		if err != nil {
+			return err
+		}
+
+		return checkBcryptCost(cost)
+	}
+
+	for _, id := range []string{"scrypt", "argon2id"} {
+		p, err := parsePHC(id, encoded)
+		if err == ErrUnsupported {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if err := checkSaltLength(len(p.salt)); err != nil {
+			return err
+		}
+
+		if id == "scrypt" {
+			return checkScrypt(p.params["ln"], p.params["r"], p.params["p"], len(p.hash))
+		}
+
+		return checkArgon2id(p.params["t"], p.params["m"], p.params["p"], len(p.hash))
+	}
+
+	return ErrUnsupported
+}
+
+// Default hashes with bcrypt at its default cost, as the service always has.
+var Default, _ = New("bcrypt", DefaultBcrypt, DefaultScrypt, DefaultArgon2id)
+
+// phc is a parsed "$id$param=value,...$salt$hash" string.
+type phc struct {
+	id     string
+	params map[string]int
+	salt   []byte
+	hash   []byte
+}
+
+var b64 = base64.RawStdEncoding
+
+func (p phc) String() string {
+	var params []string
+	for _, key := range p.paramOrder() {
+		params = append(params, fmt.Sprintf("%s=%d", key, p.params[key]))
+	}
+
+	return fmt.Sprintf("$%s$%s$%s$%s", p.id, strings.Join(params, ","), b64.EncodeToString(p.salt), b64.EncodeToString(p.hash))
+}
+
+func (p phc) paramOrder() []string {
+	switch p.id {
+	case "argon2id":
+		return []string{"m", "t", "p"}
+	case "scrypt":
+		return []string{"ln", "r", "p"}
+	}
+
+	return nil
+}
+
+func parsePHC(id, encoded string) (phc, error) {
+	fields := strings.Split(encoded, "$")
+	if len(fields) < 5 || fields[0] != "" || fields[1] != id {
+		return phc{}, ErrUnsupported
+	}
+
+	// Skip the optional version field, as in "$argon2id$v=19$m=...".
+	if len(fields) == 6 {
+		fields = append(fields[:2], fields[3:]...)
+	}
+
+	if len(fields) != 5 {
+		return phc{}, ErrUnsupported
+	}
+
+	p := phc{id: id, params: map[string]int{}}
+	for _, param := range strings.Split(fields[2], ",") {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			return phc{}, fmt.Errorf("invalid password hash parameter: %q", param)
+		}
+
+		value, err := strconv.Atoi(kv[1])
+		if err != nil {
+			return phc{}, fmt.Errorf("invalid password hash parameter: %q", param)
+		}
+
+		p.params[kv[0]] = value
+	}
+
+	var err error
+	if p.salt, err = b64.DecodeString(fields[3]); err != nil {
+		return phc{}, err
+	}
+
+	if p.hash, err = b64.DecodeString(fields[4]); err != nil {
+		return phc{}, err
+	}
+
+	return p, nil
+}
+
+func salt(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Bounds on salt and key lengths, shared by scrypt and argon2id.
+const (
+	minSaltLength = 8
+	maxSaltLength = 64
+	minKeyLength  = 16
+	maxKeyLength  = 64
+)
+
+func checkSaltLength(n int) error {
+	if n < minSaltLength || n > maxSaltLength {
+		return fmt.Errorf("salt length must be between %d and %d bytes, got %d", minSaltLength, maxSaltLength, n)
+	}
+
+	return nil
+}
+
+func checkKeyLength(n int) error {
+	if n < minKeyLength || n > maxKeyLength {
+		return fmt.Errorf("key length must be between %d and %d bytes, got %d", minKeyLength, maxKeyLength, n)
+	}
+
+	return nil
+}
+
+func equal(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
@@ -0,0 +1,223 @@
+package passhash
+
+import (
+	"strings"
+	"testing"
+)
+
+// Cheap parameters, so the tests run quickly.
+var (
+	testBcrypt   = Bcrypt{Cost: 4}
+	testScrypt   = Scrypt{N: 1 << 4, R: 8, P: 1, SaltLength: 16, KeyLength: 32}
+	testArgon2id = Argon2id{Time: 1, Memory: 64, Threads: 1, SaltLength: 16, KeyLength: 32}
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		hasher Hasher
+		prefix string
+	}{
+		{"bcrypt", testBcrypt, "$2a$04$"},
+		{"scrypt", testScrypt, "$scrypt$ln=4,r=8,p=1$"},
+		{"argon2id", testArgon2id, "$argon2id$v=19$m=64,t=1,p=1$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.hasher.Hash("hunter2")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.HasPrefix(encoded, tt.prefix) {
+				t.Errorf("Hash() = %q, want prefix %q", encoded, tt.prefix)
+			}
+
+			if ok, err := tt.hasher.Verify(encoded, "hunter2"); !ok || err != nil {
+				t.Errorf("Verify(right password) = %v, %v, want true", ok, err)
+			}
+
+			if ok, err := tt.hasher.Verify(encoded, "hunter3"); ok || err != nil {
+				t.Errorf("Verify(wrong password) = %v, %v, want false", ok, err)
+			}
+
+			if tt.hasher.NeedsRehash(encoded) {
+				t.Error("NeedsRehash() = true for a hash made with the same parameters")
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	set, err := New("argon2id", testBcrypt, testScrypt, testArgon2id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, h := range []Hasher{testBcrypt, testScrypt, testArgon2id} {
+		encoded, err := h.Hash("hunter2")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ok, err := set.Verify(encoded, "hunter2"); !ok || err != nil {
+			t.Errorf("Verify(%q) = %v, %v, want true", encoded, ok, err)
+		}
+
+		wantRehash := h != Hasher(testArgon2id)
+		if got := set.NeedsRehash(encoded); got != wantRehash {
+			t.Errorf("NeedsRehash(%q) = %v, want %v", encoded, got, wantRehash)
+		}
+	}
+
+	if _, err := set.Verify("plaintext", "plaintext"); err != ErrUnsupported {
+		t.Errorf("Verify(unknown format) error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		b         Bcrypt
+		s         Scrypt
+		a         Argon2id
+		wantErr   bool
+	}{
+		{"defaults", "bcrypt", DefaultBcrypt, DefaultScrypt, DefaultArgon2id, false},
+		{"unknown algorithm", "md5", DefaultBcrypt, DefaultScrypt, DefaultArgon2id, true},
+		{"bcrypt cost too high", "bcrypt", Bcrypt{Cost: 31}, DefaultScrypt, DefaultArgon2id, true},
+		{"scrypt N not a power of two", "bcrypt", DefaultBcrypt, Scrypt{N: 1000, R: 8, P: 1, SaltLength: 16, KeyLength: 32}, DefaultArgon2id, true},
+		{"scrypt memory too high", "bcrypt", DefaultBcrypt, Scrypt{N: 1 << 20, R: 32, P: 1, SaltLength: 16, KeyLength: 32}, DefaultArgon2id, true},
+		{"argon2id zero threads", "bcrypt", DefaultBcrypt, DefaultScrypt, Argon2id{Time: 3, Memory: 64 * 1024, SaltLength: 16, KeyLength: 32}, true},
+		{"argon2id memory too high", "bcrypt", DefaultBcrypt, DefaultScrypt, Argon2id{Time: 3, Memory: 1 << 30, Threads: 4, SaltLength: 16, KeyLength: 32}, true},
+		{"argon2id short salt", "bcrypt", DefaultBcrypt, DefaultScrypt, Argon2id{Time: 3, Memory: 64 * 1024, Threads: 4, SaltLength: 4, KeyLength: 32}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.algorithm, tt.b, tt.s, tt.a)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsOutOfBoundsParameters(t *testing.T) {
+	const salt, key = "c2FsdHNhbHRzYWx0c2FsdA", "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+
+	tests := []struct {
+		name    string
+		hasher  Hasher
+		encoded string
+	}{
+		{"argon2id huge memory", testArgon2id, "$argon2id$v=19$m=4294967295,t=1,p=1$" + salt + "$" + key},
+		{"argon2id huge time", testArgon2id, "$argon2id$v=19$m=64,t=1000000,p=1$" + salt + "$" + key},
+		{"argon2id zero threads", testArgon2id, "$argon2id$v=19$m=64,t=1,p=0$" + salt + "$" + key},
+		{"argon2id too many threads", testArgon2id, "$argon2id$v=19$m=4096,t=1,p=256$" + salt + "$" + key},
+		{"argon2id short key", testArgon2id, "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$a2V5"},
+		{"scrypt huge ln", testScrypt, "$scrypt$ln=40,r=8,p=1$" + salt + "$" + key},
+		{"scrypt huge r", testScrypt, "$scrypt$ln=4,r=1000,p=1$" + salt + "$" + key},
+		{"scrypt huge p", testScrypt, "$scrypt$ln=4,r=8,p=1000$" + salt + "$" + key},
+		{"bcrypt huge cost", testBcrypt, "$2a$31$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := tt.hasher.Verify(tt.encoded, "hunter2")
+			if ok || err == nil || err == ErrUnsupported {
+				t.Errorf("Verify() = %v, %v, want a parameter error", ok, err)
+			}
+		})
+	}
+}
+
+func TestVerifyUnsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		hasher  Hasher
+		encoded string
+	}{
+		{"bcrypt given argon2id", testBcrypt, "$argon2id$v=19$m=64,t=1,p=1$c2FsdA$a2V5"},
+		{"scrypt given bcrypt", testScrypt, "$2a$04$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"},
+		{"argon2id given scrypt", testArgon2id, "$scrypt$ln=4,r=8,p=1$c2FsdA$a2V5"},
+		{"argon2id given garbage", testArgon2id, "argon2id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.hasher.Verify(tt.encoded, "hunter2"); err != ErrUnsupported {
+				t.Errorf("Verify() error = %v, want ErrUnsupported", err)
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	encoded, err := testArgon2id.Hash("hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		hasher Hasher
+		want   bool
+	}{
+		{"same parameters", testArgon2id, false},
+		{"more time", Argon2id{Time: 2, Memory: 64, Threads: 1, SaltLength: 16, KeyLength: 32}, true},
+		{"more memory", Argon2id{Time: 1, Memory: 128, Threads: 1, SaltLength: 16, KeyLength: 32}, true},
+		{"longer key", Argon2id{Time: 1, Memory: 64, Threads: 1, SaltLength: 16, KeyLength: 64}, true},
+		{"different algorithm", testScrypt, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(encoded); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var valid []string
+	for _, h := range []Hasher{testBcrypt, testScrypt, testArgon2id} {
+		encoded, err := h.Hash("hunter2")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		valid = append(valid, encoded)
+	}
+
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr bool
+	}{
+		{"bcrypt", valid[0], false},
+		{"scrypt", valid[1], false},
+		{"argon2id", valid[2], false},
+		{"argon2id without version", "$argon2id$m=64,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U", false},
+		{"plaintext", "hunter2", true},
+		{"md5 crypt", "$1$salt$qJH7.N4xYta3aEG/dfqo/0", true},
+		{"truncated bcrypt", "$2a$10$N9qo8uLOickgx2ZMRZo", true},
+		{"bcrypt cost too high", "$2a$31$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", true},
+		{"argon2id missing parameter", "$argon2id$v=19$m=64,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U", true},
+		{"argon2id huge memory", "$argon2id$v=19$m=4194304,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U", true},
+		{"argon2id short salt", "$argon2id$v=19$m=64,t=1,p=1$c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U", true},
+		{"scrypt bad base64", "$scrypt$ln=4,r=8,p=1$!!!$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U", true},
+		{"scrypt huge ln", "$scrypt$ln=40,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Check(tt.encoded); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
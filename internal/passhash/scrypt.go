@@ -0,0 +1,113 @@
+package passhash
+
+import (
+	"fmt"
+	"math/bits"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+var DefaultScrypt = Scrypt{N: 1 << 15, R: 8, P: 1, SaltLength: 16, KeyLength: 32}
+
+// Bounds on scrypt parameters, which like argon2id's apply to stored hashes
+// too. scrypt uses 128*N*r bytes of memory.
+const (
+	maxScryptLogN   = 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxScryptMemory = 256 << 20
+)
+
+// Scrypt stores hashes as "$scrypt$ln=15,r=8,p=1$salt$hash", where ln is
+// log2(N).
+type Scrypt struct {
+	N          int
+	R          int
+	P          int
+	SaltLength int
+	KeyLength  int
+}
+
+// Validate reports whether s's parameters are within bounds.
+func (s Scrypt) Validate() error {
+	if s.N < 2 || s.N&(s.N-1) != 0 {
+		return fmt.Errorf("scrypt N must be a power of two greater than one, got %d", s.N)
+	}
+
+	if err := checkScrypt(bits.TrailingZeros(uint(s.N)), s.R, s.P, s.KeyLength); err != nil {
+		return err
+	}
+
+	return checkSaltLength(s.SaltLength)
+}
+
+func (s Scrypt) Hash(password string) (string, error) {
+	if err := s.Validate(); err != nil {
+		return "", err
+	}
+
+	salt, err := salt(s.SaltLength)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, s.N, s.R, s.P, s.KeyLength)
+	if err != nil {
+		return "", err
+	}
+
+	return phc{
+		id:     "scrypt",
+		params: map[string]int{"ln": bits.TrailingZeros(uint(s.N)), "r": s.R, "p": s.P},
+		salt:   salt,
+		hash:   key,
+	}.String(), nil
+}
+
+func (s Scrypt) Verify(encoded, password string) (bool, error) {
+	p, err := parsePHC("scrypt", encoded)
+	if err != nil {
+		return false, err
+	}
+
+	if err := checkScrypt(p.params["ln"], p.params["r"], p.params["p"], len(p.hash)); err != nil {
+		return false, err
+	}
+
+	key, err := scrypt.Key([]byte(password), p.salt, 1<<uint(p.params["ln"]), p.params["r"], p.params["p"], len(p.hash))
+	if err != nil {
+		return false, err
+	}
+
+	return equal(key, p.hash), nil
+}
+
+func (s Scrypt) NeedsRehash(encoded string) bool {
+	p, err := parsePHC("scrypt", encoded)
+	if err != nil {
+		return true
+	}
+
+	return 1<<uint(p.params["ln"]) != s.N || p.params["r"] != s.R || p.params["p"] != s.P ||
+		len(p.salt) != s.SaltLength || len(p.hash) != s.KeyLength
+}
+
+func checkScrypt(logN, r, p, keyLength int) error {
+	if logN < 1 || logN > maxScryptLogN {
+		return fmt.Errorf("scrypt ln must be between 1 and %d, got %d", maxScryptLogN, logN)
+	}
+
+	if r < 1 || r > maxScryptR {
+		return fmt.Errorf("scrypt r must be between 1 and %d, got %d", maxScryptR, r)
+	}
+
+	if p < 1 || p > maxScryptP {
+		return fmt.Errorf("scrypt p must be between 1 and %d, got %d", maxScryptP, p)
+	}
+
+	if memory := 128 * r << uint(logN); memory > maxScryptMemory {
+		return fmt.Errorf("scrypt parameters need %d MiB of memory, more than %d MiB", memory>>20, maxScryptMemory>>20)
+	}
+
+	return checkKeyLength(keyLength)
+}
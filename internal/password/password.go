@@ -0,0 +1,85 @@
+// Package password checks passwords against an account's password policy.
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+// DefaultPolicy applies to accounts that haven't configured their own.
+var DefaultPolicy = models.PasswordPolicy{
+	MinLength: 8,
+}
+
+// Strictest returns a policy that is at least as strict as both a and b in
+// every respect. It keeps a's name and update time.
+func Strictest(a, b models.PasswordPolicy) models.PasswordPolicy {
+	if b.MinLength > a.MinLength {
+		a.MinLength = b.MinLength
+	}
+
+	a.RequireUppercase = a.RequireUppercase || b.RequireUppercase
+	a.RequireLowercase = a.RequireLowercase || b.RequireLowercase
+	a.RequireDigit = a.RequireDigit || b.RequireDigit
+	a.RequireSymbol = a.RequireSymbol || b.RequireSymbol
+
+	if b.HistorySize > a.HistorySize {
+		a.HistorySize = b.HistorySize
+	}
+
+	if b.MaxAge > 0 && (a.MaxAge == 0 || b.MaxAge < a.MaxAge) {
+		a.MaxAge = b.MaxAge
+	}
+
+	return a
+}
+
+// Check returns a description of each way password fails policy. Common
+// passwords are always rejected, whatever the policy.
+func Check(policy models.PasswordPolicy, password string) []string {
+	var violations []string
+
+	if n := utf8.RuneCountInString(password); n < policy.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", policy.MinLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+
+	if policy.RequireUppercase && !upper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+
+	if policy.RequireLowercase && !lower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+
+	if policy.RequireDigit && !digit {
+		violations = append(violations, "must contain a digit")
+	}
+
+	if policy.RequireSymbol && !symbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	if _, ok := common[strings.ToLower(password)]; ok {
+		violations = append(violations, "is too common")
+	}
+
+	return violations
+}
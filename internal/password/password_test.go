@@ -0,0 +1,93 @@
+package password
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func TestCheck(t *testing.T) {
+	strict := models.PasswordPolicy{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}
+
+	tests := []struct {
+		name     string
+		policy   models.PasswordPolicy
+		password string
+		want     []string
+	}{
+		{name: "default ok", policy: DefaultPolicy, password: "long enough"},
+		{name: "default short", policy: DefaultPolicy, password: "short", want: []string{"must be at least 8 characters"}},
+		{name: "length counts runes", policy: DefaultPolicy, password: "ééééééé", want: []string{"must be at least 8 characters"}},
+		{name: "common", policy: DefaultPolicy, password: "Password", want: []string{"is too common"}},
+		{name: "strict ok", policy: strict, password: "Tr0ub4dor&3"},
+		{
+			name:     "strict lowercase only",
+			policy:   strict,
+			password: "troubadours",
+			want:     []string{"must contain an uppercase letter", "must contain a digit", "must contain a symbol"},
+		},
+		{
+			name:     "strict every violation",
+			policy:   strict,
+			password: "1234",
+			want: []string{
+				"must be at least 10 characters",
+				"must contain an uppercase letter",
+				"must contain a lowercase letter",
+				"must contain a symbol",
+				"is too common",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Check(tt.policy, tt.password); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check(%q) = %q, want %q", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrictest(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b models.PasswordPolicy
+		want models.PasswordPolicy
+	}{
+		{
+			name: "takes stricter values",
+			a:    models.PasswordPolicy{Name: "a", MinLength: 8, RequireDigit: true, HistorySize: 5, MaxAge: 90 * 24 * time.Hour},
+			b:    models.PasswordPolicy{Name: "b", MinLength: 12, RequireSymbol: true, HistorySize: 3, MaxAge: 30 * 24 * time.Hour},
+			want: models.PasswordPolicy{Name: "a", MinLength: 12, RequireDigit: true, RequireSymbol: true, HistorySize: 5, MaxAge: 30 * 24 * time.Hour},
+		},
+		{
+			name: "zero max age means none",
+			a:    models.PasswordPolicy{MaxAge: 0},
+			b:    models.PasswordPolicy{MaxAge: time.Hour},
+			want: models.PasswordPolicy{MaxAge: time.Hour},
+		},
+		{
+			name: "keeps max age over none",
+			a:    models.PasswordPolicy{MaxAge: time.Hour},
+			b:    models.PasswordPolicy{MaxAge: 0},
+			want: models.PasswordPolicy{MaxAge: time.Hour},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Strictest(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Strictest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package purge permanently removes soft-deleted resources once they can no
+// longer be undeleted.
+package purge
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type Purger struct {
+	Store store.Store
+
+	// Retention is how long deleted resources are kept, and so how long they
+	// can be undeleted for.
+	Retention time.Duration
+}
+
+// Purge removes everything that was deleted more than Retention ago.
+func (p *Purger) Purge(ctx context.Context) error {
+	res, err := p.Store.PurgeDeleted(ctx, store.PurgeDeletedRequest{
+		DeletedBefore: time.Now().Add(-p.Retention),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if res.Accounts > 0 || res.Users > 0 || res.Identities > 0 {
+		log.Printf("purged %d accounts, %d users and %d identities", res.Accounts, res.Users, res.Identities)
+	}
+
+	return nil
+}
+
+// Run calls Purge every interval until ctx is done.
+func (p *Purger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Purge(ctx); err != nil {
+				log.Printf("error purging deleted resources: %v", err)
+			}
+		}
+	}
+}
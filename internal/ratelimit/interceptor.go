@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type Interceptor struct {
+	Limiter Limiter
+	Config  Config
+
+	// Principal returns the authenticated caller of the request in ctx, or ""
+	// for anonymous requests.
+	Principal func(context.Context) string
+}
+
+// Unary enforces the configured limit for each method separately per client
+// IP and, for authenticated requests, per principal.
+func (i *Interceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	limit := i.Config.limit(info.FullMethod)
+	if limit.Rate <= 0 {
+		return handler(ctx, req)
+	}
+
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	keys := []string{fmt.Sprintf("%s|ip|%s", info.FullMethod, ClientIP(ctx))}
+	if principal := i.Principal(ctx); principal != "" {
+		keys = append(keys, fmt.Sprintf("%s|principal|%s", info.FullMethod, principal))
+	}
+
+	for _, key := range keys {
+		ok, retryDelay, err := i.Limiter.Take(ctx, key, limit)
+		if err != nil {
+			// Fail open, so that an outage of a shared backend doesn't take
+			// down the service with it.
+			log.Printf("error checking rate limit: %v", err)
+			continue
+		}
+
+		if !ok {
+			return nil, exhausted(retryDelay)
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+func exhausted(retryDelay time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: ptypes.DurationProto(retryDelay),
+	}); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// ClientIP returns the address of the client that made the request in ctx.
+// Requests from the HTTP gateway arrive over loopback, so for those the
+// address the gateway appended to x-forwarded-for is used. Earlier entries
+// are supplied by the client and can't be trusted, and neither can the
+// header on requests from anywhere else.
+func ClientIP(ctx context.Context) string {
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.IsLoopback() {
+		if mdata, ok := metadata.FromIncomingContext(ctx); ok {
+			if forwarded := mdata["x-forwarded-for"]; len(forwarded) > 0 {
+				hops := strings.Split(forwarded[len(forwarded)-1], ",")
+				ip = strings.TrimSpace(hops[len(hops)-1])
+			}
+		}
+	}
+
+	return ip
+}
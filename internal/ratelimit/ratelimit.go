@@ -0,0 +1,92 @@
+// Package ratelimit enforces token-bucket rate limits on gRPC requests.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Limit describes a token bucket that refills at Rate tokens per second and
+// holds at most Burst tokens.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Limiter takes tokens from buckets identified by key. Implementations backed
+// by a shared store let several replicas enforce one limit.
+type Limiter interface {
+	// Take removes a token from the bucket for key. If the bucket is empty, it
+	// returns false and how long until a token will be available.
+	Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
+
+// Config maps full gRPC method names to limits. Methods without an entry use
+// Default. A limit with a zero Rate is not enforced.
+type Config struct {
+	Default Limit
+	Methods map[string]Limit
+}
+
+func (c Config) limit(method string) Limit {
+	if limit, ok := c.Methods[method]; ok {
+		return limit
+	}
+
+	return c.Default
+}
+
+// ParseMethods parses per-method limits of the form
+// "Authenticate=1:5,CreateAccount=0.1:3", where each limit is rate:burst.
+// Short method names are qualified with service.
+func ParseMethods(service, s string) (map[string]Limit, error) {
+	methods := map[string]Limit{}
+	if strings.TrimSpace(s) == "" {
+		return methods, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid rate limit: %q", entry)
+		}
+
+		limit, err := parseLimit(parts[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rate limit for %s", parts[0])
+		}
+
+		method := parts[0]
+		if !strings.HasPrefix(method, "/") {
+			method = fmt.Sprintf("/%s/%s", service, method)
+		}
+
+		methods[method] = limit
+	}
+
+	return methods, nil
+}
+
+func parseLimit(s string) (Limit, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("expected rate:burst, got %q", s)
+	}
+
+	rate, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return Limit{}, err
+	}
+
+	burst, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Limit{}, err
+	}
+
+	return Limit{Rate: rate, Burst: burst}, nil
+}
@@ -0,0 +1,194 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseMethods(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    map[string]Limit
+		wantErr bool
+	}{
+		{in: "", want: map[string]Limit{}},
+		{
+			in: "Authenticate=1:5, CreateAccount=0.1:3",
+			want: map[string]Limit{
+				"/iam.IAM/Authenticate":  {Rate: 1, Burst: 5},
+				"/iam.IAM/CreateAccount": {Rate: 0.1, Burst: 3},
+			},
+		},
+		{in: "/other.Svc/Call=2:2", want: map[string]Limit{"/other.Svc/Call": {Rate: 2, Burst: 2}}},
+		{in: "Authenticate", wantErr: true},
+		{in: "Authenticate=1", wantErr: true},
+		{in: "Authenticate=x:5", wantErr: true},
+		{in: "Authenticate=1:x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMethods("iam.IAM", tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMethods(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMethods(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit       Limit
+		takes       int
+		wantAllowed int
+	}{
+		{name: "within burst", limit: Limit{Rate: 0.001, Burst: 5}, takes: 5, wantAllowed: 5},
+		{name: "over burst", limit: Limit{Rate: 0.001, Burst: 5}, takes: 8, wantAllowed: 5},
+		{name: "burst of one", limit: Limit{Rate: 0.001, Burst: 1}, takes: 3, wantAllowed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var l MemoryLimiter
+			allowed := 0
+			for i := 0; i < tt.takes; i++ {
+				ok, retryDelay, err := l.Take(context.Background(), "key", tt.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if ok {
+					allowed++
+				} else if retryDelay <= 0 {
+					t.Errorf("take %d: denied with retry delay %v", i+1, retryDelay)
+				}
+			}
+
+			if allowed != tt.wantAllowed {
+				t.Errorf("allowed %d of %d takes, want %d", allowed, tt.takes, tt.wantAllowed)
+			}
+
+			// Other keys have buckets of their own.
+			if ok, _, _ := l.Take(context.Background(), "other", tt.limit); !ok {
+				t.Error("take for another key was denied")
+			}
+		})
+	}
+}
+
+func TestMemoryLimiterRefills(t *testing.T) {
+	var l MemoryLimiter
+	limit := Limit{Rate: 100, Burst: 1}
+
+	if ok, _, _ := l.Take(context.Background(), "key", limit); !ok {
+		t.Fatal("first take was denied")
+	}
+
+	ok, retryDelay, _ := l.Take(context.Background(), "key", limit)
+	if ok {
+		t.Fatal("second take was allowed")
+	}
+
+	time.Sleep(retryDelay + 5*time.Millisecond)
+	if ok, _, _ := l.Take(context.Background(), "key", limit); !ok {
+		t.Errorf("take after %v was denied", retryDelay)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{name: "direct", peer: "203.0.113.7:5000", want: "203.0.113.7"},
+		{name: "gateway", peer: "127.0.0.1:5000", forwarded: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "gateway ipv6", peer: "[::1]:5000", forwarded: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "client-supplied hops", peer: "127.0.0.1:5000", forwarded: []string{"10.0.0.1, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "header from remote peer", peer: "203.0.113.7:5000", forwarded: []string{"10.0.0.1"}, want: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := net.ResolveTCPAddr("tcp", tt.peer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+			if tt.forwarded != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{"x-forwarded-for": tt.forwarded})
+			}
+
+			if got := ClientIP(ctx); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	addr, err := net.ResolveTCPAddr("tcp", "203.0.113.7:5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	tests := []struct {
+		name       string
+		method     string
+		calls      int
+		wantDenied int
+	}{
+		{name: "default limit", method: "/iam.IAM/GetUser", calls: 3, wantDenied: 1},
+		{name: "method limit", method: "/iam.IAM/Authenticate", calls: 3, wantDenied: 2},
+		{name: "unlimited", method: "/iam.IAM/ListUsers", calls: 5, wantDenied: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := Interceptor{
+				Limiter: &MemoryLimiter{},
+				Config: Config{
+					Default: Limit{Rate: 0.001, Burst: 2},
+					Methods: map[string]Limit{
+						"/iam.IAM/Authenticate": {Rate: 0.001, Burst: 1},
+						"/iam.IAM/ListUsers":    {},
+					},
+				},
+				Principal: func(context.Context) string { return "" },
+			}
+
+			denied := 0
+			for n := 0; n < tt.calls; n++ {
+				_, err := i.Unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+				if status.Code(err) == codes.ResourceExhausted {
+					denied++
+				} else if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if denied != tt.wantDenied {
+				t.Errorf("denied %d of %d calls, want %d", denied, tt.calls, tt.wantDenied)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type ExportAccountRequest struct {
+	Token string
+	Name  string
+}
+
+type ImportAccountRequest struct {
+	Token    string
+	Name     string
+	Bundle   models.AccountBundle
+	Conflict models.ImportConflict
+}
+
+type ImportAccountResponse struct {
+	Account     models.Account
+	Created     []string
+	Overwritten []string
+	Skipped     []string
+	Renamed     map[string]string
+}
+
+// ExportAccount returns a bundle of the caller's account, including password
+// hashes. Only the root user can export.
+func (s *Service) ExportAccount(ctx context.Context, req ExportAccountRequest) (models.AccountBundle, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.AccountBundle{}, err
+	}
+
+	err = requireOwnAccount(claims, req.Name)
+	if err == nil {
+		err = s.requireRoot(ctx, claims)
+	}
+
+	var bundle models.AccountBundle
+	if err == nil {
+		bundle, err = s.Store.ExportAccount(ctx, store.ExportAccountRequest{
+			AccountID: claims.Audience,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "ExportAccount",
+	}, err)
+
+	return bundle, err
+}
+
+// ImportAccount merges a bundle's users into the caller's account. Only the
+// root user can import, and imported users are never root.
+func (s *Service) ImportAccount(ctx context.Context, req ImportAccountRequest) (ImportAccountResponse, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return ImportAccountResponse{}, err
+	}
+
+	err = requireOwnAccount(claims, req.Name)
+	if err == nil {
+		err = s.requireRoot(ctx, claims)
+	}
+
+	if err == nil {
+		err = ValidateAccountBundle(req.Bundle)
+	}
+
+	var res store.ImportAccountResponse
+	if err == nil {
+		res, err = s.Store.ImportAccount(ctx, store.ImportAccountRequest{
+			AccountID: claims.Audience,
+			Bundle:    req.Bundle,
+			Conflict:  req.Conflict,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "ImportAccount",
+	}, err)
+
+	if err != nil {
+		return ImportAccountResponse{}, err
+	}
+
+	return ImportAccountResponse{
+		Account:     res.Account,
+		Created:     res.Created,
+		Overwritten: res.Overwritten,
+		Skipped:     res.Skipped,
+		Renamed:     res.Renamed,
+	}, nil
+}
+
+// ValidateAccountBundle checks that a bundle can be imported.
+func ValidateAccountBundle(bundle models.AccountBundle) error {
+	if bundle.Version != models.AccountBundleVersion {
+		return status.Errorf(codes.InvalidArgument, "unsupported bundle version: %d", bundle.Version)
+	}
+
+	if bundle.PasswordPolicy != nil {
+		if _, err := mergePasswordPolicy(models.PasswordPolicy{}, *bundle.PasswordPolicy, nil); err != nil {
+			return err
+		}
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	addViolation := func(field, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: description,
+		})
+	}
+
+	names := map[string]bool{}
+	roots := 0
+	for i, bundled := range bundle.Users {
+		field := fmt.Sprintf("bundle.users[%d]", i)
+		user := bundled.User
+
+		if !isResourceName(user.Name, "users") {
+			addViolation(field+".user.name", "must be of the form users/{user}")
+		} else if names[user.Name] {
+			addViolation(field+".user.name", "duplicate user")
+		}
+
+		names[user.Name] = true
+
+		if user.IsRoot {
+			roots++
+		}
+
+		if validateEmail(field+".user.email", user.Email) != nil {
+			addViolation(field+".user.email", "must be a valid email address")
+		}
+
+		violations = append(violations, labelViolations(field+".user.labels", user.Labels)...)
+
+		for j, identity := range bundled.Identities {
+			if identity.Identity.AuthMethod != models.AuthMethodPassword || identity.PasswordHash == "" {
+				addViolation(fmt.Sprintf("%s.identities[%d]", field, j), "must be a password identity with a password hash")
+			} else if err := passhash.Check(identity.PasswordHash); err != nil {
+				addViolation(fmt.Sprintf("%s.identities[%d].password_hash", field, j), err.Error())
+			}
+		}
+	}
+
+	if roots > 1 {
+		addViolation("bundle.users", "must have at most one root user")
+	}
+
+	if len(violations) > 0 {
+		return badRequest(violations)
+	}
+
+	return nil
+}
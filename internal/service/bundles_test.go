@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+)
+
+func TestValidateAccountBundle(t *testing.T) {
+	hash, err := passhash.Bcrypt{Cost: 4}.Hash(testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := func(hash string) models.AccountBundle {
+		return models.AccountBundle{
+			Version: models.AccountBundleVersion,
+			Users: []models.BundledUser{{
+				User: models.User{Name: "users/alice"},
+				Identities: []models.BundledIdentity{{
+					Identity:     models.Identity{AuthMethod: models.AuthMethodPassword},
+					PasswordHash: hash,
+				}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		hash     string
+		wantCode codes.Code
+	}{
+		{"bcrypt", hash, codes.OK},
+		{"missing", "", codes.InvalidArgument},
+		{"plaintext", testPassword, codes.InvalidArgument},
+		{"unknown algorithm", "$1$salt$qJH7.N4xYta3aEG/dfqo/0", codes.InvalidArgument},
+		{"out of policy", "$argon2id$v=19$m=4194304,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U", codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantCode(t, "ValidateAccountBundle", ValidateAccountBundle(bundle(tt.hash)), tt.wantCode)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type DeleteAccountRequest struct {
+	Token string
+	Name  string
+	Etag  string
+}
+
+type UndeleteAccountRequest struct {
+	Token string
+	Name  string
+}
+
+type UndeleteUserRequest struct {
+	Token string
+	Name  string
+}
+
+// DeleteAccount soft-deletes the caller's account. Until it is undeleted or
+// purged, only its root user can still authenticate.
+func (s *Service) DeleteAccount(ctx context.Context, req DeleteAccountRequest) error {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return err
+	}
+
+	err = requireOwnAccount(claims, req.Name)
+	if err == nil {
+		err = s.requireRoot(ctx, claims)
+	}
+
+	if err == nil {
+		err = s.Store.DeleteAccount(ctx, store.DeleteAccountRequest{
+			AccountID: claims.Audience,
+			Etag:      req.Etag,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "DeleteAccount",
+	}, err)
+
+	return err
+}
+
+func (s *Service) UndeleteAccount(ctx context.Context, req UndeleteAccountRequest) (models.Account, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	err = requireOwnAccount(claims, req.Name)
+	if err == nil {
+		err = s.requireRoot(ctx, claims)
+	}
+
+	var account models.Account
+	if err == nil {
+		account, err = s.Store.UndeleteAccount(ctx, store.UndeleteAccountRequest{
+			AccountID:    claims.Audience,
+			DeletedAfter: time.Now().Add(-s.DeletionRetention),
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "UndeleteAccount",
+	}, err)
+
+	return account, err
+}
+
+func (s *Service) UndeleteUser(ctx context.Context, req UndeleteUserRequest) (models.User, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if !isResourceName(req.Name, "users") {
+		return models.User{}, status.Errorf(codes.InvalidArgument, "invalid user name: %q", req.Name)
+	}
+
+	var user models.User
+	err = s.requireRoot(ctx, claims)
+	if err == nil {
+		user, err = s.Store.UndeleteUser(ctx, store.UndeleteUserRequest{
+			AccountID:    claims.Audience,
+			Name:         req.Name,
+			DeletedAfter: time.Now().Add(-s.DeletionRetention),
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "UndeleteUser",
+	}, err)
+
+	return user, err
+}
+
+func requireOwnAccount(claims *claims, name string) error {
+	if name != fmt.Sprintf("accounts/%s", claims.Audience) {
+		return status.Errorf(codes.PermissionDenied, "cannot access account: %s", name)
+	}
+
+	return nil
+}
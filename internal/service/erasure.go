@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type EraseUserRequest struct {
+	Token string
+	Name  string
+	Etag  string
+}
+
+type EraseUserResponse struct {
+	User    models.User
+	Receipt string
+}
+
+// erasureReceipt is a record of an erasure, signed with the token signing key
+// so that it can be checked against the service's public key. It names the
+// user only by pseudonym.
+type erasureReceipt struct {
+	jwt.StandardClaims
+	Type        string `json:"typ"`
+	Identities  int64  `json:"erased_identities"`
+	AuditEvents int64  `json:"pseudonymized_audit_events"`
+}
+
+const erasureReceiptType = "erasure_receipt"
+
+// erasureReceiptHeaderType is the "typ" header of erasure receipts.
+// parseToken only accepts tokens typed "JWT", so a receipt can't be used as
+// an access token even though both are signed with the same key.
+const erasureReceiptHeaderType = "erasure-receipt+jwt"
+
+// EraseUser permanently scrubs a user's personal data and secrets, for
+// right-to-be-forgotten requests. Unlike DeleteUser it can't be undone. It
+// returns the pseudonymized user and a signed erasure receipt.
+func (s *Service) EraseUser(ctx context.Context, req EraseUserRequest) (EraseUserResponse, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return EraseUserResponse{}, err
+	}
+
+	if !isResourceName(req.Name, "users") {
+		return EraseUserResponse{}, status.Errorf(codes.InvalidArgument, "invalid user name: %q", req.Name)
+	}
+
+	var res store.EraseUserResponse
+	err = s.requireRoot(ctx, claims)
+	if err == nil {
+		res, err = s.Store.EraseUser(ctx, store.EraseUserRequest{
+			AccountID: claims.Audience,
+			Name:      req.Name,
+			Etag:      req.Etag,
+		})
+	}
+
+	account := fmt.Sprintf("accounts/%s", claims.Audience)
+	if err == nil {
+		err = s.Store.ResetLoginThrottles(ctx, store.ResetLoginThrottlesRequest{
+			Keys: []string{loginThrottleKey(account, req.Name)},
+		})
+	}
+
+	// The audit entry names the user by pseudonym, so that the erasure doesn't
+	// leave the name behind.
+	resource := req.Name
+	if res.User.Name != "" {
+		resource = res.User.Name
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  account,
+		Resource: resource,
+		Method:   "EraseUser",
+	}, err)
+
+	if err != nil {
+		return EraseUserResponse{}, err
+	}
+
+	receipt, err := s.signErasureReceipt(claims.Audience, res)
+	if err != nil {
+		return EraseUserResponse{}, errors.Wrap(err, "error signing erasure receipt")
+	}
+
+	return EraseUserResponse{
+		User:    res.User,
+		Receipt: receipt,
+	}, nil
+}
+
+func (s *Service) signErasureReceipt(accountID string, res store.EraseUserResponse) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	eraseTime := time.Now()
+	if res.User.EraseTime != nil {
+		eraseTime = *res.User.EraseTime
+	}
+
+	receipt := jwt.NewWithClaims(jwt.SigningMethodRS256, &erasureReceipt{
+		StandardClaims: jwt.StandardClaims{
+			Id:       hex.EncodeToString(id),
+			Subject:  res.User.Name,
+			Audience: accountID,
+			IssuedAt: eraseTime.Unix(),
+		},
+		Type:        erasureReceiptType,
+		Identities:  res.Identities,
+		AuditEvents: res.AuditEvents,
+	})
+
+	receipt.Header["typ"] = erasureReceiptHeaderType
+	return receipt.SignedString(s.TokenSignKey)
+}
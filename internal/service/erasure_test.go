@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc/codes"
+)
+
+func TestErasureReceiptIsNotAToken(t *testing.T) {
+	s := newTestService(t)
+	account := newTestAccount(t, s)
+	ctx := context.Background()
+
+	res, err := s.EraseUser(ctx, EraseUserRequest{Token: account.RootToken, Name: "users/alice"})
+	if err != nil {
+		t.Fatalf("EraseUser: %v", err)
+	}
+
+	receipt, err := jwt.ParseWithClaims(res.Receipt, &erasureReceipt{}, func(*jwt.Token) (interface{}, error) {
+		return &testKey.PublicKey, nil
+	})
+
+	if err != nil {
+		t.Fatalf("parsing receipt: %v", err)
+	}
+
+	if typ := receipt.Header["typ"]; typ != erasureReceiptHeaderType {
+		t.Errorf("receipt typ = %v, want %s", typ, erasureReceiptHeaderType)
+	}
+
+	_, err = s.parseToken(res.Receipt)
+	wantCode(t, "parseToken(receipt)", err, codes.Unauthenticated)
+
+	// Even with every claim an access token has, the header type keeps a
+	// receipt-typed document from authenticating.
+	forged := jwt.NewWithClaims(jwt.SigningMethodRS256, &claims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:   "users/root",
+			Audience:  accountID(account.Name),
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+		AuthMethod: amrPassword,
+	})
+
+	forged.Header["typ"] = erasureReceiptHeaderType
+	signed, err := forged.SignedString(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.parseToken(signed)
+	wantCode(t, "parseToken(receipt-typed token)", err, codes.Unauthenticated)
+
+	if _, err := s.parseToken(account.RootToken); err != nil {
+		t.Errorf("parseToken(access token): %v", err)
+	}
+}
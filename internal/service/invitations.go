@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/notify"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+// InvitationConfig controls invitations. URL is a template in which "{code}"
+// is replaced by the acceptance code; if empty, the bare code is sent.
+type InvitationConfig struct {
+	TTL time.Duration
+	URL string
+}
+
+type ListInvitationsRequest struct {
+	Token     string
+	Parent    string
+	PageSize  int32
+	PageToken string
+}
+
+type ListInvitationsResponse struct {
+	Invitations   []models.Invitation
+	NextPageToken string
+}
+
+type CreateInvitationRequest struct {
+	Token      string
+	Parent     string
+	Invitation models.Invitation
+}
+
+type DeleteInvitationRequest struct {
+	Token string
+	Name  string
+}
+
+type AcceptInvitationRequest struct {
+	Code     string
+	Password string
+}
+
+func (s *Service) ListInvitations(ctx context.Context, req ListInvitationsRequest) (ListInvitationsResponse, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return ListInvitationsResponse{}, err
+	}
+
+	if err := requireOwnAccount(claims, req.Parent); err != nil {
+		return ListInvitationsResponse{}, err
+	}
+
+	if err := s.requireRoot(ctx, claims); err != nil {
+		return ListInvitationsResponse{}, err
+	}
+
+	res, err := s.Store.ListInvitations(ctx, store.ListInvitationsRequest{
+		AccountID: claims.Audience,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+
+	if err != nil {
+		return ListInvitationsResponse{}, err
+	}
+
+	return ListInvitationsResponse{
+		Invitations:   res.Invitations,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+// CreateInvitation invites someone to the account by emailing them a
+// single-use code, with which they can create the invitation's user and
+// choose its password. The code isn't returned to the caller.
+func (s *Service) CreateInvitation(ctx context.Context, req CreateInvitationRequest) (models.Invitation, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Invitation{}, err
+	}
+
+	if err := requireOwnAccount(claims, req.Parent); err != nil {
+		return models.Invitation{}, err
+	}
+
+	if s.Notifier == nil {
+		return models.Invitation{}, status.Error(codes.FailedPrecondition, "invitations can't be sent without a notifier")
+	}
+
+	err = s.requireRoot(ctx, claims)
+	if err == nil {
+		err = validateInvitation(req.Invitation)
+	}
+
+	now := time.Now()
+	invitation := req.Invitation
+	if invitation.ExpireTime.IsZero() {
+		invitation.ExpireTime = now.Add(s.Invitations.TTL)
+	}
+
+	var code string
+	if err == nil {
+		code, err = newInvitationCode()
+	}
+
+	if err == nil {
+		invitation.Inviter = claims.Subject
+		invitation.CodeHash = hashUserToken(code)
+		invitation, err = s.Store.CreateInvitation(ctx, store.CreateInvitationRequest{
+			AccountID:  claims.Audience,
+			Invitation: invitation,
+		})
+	}
+
+	resource := invitation.Name
+	if resource == "" {
+		resource = req.Parent
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: resource,
+		Method:   "CreateInvitation",
+	}, err)
+
+	if err != nil {
+		return models.Invitation{}, err
+	}
+
+	if err := s.sendInvitation(ctx, invitation, code); err != nil {
+		log.Printf("error sending invitation: %v", err)
+	}
+
+	invitation.CodeHash = nil
+	return invitation, nil
+}
+
+// DeleteInvitation revokes an invitation, so that it can't be accepted.
+func (s *Service) DeleteInvitation(ctx context.Context, req DeleteInvitationRequest) error {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return err
+	}
+
+	err = s.requireRoot(ctx, claims)
+	if err == nil {
+		err = s.Store.DeleteInvitation(ctx, store.DeleteInvitationRequest{
+			AccountID: claims.Audience,
+			Name:      req.Name,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "DeleteInvitation",
+	}, err)
+
+	return err
+}
+
+// AcceptInvitation creates an invitation's user, with a password identity
+// using the invitee's chosen password.
+func (s *Service) AcceptInvitation(ctx context.Context, req AcceptInvitationRequest) (models.User, error) {
+	codeHash := hashUserToken(req.Code)
+
+	invitation, err := s.Store.GetPendingInvitation(ctx, store.GetPendingInvitationRequest{
+		CodeHash: codeHash,
+	})
+
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return models.User{}, status.Error(codes.InvalidArgument, "invalid or expired invitation")
+		}
+
+		return models.User{}, err
+	}
+
+	account := strings.Join(strings.Split(invitation.Name, "/")[:2], "/")
+
+	policy, err := s.passwordPolicy(ctx, strings.TrimPrefix(account, "accounts/"))
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var user models.User
+	err = checkPassword(policy, "password", req.Password)
+	if err == nil {
+		user, err = s.Store.AcceptInvitation(ctx, store.AcceptInvitationRequest{
+			CodeHash: codeHash,
+			Password: req.Password,
+		})
+
+		if status.Code(err) == codes.NotFound {
+			err = status.Error(codes.InvalidArgument, "invalid or expired invitation")
+		}
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    invitation.User.Name,
+		Account:  account,
+		Resource: invitation.Name,
+		Method:   "AcceptInvitation",
+	}, err)
+
+	return user, err
+}
+
+func validateInvitation(invitation models.Invitation) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if invitation.Email == "" {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "invitation.email",
+			Description: "is required",
+		})
+	} else if err := validateEmail("invitation.email", invitation.Email); err != nil {
+		return err
+	}
+
+	if !isResourceName(invitation.User.Name, "users") {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "invitation.user.name",
+			Description: "must be a user name",
+		})
+	}
+
+	if !invitation.ExpireTime.IsZero() && !invitation.ExpireTime.After(time.Now()) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "invitation.expire_time",
+			Description: "must be in the future",
+		})
+	}
+
+	violations = append(violations, labelViolations("invitation.user.labels", invitation.User.Labels)...)
+	if len(violations) > 0 {
+		return badRequest(violations)
+	}
+
+	return nil
+}
+
+func newInvitationCode() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "error generating invitation code")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func (s *Service) sendInvitation(ctx context.Context, invitation models.Invitation, code string) error {
+	link := code
+	if s.Invitations.URL != "" {
+		link = strings.Replace(s.Invitations.URL, "{code}", code, -1)
+	}
+
+	return s.Notifier.Notify(ctx, notify.Message{
+		To:      invitation.Email,
+		Subject: "You've been invited",
+		Body: fmt.Sprintf("You've been invited to join as %s. To accept, set your password at:\n\n%s\n\nThis link expires at %s.\n",
+			invitation.User.Name, link, invitation.ExpireTime.Format(time.RFC1123)),
+	})
+}
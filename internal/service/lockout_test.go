@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+func requestFrom(t *testing.T, addr, forwarded string) context.Context {
+	t.Helper()
+
+	tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tcpAddr})
+	return metadata.NewIncomingContext(ctx, metadata.MD{"x-forwarded-for": []string{forwarded}})
+}
+
+func TestLockout(t *testing.T) {
+	tests := []struct {
+		name string
+		// peer and forwarded give the address of the nth failed attempt.
+		peer      string
+		forwarded func(n int) string
+		user      func(n int) string
+		attempts  int
+		wantCode  codes.Code
+	}{
+		{
+			name:      "user locked out",
+			peer:      "203.0.113.7:4000",
+			forwarded: func(int) string { return "198.51.100.1" },
+			user:      func(int) string { return "users/root" },
+			attempts:  3,
+			wantCode:  codes.ResourceExhausted,
+		},
+		{
+			name:      "ip locked out despite rotating x-forwarded-for",
+			peer:      "203.0.113.7:4000",
+			forwarded: func(n int) string { return fmt.Sprintf("198.51.100.%d", n) },
+			user:      func(n int) string { return fmt.Sprintf("users/nobody-%d", n) },
+			attempts:  10,
+			wantCode:  codes.ResourceExhausted,
+		},
+		{
+			name:      "distinct clients behind the gateway",
+			peer:      "127.0.0.1:4000",
+			forwarded: func(n int) string { return fmt.Sprintf("198.51.100.%d", n) },
+			user:      func(n int) string { return fmt.Sprintf("users/nobody-%d", n) },
+			attempts:  10,
+			wantCode:  codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			account := newTestAccount(t, s)
+
+			for n := 0; n < tt.attempts; n++ {
+				_, err := s.Authenticate(requestFrom(t, tt.peer, tt.forwarded(n)), AuthenticateRequest{
+					Account:  account.Name,
+					User:     tt.user(n),
+					Password: "wrong-password",
+				})
+
+				wantCode(t, fmt.Sprintf("failed attempt %d", n+1), err, codes.Unauthenticated)
+			}
+
+			_, err := s.Authenticate(requestFrom(t, tt.peer, tt.forwarded(tt.attempts)), AuthenticateRequest{
+				Account:  account.Name,
+				User:     "users/root",
+				Password: testPassword,
+			})
+
+			wantCode(t, "Authenticate with the right password", err, tt.wantCode)
+		})
+	}
+}
+
+var errStoreDown = errors.New("store is down")
+
+// downStore fails every password check with err, as a store does during an
+// outage.
+type downStore struct {
+	store.Store
+	err error
+}
+
+func (s downStore) CheckPassword(context.Context, store.CheckPasswordRequest) (store.CheckPasswordResponse, error) {
+	return store.CheckPasswordResponse{}, s.err
+}
+
+func (s downStore) CheckPrincipalPassword(context.Context, store.CheckPrincipalPasswordRequest) (store.CheckPasswordResponse, error) {
+	return store.CheckPasswordResponse{}, s.err
+}
+
+func TestStoreErrorsDontLockOut(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	account := newTestAccount(t, s)
+
+	principal, err := s.CreatePrincipal(ctx, CreatePrincipalRequest{Principal: models.Principal{DisplayName: "Alice"}, Password: testPassword})
+	if err != nil {
+		t.Fatalf("CreatePrincipal: %v", err)
+	}
+
+	// The cases run in order: LinkPrincipal gives the principal the
+	// membership it authenticates with.
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "Authenticate",
+			call: func() error {
+				_, err := s.Authenticate(ctx, AuthenticateRequest{Account: account.Name, User: "users/root", Password: testPassword})
+				return err
+			},
+		},
+		{
+			name: "LinkPrincipal",
+			call: func() error {
+				_, err := s.LinkPrincipal(ctx, LinkPrincipalRequest{Token: account.UserToken, Name: "users/alice", Principal: principal.Name, Password: testPassword})
+				return err
+			},
+		},
+		{
+			name: "Authenticate as principal",
+			call: func() error {
+				_, err := s.Authenticate(ctx, AuthenticateRequest{Principal: principal.Name, Password: testPassword})
+				return err
+			},
+		},
+	}
+
+	working := s.Store
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.Store = downStore{working, errStoreDown}
+			for n := 0; n <= s.Lockout.MaxUserFailures; n++ {
+				if err := tt.call(); err != errStoreDown {
+					t.Fatalf("attempt %d during the outage: got %v, want %v", n+1, err, errStoreDown)
+				}
+			}
+
+			s.Store = working
+			if err := tt.call(); err != nil {
+				t.Errorf("after the outage: %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateReasons(t *testing.T) {
+	tests := []struct {
+		name       string
+		password   string
+		storeErr   error
+		wantReason string
+	}{
+		{name: "success", password: testPassword, wantReason: ""},
+		{name: "wrong password", password: "wrong-password", wantReason: AuthenticateInvalidCredentials},
+		{name: "store error", password: testPassword, storeErr: errStoreDown, wantReason: AuthenticateError},
+		{name: "store status", password: testPassword, storeErr: status.Error(codes.Unavailable, "replica is down"), wantReason: AuthenticateError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			account := newTestAccount(t, s)
+
+			var reasons []string
+			s.OnAuthenticate = func(reason string) { reasons = append(reasons, reason) }
+			if tt.storeErr != nil {
+				s.Store = downStore{s.Store, tt.storeErr}
+			}
+
+			s.Authenticate(context.Background(), AuthenticateRequest{Account: account.Name, User: "users/root", Password: tt.password})
+			if len(reasons) != 1 || reasons[0] != tt.wantReason {
+				t.Errorf("OnAuthenticate reasons = %q, want [%q]", reasons, tt.wantReason)
+			}
+		})
+	}
+}
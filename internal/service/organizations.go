@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type CreateOrganizationRequest struct {
+	Token        string
+	Organization models.Organization
+}
+
+type GetOrganizationRequest struct {
+	Token string
+	Name  string
+}
+
+type UpdateOrganizationRequest struct {
+	Token        string
+	Organization models.Organization
+	UpdateMask   []string
+}
+
+type AddOrganizationAdminRequest struct {
+	Token     string
+	Name      string
+	Principal string
+}
+
+type RemoveOrganizationAdminRequest struct {
+	Token     string
+	Name      string
+	Principal string
+}
+
+type GetOrganizationPasswordPolicyRequest struct {
+	Token string
+	Name  string
+}
+
+type UpdateOrganizationPasswordPolicyRequest struct {
+	Token          string
+	PasswordPolicy models.PasswordPolicy
+	UpdateMask     []string
+}
+
+type MoveAccountRequest struct {
+	Token        string
+	Name         string
+	Organization string
+}
+
+// CreateOrganization creates an organization with the caller's principal as
+// its only admin. The caller must be authenticated as, or linked to, a
+// principal.
+func (s *Service) CreateOrganization(ctx context.Context, req CreateOrganizationRequest) (models.Organization, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	principal, err := s.callerPrincipal(ctx, claims)
+	if err == nil && principal == "" {
+		err = status.Error(codes.FailedPrecondition, "creating an organization requires a principal")
+	}
+
+	var organization models.Organization
+	if err == nil {
+		organization, err = s.Store.CreateOrganization(ctx, store.CreateOrganizationRequest{
+			Organization: req.Organization,
+			Admin:        principal,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: organization.Name,
+		Method:   "CreateOrganization",
+	}, err)
+
+	return organization, err
+}
+
+// GetOrganization returns an organization to its admins, and to users of its
+// accounts.
+func (s *Service) GetOrganization(ctx context.Context, req GetOrganizationRequest) (models.Organization, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if err := s.requireOrganizationMember(ctx, claims, req.Name); err != nil {
+		return models.Organization{}, err
+	}
+
+	return s.Store.GetOrganization(ctx, store.GetOrganizationRequest{
+		Name: req.Name,
+	})
+}
+
+func (s *Service) UpdateOrganization(ctx context.Context, req UpdateOrganizationRequest) (models.Organization, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	for _, path := range req.UpdateMask {
+		switch path {
+		case "display_name":
+		default:
+			return models.Organization{}, status.Errorf(codes.InvalidArgument, "cannot update field: %s", path)
+		}
+	}
+
+	var organization models.Organization
+	err = s.requireOrganizationAdmin(ctx, claims, req.Organization.Name)
+	if err == nil {
+		organization, err = s.Store.UpdateOrganization(ctx, store.UpdateOrganizationRequest{
+			Organization: req.Organization,
+			UpdateMask:   req.UpdateMask,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Organization.Name,
+		Method:   "UpdateOrganization",
+	}, err)
+
+	return organization, err
+}
+
+func (s *Service) AddOrganizationAdmin(ctx context.Context, req AddOrganizationAdminRequest) (models.Organization, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	var organization models.Organization
+	err = s.requireOrganizationAdmin(ctx, claims, req.Name)
+	if err == nil {
+		organization, err = s.Store.AddOrganizationAdmin(ctx, store.AddOrganizationAdminRequest{
+			Name:      req.Name,
+			Principal: req.Principal,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "AddOrganizationAdmin",
+	}, err)
+
+	return organization, err
+}
+
+func (s *Service) RemoveOrganizationAdmin(ctx context.Context, req RemoveOrganizationAdminRequest) (models.Organization, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	var organization models.Organization
+	err = s.requireOrganizationAdmin(ctx, claims, req.Name)
+	if err == nil {
+		organization, err = s.Store.RemoveOrganizationAdmin(ctx, store.RemoveOrganizationAdminRequest{
+			Name:      req.Name,
+			Principal: req.Principal,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "RemoveOrganizationAdmin",
+	}, err)
+
+	return organization, err
+}
+
+func (s *Service) GetOrganizationPasswordPolicy(ctx context.Context, req GetOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.PasswordPolicy{}, err
+	}
+
+	organization := strings.TrimSuffix(req.Name, "/passwordPolicy")
+	if err := s.requireOrganizationMember(ctx, claims, organization); err != nil {
+		return models.PasswordPolicy{}, err
+	}
+
+	return s.organizationPasswordPolicy(ctx, organization)
+}
+
+// UpdateOrganizationPasswordPolicy sets an organization's password policy,
+// which every account in the organization must meet in addition to its own.
+func (s *Service) UpdateOrganizationPasswordPolicy(ctx context.Context, req UpdateOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.PasswordPolicy{}, err
+	}
+
+	name := req.PasswordPolicy.Name
+	organization := strings.TrimSuffix(name, "/passwordPolicy")
+
+	var policy models.PasswordPolicy
+	err = s.inTx(ctx, func(tx *Service) error {
+		if err := tx.requireOrganizationAdmin(ctx, claims, organization); err != nil {
+			return err
+		}
+
+		current, err := tx.organizationPasswordPolicy(ctx, organization)
+		if err != nil {
+			return err
+		}
+
+		merged, err := mergePasswordPolicy(current, req.PasswordPolicy, req.UpdateMask)
+		if err != nil {
+			return err
+		}
+
+		policy, err = tx.Store.UpdateOrganizationPasswordPolicy(ctx, store.UpdateOrganizationPasswordPolicyRequest{
+			Organization:   organization,
+			PasswordPolicy: merged,
+		})
+
+		return err
+	})
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: name,
+		Method:   "UpdateOrganizationPasswordPolicy",
+	}, err)
+
+	return policy, err
+}
+
+// MoveAccount moves an account into an organization, or out of one if
+// Organization is empty. The caller must be an admin of the account's current
+// organization or, if it has none, the account's root user. Moving into an
+// organization also requires being one of its admins.
+func (s *Service) MoveAccount(ctx context.Context, req MoveAccountRequest) (models.Account, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	if !isResourceName(req.Name, "accounts") {
+		return models.Account{}, status.Errorf(codes.InvalidArgument, "invalid account name: %q", req.Name)
+	}
+
+	accountID := strings.TrimPrefix(req.Name, "accounts/")
+
+	var account models.Account
+	err = s.inTx(ctx, func(tx *Service) error {
+		current, err := tx.Store.GetAccount(ctx, store.GetAccountRequest{
+			AccountID: accountID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if current.Organization != "" {
+			err = tx.requireOrganizationAdmin(ctx, claims, current.Organization)
+		} else if err = requireOwnAccount(claims, req.Name); err == nil {
+			err = tx.requireRoot(ctx, claims)
+		}
+
+		if err == nil && req.Organization != "" {
+			err = tx.requireOrganizationAdmin(ctx, claims, req.Organization)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		account, err = tx.Store.MoveAccount(ctx, store.MoveAccountRequest{
+			AccountID:    accountID,
+			Organization: req.Organization,
+		})
+
+		return err
+	})
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "MoveAccount",
+	}, err)
+
+	return account, err
+}
+
+// callerPrincipal returns the principal the caller authenticated as or is
+// linked to, or "" if there is none.
+func (s *Service) callerPrincipal(ctx context.Context, claims *claims) (string, error) {
+	if claims.Principal != "" {
+		return claims.Principal, nil
+	}
+
+	caller, err := s.Store.GetUser(ctx, store.GetUserRequest{
+		AccountID: claims.Audience,
+		Name:      claims.Subject,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return caller.Principal, nil
+}
+
+func (s *Service) requireOrganizationAdmin(ctx context.Context, claims *claims, organization string) error {
+	if !isResourceName(organization, "organizations") {
+		return status.Errorf(codes.InvalidArgument, "invalid organization name: %q", organization)
+	}
+
+	principal, err := s.callerPrincipal(ctx, claims)
+	if err != nil {
+		return err
+	}
+
+	if principal != "" {
+		isAdmin, err := s.Store.IsOrganizationAdmin(ctx, store.IsOrganizationAdminRequest{
+			Organization: organization,
+			Principal:    principal,
+		})
+
+		if err != nil || isAdmin {
+			return err
+		}
+	}
+
+	return status.Errorf(codes.PermissionDenied, "only admins of %s can do this", organization)
+}
+
+// requireOrganizationMember checks that the caller is an admin of the
+// organization, or a user in one of its accounts.
+func (s *Service) requireOrganizationMember(ctx context.Context, claims *claims, organization string) error {
+	if !isResourceName(organization, "organizations") {
+		return status.Errorf(codes.InvalidArgument, "invalid organization name: %q", organization)
+	}
+
+	account, err := s.Store.GetAccount(ctx, store.GetAccountRequest{
+		AccountID: claims.Audience,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if account.Organization == organization {
+		return nil
+	}
+
+	return s.requireOrganizationAdmin(ctx, claims, organization)
+}
+
+// organizationPasswordPolicy returns the organization's password policy, or
+// an empty one, which adds no requirements, if it hasn't set one.
+func (s *Service) organizationPasswordPolicy(ctx context.Context, organization string) (models.PasswordPolicy, error) {
+	policy, err := s.Store.GetOrganizationPasswordPolicy(ctx, store.GetOrganizationPasswordPolicyRequest{
+		Organization: organization,
+	})
+
+	if status.Code(err) == codes.NotFound {
+		if _, err := s.Store.GetOrganization(ctx, store.GetOrganizationRequest{Name: organization}); err != nil {
+			return models.PasswordPolicy{}, err
+		}
+
+		return models.PasswordPolicy{Name: fmt.Sprintf("%s/passwordPolicy", organization)}, nil
+	}
+
+	return policy, err
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/password"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+var passwordPolicyFields = []string{
+	"min_length",
+	"require_uppercase",
+	"require_lowercase",
+	"require_digit",
+	"require_symbol",
+	"history_size",
+	"max_age",
+}
+
+func (s *Service) GetPasswordPolicy(ctx context.Context, req GetPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.PasswordPolicy{}, err
+	}
+
+	if req.Name != passwordPolicyName(claims.Audience) {
+		return models.PasswordPolicy{}, status.Errorf(codes.PermissionDenied, "cannot get password policy: %s", req.Name)
+	}
+
+	return s.accountPasswordPolicy(ctx, claims.Audience)
+}
+
+func (s *Service) UpdatePasswordPolicy(ctx context.Context, req UpdatePasswordPolicyRequest) (models.PasswordPolicy, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.PasswordPolicy{}, err
+	}
+
+	name := passwordPolicyName(claims.Audience)
+	if req.PasswordPolicy.Name != name {
+		return models.PasswordPolicy{}, status.Errorf(codes.PermissionDenied, "cannot update password policy: %s", req.PasswordPolicy.Name)
+	}
+
+	var policy models.PasswordPolicy
+	err = s.inTx(ctx, func(tx *Service) error {
+		if err := tx.requireRoot(ctx, claims); err != nil {
+			return err
+		}
+
+		current, err := tx.accountPasswordPolicy(ctx, claims.Audience)
+		if err != nil {
+			return err
+		}
+
+		merged, err := mergePasswordPolicy(current, req.PasswordPolicy, req.UpdateMask)
+		if err != nil {
+			return err
+		}
+
+		policy, err = tx.Store.UpdatePasswordPolicy(ctx, store.UpdatePasswordPolicyRequest{
+			AccountID:      claims.Audience,
+			PasswordPolicy: merged,
+		})
+
+		return err
+	})
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: name,
+		Method:   "UpdatePasswordPolicy",
+	}, err)
+
+	return policy, err
+}
+
+func (s *Service) UpdateIdentity(ctx context.Context, req UpdateIdentityRequest) (models.Identity, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	for _, path := range req.UpdateMask {
+		if path != "password" {
+			return models.Identity{}, status.Errorf(codes.InvalidArgument, "cannot update field: %s", path)
+		}
+	}
+
+	segments := strings.Split(req.Identity.Name, "/")
+	if len(segments) != 4 || segments[0] != "users" || segments[2] != "identities" {
+		return models.Identity{}, status.Errorf(codes.InvalidArgument, "invalid identity name: %s", req.Identity.Name)
+	}
+
+	// Users may change their own passwords; the root user may change anyone's.
+	if owner := strings.Join(segments[:2], "/"); owner != claims.Subject {
+		if err := s.requireRoot(ctx, claims); err != nil {
+			return models.Identity{}, err
+		}
+	}
+
+	var identity models.Identity
+	err = s.inTx(ctx, func(tx *Service) error {
+		policy, err := tx.passwordPolicy(ctx, claims.Audience)
+		if err != nil {
+			return err
+		}
+
+		if err := checkPassword(policy, "identity.password", req.Identity.Password); err != nil {
+			return err
+		}
+
+		reused, err := tx.Store.PasswordReused(ctx, store.PasswordReusedRequest{
+			AccountID:   claims.Audience,
+			Identity:    req.Identity.Name,
+			Password:    req.Identity.Password,
+			HistorySize: policy.HistorySize,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if reused {
+			return passwordViolations("identity.password", []string{
+				fmt.Sprintf("must not match any of the last %d passwords", policy.HistorySize),
+			})
+		}
+
+		identity, err = tx.Store.UpdateIdentity(ctx, store.UpdateIdentityRequest{
+			AccountID: claims.Audience,
+			Identity:  req.Identity,
+		})
+
+		return err
+	})
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Identity.Name,
+		Method:   "UpdateIdentity",
+	}, err)
+
+	return identity, err
+}
+
+func (s *Service) requireRoot(ctx context.Context, claims *claims) error {
+	caller, err := s.Store.GetUser(ctx, store.GetUserRequest{
+		AccountID: claims.Audience,
+		Name:      claims.Subject,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if caller.IsRoot {
+		return nil
+	}
+
+	// Admins of the account's organization have the root user's permissions.
+	principal := claims.Principal
+	if principal == "" {
+		principal = caller.Principal
+	}
+
+	if principal != "" {
+		account, err := s.Store.GetAccount(ctx, store.GetAccountRequest{
+			AccountID: claims.Audience,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if account.Organization != "" {
+			isAdmin, err := s.Store.IsOrganizationAdmin(ctx, store.IsOrganizationAdminRequest{
+				Organization: account.Organization,
+				Principal:    principal,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			if isAdmin {
+				return nil
+			}
+		}
+	}
+
+	return status.Error(codes.PermissionDenied, "only the root user can do this")
+}
+
+// passwordPolicy returns the policy that passwords in the account must meet:
+// the account's own policy, made at least as strict as its organization's.
+func (s *Service) passwordPolicy(ctx context.Context, accountID string) (models.PasswordPolicy, error) {
+	policy, err := s.accountPasswordPolicy(ctx, accountID)
+	if err != nil {
+		return models.PasswordPolicy{}, err
+	}
+
+	account, err := s.Store.GetAccount(ctx, store.GetAccountRequest{
+		AccountID:   accountID,
+		ShowDeleted: true,
+	})
+
+	if err != nil || account.Organization == "" {
+		return policy, err
+	}
+
+	organizationPolicy, err := s.Store.GetOrganizationPasswordPolicy(ctx, store.GetOrganizationPasswordPolicyRequest{
+		Organization: account.Organization,
+	})
+
+	if status.Code(err) == codes.NotFound {
+		return policy, nil
+	}
+
+	if err != nil {
+		return models.PasswordPolicy{}, err
+	}
+
+	return password.Strictest(policy, organizationPolicy), nil
+}
+
+// accountPasswordPolicy returns the account's password policy, or the default
+// one if the account hasn't set its own.
+func (s *Service) accountPasswordPolicy(ctx context.Context, accountID string) (models.PasswordPolicy, error) {
+	policy, err := s.Store.GetPasswordPolicy(ctx, store.GetPasswordPolicyRequest{
+		AccountID: accountID,
+	})
+
+	if status.Code(err) == codes.NotFound {
+		policy = password.DefaultPolicy
+		policy.Name = passwordPolicyName(accountID)
+		return policy, nil
+	}
+
+	return policy, err
+}
+
+func (s *Service) checkPasswordAge(ctx context.Context, account string, updateTime time.Time) error {
+	policy, err := s.passwordPolicy(ctx, strings.TrimPrefix(account, "accounts/"))
+	if err != nil {
+		return err
+	}
+
+	if policy.MaxAge > 0 && time.Since(updateTime) > policy.MaxAge {
+		return status.Error(codes.FailedPrecondition, "password has expired")
+	}
+
+	return nil
+}
+
+func mergePasswordPolicy(policy, update models.PasswordPolicy, mask []string) (models.PasswordPolicy, error) {
+	if len(mask) == 0 {
+		mask = passwordPolicyFields
+	}
+
+	for _, path := range mask {
+		switch path {
+		case "min_length":
+			policy.MinLength = update.MinLength
+		case "require_uppercase":
+			policy.RequireUppercase = update.RequireUppercase
+		case "require_lowercase":
+			policy.RequireLowercase = update.RequireLowercase
+		case "require_digit":
+			policy.RequireDigit = update.RequireDigit
+		case "require_symbol":
+			policy.RequireSymbol = update.RequireSymbol
+		case "history_size":
+			policy.HistorySize = update.HistorySize
+		case "max_age":
+			policy.MaxAge = update.MaxAge
+		default:
+			return models.PasswordPolicy{}, status.Errorf(codes.InvalidArgument, "cannot update field: %s", path)
+		}
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	addViolation := func(field, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       fmt.Sprintf("password_policy.%s", field),
+			Description: description,
+		})
+	}
+
+	if policy.MinLength < 0 {
+		addViolation("min_length", "must not be negative")
+	}
+
+	if policy.HistorySize < 0 {
+		addViolation("history_size", "must not be negative")
+	}
+
+	if policy.MaxAge < 0 {
+		addViolation("max_age", "must not be negative")
+	}
+
+	if len(violations) > 0 {
+		return models.PasswordPolicy{}, badRequest(violations)
+	}
+
+	return policy, nil
+}
+
+func checkPassword(policy models.PasswordPolicy, field, pw string) error {
+	if violations := password.Check(policy, pw); len(violations) > 0 {
+		return passwordViolations(field, violations)
+	}
+
+	return nil
+}
+
+func passwordViolations(field string, descriptions []string) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, description := range descriptions {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf("password %s", description),
+		})
+	}
+
+	return badRequest(violations)
+}
+
+func badRequest(violations []*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(codes.InvalidArgument, "invalid request")
+	if withDetails, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: violations,
+	}); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+func passwordPolicyName(accountID string) string {
+	return fmt.Sprintf("accounts/%s/passwordPolicy", accountID)
+}
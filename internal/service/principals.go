@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/password"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type CreatePrincipalRequest struct {
+	Principal models.Principal
+	Password  string
+}
+
+type LinkPrincipalRequest struct {
+	Token string
+	Name  string
+
+	// Principal and Password prove that the caller controls the principal
+	// being linked.
+	Principal string
+	Password  string
+}
+
+type UnlinkPrincipalRequest struct {
+	Token string
+	Name  string
+}
+
+type ListMyAccountsRequest struct {
+	Token string
+}
+
+type ListMyAccountsResponse struct {
+	Memberships []models.AccountMembership
+}
+
+func (s *Service) CreatePrincipal(ctx context.Context, req CreatePrincipalRequest) (models.Principal, error) {
+	if err := checkPassword(password.DefaultPolicy, "password", req.Password); err != nil {
+		return models.Principal{}, err
+	}
+
+	if err := validateEmail("principal.email", req.Principal.Email); err != nil {
+		return models.Principal{}, err
+	}
+
+	principal, err := s.Store.CreatePrincipal(ctx, store.CreatePrincipalRequest{
+		Principal: req.Principal,
+		Password:  req.Password,
+	})
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    principal.Name,
+		Resource: principal.Name,
+		Method:   "CreatePrincipal",
+	}, err)
+
+	return principal, err
+}
+
+// LinkPrincipal links a user to a principal, so that the principal can
+// authenticate as the user. Users can link themselves; linking anyone else
+// requires the root user. Either way the caller must know the principal's
+// password.
+func (s *Service) LinkPrincipal(ctx context.Context, req LinkPrincipalRequest) (models.User, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if req.Name != claims.Subject {
+		err = s.requireRoot(ctx, claims)
+	}
+
+	thresholds := map[string]int{req.Principal: s.Lockout.MaxUserFailures}
+	if err == nil {
+		err = s.checkLoginThrottles(ctx, thresholds)
+	}
+
+	if err == nil {
+		var res store.CheckPasswordResponse
+		res, err = s.Store.CheckPrincipalPassword(ctx, store.CheckPrincipalPasswordRequest{
+			Principal: req.Principal,
+			Password:  req.Password,
+		})
+
+		// As in Authenticate, store errors aren't failed logins.
+		if err == nil && !res.Valid {
+			err = status.Error(codes.PermissionDenied, "invalid principal credentials")
+			s.recordLoginFailure(ctx, AuthenticateRequest{Principal: req.Principal}, thresholds)
+		}
+	}
+
+	var user models.User
+	if err == nil {
+		user, err = s.Store.LinkPrincipal(ctx, store.LinkPrincipalRequest{
+			AccountID: claims.Audience,
+			Name:      req.Name,
+			Principal: req.Principal,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "LinkPrincipal",
+	}, err)
+
+	return user, err
+}
+
+func (s *Service) UnlinkPrincipal(ctx context.Context, req UnlinkPrincipalRequest) (models.User, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if req.Name != claims.Subject {
+		err = s.requireRoot(ctx, claims)
+	}
+
+	var user models.User
+	if err == nil {
+		user, err = s.Store.UnlinkPrincipal(ctx, store.UnlinkPrincipalRequest{
+			AccountID: claims.Audience,
+			Name:      req.Name,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "UnlinkPrincipal",
+	}, err)
+
+	return user, err
+}
+
+// ListMyAccounts lists the accounts that the caller can authenticate to as
+// the same principal. Callers whose user isn't linked to a principal only
+// see their own account.
+func (s *Service) ListMyAccounts(ctx context.Context, req ListMyAccountsRequest) (ListMyAccountsResponse, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return ListMyAccountsResponse{}, err
+	}
+
+	principal := claims.Principal
+	if principal == "" {
+		user, err := s.Store.GetUser(ctx, store.GetUserRequest{
+			AccountID: claims.Audience,
+			Name:      claims.Subject,
+		})
+
+		if err != nil {
+			return ListMyAccountsResponse{}, err
+		}
+
+		principal = user.Principal
+	}
+
+	if principal == "" {
+		account, err := s.Store.GetAccount(ctx, store.GetAccountRequest{
+			AccountID: claims.Audience,
+		})
+
+		if err != nil {
+			return ListMyAccountsResponse{}, err
+		}
+
+		return ListMyAccountsResponse{
+			Memberships: []models.AccountMembership{{Account: account, User: claims.Subject}},
+		}, nil
+	}
+
+	memberships, err := s.Store.ListAccountMemberships(ctx, store.ListAccountMembershipsRequest{
+		Principal: principal,
+	})
+
+	if err != nil {
+		return ListMyAccountsResponse{}, err
+	}
+
+	return ListMyAccountsResponse{Memberships: memberships}, nil
+}
+
+// authenticatePrincipal authenticates a principal and issues a token for its
+// linked user in the chosen account.
+func (s *Service) authenticatePrincipal(ctx context.Context, req AuthenticateRequest) (AuthenticateResponse, error) {
+	thresholds := map[string]int{req.Principal: s.Lockout.MaxUserFailures}
+	if ip := audit.SourceIP(ctx); ip != "" {
+		thresholds[fmt.Sprintf("ips/%s", ip)] = s.Lockout.MaxIPFailures
+	}
+
+	var membership models.AccountMembership
+	reason := AuthenticateLockedOut
+	err := s.checkLoginThrottles(ctx, thresholds)
+	if err == nil {
+		var res store.CheckPasswordResponse
+		res, err = s.Store.CheckPrincipalPassword(ctx, store.CheckPrincipalPasswordRequest{
+			Principal: req.Principal,
+			Password:  req.Password,
+		})
+
+		// As in Authenticate, store errors aren't failed logins.
+		switch {
+		case err != nil:
+			reason = AuthenticateError
+		case !res.Valid:
+			reason = AuthenticateInvalidCredentials
+			err = status.Error(codes.Unauthenticated, "failed to authenticate")
+			s.recordLoginFailure(ctx, req, thresholds)
+		default:
+			reason = AuthenticateNoMembership
+			if membership, err = s.chooseMembership(ctx, req); err == nil {
+				reason = AuthenticatePasswordExpired
+				err = s.checkPasswordAge(ctx, membership.Account.Name, res.PasswordUpdateTime)
+			}
+		}
+	}
+
+	account := req.Account
+	if membership.Account.Name != "" {
+		account = membership.Account.Name
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    req.Principal,
+		Account:  account,
+		Resource: req.Principal,
+		Method:   "Authenticate",
+	}, err)
+
+	s.authenticated(reason, err)
+
+	if err != nil {
+		return AuthenticateResponse{}, err
+	}
+
+	if err := s.Store.ResetLoginThrottles(ctx, store.ResetLoginThrottlesRequest{
+		Keys: []string{req.Principal},
+	}); err != nil {
+		log.Printf("error resetting login throttle: %v", err)
+	}
+
+	return s.issueToken(ctx, membership.Account.Name, membership.User, req.Principal)
+}
+
+// chooseMembership picks the account a principal is authenticating to. If
+// the request doesn't name one and the principal has several, the error
+// lists them.
+func (s *Service) chooseMembership(ctx context.Context, req AuthenticateRequest) (models.AccountMembership, error) {
+	memberships, err := s.Store.ListAccountMemberships(ctx, store.ListAccountMembershipsRequest{
+		Principal: req.Principal,
+	})
+
+	if err != nil {
+		return models.AccountMembership{}, err
+	}
+
+	if req.Account != "" {
+		for _, membership := range memberships {
+			if membership.Account.Name == req.Account {
+				return membership, nil
+			}
+		}
+
+		return models.AccountMembership{}, status.Errorf(codes.PermissionDenied, "principal is not a member of account: %s", req.Account)
+	}
+
+	switch len(memberships) {
+	case 0:
+		return models.AccountMembership{}, status.Error(codes.PermissionDenied, "principal is not a member of any account")
+	case 1:
+		return memberships[0], nil
+	}
+
+	var accounts []string
+	var violations []*errdetails.PreconditionFailure_Violation
+	for _, membership := range memberships {
+		accounts = append(accounts, membership.Account.Name)
+		violations = append(violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "ACCOUNT_REQUIRED",
+			Subject:     membership.Account.Name,
+			Description: fmt.Sprintf("%s as %s", membership.Account.DisplayName, membership.User),
+		})
+	}
+
+	st := status.Newf(codes.FailedPrecondition, "account is required; principal is a member of: %s", strings.Join(accounts, ", "))
+	if withDetails, err := st.WithDetails(&errdetails.PreconditionFailure{
+		Violations: violations,
+	}); err == nil {
+		st = withDetails
+	}
+
+	return models.AccountMembership{}, st.Err()
+}
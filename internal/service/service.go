@@ -2,30 +2,103 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/rsa"
+	"encoding/hex"
 	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
 	"strings"
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/pkg/errors"
-	uuid "github.com/satori/go.uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/json-multiplex/iam-service/internal/audit"
 	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/notify"
+	"github.com/json-multiplex/iam-service/internal/password"
 	"github.com/json-multiplex/iam-service/internal/store"
 )
 
 type Service struct {
 	Store                 store.Store
+	Audit                 audit.Logger
 	TokenSignKey          *rsa.PrivateKey
 	TokenVerifyKey        *rsa.PublicKey
 	TokenExpirationPeriod time.Duration
+	Lockout               LockoutPolicy
+	Notifier              notify.Notifier
+	UserTokens            UserTokenConfig
+	Invitations           InvitationConfig
+
+	// TokenClaims lists the profile attributes, such as TokenClaimEmail, that
+	// Authenticate adds to the tokens it issues.
+	TokenClaims []string
+
+	// DeletionRetention is how long deleted accounts and users can be
+	// undeleted for before they are purged.
+	DeletionRetention time.Duration
+
+	// TokenCache, if set, caches the claims of verified tokens.
+	TokenCache *TokenCache
+
+	// OnAuthenticate, if set, is called after each Authenticate call with
+	// the reason it failed, such as AuthenticateLockedOut, or "" if it
+	// succeeded.
+	OnAuthenticate func(reason string)
+}
+
+// Reasons that Authenticate calls fail for, as reported to OnAuthenticate.
+const (
+	AuthenticateInvalidCredentials = "invalid_credentials"
+	AuthenticateLockedOut          = "locked_out"
+	AuthenticatePasswordExpired    = "password_expired"
+	AuthenticateNoMembership       = "no_membership"
+	AuthenticateError              = "error"
+)
+
+// LockoutPolicy controls how failed Authenticate attempts are throttled. Once
+// a user or source IP reaches its failure threshold within FailureWindow, it
+// is locked out for LockoutDuration, doubling with every further failure up
+// to MaxLockoutDuration. A zero threshold disables that kind of lockout.
+type LockoutPolicy struct {
+	MaxUserFailures    int
+	MaxIPFailures      int
+	FailureWindow      time.Duration
+	LockoutDuration    time.Duration
+	MaxLockoutDuration time.Duration
+}
+
+func (p LockoutPolicy) duration(failures, threshold int) time.Duration {
+	if threshold <= 0 || failures < threshold {
+		return 0
+	}
+
+	d := p.LockoutDuration
+	for i := threshold; i < failures && d < p.MaxLockoutDuration; i++ {
+		d *= 2
+	}
+
+	if d > p.MaxLockoutDuration {
+		d = p.MaxLockoutDuration
+	}
+
+	return d
 }
 
+// AuthenticateRequest identifies either a user in an account, or a principal.
+// When authenticating as a principal, Account picks which of its accounts the
+// token is for, and can be left empty if it is linked to only one.
 type AuthenticateRequest struct {
-	Account  string
-	User     string
-	Password string
+	Account   string
+	User      string
+	Principal string
+	Password  string
 }
 
 type AuthenticateResponse struct {
@@ -38,9 +111,22 @@ type CreateAccountRequest struct {
 	RootPassword string
 }
 
+type GetAccountRequest struct {
+	Token       string
+	Name        string
+	ShowDeleted bool
+}
+
+type UpdateAccountRequest struct {
+	Token      string
+	Account    models.Account
+	UpdateMask []string
+}
+
 type GetUserRequest struct {
-	Token string
-	Name  string
+	Token       string
+	Name        string
+	ShowDeleted bool
 }
 
 type CreateUserRequest struct {
@@ -48,15 +134,115 @@ type CreateUserRequest struct {
 	User  models.User
 }
 
+type DeleteUserRequest struct {
+	Token string
+	Name  string
+	Etag  string
+}
+
+type GetPasswordPolicyRequest struct {
+	Token string
+	Name  string
+}
+
+type UpdatePasswordPolicyRequest struct {
+	Token          string
+	PasswordPolicy models.PasswordPolicy
+	UpdateMask     []string
+}
+
+type UnlockUserRequest struct {
+	Token string
+	Name  string
+}
+
+type ListIdentitiesRequest struct {
+	Token       string
+	Parent      string
+	ShowDeleted bool
+	Filter      string
+	OrderBy     string
+	PageSize    int32
+	PageToken   string
+}
+
+type ListIdentitiesResponse struct {
+	Identities    []models.Identity
+	NextPageToken string
+}
+
 type CreateIdentityRequest struct {
 	Token    string
 	Identity models.Identity
 	Parent   string
 }
 
+type UpdateIdentityRequest struct {
+	Token      string
+	Identity   models.Identity
+	UpdateMask []string
+}
+
+type ListAuditEventsRequest struct {
+	Token     string
+	StartTime time.Time
+	EndTime   time.Time
+	Filter    string
+	PageSize  int32
+	PageToken string
+}
+
+type ListAuditEventsResponse struct {
+	AuditEvents   []models.AuditEvent
+	NextPageToken string
+}
+
+type ListWebhooksRequest struct {
+	Token     string
+	PageSize  int32
+	PageToken string
+}
+
+type ListWebhooksResponse struct {
+	Webhooks      []models.Webhook
+	NextPageToken string
+}
+
+type CreateWebhookRequest struct {
+	Token   string
+	Webhook models.Webhook
+}
+
+type DeleteWebhookRequest struct {
+	Token string
+	Name  string
+}
+
+type ListDeadLettersRequest struct {
+	Token     string
+	Parent    string
+	PageSize  int32
+	PageToken string
+}
+
+type ListDeadLettersResponse struct {
+	DeadLetters   []models.WebhookDelivery
+	NextPageToken string
+}
+
 type claims struct {
 	jwt.StandardClaims
 	AuthMethod string `json:"amr"`
+
+	Email         string            `json:"email,omitempty"`
+	EmailVerified bool              `json:"email_verified,omitempty"`
+	Phone         string            `json:"phone_number,omitempty"`
+	Locale        string            `json:"locale,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+
+	// Principal is set on tokens issued to a principal for one of its linked
+	// users.
+	Principal string `json:"prn,omitempty"`
 }
 
 func (c *claims) Valid() error {
@@ -68,14 +254,67 @@ const (
 )
 
 func (s *Service) Authenticate(ctx context.Context, req AuthenticateRequest) (AuthenticateResponse, error) {
-	ok, err := s.Store.CheckPassword(ctx, store.CheckPasswordRequest{
+	if req.Principal != "" {
+		return s.authenticatePrincipal(ctx, req)
+	}
+
+	if !isResourceName(req.Account, "accounts") {
+		return AuthenticateResponse{}, status.Errorf(codes.InvalidArgument, "invalid account name: %s", req.Account)
+	}
+
+	if !isResourceName(req.User, "users") {
+		return AuthenticateResponse{}, status.Errorf(codes.InvalidArgument, "invalid user name: %s", req.User)
+	}
+
+	userKey := loginThrottleKey(req.Account, req.User)
+	thresholds := map[string]int{userKey: s.Lockout.MaxUserFailures}
+	if ip := audit.SourceIP(ctx); ip != "" {
+		thresholds[fmt.Sprintf("ips/%s", ip)] = s.Lockout.MaxIPFailures
+	}
+
+	reason := AuthenticateLockedOut
+	err := s.checkLoginThrottles(ctx, thresholds)
+	if err == nil {
+		var res store.CheckPasswordResponse
+		res, err = s.Store.CheckPassword(ctx, store.CheckPasswordRequest{
+			Account:  req.Account,
+			User:     req.User,
+			Password: req.Password,
+		})
+
+		// Missing users and wrong passwords get the same error, so that callers
+		// can't enumerate users. Store errors aren't failed logins, or an outage
+		// would lock out everyone who tried to sign in during it.
+		switch {
+		case err != nil:
+			reason = AuthenticateError
+		case !res.Valid:
+			reason = AuthenticateInvalidCredentials
+			err = status.Error(codes.Unauthenticated, "failed to authenticate")
+			s.recordLoginFailure(ctx, req, thresholds)
+		default:
+			reason = AuthenticatePasswordExpired
+			err = s.checkPasswordAge(ctx, req.Account, res.PasswordUpdateTime)
+		}
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    req.User,
 		Account:  req.Account,
-		User:     req.User,
-		Password: req.Password,
-	})
+		Resource: req.User,
+		Method:   "Authenticate",
+	}, err)
+
+	s.authenticated(reason, err)
 
-	if !ok {
-		return AuthenticateResponse{}, errors.New("failed to authenticate")
+	if err != nil {
+		return AuthenticateResponse{}, err
+	}
+
+	if err := s.Store.ResetLoginThrottles(ctx, store.ResetLoginThrottlesRequest{
+		Keys: []string{userKey},
+	}); err != nil {
+		log.Printf("error resetting login throttle: %v", err)
 	}
 
 	// token := jwt.NewWithClaims(jwt.SigningMethodRS256, &jwt.MapClaims{
@@ -86,33 +325,209 @@ func (s *Service) Authenticate(ctx context.Context, req AuthenticateRequest) (Au
 	// 	"amr": "password",
 	// })
 
-	accountSegments := strings.Split(req.Account, "/")
+	return s.issueToken(ctx, req.Account, req.User, "")
+}
+
+// authenticated reports the outcome of an Authenticate call to
+// OnAuthenticate. Errors that aren't statuses are reported as
+// AuthenticateError whatever the step that failed, as are store errors from
+// checking the password.
+func (s *Service) authenticated(reason string, err error) {
+	if s.OnAuthenticate == nil {
+		return
+	}
+
+	if err == nil {
+		reason = ""
+	} else if _, ok := status.FromError(err); !ok {
+		reason = AuthenticateError
+	}
+
+	s.OnAuthenticate(reason)
+}
+
+// issueToken signs a token for user in account, on behalf of principal if it
+// is set.
+func (s *Service) issueToken(ctx context.Context, account, user, principal string) (AuthenticateResponse, error) {
+	accountSegments := strings.Split(account, "/")
 	accountID := accountSegments[1]
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, &claims{
+	tokenClaims := &claims{
 		AuthMethod: amrPassword,
 		StandardClaims: jwt.StandardClaims{
-			Subject:   req.User,
+			Subject:   user,
 			Audience:  accountID,
 			ExpiresAt: time.Now().Add(s.TokenExpirationPeriod).Unix(),
 			IssuedAt:  time.Now().Unix(),
 		},
-	})
+		Principal: principal,
+	}
+
+	if err := s.profileClaims(ctx, tokenClaims); err != nil {
+		return AuthenticateResponse{}, errors.Wrap(err, "error loading token claims")
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, tokenClaims)
 
 	tokenString, err := token.SignedString(s.TokenSignKey)
 	if err != nil {
 		return AuthenticateResponse{}, errors.Wrap(err, "error signing token")
 	}
 
-	return AuthenticateResponse{Token: tokenString}, err
+	return AuthenticateResponse{Token: tokenString}, nil
+}
+
+func (s *Service) checkLoginThrottles(ctx context.Context, thresholds map[string]int) error {
+	var keys []string
+	for key := range thresholds {
+		keys = append(keys, key)
+	}
+
+	throttles, err := s.Store.GetLoginThrottles(ctx, store.GetLoginThrottlesRequest{
+		Keys: keys,
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "error getting login throttles")
+	}
+
+	now := time.Now()
+	for _, throttle := range throttles {
+		if throttle.LockedUntil != nil && throttle.LockedUntil.After(now) {
+			return status.Error(codes.ResourceExhausted, "too many failed attempts, try again later")
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed attempt against each throttle key and
+// locks out any that have crossed their threshold. Errors are logged rather
+// than returned, so that the caller sees the same response either way.
+func (s *Service) recordLoginFailure(ctx context.Context, req AuthenticateRequest, thresholds map[string]int) {
+	for key, threshold := range thresholds {
+		throttle, err := s.Store.RecordLoginFailure(ctx, store.RecordLoginFailureRequest{
+			Key:    key,
+			Window: s.Lockout.FailureWindow,
+		})
+
+		if err != nil {
+			log.Printf("error recording login failure: %v", err)
+			continue
+		}
+
+		if d := s.Lockout.duration(throttle.Failures, threshold); d > 0 {
+			if err := s.Store.LockLogin(ctx, store.LockLoginRequest{
+				Key:   key,
+				Until: time.Now().Add(d),
+			}); err != nil {
+				log.Printf("error locking login: %v", err)
+			}
+		}
+	}
+
+	// Principals that didn't pick an account have no account to notify.
+	if req.Account == "" {
+		return
+	}
+
+	resource := req.User
+	if req.Principal != "" {
+		resource = req.Principal
+	}
+
+	if err := s.Store.EnqueueWebhookEvent(ctx, store.EnqueueWebhookEventRequest{
+		AccountID: strings.TrimPrefix(req.Account, "accounts/"),
+		EventType: models.WebhookEventLoginFailed,
+		Resource:  resource,
+	}); err != nil {
+		log.Printf("error enqueueing webhook event: %v", err)
+	}
+}
+
+func loginThrottleKey(account, user string) string {
+	return fmt.Sprintf("%s/%s", account, user)
 }
 
 func (s *Service) CreateAccount(ctx context.Context, req CreateAccountRequest) (models.Account, error) {
-	return s.Store.CreateAccount(ctx, store.CreateAccountRequest{
+	if err := checkPassword(password.DefaultPolicy, "root_password", req.RootPassword); err != nil {
+		return models.Account{}, err
+	}
+
+	if err := validateEmail("root.email", req.Root.Email); err != nil {
+		return models.Account{}, err
+	}
+
+	account, err := s.Store.CreateAccount(ctx, store.CreateAccountRequest{
 		Account:      req.Account,
 		Root:         req.Root,
 		RootPassword: req.RootPassword,
 	})
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    req.Root.Name,
+		Account:  account.Name,
+		Resource: account.Name,
+		Method:   "CreateAccount",
+	}, err)
+
+	if err == nil {
+		s.sendEmailVerification(ctx, strings.TrimPrefix(account.Name, "accounts/"), req.Root)
+	}
+
+	return account, err
+}
+
+func (s *Service) GetAccount(ctx context.Context, req GetAccountRequest) (models.Account, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	if err := requireOwnAccount(claims, req.Name); err != nil {
+		return models.Account{}, err
+	}
+
+	return s.Store.GetAccount(ctx, store.GetAccountRequest{
+		AccountID:   claims.Audience,
+		ShowDeleted: req.ShowDeleted,
+	})
+}
+
+func (s *Service) UpdateAccount(ctx context.Context, req UpdateAccountRequest) (models.Account, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	if req.Account.Name != fmt.Sprintf("accounts/%s", claims.Audience) {
+		return models.Account{}, status.Errorf(codes.PermissionDenied, "cannot update account: %s", req.Account.Name)
+	}
+
+	for _, path := range req.UpdateMask {
+		if path != "display_name" {
+			return models.Account{}, status.Errorf(codes.InvalidArgument, "cannot update field: %s", path)
+		}
+	}
+
+	var account models.Account
+	err = s.requireRoot(ctx, claims)
+	if err == nil {
+		account, err = s.Store.UpdateAccount(ctx, store.UpdateAccountRequest{
+			AccountID:  claims.Audience,
+			Account:    req.Account,
+			UpdateMask: req.UpdateMask,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  req.Account.Name,
+		Resource: req.Account.Name,
+		Method:   "UpdateAccount",
+	}, err)
+
+	return account, err
 }
 
 func (s *Service) GetUser(ctx context.Context, req GetUserRequest) (models.User, error) {
@@ -122,33 +537,480 @@ func (s *Service) GetUser(ctx context.Context, req GetUserRequest) (models.User,
 	}
 
 	return s.Store.GetUser(ctx, store.GetUserRequest{
-		AccountID: claims.Audience,
-		Name:      req.Name,
+		AccountID:   claims.Audience,
+		Name:        req.Name,
+		ShowDeleted: req.ShowDeleted,
 	})
 }
 
 func (s *Service) CreateUser(ctx context.Context, req CreateUserRequest) (models.User, error) {
-	return s.Store.CreateUser(ctx, store.CreateUserRequest{
-		AccountID: uuid.NewV4().String(),
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err := validateEmail("user.email", req.User.Email); err != nil {
+		return models.User{}, err
+	}
+
+	if violations := labelViolations("user.labels", req.User.Labels); len(violations) > 0 {
+		return models.User{}, badRequest(violations)
+	}
+
+	user, err := s.Store.CreateUser(ctx, store.CreateUserRequest{
+		AccountID: claims.Audience,
 		User:      req.User,
 	})
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.User.Name,
+		Method:   "CreateUser",
+	}, err)
+
+	if err == nil {
+		s.sendEmailVerification(ctx, claims.Audience, user)
+	}
+
+	return user, err
+}
+
+func (s *Service) DeleteUser(ctx context.Context, req DeleteUserRequest) error {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return err
+	}
+
+	err = s.requireRoot(ctx, claims)
+	if err == nil {
+		err = s.Store.WithTx(ctx, func(tx store.Store) error {
+			user, err := tx.GetUser(ctx, store.GetUserRequest{
+				AccountID: claims.Audience,
+				Name:      req.Name,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			if user.IsRoot {
+				return status.Error(codes.FailedPrecondition, "cannot delete root user")
+			}
+
+			return tx.DeleteUser(ctx, store.DeleteUserRequest{
+				AccountID: claims.Audience,
+				Name:      req.Name,
+				Etag:      req.Etag,
+			})
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "DeleteUser",
+	}, err)
+
+	return err
+}
+
+func (s *Service) UnlockUser(ctx context.Context, req UnlockUserRequest) error {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return err
+	}
+
+	err = s.requireRoot(ctx, claims)
+	if err == nil {
+		_, err = s.Store.GetUser(ctx, store.GetUserRequest{
+			AccountID: claims.Audience,
+			Name:      req.Name,
+		})
+	}
+
+	account := fmt.Sprintf("accounts/%s", claims.Audience)
+	if err == nil {
+		err = s.Store.ResetLoginThrottles(ctx, store.ResetLoginThrottlesRequest{
+			Keys: []string{loginThrottleKey(account, req.Name)},
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  account,
+		Resource: req.Name,
+		Method:   "UnlockUser",
+	}, err)
+
+	return err
+}
+
+func (s *Service) ListIdentities(ctx context.Context, req ListIdentitiesRequest) (ListIdentitiesResponse, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return ListIdentitiesResponse{}, err
+	}
+
+	if !isResourceName(req.Parent, "users") {
+		return ListIdentitiesResponse{}, status.Errorf(codes.InvalidArgument, "invalid parent: %q", req.Parent)
+	}
+
+	if req.Parent != claims.Subject {
+		if err := s.requireRoot(ctx, claims); err != nil {
+			return ListIdentitiesResponse{}, err
+		}
+	}
+
+	expr, orders, err := parseListQuery(req.Filter, req.OrderBy)
+	if err != nil {
+		return ListIdentitiesResponse{}, err
+	}
+
+	res, err := s.Store.ListIdentities(ctx, store.ListIdentitiesRequest{
+		AccountID:   claims.Audience,
+		Parent:      req.Parent,
+		ShowDeleted: req.ShowDeleted,
+		Filter:      expr,
+		OrderBy:     orders,
+		PageSize:    req.PageSize,
+		PageToken:   req.PageToken,
+	})
+
+	if err != nil {
+		return ListIdentitiesResponse{}, err
+	}
+
+	return ListIdentitiesResponse{
+		Identities:    res.Identities,
+		NextPageToken: res.NextPageToken,
+	}, nil
 }
 
 func (s *Service) CreateIdentity(ctx context.Context, req CreateIdentityRequest) (models.Identity, error) {
-	return s.Store.CreateIdentity(ctx, store.CreateIdentityRequest{
-		Identity: req.Identity,
-		Parent:   req.Parent,
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	// Users may add identities to themselves; the root user may add them to
+	// anyone.
+	if req.Parent != claims.Subject {
+		if err := s.requireRoot(ctx, claims); err != nil {
+			return models.Identity{}, err
+		}
+	}
+
+	if req.Identity.AuthMethod == models.AuthMethodPassword {
+		policy, err := s.passwordPolicy(ctx, claims.Audience)
+		if err != nil {
+			return models.Identity{}, err
+		}
+
+		if err := checkPassword(policy, "identity.password", req.Identity.Password); err != nil {
+			return models.Identity{}, err
+		}
+	}
+
+	identity, err := s.Store.CreateIdentity(ctx, store.CreateIdentityRequest{
+		AccountID: claims.Audience,
+		Identity:  req.Identity,
+		Parent:    req.Parent,
+	})
+
+	resource := identity.Name
+	if resource == "" {
+		resource = req.Parent
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: resource,
+		Method:   "CreateIdentity",
+	}, err)
+
+	return identity, err
+}
+
+func (s *Service) ListAuditEvents(ctx context.Context, req ListAuditEventsRequest) (ListAuditEventsResponse, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return ListAuditEventsResponse{}, err
+	}
+
+	if err := s.requireRoot(ctx, claims); err != nil {
+		return ListAuditEventsResponse{}, err
+	}
+
+	filter, err := audit.ParseFilter(req.Filter)
+	if err != nil {
+		return ListAuditEventsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res, err := s.Store.ListAuditEvents(ctx, store.ListAuditEventsRequest{
+		AccountID: claims.Audience,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Filter:    filter,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+
+	if err != nil {
+		return ListAuditEventsResponse{}, err
+	}
+
+	return ListAuditEventsResponse{
+		AuditEvents:   res.AuditEvents,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func (s *Service) ListWebhooks(ctx context.Context, req ListWebhooksRequest) (ListWebhooksResponse, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return ListWebhooksResponse{}, err
+	}
+
+	if err := s.requireRoot(ctx, claims); err != nil {
+		return ListWebhooksResponse{}, err
+	}
+
+	res, err := s.Store.ListWebhooks(ctx, store.ListWebhooksRequest{
+		AccountID: claims.Audience,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+
+	if err != nil {
+		return ListWebhooksResponse{}, err
+	}
+
+	// Secrets are only returned from CreateWebhook.
+	for i := range res.Webhooks {
+		res.Webhooks[i].Secret = ""
+	}
+
+	return ListWebhooksResponse{
+		Webhooks:      res.Webhooks,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func (s *Service) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (models.Webhook, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	// Check the caller first, so that only root can probe URL validation or
+	// have a secret generated.
+	err = s.requireRoot(ctx, claims)
+	if err == nil {
+		err = validateWebhook(req.Webhook)
+	}
+
+	webhook := req.Webhook
+	if err == nil && webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err = rand.Read(secret); err != nil {
+			err = errors.Wrap(err, "error generating webhook secret")
+		}
+
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	if err == nil {
+		webhook, err = s.Store.CreateWebhook(ctx, store.CreateWebhookRequest{
+			AccountID: claims.Audience,
+			Webhook:   webhook,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: webhook.Name,
+		Method:   "CreateWebhook",
+	}, err)
+
+	return webhook, err
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, req DeleteWebhookRequest) error {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return err
+	}
+
+	if !isResourceName(req.Name, "webhooks") {
+		return status.Errorf(codes.InvalidArgument, "invalid webhook name: %s", req.Name)
+	}
+
+	err = s.requireRoot(ctx, claims)
+	if err == nil {
+		err = s.Store.DeleteWebhook(ctx, store.DeleteWebhookRequest{
+			AccountID: claims.Audience,
+			Name:      req.Name,
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.Name,
+		Method:   "DeleteWebhook",
+	}, err)
+
+	return err
+}
+
+func (s *Service) ListDeadLetters(ctx context.Context, req ListDeadLettersRequest) (ListDeadLettersResponse, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return ListDeadLettersResponse{}, err
+	}
+
+	if !isResourceName(req.Parent, "webhooks") {
+		return ListDeadLettersResponse{}, status.Errorf(codes.InvalidArgument, "invalid webhook name: %s", req.Parent)
+	}
+
+	if err := s.requireRoot(ctx, claims); err != nil {
+		return ListDeadLettersResponse{}, err
+	}
+
+	res, err := s.Store.ListDeadLetters(ctx, store.ListDeadLettersRequest{
+		AccountID: claims.Audience,
+		Parent:    req.Parent,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
 	})
+
+	if err != nil {
+		return ListDeadLettersResponse{}, err
+	}
+
+	for i := range res.DeadLetters {
+		res.DeadLetters[i].Secret = ""
+	}
+
+	return ListDeadLettersResponse{
+		DeadLetters:   res.DeadLetters,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func validateWebhook(w models.Webhook) error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return status.Errorf(codes.InvalidArgument, "invalid webhook url: %s", w.URL)
+	}
+
+	if len(w.EventTypes) == 0 {
+		return status.Error(codes.InvalidArgument, "event_types is required")
+	}
+
+	for _, eventType := range w.EventTypes {
+		known := false
+		for _, t := range models.WebhookEventTypes {
+			if eventType == t {
+				known = true
+			}
+		}
+
+		if !known {
+			return status.Errorf(codes.InvalidArgument, "unknown event type: %s", eventType)
+		}
+	}
+
+	return nil
+}
+
+func validateEmail(field, email string) error {
+	if email == "" {
+		return nil
+	}
+
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return badRequest([]*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: "must be a valid email address",
+		}})
+	}
+
+	return nil
+}
+
+func isResourceName(name, collection string) bool {
+	segments := strings.Split(name, "/")
+	return len(segments) == 2 && segments[0] == collection && segments[1] != ""
+}
+
+// Principal returns the user that token authenticates, as
+// "accounts/{account}/users/{user}", or "" if token is not valid.
+func (s *Service) Principal(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	claims, err := s.parseToken(token)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("accounts/%s/%s", claims.Audience, claims.Subject)
 }
 
 func (s *Service) parseToken(token string) (*claims, error) {
+	if s.TokenCache != nil {
+		if c, ok := s.TokenCache.get(token); ok {
+			if err := c.Valid(); err != nil {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+
+			return c, nil
+		}
+	}
+
 	parsed, err := jwt.ParseWithClaims(token, &claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
 			return nil, fmt.Errorf("unexpected token signing method: %v", token.Header["alg"])
 		}
 
+		if typ := token.Header["typ"]; typ != "JWT" {
+			return nil, fmt.Errorf("unexpected token type: %v", typ)
+		}
+
 		return s.TokenVerifyKey, nil
 	})
 
-	return parsed.Claims.(*claims), err
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// Other documents signed with the token key, such as erasure receipts,
+	// have no amr and must not authenticate.
+	c := parsed.Claims.(*claims)
+	if c.AuthMethod == "" {
+		return nil, status.Error(codes.Unauthenticated, "token has no authentication method")
+	}
+
+	if s.TokenCache != nil {
+		s.TokenCache.put(token, c)
+	}
+
+	return c, nil
+}
+
+// inTx calls fn with a copy of the service whose Store runs in one
+// transaction, so that checks and the writes they guard see the same data.
+// fn may be called more than once if the transaction has to be retried.
+func (s *Service) inTx(ctx context.Context, fn func(*Service) error) error {
+	return s.Store.WithTx(ctx, func(st store.Store) error {
+		tx := *s
+		tx.Store = st
+		return fn(&tx)
+	})
 }
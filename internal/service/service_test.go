@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+const testPassword = "correct-horse-battery-1"
+
+var testKey *rsa.PrivateKey
+
+func init() {
+	var err error
+	if testKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		panic(err)
+	}
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	st := store.NewMemoryStore(passhash.Bcrypt{Cost: 4})
+	return &Service{
+		Store:                 st,
+		Audit:                 audit.Logger{Store: st},
+		TokenSignKey:          testKey,
+		TokenVerifyKey:        &testKey.PublicKey,
+		TokenExpirationPeriod: time.Hour,
+		Lockout: LockoutPolicy{
+			MaxUserFailures:    3,
+			MaxIPFailures:      10,
+			FailureWindow:      time.Minute,
+			LockoutDuration:    time.Minute,
+			MaxLockoutDuration: time.Hour,
+		},
+	}
+}
+
+// testAccount is an account with a root user and a regular user, and a token
+// for each.
+type testAccount struct {
+	Name      string
+	RootToken string
+	UserToken string
+}
+
+func newTestAccount(t *testing.T, s *Service) testAccount {
+	t.Helper()
+	ctx := context.Background()
+
+	account, err := s.CreateAccount(ctx, CreateAccountRequest{
+		Account:      models.Account{DisplayName: "Test"},
+		Root:         models.User{Name: "users/root", IsRoot: true},
+		RootPassword: testPassword,
+	})
+
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+
+	root, err := s.Authenticate(ctx, AuthenticateRequest{Account: account.Name, User: "users/root", Password: testPassword})
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+
+	if _, err := s.CreateUser(ctx, CreateUserRequest{Token: root.Token, User: models.User{Name: "users/alice"}}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	user, err := s.issueToken(ctx, account.Name, "users/alice", "")
+	if err != nil {
+		t.Fatalf("issueToken: %v", err)
+	}
+
+	return testAccount{Name: account.Name, RootToken: root.Token, UserToken: user.Token}
+}
+
+func accountID(name string) string {
+	return strings.TrimPrefix(name, "accounts/")
+}
+
+func wantCode(t *testing.T, what string, err error, want codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != want {
+		t.Errorf("%s: got %v (%v), want %v", what, got, err, want)
+	}
+}
+
+func TestListAuditEventsRequiresRoot(t *testing.T) {
+	s := newTestService(t)
+	account := newTestAccount(t, s)
+	ctx := context.Background()
+
+	_, err := s.ListAuditEvents(ctx, ListAuditEventsRequest{Token: account.UserToken})
+	wantCode(t, "ListAuditEvents as user", err, codes.PermissionDenied)
+
+	res, err := s.ListAuditEvents(ctx, ListAuditEventsRequest{Token: account.RootToken})
+	if err != nil {
+		t.Fatalf("ListAuditEvents as root: %v", err)
+	}
+
+	if len(res.AuditEvents) == 0 {
+		t.Error("ListAuditEvents as root: no events")
+	}
+}
+
+func TestWebhooksRequireRoot(t *testing.T) {
+	s := newTestService(t)
+	account := newTestAccount(t, s)
+	ctx := context.Background()
+
+	webhook := models.Webhook{URL: "https://example.com/hook", EventTypes: []string{models.WebhookEventUserCreated}}
+
+	_, err := s.CreateWebhook(ctx, CreateWebhookRequest{Token: account.UserToken, Webhook: webhook})
+	wantCode(t, "CreateWebhook as user", err, codes.PermissionDenied)
+
+	// Callers are checked before the webhook, so they can't probe validation.
+	invalid := models.Webhook{URL: "ftp://example.com/hook", EventTypes: []string{models.WebhookEventUserCreated}}
+	_, err = s.CreateWebhook(ctx, CreateWebhookRequest{Token: account.UserToken, Webhook: invalid})
+	wantCode(t, "CreateWebhook with an invalid URL as user", err, codes.PermissionDenied)
+
+	_, err = s.CreateWebhook(ctx, CreateWebhookRequest{Token: account.RootToken, Webhook: invalid})
+	wantCode(t, "CreateWebhook with an invalid URL as root", err, codes.InvalidArgument)
+
+	created, err := s.CreateWebhook(ctx, CreateWebhookRequest{Token: account.RootToken, Webhook: webhook})
+	if err != nil {
+		t.Fatalf("CreateWebhook as root: %v", err)
+	}
+
+	_, err = s.ListWebhooks(ctx, ListWebhooksRequest{Token: account.UserToken})
+	wantCode(t, "ListWebhooks as user", err, codes.PermissionDenied)
+
+	_, err = s.ListDeadLetters(ctx, ListDeadLettersRequest{Token: account.UserToken, Parent: created.Name})
+	wantCode(t, "ListDeadLetters as user", err, codes.PermissionDenied)
+
+	err = s.DeleteWebhook(ctx, DeleteWebhookRequest{Token: account.UserToken, Name: created.Name})
+	wantCode(t, "DeleteWebhook as user", err, codes.PermissionDenied)
+
+	if err := s.DeleteWebhook(ctx, DeleteWebhookRequest{Token: account.RootToken, Name: created.Name}); err != nil {
+		t.Errorf("DeleteWebhook as root: %v", err)
+	}
+}
+
+func TestAccountAndUserChangesRequireRoot(t *testing.T) {
+	s := newTestService(t)
+	account := newTestAccount(t, s)
+	ctx := context.Background()
+
+	if _, err := s.CreateUser(ctx, CreateUserRequest{Token: account.RootToken, User: models.User{Name: "users/bob"}}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	update := UpdateAccountRequest{
+		Account:    models.Account{Name: account.Name, DisplayName: "Renamed"},
+		UpdateMask: []string{"display_name"},
+	}
+
+	update.Token = account.UserToken
+	_, err := s.UpdateAccount(ctx, update)
+	wantCode(t, "UpdateAccount as user", err, codes.PermissionDenied)
+
+	err = s.DeleteUser(ctx, DeleteUserRequest{Token: account.UserToken, Name: "users/bob"})
+	wantCode(t, "DeleteUser as user", err, codes.PermissionDenied)
+
+	err = s.DeleteUser(ctx, DeleteUserRequest{Token: account.UserToken, Name: "users/root"})
+	wantCode(t, "DeleteUser of root as user", err, codes.PermissionDenied)
+
+	got, err := s.GetAccount(ctx, GetAccountRequest{Token: account.RootToken, Name: account.Name})
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+
+	if got.DisplayName != "Test" {
+		t.Errorf("DisplayName = %q after a denied update, want %q", got.DisplayName, "Test")
+	}
+
+	update.Token = account.RootToken
+	if _, err := s.UpdateAccount(ctx, update); err != nil {
+		t.Errorf("UpdateAccount as root: %v", err)
+	}
+
+	if err := s.DeleteUser(ctx, DeleteUserRequest{Token: account.RootToken, Name: "users/bob"}); err != nil {
+		t.Errorf("DeleteUser as root: %v", err)
+	}
+}
+
+func TestCreateIdentityRequiresOwnerOrRoot(t *testing.T) {
+	s := newTestService(t)
+	account := newTestAccount(t, s)
+	ctx := context.Background()
+
+	identity := models.Identity{AuthMethod: models.AuthMethodPassword, Password: "another-long-password-2"}
+
+	tests := []struct {
+		name     string
+		token    string
+		parent   string
+		wantCode codes.Code
+	}{
+		{name: "user to root", token: account.UserToken, parent: "users/root", wantCode: codes.PermissionDenied},
+		{name: "user to self", token: account.UserToken, parent: "users/alice", wantCode: codes.OK},
+		{name: "root to user", token: account.RootToken, parent: "users/alice", wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateIdentity(ctx, CreateIdentityRequest{Token: tt.token, Parent: tt.parent, Identity: identity})
+			wantCode(t, "CreateIdentity", err, tt.wantCode)
+		})
+	}
+}
+
+func TestAuthenticateValidatesNames(t *testing.T) {
+	s := newTestService(t)
+	account := newTestAccount(t, s)
+
+	tests := []struct {
+		name    string
+		account string
+		user    string
+	}{
+		{"missing account", "", "users/root"},
+		{"bare account", "accounts", "users/root"},
+		{"bare user", account.Name, "root"},
+		{"empty user", account.Name, "users/"},
+		{"nested user", account.Name, "users/root/identities/x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Authenticate(context.Background(), AuthenticateRequest{Account: tt.account, User: tt.user, Password: testPassword})
+			wantCode(t, "Authenticate", err, codes.InvalidArgument)
+		})
+	}
+}
+
+func TestUpdateOrganizationRejectsRequireMFA(t *testing.T) {
+	s := newTestService(t)
+	account := newTestAccount(t, s)
+
+	_, err := s.UpdateOrganization(context.Background(), UpdateOrganizationRequest{
+		Token:        account.RootToken,
+		Organization: models.Organization{Name: "organizations/x"},
+		UpdateMask:   []string{"require_mfa"},
+	})
+
+	wantCode(t, "UpdateOrganization(require_mfa)", err, codes.InvalidArgument)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"crypto/sha256"
+	"sync"
+	"sync/atomic"
+)
+
+// TokenCache remembers the claims of tokens whose signatures have been
+// verified, so that a token used for several calls is only verified once.
+// Cached tokens are still checked for expiry every time they're used.
+type TokenCache struct {
+	size int
+
+	hits, misses int64
+
+	mu     sync.Mutex
+	tokens map[[sha256.Size]byte]claims
+}
+
+// NewTokenCache returns a TokenCache that holds up to size tokens.
+func NewTokenCache(size int) *TokenCache {
+	return &TokenCache{
+		size:   size,
+		tokens: map[[sha256.Size]byte]claims{},
+	}
+}
+
+// Stats returns how many tokens were found in the cache, and how many had to
+// be verified.
+func (c *TokenCache) Stats() (hits, misses int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+}
+
+func (c *TokenCache) get(token string) (*claims, bool) {
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	cached, ok := c.tokens[key]
+	c.mu.Unlock()
+
+	if !ok {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+
+	atomic.AddInt64(&c.hits, 1)
+	return &cached, true
+}
+
+func (c *TokenCache) put(token string, verified *claims) {
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Make room by dropping an arbitrary token.
+	if len(c.tokens) >= c.size {
+		for k := range c.tokens {
+			delete(c.tokens, k)
+			break
+		}
+	}
+
+	c.tokens[key] = *verified
+}
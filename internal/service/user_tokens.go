@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/notify"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+// UserTokenConfig controls the single-use tokens emailed to users. The URLs
+// are templates in which "{token}" is replaced by the token; if empty, the
+// bare token is sent.
+type UserTokenConfig struct {
+	PasswordResetTTL     time.Duration
+	EmailVerificationTTL time.Duration
+	PasswordResetURL     string
+	EmailVerificationURL string
+}
+
+type RequestPasswordResetRequest struct {
+	Account string
+	User    string
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token       string
+	NewPassword string
+}
+
+type VerifyEmailRequest struct {
+	Token string
+}
+
+// RequestPasswordReset emails a password reset token to the user if they have
+// a verified email address. It succeeds whether or not they do, and sends the
+// email in the background, so that callers can't probe for users.
+func (s *Service) RequestPasswordReset(ctx context.Context, req RequestPasswordResetRequest) error {
+	if !isResourceName(req.Account, "accounts") {
+		return status.Errorf(codes.InvalidArgument, "invalid account name: %s", req.Account)
+	}
+
+	if !isResourceName(req.User, "users") {
+		return status.Errorf(codes.InvalidArgument, "invalid user name: %s", req.User)
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    req.User,
+		Account:  req.Account,
+		Resource: req.User,
+		Method:   "RequestPasswordReset",
+	}, nil)
+
+	go func() {
+		ctx := context.Background()
+
+		accountID := strings.TrimPrefix(req.Account, "accounts/")
+		user, err := s.Store.GetUser(ctx, store.GetUserRequest{
+			AccountID: accountID,
+			Name:      req.User,
+		})
+
+		if err != nil || user.Email == "" || !user.EmailVerified {
+			return
+		}
+
+		if err := s.sendUserToken(ctx, accountID, user, models.UserTokenPurposePasswordReset); err != nil {
+			log.Printf("error sending password reset: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *Service) ConfirmPasswordReset(ctx context.Context, req ConfirmPasswordResetRequest) error {
+	tokenHash := hashUserToken(req.Token)
+
+	token, err := s.Store.GetUserToken(ctx, store.GetUserTokenRequest{
+		Purpose:   models.UserTokenPurposePasswordReset,
+		TokenHash: tokenHash,
+	})
+
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Error(codes.InvalidArgument, "invalid or expired token")
+		}
+
+		return err
+	}
+
+	policy, err := s.passwordPolicy(ctx, strings.TrimPrefix(token.Account, "accounts/"))
+	if err != nil {
+		return err
+	}
+
+	err = checkPassword(policy, "new_password", req.NewPassword)
+	if err == nil {
+		err = s.Store.ResetPassword(ctx, store.ResetPasswordRequest{
+			TokenHash:   tokenHash,
+			Password:    req.NewPassword,
+			HistorySize: policy.HistorySize,
+		})
+
+		switch {
+		case err == store.ErrPasswordReused:
+			err = passwordViolations("new_password", []string{
+				fmt.Sprintf("must not match any of the last %d passwords", policy.HistorySize),
+			})
+		case status.Code(err) == codes.NotFound:
+			err = status.Error(codes.InvalidArgument, "invalid or expired token")
+		}
+	}
+
+	// A successful reset is the way out of a lockout.
+	if err == nil {
+		if err := s.Store.ResetLoginThrottles(ctx, store.ResetLoginThrottlesRequest{
+			Keys: []string{loginThrottleKey(token.Account, token.User)},
+		}); err != nil {
+			log.Printf("error resetting login throttle: %v", err)
+		}
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    token.User,
+		Account:  token.Account,
+		Resource: token.User,
+		Method:   "ConfirmPasswordReset",
+	}, err)
+
+	return err
+}
+
+func (s *Service) VerifyEmail(ctx context.Context, req VerifyEmailRequest) error {
+	tokenHash := hashUserToken(req.Token)
+
+	token, err := s.Store.GetUserToken(ctx, store.GetUserTokenRequest{
+		Purpose:   models.UserTokenPurposeEmailVerification,
+		TokenHash: tokenHash,
+	})
+
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Error(codes.InvalidArgument, "invalid or expired token")
+		}
+
+		return err
+	}
+
+	err = s.Store.VerifyEmail(ctx, store.VerifyEmailRequest{
+		TokenHash: tokenHash,
+	})
+
+	if status.Code(err) == codes.NotFound {
+		err = status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    token.User,
+		Account:  token.Account,
+		Resource: token.User,
+		Method:   "VerifyEmail",
+	}, err)
+
+	return err
+}
+
+// sendEmailVerification emails a verification token to a newly set address.
+// Failures are logged rather than returned, since the change that set the
+// address has already been made.
+func (s *Service) sendEmailVerification(ctx context.Context, accountID string, user models.User) {
+	if user.Email == "" {
+		return
+	}
+
+	if err := s.sendUserToken(ctx, accountID, user, models.UserTokenPurposeEmailVerification); err != nil {
+		log.Printf("error sending email verification: %v", err)
+	}
+}
+
+func (s *Service) sendUserToken(ctx context.Context, accountID string, user models.User, purpose models.UserTokenPurpose) error {
+	if s.Notifier == nil {
+		return nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	ttl, url, subject := s.UserTokens.PasswordResetTTL, s.UserTokens.PasswordResetURL, "Reset your password"
+	if purpose == models.UserTokenPurposeEmailVerification {
+		ttl, url, subject = s.UserTokens.EmailVerificationTTL, s.UserTokens.EmailVerificationURL, "Verify your email address"
+	}
+
+	if err := s.Store.CreateUserToken(ctx, store.CreateUserTokenRequest{
+		AccountID: accountID,
+		UserToken: models.UserToken{
+			User:       user.Name,
+			Purpose:    purpose,
+			TokenHash:  hashUserToken(token),
+			Email:      user.Email,
+			ExpireTime: time.Now().Add(ttl),
+		},
+	}); err != nil {
+		return err
+	}
+
+	link := token
+	if url != "" {
+		link = strings.Replace(url, "{token}", token, -1)
+	}
+
+	return s.Notifier.Notify(ctx, notify.Message{
+		To:      user.Email,
+		Subject: subject,
+		Body:    fmt.Sprintf("%s\n\nThis link expires in %s. If you didn't ask for it, you can ignore this email.\n", link, ttl),
+	})
+}
+
+func hashUserToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/notify"
+)
+
+// recorder is a notify.Notifier that hands messages to the test.
+type recorder chan notify.Message
+
+func (r recorder) Notify(ctx context.Context, m notify.Message) error {
+	r <- m
+	return nil
+}
+
+// receive returns the token in the next message, which is sent in the
+// background for password resets.
+func (r recorder) receive(t *testing.T) string {
+	t.Helper()
+
+	select {
+	case m := <-r:
+		return strings.SplitN(m.Body, "\n", 2)[0]
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message sent")
+		return ""
+	}
+}
+
+func (r recorder) none(t *testing.T) {
+	t.Helper()
+
+	select {
+	case m := <-r:
+		t.Fatalf("unexpected message: %+v", m)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func newUserTokenService(t *testing.T, ttl time.Duration) (*Service, recorder, testAccount) {
+	t.Helper()
+
+	s := newTestService(t)
+	messages := make(recorder, 10)
+	s.Notifier = messages
+	s.UserTokens = UserTokenConfig{PasswordResetTTL: ttl, EmailVerificationTTL: ttl}
+
+	account := newTestAccount(t, s)
+	if _, err := s.UpdateUser(context.Background(), UpdateUserRequest{
+		Token:      account.UserToken,
+		User:       models.User{Name: "users/alice", Email: "alice@example.com"},
+		UpdateMask: []string{"email"},
+	}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	if _, err := s.CreateIdentity(context.Background(), CreateIdentityRequest{
+		Token:    account.UserToken,
+		Parent:   "users/alice",
+		Identity: models.Identity{AuthMethod: models.AuthMethodPassword, Password: testPassword},
+	}); err != nil {
+		t.Fatalf("CreateIdentity: %v", err)
+	}
+
+	return s, messages, account
+}
+
+func TestPasswordReset(t *testing.T) {
+	s, messages, account := newUserTokenService(t, time.Hour)
+	ctx := context.Background()
+	reset := RequestPasswordResetRequest{Account: account.Name, User: "users/alice"}
+
+	// Resets are only sent to verified addresses.
+	verification := messages.receive(t)
+	if err := s.RequestPasswordReset(ctx, reset); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+
+	messages.none(t)
+
+	if err := s.VerifyEmail(ctx, VerifyEmailRequest{Token: verification}); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+
+	err := s.VerifyEmail(ctx, VerifyEmailRequest{Token: verification})
+	wantCode(t, "VerifyEmail reused", err, codes.InvalidArgument)
+
+	// Lock alice out; the reset should let alice back in.
+	for i := 0; i < s.Lockout.MaxUserFailures; i++ {
+		s.Authenticate(ctx, AuthenticateRequest{Account: account.Name, User: "users/alice", Password: "wrong"})
+	}
+
+	if err := s.RequestPasswordReset(ctx, reset); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+
+	token := messages.receive(t)
+	const newPassword = "a-brand-new-password-9"
+
+	tests := []struct {
+		name     string
+		req      ConfirmPasswordResetRequest
+		wantCode codes.Code
+	}{
+		{"unknown token", ConfirmPasswordResetRequest{Token: "nope", NewPassword: newPassword}, codes.InvalidArgument},
+		{"verification token", ConfirmPasswordResetRequest{Token: verification, NewPassword: newPassword}, codes.InvalidArgument},
+		{"weak password", ConfirmPasswordResetRequest{Token: token, NewPassword: "short"}, codes.InvalidArgument},
+		{"valid", ConfirmPasswordResetRequest{Token: token, NewPassword: newPassword}, codes.OK},
+		{"reused", ConfirmPasswordResetRequest{Token: token, NewPassword: newPassword + "0"}, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		err := s.ConfirmPasswordReset(ctx, tt.req)
+		wantCode(t, "ConfirmPasswordReset "+tt.name, err, tt.wantCode)
+	}
+
+	if _, err := s.Authenticate(ctx, AuthenticateRequest{Account: account.Name, User: "users/alice", Password: newPassword}); err != nil {
+		t.Errorf("Authenticate with new password: %v", err)
+	}
+}
+
+func TestUserTokenExpired(t *testing.T) {
+	s, messages, _ := newUserTokenService(t, -time.Minute)
+
+	err := s.VerifyEmail(context.Background(), VerifyEmailRequest{Token: messages.receive(t)})
+	wantCode(t, "VerifyEmail expired", err, codes.InvalidArgument)
+}
+
+func TestRequestPasswordResetValidatesNames(t *testing.T) {
+	s, messages, account := newUserTokenService(t, time.Hour)
+	messages.receive(t)
+
+	tests := []struct {
+		name     string
+		req      RequestPasswordResetRequest
+		wantCode codes.Code
+	}{
+		{"bad account", RequestPasswordResetRequest{Account: "users/alice", User: "users/alice"}, codes.InvalidArgument},
+		{"bad user", RequestPasswordResetRequest{Account: account.Name, User: "alice"}, codes.InvalidArgument},
+		{"unknown user", RequestPasswordResetRequest{Account: account.Name, User: "users/bob"}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		err := s.RequestPasswordReset(context.Background(), tt.req)
+		wantCode(t, "RequestPasswordReset "+tt.name, err, tt.wantCode)
+	}
+
+	messages.none(t)
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit"
+	"github.com/json-multiplex/iam-service/internal/filter"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+type ListUsersRequest struct {
+	Token       string
+	ShowDeleted bool
+	Filter      string
+	OrderBy     string
+	PageSize    int32
+	PageToken   string
+}
+
+type ListUsersResponse struct {
+	Users         []models.User
+	NextPageToken string
+}
+
+type UpdateUserRequest struct {
+	Token      string
+	User       models.User
+	UpdateMask []string
+}
+
+// Profile claims that can be added to tokens with Service.TokenClaims.
+const (
+	TokenClaimEmail  = "email"
+	TokenClaimPhone  = "phone"
+	TokenClaimLocale = "locale"
+	TokenClaimLabels = "labels"
+)
+
+var (
+	labelKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+
+	userUpdatableFields = []string{"display_name", "email", "phone", "locale", "labels", "annotations"}
+)
+
+const maxLabelValueLength = 63
+
+func (s *Service) ListUsers(ctx context.Context, req ListUsersRequest) (ListUsersResponse, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return ListUsersResponse{}, err
+	}
+
+	expr, orders, err := parseListQuery(req.Filter, req.OrderBy)
+	if err != nil {
+		return ListUsersResponse{}, err
+	}
+
+	res, err := s.Store.ListUsers(ctx, store.ListUsersRequest{
+		AccountID:   claims.Audience,
+		ShowDeleted: req.ShowDeleted,
+		Filter:      expr,
+		OrderBy:     orders,
+		PageSize:    req.PageSize,
+		PageToken:   req.PageToken,
+	})
+
+	if err != nil {
+		return ListUsersResponse{}, err
+	}
+
+	return ListUsersResponse{
+		Users:         res.Users,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+// UpdateUser updates a user's profile. Users can update their own profile;
+// updating anyone else's requires the root user.
+func (s *Service) UpdateUser(ctx context.Context, req UpdateUserRequest) (models.User, error) {
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	mask := req.UpdateMask
+	if len(mask) == 0 {
+		mask = userUpdatableFields
+	}
+
+	if req.User.Name != claims.Subject {
+		err = s.requireRoot(ctx, claims)
+	}
+
+	if err == nil {
+		err = validateUser(req.User, mask)
+	}
+
+	var before, user models.User
+	if err == nil {
+		err = s.Store.WithTx(ctx, func(tx store.Store) error {
+			var err error
+			before, err = tx.GetUser(ctx, store.GetUserRequest{
+				AccountID: claims.Audience,
+				Name:      req.User.Name,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			user, err = tx.UpdateUser(ctx, store.UpdateUserRequest{
+				AccountID:  claims.Audience,
+				User:       req.User,
+				UpdateMask: mask,
+			})
+
+			return err
+		})
+	}
+
+	s.Audit.Log(ctx, audit.Entry{
+		Actor:    claims.Subject,
+		Account:  fmt.Sprintf("accounts/%s", claims.Audience),
+		Resource: req.User.Name,
+		Method:   "UpdateUser",
+	}, err)
+
+	if err == nil && user.Email != before.Email {
+		s.sendEmailVerification(ctx, claims.Audience, user)
+	}
+
+	return user, err
+}
+
+func validateUser(user models.User, mask []string) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, path := range mask {
+		switch path {
+		case "display_name", "phone", "locale", "annotations":
+		case "email":
+			if err := validateEmail("user.email", user.Email); err != nil {
+				return err
+			}
+		case "labels":
+			violations = append(violations, labelViolations("user.labels", user.Labels)...)
+		default:
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       "update_mask",
+				Description: fmt.Sprintf("cannot update field: %s", path),
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return badRequest(violations)
+	}
+
+	return nil
+}
+
+func labelViolations(field string, labels map[string]string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for key, value := range labels {
+		if !labelKeyPattern.MatchString(key) {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("%s.%s", field, key),
+				Description: "label keys must start with a lowercase letter and contain only lowercase letters, digits, '-' and '_'",
+			})
+		}
+
+		if len(value) > maxLabelValueLength {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("%s.%s", field, key),
+				Description: fmt.Sprintf("label values must be at most %d characters", maxLabelValueLength),
+			})
+		}
+	}
+
+	return violations
+}
+
+// profileClaims copies the profile attributes named in s.TokenClaims into c.
+func (s *Service) profileClaims(ctx context.Context, c *claims) error {
+	if len(s.TokenClaims) == 0 {
+		return nil
+	}
+
+	user, err := s.Store.GetUser(ctx, store.GetUserRequest{
+		AccountID: c.Audience,
+		Name:      c.Subject,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, claim := range s.TokenClaims {
+		switch claim {
+		case TokenClaimEmail:
+			c.Email = user.Email
+			c.EmailVerified = user.EmailVerified
+		case TokenClaimPhone:
+			c.Phone = user.Phone
+		case TokenClaimLocale:
+			c.Locale = user.Locale
+		case TokenClaimLabels:
+			c.Labels = user.Labels
+		}
+	}
+
+	return nil
+}
+
+// parseListQuery parses the filter and order_by fields of a list request.
+// Field names are checked by the store, which knows what each resource can
+// be filtered on.
+func parseListQuery(filterString, orderBy string) (filter.Expr, []filter.Order, error) {
+	expr, err := filter.Parse(filterString)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+
+	orders, err := filter.ParseOrderBy(orderBy)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+
+	return expr, orders, nil
+}
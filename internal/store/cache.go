@@ -0,0 +1,479 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+// cacheInvalidationChannel is the Postgres channel on which CachingStores
+// share invalidations.
+const cacheInvalidationChannel = "iam_cache_invalidation"
+
+const defaultCacheSize = 10000
+
+// CachingStore is a Store that caches the results of GetUser and of
+// unfiltered ListIdentities requests for up to TTL. Entries are invalidated
+// when the user or its identities are changed through the CachingStore and,
+// if DB is set, when another process's CachingStore publishes a change with
+// NOTIFY and Listen receives it. Reads inside WithTx aren't cached.
+//
+// Results read from a replica may be cached while the replica lags behind,
+// so a change can take up to TTL to be seen even with invalidation.
+type CachingStore struct {
+	Store
+
+	// DB, if set, is the Postgres database through which invalidations are
+	// shared with other processes.
+	DB *sqlx.DB
+
+	cache *cache
+
+	// pending is set on the Store that WithTx passes to its function. It
+	// collects the invalidations to apply once the transaction is over.
+	pending *[]cacheInvalidation
+}
+
+// NewCachingStore returns a CachingStore in front of st that keeps up to size
+// entries, or a default number if size is 0, for up to ttl each.
+func NewCachingStore(st Store, ttl time.Duration, size int) *CachingStore {
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+
+	return &CachingStore{
+		Store: st,
+		cache: &cache{
+			ttl:        ttl,
+			size:       size,
+			users:      map[cacheKey]cachedUser{},
+			identities: map[identitiesKey]cachedIdentities{},
+		},
+	}
+}
+
+// Stats returns how many GetUser and ListIdentities calls were served from
+// the cache, and how many weren't.
+func (s *CachingStore) Stats() (hits, misses int64) {
+	return atomic.LoadInt64(&s.cache.hits), atomic.LoadInt64(&s.cache.misses)
+}
+
+func (s *CachingStore) WithTx(ctx context.Context, fn func(Store) error) error {
+	if s.pending != nil {
+		return s.Store.WithTx(ctx, func(tx Store) error {
+			return fn(&CachingStore{Store: tx, DB: s.DB, cache: s.cache, pending: s.pending})
+		})
+	}
+
+	var pending []cacheInvalidation
+	err := s.Store.WithTx(ctx, func(tx Store) error {
+		// Start over if the transaction is retried.
+		pending = pending[:0]
+		return fn(&CachingStore{Store: tx, DB: s.DB, cache: s.cache, pending: &pending})
+	})
+
+	// Invalidate even if the transaction failed, since it may have failed to
+	// commit after the changes were made.
+	for _, inv := range pending {
+		s.invalidate(ctx, inv)
+	}
+
+	return err
+}
+
+func (s *CachingStore) GetUser(ctx context.Context, req GetUserRequest) (models.User, error) {
+	if s.pending != nil || req.ShowDeleted {
+		return s.Store.GetUser(ctx, req)
+	}
+
+	key := cacheKey{AccountID: req.AccountID, User: req.Name}
+	if user, ok := s.cache.user(key); ok {
+		return user, nil
+	}
+
+	generation := s.cache.generation()
+	user, err := s.Store.GetUser(ctx, req)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	s.cache.putUser(generation, key, user)
+	return user, nil
+}
+
+func (s *CachingStore) ListIdentities(ctx context.Context, req ListIdentitiesRequest) (ListIdentitiesResponse, error) {
+	if s.pending != nil || req.Filter != nil || len(req.OrderBy) > 0 || req.PageToken != "" {
+		return s.Store.ListIdentities(ctx, req)
+	}
+
+	key := identitiesKey{
+		cacheKey:    cacheKey{AccountID: req.AccountID, User: req.Parent},
+		ShowDeleted: req.ShowDeleted,
+		PageSize:    req.PageSize,
+	}
+
+	if res, ok := s.cache.identityList(key); ok {
+		return res, nil
+	}
+
+	generation := s.cache.generation()
+	res, err := s.Store.ListIdentities(ctx, req)
+	if err != nil {
+		return ListIdentitiesResponse{}, err
+	}
+
+	s.cache.putIdentities(generation, key, res)
+	return res, nil
+}
+
+func (s *CachingStore) UpdateUser(ctx context.Context, req UpdateUserRequest) (models.User, error) {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID, User: req.User.Name})
+	return s.Store.UpdateUser(ctx, req)
+}
+
+func (s *CachingStore) DeleteUser(ctx context.Context, req DeleteUserRequest) error {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID, User: req.Name})
+	return s.Store.DeleteUser(ctx, req)
+}
+
+func (s *CachingStore) UndeleteUser(ctx context.Context, req UndeleteUserRequest) (models.User, error) {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID, User: req.Name})
+	return s.Store.UndeleteUser(ctx, req)
+}
+
+func (s *CachingStore) EraseUser(ctx context.Context, req EraseUserRequest) (EraseUserResponse, error) {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID, User: req.Name})
+	return s.Store.EraseUser(ctx, req)
+}
+
+func (s *CachingStore) LinkPrincipal(ctx context.Context, req LinkPrincipalRequest) (models.User, error) {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID, User: req.Name})
+	return s.Store.LinkPrincipal(ctx, req)
+}
+
+func (s *CachingStore) UnlinkPrincipal(ctx context.Context, req UnlinkPrincipalRequest) (models.User, error) {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID, User: req.Name})
+	return s.Store.UnlinkPrincipal(ctx, req)
+}
+
+func (s *CachingStore) CreateIdentity(ctx context.Context, req CreateIdentityRequest) (models.Identity, error) {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID, User: req.Parent})
+	return s.Store.CreateIdentity(ctx, req)
+}
+
+func (s *CachingStore) UpdateIdentity(ctx context.Context, req UpdateIdentityRequest) (models.Identity, error) {
+	user := req.Identity.Name
+	if i := strings.Index(user, "/identities/"); i >= 0 {
+		user = user[:i]
+	}
+
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID, User: user})
+	return s.Store.UpdateIdentity(ctx, req)
+}
+
+// Deleting, undeleting or moving an account changes what its users' reads
+// return, so those invalidate the whole account.
+
+func (s *CachingStore) DeleteAccount(ctx context.Context, req DeleteAccountRequest) error {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID})
+	return s.Store.DeleteAccount(ctx, req)
+}
+
+func (s *CachingStore) UndeleteAccount(ctx context.Context, req UndeleteAccountRequest) (models.Account, error) {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID})
+	return s.Store.UndeleteAccount(ctx, req)
+}
+
+func (s *CachingStore) MoveAccount(ctx context.Context, req MoveAccountRequest) (models.Account, error) {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID})
+	return s.Store.MoveAccount(ctx, req)
+}
+
+func (s *CachingStore) ImportAccount(ctx context.Context, req ImportAccountRequest) (ImportAccountResponse, error) {
+	defer s.invalidate(ctx, cacheInvalidation{AccountID: req.AccountID})
+	return s.Store.ImportAccount(ctx, req)
+}
+
+// The users that PurgeDeleted, ResetPassword, VerifyEmail and
+// AcceptInvitation change aren't known in advance, so they invalidate every
+// entry.
+
+func (s *CachingStore) PurgeDeleted(ctx context.Context, req PurgeDeletedRequest) (PurgeDeletedResponse, error) {
+	defer s.invalidate(ctx, cacheInvalidation{})
+	return s.Store.PurgeDeleted(ctx, req)
+}
+
+func (s *CachingStore) ResetPassword(ctx context.Context, req ResetPasswordRequest) error {
+	defer s.invalidate(ctx, cacheInvalidation{})
+	return s.Store.ResetPassword(ctx, req)
+}
+
+func (s *CachingStore) VerifyEmail(ctx context.Context, req VerifyEmailRequest) error {
+	defer s.invalidate(ctx, cacheInvalidation{})
+	return s.Store.VerifyEmail(ctx, req)
+}
+
+func (s *CachingStore) AcceptInvitation(ctx context.Context, req AcceptInvitationRequest) (models.User, error) {
+	defer s.invalidate(ctx, cacheInvalidation{})
+	return s.Store.AcceptInvitation(ctx, req)
+}
+
+// invalidate drops the entries inv covers and publishes inv to other
+// processes, or defers both until the transaction is over inside WithTx.
+func (s *CachingStore) invalidate(ctx context.Context, inv cacheInvalidation) {
+	if s.pending != nil {
+		*s.pending = append(*s.pending, inv)
+		return
+	}
+
+	s.cache.invalidate(inv)
+
+	if s.DB == nil {
+		return
+	}
+
+	payload, err := json.Marshal(inv)
+	if err != nil {
+		log.Printf("error publishing cache invalidation: %v", err)
+		return
+	}
+
+	if _, err := s.DB.ExecContext(ctx, `
+		SELECT pg_notify($1, $2)
+	`, cacheInvalidationChannel, string(payload)); err != nil {
+		log.Printf("error publishing cache invalidation: %v", err)
+	}
+}
+
+// Listen applies the invalidations published by other processes until ctx is
+// done. dsn is the connection string of DB.
+func (s *CachingStore) Listen(ctx context.Context, dsn string) error {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("error listening for cache invalidations: %v", err)
+		}
+	})
+
+	defer listener.Close()
+
+	if err := listener.Listen(cacheInvalidationChannel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case n := <-listener.Notify:
+			// A nil notification means that the connection was lost, and
+			// invalidations may have been missed in the meantime.
+			var inv cacheInvalidation
+			if n != nil {
+				if err := json.Unmarshal([]byte(n.Extra), &inv); err != nil {
+					log.Printf("error reading cache invalidation: %v", err)
+				}
+			}
+
+			s.cache.invalidate(inv)
+		case <-time.After(90 * time.Second):
+			go listener.Ping()
+		}
+	}
+}
+
+// cacheInvalidation covers the entries of one user, or of all users in an
+// account if User is empty, or every entry if AccountID is empty too.
+type cacheInvalidation struct {
+	AccountID string `json:"account_id,omitempty"`
+	User      string `json:"user,omitempty"`
+}
+
+type cacheKey struct {
+	AccountID string
+	User      string
+}
+
+type identitiesKey struct {
+	cacheKey
+	ShowDeleted bool
+	PageSize    int32
+}
+
+type cachedUser struct {
+	user       models.User
+	expireTime time.Time
+}
+
+type cachedIdentities struct {
+	res        ListIdentitiesResponse
+	expireTime time.Time
+}
+
+type cache struct {
+	ttl  time.Duration
+	size int
+
+	hits, misses int64
+
+	mu         sync.Mutex
+	users      map[cacheKey]cachedUser
+	identities map[identitiesKey]cachedIdentities
+
+	// invalidations counts calls to invalidate, so that a result loaded while
+	// an invalidation happened isn't cached.
+	invalidations uint64
+}
+
+func (c *cache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.invalidations
+}
+
+func (c *cache) user(key cacheKey) (models.User, bool) {
+	c.mu.Lock()
+	entry, ok := c.users[key]
+	c.mu.Unlock()
+
+	if !ok || time.Now().After(entry.expireTime) {
+		atomic.AddInt64(&c.misses, 1)
+		return models.User{}, false
+	}
+
+	atomic.AddInt64(&c.hits, 1)
+	return copyUser(entry.user), true
+}
+
+func (c *cache) identityList(key identitiesKey) (ListIdentitiesResponse, bool) {
+	c.mu.Lock()
+	entry, ok := c.identities[key]
+	c.mu.Unlock()
+
+	if !ok || time.Now().After(entry.expireTime) {
+		atomic.AddInt64(&c.misses, 1)
+		return ListIdentitiesResponse{}, false
+	}
+
+	atomic.AddInt64(&c.hits, 1)
+
+	res := entry.res
+	res.Identities = append([]models.Identity(nil), res.Identities...)
+	return res, true
+}
+
+func (c *cache) putUser(generation uint64, key cacheKey, user models.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.invalidations {
+		return
+	}
+
+	c.makeRoom()
+	c.users[key] = cachedUser{user: copyUser(user), expireTime: time.Now().Add(c.ttl)}
+}
+
+func (c *cache) putIdentities(generation uint64, key identitiesKey, res ListIdentitiesResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.invalidations {
+		return
+	}
+
+	res.Identities = append([]models.Identity(nil), res.Identities...)
+
+	c.makeRoom()
+	c.identities[key] = cachedIdentities{res: res, expireTime: time.Now().Add(c.ttl)}
+}
+
+// makeRoom makes sure that there is room for one more entry, by dropping the
+// expired ones or, failing that, an arbitrary one. c.mu must be held.
+func (c *cache) makeRoom() {
+	if len(c.users)+len(c.identities) < c.size {
+		return
+	}
+
+	now := time.Now()
+	for key, entry := range c.users {
+		if now.After(entry.expireTime) {
+			delete(c.users, key)
+		}
+	}
+
+	for key, entry := range c.identities {
+		if now.After(entry.expireTime) {
+			delete(c.identities, key)
+		}
+	}
+
+	for key := range c.users {
+		if len(c.users)+len(c.identities) < c.size {
+			return
+		}
+
+		delete(c.users, key)
+	}
+
+	for key := range c.identities {
+		if len(c.users)+len(c.identities) < c.size {
+			return
+		}
+
+		delete(c.identities, key)
+	}
+}
+
+func (c *cache) invalidate(inv cacheInvalidation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidations++
+
+	covers := func(key cacheKey) bool {
+		return inv.AccountID == "" ||
+			key.AccountID == inv.AccountID && (inv.User == "" || key.User == inv.User)
+	}
+
+	for key := range c.users {
+		if covers(key) {
+			delete(c.users, key)
+		}
+	}
+
+	for key := range c.identities {
+		if covers(key.cacheKey) {
+			delete(c.identities, key)
+		}
+	}
+}
+
+// copyUser copies user's maps, so that callers can't change a cached user.
+func copyUser(user models.User) models.User {
+	user.Labels = copyLabels(user.Labels)
+	user.Annotations = copyLabels(user.Annotations)
+	return user
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		out[k] = v
+	}
+
+	return out
+}
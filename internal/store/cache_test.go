@@ -0,0 +1,170 @@
+package store_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+	"github.com/json-multiplex/iam-service/internal/store"
+	"github.com/json-multiplex/iam-service/internal/store/storetest"
+)
+
+func TestCachingStore(t *testing.T) {
+	// A small cache also exercises eviction.
+	storetest.Run(t, func(t *testing.T) store.Store {
+		return store.NewCachingStore(store.NewMemoryStore(passhash.Bcrypt{Cost: 4}), time.Minute, 3)
+	})
+}
+
+func TestCachingStoreInvalidation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+
+		// setup runs against the underlying store, so it doesn't invalidate
+		// anything itself.
+		setup func(t *testing.T, s store.Store, accountID string)
+		op    func(s store.Store, accountID string) error
+	}{
+		{
+			name: "UpdateUser",
+			op: func(s store.Store, accountID string) error {
+				_, err := s.UpdateUser(ctx, store.UpdateUserRequest{
+					AccountID:  accountID,
+					User:       models.User{Name: "users/root", DisplayName: "Root"},
+					UpdateMask: []string{"display_name"},
+				})
+
+				return err
+			},
+		},
+		{
+			name: "DeleteAccount",
+			op: func(s store.Store, accountID string) error {
+				return s.DeleteAccount(ctx, store.DeleteAccountRequest{AccountID: accountID})
+			},
+		},
+		{
+			name: "UndeleteAccount",
+			setup: func(t *testing.T, s store.Store, accountID string) {
+				if err := s.DeleteAccount(ctx, store.DeleteAccountRequest{AccountID: accountID}); err != nil {
+					t.Fatalf("DeleteAccount: %v", err)
+				}
+			},
+			op: func(s store.Store, accountID string) error {
+				_, err := s.UndeleteAccount(ctx, store.UndeleteAccountRequest{AccountID: accountID})
+				return err
+			},
+		},
+		{
+			name: "MoveAccount",
+			op: func(s store.Store, accountID string) error {
+				_, err := s.MoveAccount(ctx, store.MoveAccountRequest{AccountID: accountID})
+				return err
+			},
+		},
+		{
+			name: "AcceptInvitation",
+			setup: func(t *testing.T, s store.Store, accountID string) {
+				if _, err := s.CreateInvitation(ctx, store.CreateInvitationRequest{
+					AccountID: accountID,
+					Invitation: models.Invitation{
+						ExpireTime: time.Now().Add(time.Hour),
+						Email:      "ann@example.com",
+						User:       models.User{Name: "users/ann"},
+						Inviter:    "users/root",
+						CodeHash:   []byte("code hash"),
+					},
+				}); err != nil {
+					t.Fatalf("CreateInvitation: %v", err)
+				}
+			},
+			op: func(s store.Store, accountID string) error {
+				_, err := s.AcceptInvitation(ctx, store.AcceptInvitationRequest{CodeHash: []byte("code hash"), Password: "password"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := store.NewMemoryStore(passhash.Bcrypt{Cost: 4})
+			cs := store.NewCachingStore(inner, time.Minute, 0)
+
+			account, err := inner.CreateAccount(ctx, store.CreateAccountRequest{
+				Account:      models.Account{DisplayName: "Test"},
+				Root:         models.User{Name: "users/root", IsRoot: true},
+				RootPassword: "password",
+			})
+
+			if err != nil {
+				t.Fatalf("CreateAccount: %v", err)
+			}
+
+			accountID := strings.TrimPrefix(account.Name, "accounts/")
+			if tt.setup != nil {
+				tt.setup(t, inner, accountID)
+			}
+
+			list := func() {
+				t.Helper()
+
+				if _, err := cs.ListIdentities(ctx, store.ListIdentitiesRequest{AccountID: accountID, Parent: "users/root"}); err != nil {
+					t.Fatalf("ListIdentities: %v", err)
+				}
+			}
+
+			// The second call is served from the cache.
+			list()
+			list()
+			if hits, _ := cs.Stats(); hits != 1 {
+				t.Fatalf("hits = %d before %s, want 1", hits, tt.name)
+			}
+
+			if err := tt.op(cs, accountID); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+
+			_, missesBefore := cs.Stats()
+			list()
+			if _, misses := cs.Stats(); misses != missesBefore+1 {
+				t.Errorf("ListIdentities after %s was served from the cache", tt.name)
+			}
+		})
+	}
+}
+
+func TestCachingStoreCopiesUsers(t *testing.T) {
+	ctx := context.Background()
+	cs := store.NewCachingStore(store.NewMemoryStore(passhash.Bcrypt{Cost: 4}), time.Minute, 0)
+
+	account, err := cs.CreateAccount(ctx, store.CreateAccountRequest{
+		Account:      models.Account{DisplayName: "Test"},
+		Root:         models.User{Name: "users/root", IsRoot: true, Labels: map[string]string{"team": "eng"}},
+		RootPassword: "password",
+	})
+
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+
+	get := func() models.User {
+		t.Helper()
+
+		user, err := cs.GetUser(ctx, store.GetUserRequest{AccountID: strings.TrimPrefix(account.Name, "accounts/"), Name: "users/root"})
+		if err != nil {
+			t.Fatalf("GetUser: %v", err)
+		}
+
+		return user
+	}
+
+	get().Labels["team"] = "changed"
+	if team := get().Labels["team"]; team != "eng" {
+		t.Errorf("cached user's labels were changed through a returned copy: team = %q", team)
+	}
+}
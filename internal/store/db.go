@@ -2,144 +2,535 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"log"
 	"strings"
+	"sync"
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/passhash"
 	uuid "github.com/satori/go.uuid"
 )
 
 type DBStore struct {
-	DB *sqlx.DB
+	DB     *sqlx.DB
+	Hasher passhash.Hasher
+
+	// Replica, if set, is a read replica of DB that serves GetUser,
+	// CheckPassword and the List methods in contexts from WithReadSession.
+	Replica *sqlx.DB
+
+	missingUserHashOnce sync.Once
+	missingUserHashed   string
+
+	// tx is set on the Store that WithTx passes to its function.
+	tx *sqlx.Tx
 }
 
 type dbUser struct {
-	ID          uuid.UUID  `db:"id"`
-	AccountID   uuid.UUID  `db:"account_id"`
-	Slug        string     `db:"slug"`
-	DisplayName string     `db:"display_name"`
-	IsRoot      bool       `db:"is_root"`
-	CreateTime  time.Time  `db:"create_time"`
-	UpdateTime  time.Time  `db:"update_time"`
-	DeleteTime  *time.Time `db:"delete_time"`
+	ID            uuid.UUID     `db:"id"`
+	AccountID     uuid.UUID     `db:"account_id"`
+	Slug          string        `db:"slug"`
+	DisplayName   string        `db:"display_name"`
+	IsRoot        bool          `db:"is_root"`
+	Email         string        `db:"email"`
+	EmailVerified bool          `db:"email_verified"`
+	Phone         string        `db:"phone"`
+	Locale        string        `db:"locale"`
+	Labels        jsonMap       `db:"labels"`
+	Annotations   jsonMap       `db:"annotations"`
+	Version       int64         `db:"version"`
+	CreateTime    time.Time     `db:"create_time"`
+	UpdateTime    time.Time     `db:"update_time"`
+	DeleteTime    *time.Time    `db:"delete_time"`
+	EraseTime     *time.Time    `db:"erase_time"`
+	PrincipalID   uuid.NullUUID `db:"principal_id"`
+}
+
+const userColumns = `
+	id, account_id, create_time, update_time, delete_time, slug, display_name, is_root,
+	email, email_verified, phone, locale, labels, annotations, version, erase_time,
+	principal_id
+`
+
+func (u dbUser) model() models.User {
+	user := models.User{
+		Name:          fmt.Sprintf("users/%s", u.Slug),
+		CreateTime:    u.CreateTime,
+		UpdateTime:    u.UpdateTime,
+		DeleteTime:    u.DeleteTime,
+		EraseTime:     u.EraseTime,
+		Etag:          etag(u.Version),
+		DisplayName:   u.DisplayName,
+		IsRoot:        u.IsRoot,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Phone:         u.Phone,
+		Locale:        u.Locale,
+		Labels:        u.Labels,
+		Annotations:   u.Annotations,
+	}
+
+	if u.PrincipalID.Valid {
+		user.Principal = fmt.Sprintf("principals/%s", u.PrincipalID.UUID)
+	}
+
+	return user
+}
+
+type dbAccount struct {
+	ID             uuid.UUID      `db:"id"`
+	CreateTime     time.Time      `db:"create_time"`
+	UpdateTime     time.Time      `db:"update_time"`
+	DeleteTime     *time.Time     `db:"delete_time"`
+	DisplayName    string         `db:"display_name"`
+	RootSlug       sql.NullString `db:"root_slug"`
+	OrganizationID uuid.NullUUID  `db:"organization_id"`
+	Version        int64          `db:"version"`
+}
+
+func (a dbAccount) model() models.Account {
+	account := models.Account{
+		Name:        fmt.Sprintf("accounts/%s", a.ID),
+		CreateTime:  a.CreateTime,
+		UpdateTime:  a.UpdateTime,
+		DeleteTime:  a.DeleteTime,
+		Etag:        etag(a.Version),
+		DisplayName: a.DisplayName,
+	}
+
+	if a.RootSlug.Valid {
+		account.Root = fmt.Sprintf("users/%s", a.RootSlug.String)
+	}
+
+	if a.OrganizationID.Valid {
+		account.Organization = fmt.Sprintf("organizations/%s", a.OrganizationID.UUID)
+	}
+
+	return account
 }
 
 type dbIdentity struct {
-	PasswordHash string `db:"password_hash"`
+	ID           uuid.UUID  `db:"id"`
+	CreateTime   time.Time  `db:"create_time"`
+	UpdateTime   time.Time  `db:"update_time"`
+	DeleteTime   *time.Time `db:"delete_time"`
+	PasswordHash string     `db:"password_hash"`
+	Version      int64      `db:"version"`
+}
+
+// checkPasswordNames returns the account ID and user slug that a
+// CheckPasswordRequest names.
+func checkPasswordNames(req CheckPasswordRequest) (string, string, error) {
+	accountID, err := nameID(req.Account, "accounts")
+	if err != nil {
+		return "", "", err
+	}
+
+	userSlug, err := nameID(req.User, "users")
+	if err != nil {
+		return "", "", err
+	}
+
+	return accountID, userSlug, nil
 }
 
-func (s *DBStore) CheckPassword(ctx context.Context, req CheckPasswordRequest) (bool, error) {
-	accountSegments := strings.Split(req.Account, "/")
-	accountID := accountSegments[1]
+// nameID returns the ID in name, which must be "{collection}/{id}". Names
+// come from callers, so a malformed one is an InvalidArgument error.
+func nameID(name, collection string) (string, error) {
+	segments := strings.Split(name, "/")
+	if len(segments) != 2 || segments[0] != collection || segments[1] == "" {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s name: %q", strings.TrimSuffix(collection, "s"), name)
+	}
 
-	userSegments := strings.Split(req.User, "/")
-	userSlug := userSegments[1]
+	return segments[1], nil
+}
+
+func (s *DBStore) CheckPassword(ctx context.Context, req CheckPasswordRequest) (CheckPasswordResponse, error) {
+	accountID, userSlug, err := checkPasswordNames(req)
+	if err != nil {
+		return CheckPasswordResponse{}, err
+	}
 
 	var identity dbIdentity
-	if err := s.DB.GetContext(ctx, &identity, `
+	if err := s.reader(ctx).GetContext(ctx, &identity, `
 		SELECT
-			identities.password_hash
+			identities.id, identities.update_time, identities.password_hash
 		FROM
-			identities, users
+			identities, users, accounts
 		WHERE
-			identities.user_id = users.id AND identities.auth_method = 'password' AND
-			users.account_id = $1 AND users.slug = $2
-	`, accountID, userSlug); err != nil {
-		return false, err
+			identities.user_id = users.id AND users.account_id = accounts.id AND
+			identities.auth_method = 'password' AND users.account_id = $1 AND users.slug = $2 AND
+			users.delete_time IS NULL AND identities.delete_time IS NULL AND
+			(accounts.delete_time IS NULL OR users.is_root)
+	`, nullUUID(accountID), userSlug); err != nil {
+		if err != sql.ErrNoRows {
+			return CheckPasswordResponse{}, err
+		}
+
+		// Compare against a throwaway hash anyway, so that a missing user takes
+		// as long to reject as a wrong password.
+		s.hasher().Verify(s.missingUserHash(), req.Password)
+		return CheckPasswordResponse{}, nil
+	}
+
+	valid, err := s.hasher().Verify(identity.PasswordHash, req.Password)
+	if err != nil {
+		return CheckPasswordResponse{}, err
 	}
 
-	hashedPassword := []byte(identity.PasswordHash)
-	attempt := []byte(req.Password)
-	return bcrypt.CompareHashAndPassword(hashedPassword, attempt) == nil, nil
+	if valid && s.hasher().NeedsRehash(identity.PasswordHash) {
+		s.rehash(ctx, identity, req.Password)
+	}
+
+	return CheckPasswordResponse{
+		Valid:              valid,
+		PasswordUpdateTime: identity.UpdateTime,
+	}, nil
 }
 
-func (s *DBStore) CreateAccount(ctx context.Context, req CreateAccountRequest) (models.Account, error) {
-	accountId := uuid.NewV4()
-	now := time.Now()
+// rehash upgrades an identity's stored hash to the current algorithm and
+// parameters. It leaves update_time alone, since the password itself hasn't
+// changed, and does nothing if the password changed concurrently.
+func (s *DBStore) rehash(ctx context.Context, identity dbIdentity, password string) {
+	passwordHash, err := s.hasher().Hash(password)
+	if err != nil {
+		log.Printf("error rehashing password: %v", err)
+		return
+	}
 
-	if _, err := s.DB.ExecContext(ctx, `
-		INSERT INTO accounts
-			(id, create_time, update_time, delete_time, display_name)
-		VALUES
-			($1, $2, $3, NULL, $4);
-	`, accountId, now, now, req.Account.DisplayName); err != nil {
-		return models.Account{}, err
+	if _, err := s.writer(ctx).ExecContext(ctx, `
+		UPDATE identities
+		SET
+			password_hash = $3
+		WHERE
+			id = $1 AND password_hash = $2
+	`, identity.ID, identity.PasswordHash, passwordHash); err != nil {
+		log.Printf("error rehashing password: %v", err)
+	}
+}
+
+func (s *DBStore) hasher() passhash.Hasher {
+	if s.Hasher == nil {
+		return passhash.Default
 	}
 
-	user, err := s.CreateUser(ctx, CreateUserRequest{
-		AccountID: accountId.String(),
-		User:      req.Root,
+	return s.Hasher
+}
+
+func (s *DBStore) missingUserHash() string {
+	s.missingUserHashOnce.Do(func() {
+		s.missingUserHashed, _ = s.hasher().Hash("missing user")
 	})
 
-	if err != nil {
+	return s.missingUserHashed
+}
+
+const accountColumns = `
+	id, create_time, update_time, delete_time, display_name, version, organization_id,
+	(SELECT slug FROM users WHERE account_id = accounts.id AND is_root AND delete_time IS NULL LIMIT 1) AS root_slug
+`
+
+func (s *DBStore) GetAccount(ctx context.Context, req GetAccountRequest) (models.Account, error) {
+	var account dbAccount
+	if err := s.db().GetContext(ctx, &account, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			accounts
+		WHERE
+			id = $1 AND ($2 OR delete_time IS NULL)
+	`, accountColumns), req.AccountID, req.ShowDeleted); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Account{}, status.Errorf(codes.NotFound, "account not found: accounts/%s", req.AccountID)
+		}
+
 		return models.Account{}, err
 	}
 
-	_, err = s.CreateIdentity(ctx, CreateIdentityRequest{
-		AccountID: accountId.String(),
-		Parent:    user.Name,
-		Identity: models.Identity{
-			AuthMethod: models.AuthMethodPassword,
-			Password:   req.RootPassword,
-		},
+	return account.model(), nil
+}
+
+// CreateAccount creates an account along with its root user and the root
+// user's password identity, in one transaction.
+func (s *DBStore) CreateAccount(ctx context.Context, req CreateAccountRequest) (models.Account, error) {
+	var account models.Account
+	err := s.withTx(ctx, func(tx *DBStore) error {
+		accountId := uuid.NewV4()
+		now := time.Now()
+
+		if _, err := tx.db().ExecContext(ctx, `
+			INSERT INTO accounts
+				(id, create_time, update_time, delete_time, display_name)
+			VALUES
+				($1, $2, $3, NULL, $4);
+		`, accountId, now, now, req.Account.DisplayName); err != nil {
+			return err
+		}
+
+		user, err := tx.CreateUser(ctx, CreateUserRequest{
+			AccountID: accountId.String(),
+			User:      req.Root,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateIdentity(ctx, CreateIdentityRequest{
+			AccountID: accountId.String(),
+			Parent:    user.Name,
+			Identity: models.Identity{
+				AuthMethod: models.AuthMethodPassword,
+				Password:   req.RootPassword,
+			},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		account = models.Account{
+			Name:       fmt.Sprintf("accounts/%s", accountId),
+			CreateTime: now,
+			UpdateTime: now,
+			DeleteTime: nil,
+			Etag:       etag(1),
+			Root:       user.Name,
+		}
+
+		return nil
 	})
 
+	return account, err
+}
+
+func (s *DBStore) UpdateAccount(ctx context.Context, req UpdateAccountRequest) (models.Account, error) {
+	now := time.Now()
+
+	updateDisplayName := len(req.UpdateMask) == 0
+	for _, path := range req.UpdateMask {
+		if path == "display_name" {
+			updateDisplayName = true
+		}
+	}
+
+	tx, err := s.begin(ctx, nil)
 	if err != nil {
 		return models.Account{}, err
 	}
 
-	return models.Account{
-		Name:       fmt.Sprintf("accounts/%s", accountId),
-		CreateTime: now,
-		UpdateTime: now,
-		DeleteTime: nil,
-		Root:       user.Name,
-	}, nil
+	defer tx.Rollback()
+
+	var account dbAccount
+	if err := tx.GetContext(ctx, &account, fmt.Sprintf(`
+		UPDATE accounts
+		SET
+			display_name = CASE WHEN $2 THEN $3 ELSE display_name END,
+			update_time = $4,
+			version = version + 1
+		WHERE
+			id = $1 AND delete_time IS NULL AND ($5 = '' OR version::text = $5)
+		RETURNING
+			%s
+	`, accountColumns), req.AccountID, updateDisplayName, req.Account.DisplayName, now, req.Account.Etag); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Account{}, etagOrNotFound(ctx, tx, req.Account.Name, req.Account.Etag,
+				status.Errorf(codes.NotFound, "account not found: %s", req.Account.Name), `
+				SELECT 1 FROM accounts WHERE id = $1 AND delete_time IS NULL
+			`, req.AccountID)
+		}
+
+		return models.Account{}, err
+	}
+
+	name := fmt.Sprintf("accounts/%s", account.ID)
+	if err := enqueueWebhookEvent(ctx, tx, account.ID.String(), models.WebhookEventAccountUpdated, name); err != nil {
+		return models.Account{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Account{}, err
+	}
+
+	return account.model(), nil
 }
 
 func (s *DBStore) GetUser(ctx context.Context, req GetUserRequest) (models.User, error) {
-	segments := strings.Split(req.Name, "/")
-	slug := segments[1]
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
 
 	var user dbUser
-	if err := s.DB.GetContext(ctx, &user, `
+	if err := s.reader(ctx).GetContext(ctx, &user, fmt.Sprintf(`
 		SELECT
-			id, account_id, create_time, update_time, delete_time, slug, display_name, is_root
+			%s
 		FROM
 			users
 		WHERE
-			account_id = $1 AND slug = $2
-	`, req.AccountID, slug); err != nil {
+			account_id = $1 AND slug = $2 AND ($3 OR delete_time IS NULL)
+		ORDER BY
+			delete_time IS NOT NULL, delete_time DESC
+		LIMIT 1
+	`, userColumns), req.AccountID, slug, req.ShowDeleted); err != nil {
+		if err == sql.ErrNoRows {
+			return models.User{}, status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+		}
+
 		return models.User{}, err
 	}
 
-	return models.User{
-		Name:        fmt.Sprintf("users/%s", user.Slug),
-		CreateTime:  user.CreateTime,
-		UpdateTime:  user.UpdateTime,
-		DeleteTime:  user.DeleteTime,
-		DisplayName: user.DisplayName,
-		IsRoot:      user.IsRoot,
-	}, nil
+	return user.model(), nil
+}
+
+func (s *DBStore) ListUsers(ctx context.Context, req ListUsersRequest) (ListUsersResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListUsersResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	conditions := []string{"account_id = $1"}
+	if !req.ShowDeleted {
+		conditions = append(conditions, "delete_time IS NULL")
+	}
+
+	conditions, args, orderBy, err := filterSQL(userFilterSchema, req.Filter, req.OrderBy,
+		conditions, []interface{}{req.AccountID}, "create_time, id")
+	if err != nil {
+		return ListUsersResponse{}, err
+	}
+
+	args = append(args, limit+1, offset)
+
+	var users []dbUser
+	if err := s.reader(ctx).SelectContext(ctx, &users, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			users
+		WHERE
+			%s
+		ORDER BY
+			%s
+		LIMIT $%d OFFSET $%d
+	`, userColumns, strings.Join(conditions, " AND "), orderBy, len(args)-1, len(args)), args...); err != nil {
+		return ListUsersResponse{}, err
+	}
+
+	var res ListUsersResponse
+	if len(users) > limit {
+		users = users[:limit]
+		res.NextPageToken = encodePageToken(offset + limit)
+	}
+
+	for _, user := range users {
+		res.Users = append(res.Users, user.model())
+	}
+
+	return res, nil
+}
+
+// UpdateUser sets the fields of the user named in UpdateMask. Changing the
+// email address clears email_verified.
+func (s *DBStore) UpdateUser(ctx context.Context, req UpdateUserRequest) (models.User, error) {
+	slug, err := nameID(req.User.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	sets := []string{"update_time = $3", "version = version + 1"}
+	args := []interface{}{req.AccountID, slug, time.Now(), req.User.Etag}
+
+	addSet := func(assignment string, value interface{}) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf(assignment, len(args)))
+	}
+
+	for _, path := range req.UpdateMask {
+		switch path {
+		case "display_name":
+			addSet("display_name = $%d", req.User.DisplayName)
+		case "email":
+			addSet("email_verified = email_verified AND email = $%d", req.User.Email)
+			addSet("email = $%d", req.User.Email)
+		case "phone":
+			addSet("phone = $%d", req.User.Phone)
+		case "locale":
+			addSet("locale = $%d", req.User.Locale)
+		case "labels":
+			addSet("labels = $%d", jsonMap(req.User.Labels))
+		case "annotations":
+			addSet("annotations = $%d", jsonMap(req.User.Annotations))
+		default:
+			return models.User{}, status.Errorf(codes.InvalidArgument, "cannot update field: %s", path)
+		}
+	}
+
+	var user dbUser
+	if err := s.writer(ctx).GetContext(ctx, &user, fmt.Sprintf(`
+		UPDATE users
+		SET
+			%s
+		WHERE
+			account_id = $1 AND slug = $2 AND delete_time IS NULL AND ($4 = '' OR version::text = $4)
+		RETURNING
+			%s
+	`, strings.Join(sets, ", "), userColumns), args...); err != nil {
+		if err == sql.ErrNoRows {
+			return models.User{}, etagOrNotFound(ctx, s.db(), req.User.Name, req.User.Etag,
+				status.Errorf(codes.NotFound, "user not found: %s", req.User.Name), `
+				SELECT 1 FROM users WHERE account_id = $1 AND slug = $2 AND delete_time IS NULL
+			`, req.AccountID, slug)
+		}
+
+		return models.User{}, err
+	}
+
+	return user.model(), nil
 }
 
 func (s *DBStore) CreateUser(ctx context.Context, req CreateUserRequest) (models.User, error) {
 	id := uuid.NewV4()
 	now := time.Now()
 
-	segments := strings.Split(req.User.Name, "/")
-	slug := segments[1]
+	slug, err := nameID(req.User.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	defer tx.Rollback()
 
-	if _, err := s.DB.ExecContext(ctx, `
+	if _, err := tx.ExecContext(ctx, `
 		INSERT INTO users
-			(id, account_id, create_time, update_time, delete_time, slug, display_name, is_root)
+			(id, account_id, create_time, update_time, delete_time, slug, display_name, is_root,
+			 email, email_verified, phone, locale, labels, annotations)
 		VALUES
-			($1, $2, $3, $3, NULL, $4, $5, $6)
-	`, id, req.AccountID, now, slug, req.User.DisplayName, req.User.IsRoot); err != nil {
+			($1, $2, $3, $3, NULL, $4, $5, $6, $7, FALSE, $8, $9, $10, $11)
+	`, id, req.AccountID, now, slug, req.User.DisplayName, req.User.IsRoot, req.User.Email,
+		req.User.Phone, req.User.Locale, jsonMap(req.User.Labels),
+		jsonMap(req.User.Annotations)); err != nil {
+		return models.User{}, alreadyExists(err, "user already exists: %s", req.User.Name)
+	}
+
+	if err := enqueueWebhookEvent(ctx, tx, req.AccountID, models.WebhookEventUserCreated, req.User.Name); err != nil {
+		return models.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return models.User{}, err
 	}
 
@@ -147,39 +538,174 @@ func (s *DBStore) CreateUser(ctx context.Context, req CreateUserRequest) (models
 		Name:        req.User.Name,
 		IsRoot:      req.User.IsRoot,
 		DisplayName: req.User.DisplayName,
-		CreateTime:  req.User.CreateTime,
-		UpdateTime:  req.User.UpdateTime,
-		DeleteTime:  req.User.DeleteTime,
+		Email:       req.User.Email,
+		Phone:       req.User.Phone,
+		Locale:      req.User.Locale,
+		Labels:      req.User.Labels,
+		Annotations: req.User.Annotations,
+		CreateTime:  now,
+		UpdateTime:  now,
+		Etag:        etag(1),
 	}, nil
 }
 
+func (s *DBStore) DeleteUser(ctx context.Context, req DeleteUserRequest) error {
+	now := time.Now()
+
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET
+			delete_time = $3, update_time = $3, version = version + 1
+		WHERE
+			account_id = $1 AND slug = $2 AND delete_time IS NULL AND ($4 = '' OR version::text = $4)
+	`, req.AccountID, slug, now, req.Etag)
+
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return etagOrNotFound(ctx, tx, req.Name, req.Etag,
+			status.Errorf(codes.NotFound, "user not found: %s", req.Name), `
+			SELECT 1 FROM users WHERE account_id = $1 AND slug = $2 AND delete_time IS NULL
+		`, req.AccountID, slug)
+	}
+
+	if err := enqueueWebhookEvent(ctx, tx, req.AccountID, models.WebhookEventUserDeleted, req.Name); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *DBStore) ListIdentities(ctx context.Context, req ListIdentitiesRequest) (ListIdentitiesResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListIdentitiesResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	slug, err := nameID(req.Parent, "users")
+	if err != nil {
+		return ListIdentitiesResponse{}, err
+	}
+
+	conditions := []string{
+		"identities.user_id = users.id",
+		"users.account_id = $1",
+		"users.slug = $2",
+		"users.delete_time IS NULL",
+	}
+
+	if !req.ShowDeleted {
+		conditions = append(conditions, "identities.delete_time IS NULL")
+	}
+
+	conditions, args, orderBy, err := filterSQL(identityFilterSchema, req.Filter, req.OrderBy,
+		conditions, []interface{}{req.AccountID, slug}, "identities.create_time, identities.id")
+	if err != nil {
+		return ListIdentitiesResponse{}, err
+	}
+
+	args = append(args, limit+1, offset)
+
+	var identities []dbIdentity
+	if err := s.reader(ctx).SelectContext(ctx, &identities, fmt.Sprintf(`
+		SELECT
+			identities.id, identities.create_time, identities.update_time, identities.delete_time,
+			identities.version
+		FROM
+			identities, users
+		WHERE
+			%s
+		ORDER BY
+			%s
+		LIMIT $%d OFFSET $%d
+	`, strings.Join(conditions, " AND "), orderBy, len(args)-1, len(args)), args...); err != nil {
+		return ListIdentitiesResponse{}, err
+	}
+
+	var res ListIdentitiesResponse
+	if len(identities) > limit {
+		identities = identities[:limit]
+		res.NextPageToken = encodePageToken(offset + limit)
+	}
+
+	for _, identity := range identities {
+		res.Identities = append(res.Identities, models.Identity{
+			Name:       fmt.Sprintf("%s/identities/%s", req.Parent, identity.ID),
+			CreateTime: identity.CreateTime,
+			UpdateTime: identity.UpdateTime,
+			DeleteTime: identity.DeleteTime,
+			Etag:       etag(identity.Version),
+			AuthMethod: models.AuthMethodPassword,
+		})
+	}
+
+	return res, nil
+}
+
 func (s *DBStore) CreateIdentity(ctx context.Context, req CreateIdentityRequest) (models.Identity, error) {
 	id := uuid.NewV4()
 	now := time.Now()
 
-	segments := strings.Split(req.Parent, "/")
-	slug := segments[1]
+	slug, err := nameID(req.Parent, "users")
+	if err != nil {
+		return models.Identity{}, err
+	}
 
 	authMethod := "password"
-	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Identity.Password), bcrypt.DefaultCost)
+	passwordHash, err := s.hasher().Hash(req.Identity.Password)
 	if err != nil {
 		return models.Identity{}, err
 	}
 
-	if _, err := s.DB.ExecContext(ctx, `
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
 		INSERT INTO identities
 			(id, user_id, create_time, update_time, delete_time, auth_method, password_hash)
 		VALUES
-			($1, (SELECT id FROM users WHERE account_id = $2 AND slug = $3), $4, $4, NULL, $5, $6);
+			($1, (SELECT id FROM users WHERE account_id = $2 AND slug = $3 AND delete_time IS NULL), $4, $4, NULL, $5, $6);
 	`, id, req.AccountID, slug, now, authMethod, passwordHash); err != nil {
 		return models.Identity{}, err
 	}
 
+	name := fmt.Sprintf("%s/identities/%s", req.Parent, id)
+	if err := enqueueWebhookEvent(ctx, tx, req.AccountID, models.WebhookEventIdentityCreated, name); err != nil {
+		return models.Identity{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Identity{}, err
+	}
+
 	return models.Identity{
-		Name:       fmt.Sprintf("%s/identities/%s", req.Parent, id),
+		Name:       name,
 		CreateTime: now,
 		UpdateTime: now,
 		DeleteTime: nil,
+		Etag:       etag(1),
 		AuthMethod: models.AuthMethodPassword,
 	}, nil
 }
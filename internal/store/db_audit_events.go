@@ -0,0 +1,362 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit/chain"
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+type dbAuditEvent struct {
+	ID         uuid.UUID     `db:"id"`
+	CreateTime time.Time     `db:"create_time"`
+	AccountID  uuid.NullUUID `db:"account_id"`
+	Actor      string        `db:"actor"`
+	Resource   string        `db:"resource"`
+	Method     string        `db:"method"`
+	SourceIP   string        `db:"source_ip"`
+	Outcome    string        `db:"outcome"`
+	Sequence   sql.NullInt64 `db:"sequence"`
+	PrevHash   []byte        `db:"prev_hash"`
+	Hash       []byte        `db:"hash"`
+
+	ActorDigest    []byte `db:"actor_digest"`
+	ResourceDigest []byte `db:"resource_digest"`
+}
+
+type dbAuditCheckpoint struct {
+	ID         uuid.UUID     `db:"id"`
+	CreateTime time.Time     `db:"create_time"`
+	AccountID  uuid.NullUUID `db:"account_id"`
+	Sequence   int64         `db:"sequence"`
+	Hash       []byte        `db:"hash"`
+	Signature  []byte        `db:"signature"`
+}
+
+const (
+	auditOutcomeSuccess string = "success"
+	auditOutcomeFailure string = "failure"
+)
+
+const auditEventColumns = `
+	id, create_time, account_id, actor, resource, method, source_ip, outcome,
+	sequence, prev_hash, hash, actor_digest, resource_digest
+`
+
+func (s *DBStore) CreateAuditEvent(ctx context.Context, req CreateAuditEventRequest) (models.AuditEvent, error) {
+	id := uuid.NewV4()
+
+	// Postgres keeps microsecond precision, so truncate before hashing for the
+	// chain to verify against the stored row.
+	now := time.Now().Truncate(time.Microsecond)
+
+	var accountID uuid.NullUUID
+	if segments := strings.Split(req.AuditEvent.Account, "/"); len(segments) == 2 {
+		accountID = nullUUID(segments[1])
+	}
+
+	outcome := auditOutcomeSuccess
+	if req.AuditEvent.Outcome == models.AuditOutcomeFailure {
+		outcome = auditOutcomeFailure
+	}
+
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	defer tx.Rollback()
+
+	// Serialize appends to the same chain so that two events can't claim the
+	// same predecessor.
+	if _, err := tx.ExecContext(ctx, `
+		SELECT pg_advisory_xact_lock(hashtext('audit_events:' || COALESCE($1::text, '')))
+	`, accountID); err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	var head dbAuditEvent
+	err = tx.GetContext(ctx, &head, `
+		SELECT
+			sequence, hash
+		FROM
+			audit_events
+		WHERE
+			account_id IS NOT DISTINCT FROM $1 AND sequence IS NOT NULL
+		ORDER BY
+			sequence DESC
+		LIMIT 1
+	`, accountID)
+
+	if err != nil && err != sql.ErrNoRows {
+		return models.AuditEvent{}, err
+	}
+
+	event := models.AuditEvent{
+		Name:       fmt.Sprintf("auditEvents/%s", id),
+		CreateTime: now,
+		Actor:      req.AuditEvent.Actor,
+		Resource:   req.AuditEvent.Resource,
+		Method:     req.AuditEvent.Method,
+		SourceIP:   req.AuditEvent.SourceIP,
+		Outcome:    models.AuditOutcomeSuccess,
+		Sequence:   head.Sequence.Int64 + 1,
+		PrevHash:   head.Hash,
+	}
+
+	if accountID.Valid {
+		event.Account = fmt.Sprintf("accounts/%s", accountID.UUID)
+	}
+
+	if outcome == auditOutcomeFailure {
+		event.Outcome = models.AuditOutcomeFailure
+	}
+
+	event.Hash = chain.Hash(event.PrevHash, event)
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO audit_events
+			(id, create_time, account_id, actor, resource, method, source_ip, outcome,
+			 sequence, prev_hash, hash)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, id, now, accountID, event.Actor, event.Resource, event.Method, event.SourceIP,
+		outcome, event.Sequence, event.PrevHash, event.Hash); err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	return event, nil
+}
+
+func (s *DBStore) ListAuditEvents(ctx context.Context, req ListAuditEventsRequest) (ListAuditEventsResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListAuditEventsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	conditions := []string{"account_id = $1"}
+	args := []interface{}{req.AccountID}
+
+	addCondition := func(column string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s $%d", column, len(args)))
+	}
+
+	if !req.StartTime.IsZero() {
+		addCondition("create_time >=", req.StartTime)
+	}
+
+	if !req.EndTime.IsZero() {
+		addCondition("create_time <", req.EndTime)
+	}
+
+	if req.Filter.Actor != "" {
+		addCondition("actor =", req.Filter.Actor)
+	}
+
+	if req.Filter.Resource != "" {
+		addCondition("resource =", req.Filter.Resource)
+	}
+
+	if req.Filter.Method != "" {
+		addCondition("method =", req.Filter.Method)
+	}
+
+	if req.Filter.SourceIP != "" {
+		addCondition("source_ip =", req.Filter.SourceIP)
+	}
+
+	switch req.Filter.Outcome {
+	case models.AuditOutcomeSuccess:
+		addCondition("outcome =", auditOutcomeSuccess)
+	case models.AuditOutcomeFailure:
+		addCondition("outcome =", auditOutcomeFailure)
+	}
+
+	args = append(args, limit+1, offset)
+
+	var events []dbAuditEvent
+	if err := s.reader(ctx).SelectContext(ctx, &events, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			audit_events
+		WHERE
+			%s
+		ORDER BY
+			create_time, id
+		LIMIT $%d OFFSET $%d
+	`, auditEventColumns, strings.Join(conditions, " AND "), len(args)-1, len(args)), args...); err != nil {
+		return ListAuditEventsResponse{}, err
+	}
+
+	var res ListAuditEventsResponse
+	if len(events) > limit {
+		events = events[:limit]
+		res.NextPageToken = encodePageToken(offset + limit)
+	}
+
+	for _, event := range events {
+		res.AuditEvents = append(res.AuditEvents, event.model())
+	}
+
+	return res, nil
+}
+
+func (s *DBStore) ListAuditChainHeads(ctx context.Context) ([]models.AuditEvent, error) {
+	var events []dbAuditEvent
+	if err := s.db().SelectContext(ctx, &events, fmt.Sprintf(`
+		SELECT DISTINCT ON (account_id)
+			%s
+		FROM
+			audit_events
+		WHERE
+			sequence IS NOT NULL
+		ORDER BY
+			account_id, sequence DESC
+	`, auditEventColumns)); err != nil {
+		return nil, err
+	}
+
+	var out []models.AuditEvent
+	for _, event := range events {
+		out = append(out, event.model())
+	}
+
+	return out, nil
+}
+
+func (s *DBStore) ListAuditChain(ctx context.Context, req ListAuditChainRequest) ([]models.AuditEvent, error) {
+	var events []dbAuditEvent
+	if err := s.db().SelectContext(ctx, &events, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			audit_events
+		WHERE
+			account_id IS NOT DISTINCT FROM $1 AND sequence > $2
+		ORDER BY
+			sequence
+		LIMIT $3
+	`, auditEventColumns), nullUUID(req.AccountID), req.AfterSequence, pageSize(req.PageSize)); err != nil {
+		return nil, err
+	}
+
+	var out []models.AuditEvent
+	for _, event := range events {
+		out = append(out, event.model())
+	}
+
+	return out, nil
+}
+
+func (s *DBStore) CreateAuditCheckpoint(ctx context.Context, req CreateAuditCheckpointRequest) (models.AuditCheckpoint, error) {
+	id := uuid.NewV4()
+	now := time.Now()
+
+	var accountID uuid.NullUUID
+	if segments := strings.Split(req.AuditCheckpoint.Account, "/"); len(segments) == 2 {
+		accountID = nullUUID(segments[1])
+	}
+
+	if _, err := s.writer(ctx).ExecContext(ctx, `
+		INSERT INTO audit_checkpoints
+			(id, create_time, account_id, sequence, hash, signature)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+	`, id, now, accountID, req.AuditCheckpoint.Sequence, req.AuditCheckpoint.Hash,
+		req.AuditCheckpoint.Signature); err != nil {
+		return models.AuditCheckpoint{}, err
+	}
+
+	checkpoint := req.AuditCheckpoint
+	checkpoint.Name = fmt.Sprintf("auditCheckpoints/%s", id)
+	checkpoint.CreateTime = now
+	return checkpoint, nil
+}
+
+func (s *DBStore) ListAuditCheckpoints(ctx context.Context, req ListAuditCheckpointsRequest) ([]models.AuditCheckpoint, error) {
+	var checkpoints []dbAuditCheckpoint
+	if err := s.db().SelectContext(ctx, &checkpoints, `
+		SELECT
+			id, create_time, account_id, sequence, hash, signature
+		FROM
+			audit_checkpoints
+		WHERE
+			account_id IS NOT DISTINCT FROM $1
+		ORDER BY
+			sequence
+	`, nullUUID(req.AccountID)); err != nil {
+		return nil, err
+	}
+
+	var out []models.AuditCheckpoint
+	for _, c := range checkpoints {
+		checkpoint := models.AuditCheckpoint{
+			Name:       fmt.Sprintf("auditCheckpoints/%s", c.ID),
+			CreateTime: c.CreateTime,
+			Sequence:   c.Sequence,
+			Hash:       c.Hash,
+			Signature:  c.Signature,
+		}
+
+		if c.AccountID.Valid {
+			checkpoint.Account = fmt.Sprintf("accounts/%s", c.AccountID.UUID)
+		}
+
+		out = append(out, checkpoint)
+	}
+
+	return out, nil
+}
+
+func (e dbAuditEvent) model() models.AuditEvent {
+	event := models.AuditEvent{
+		Name:       fmt.Sprintf("auditEvents/%s", e.ID),
+		CreateTime: e.CreateTime,
+		Actor:      e.Actor,
+		Resource:   e.Resource,
+		Method:     e.Method,
+		SourceIP:   e.SourceIP,
+		Outcome:    models.AuditOutcomeSuccess,
+		Sequence:   e.Sequence.Int64,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+
+		ActorDigest:    e.ActorDigest,
+		ResourceDigest: e.ResourceDigest,
+	}
+
+	if e.AccountID.Valid {
+		event.Account = fmt.Sprintf("accounts/%s", e.AccountID.UUID)
+	}
+
+	if e.Outcome == auditOutcomeFailure {
+		event.Outcome = models.AuditOutcomeFailure
+	}
+
+	return event
+}
+
+func nullUUID(s string) uuid.NullUUID {
+	parsed, err := uuid.FromString(s)
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+
+	return uuid.NullUUID{UUID: parsed, Valid: true}
+}
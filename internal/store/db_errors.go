@@ -0,0 +1,21 @@
+package store
+
+import (
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const pqUniqueViolation = "23505"
+
+// alreadyExists returns an AlreadyExists error with the given message if err
+// is a unique violation, such as from a concurrent insert that passed the
+// same existence check, and err otherwise.
+func alreadyExists(err error, format string, args ...interface{}) error {
+	if pqErr, ok := errors.Cause(err).(*pq.Error); ok && pqErr.Code == pqUniqueViolation {
+		return status.Errorf(codes.AlreadyExists, format, args...)
+	}
+
+	return err
+}
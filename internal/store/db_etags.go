@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// etag returns the etag for a row's version column. Etags are opaque to
+// clients; they are only compared for equality.
+func etag(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+func errEtagMismatch(name string) error {
+	return status.Errorf(codes.Aborted, "etag mismatch: %s was modified concurrently; fetch it again and retry", name)
+}
+
+// etagOrNotFound explains why a conditional UPDATE matched no rows. If the
+// request had an etag and the row exists, the etag must have been stale;
+// otherwise notFound is returned. query selects the row without the etag
+// condition.
+func etagOrNotFound(ctx context.Context, q sqlx.QueryerContext, name, etag string, notFound error, query string, args ...interface{}) error {
+	if etag == "" {
+		return notFound
+	}
+
+	var exists bool
+	if err := sqlx.GetContext(ctx, q, &exists, fmt.Sprintf("SELECT EXISTS (%s)", query), args...); err != nil {
+		return err
+	}
+
+	if !exists {
+		return notFound
+	}
+
+	return errEtagMismatch(name)
+}
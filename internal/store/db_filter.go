@@ -0,0 +1,59 @@
+package store
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/filter"
+)
+
+var userFilterSchema = filter.Schema{
+	"create_time":    {Column: "create_time", Type: filter.Timestamp},
+	"update_time":    {Column: "update_time", Type: filter.Timestamp},
+	"is_root":        {Column: "is_root", Type: filter.Bool},
+	"display_name":   {Column: "display_name", Type: filter.String},
+	"email":          {Column: "email", Type: filter.String},
+	"email_verified": {Column: "email_verified", Type: filter.Bool},
+	"phone":          {Column: "phone", Type: filter.String},
+	"locale":         {Column: "locale", Type: filter.String},
+	"labels":         {Column: "labels", Type: filter.Map},
+}
+
+var identityFilterSchema = filter.Schema{
+	"create_time": {Column: "identities.create_time", Type: filter.Timestamp},
+	"update_time": {Column: "identities.update_time", Type: filter.Timestamp},
+	"auth_method": {Column: "identities.auth_method::text", Type: filter.String},
+}
+
+// filterSQL compiles a list request's filter and order_by against schema.
+// conditions and args are the query's existing WHERE conditions and their
+// values. The returned ORDER BY clause ends with tiebreak so that paging is
+// stable.
+func filterSQL(schema filter.Schema, expr filter.Expr, orders []filter.Order, conditions []string, args []interface{}, tiebreak string) ([]string, []interface{}, string, error) {
+	return compileFilter(filter.SQL, schema, expr, orders, conditions, args, tiebreak)
+}
+
+// compileFilter is filterSQL with the function that compiles the filter to
+// the database's dialect.
+func compileFilter(compile func(filter.Expr, filter.Schema, []interface{}) (string, []interface{}, error), schema filter.Schema, expr filter.Expr, orders []filter.Order, conditions []string, args []interface{}, tiebreak string) ([]string, []interface{}, string, error) {
+	if expr != nil {
+		cond, condArgs, err := compile(expr, schema, args)
+		if err != nil {
+			return nil, nil, "", status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+		}
+
+		conditions = append(conditions, cond)
+		args = condArgs
+	}
+
+	orderBy, err := filter.OrderBySQL(orders, schema)
+	if err != nil {
+		return nil, nil, "", status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+
+	if orderBy == "" {
+		return conditions, args, tiebreak, nil
+	}
+
+	return conditions, args, orderBy + ", " + tiebreak, nil
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+type dbLoginThrottle struct {
+	Key             string     `db:"key"`
+	Failures        int        `db:"failures"`
+	LastFailureTime time.Time  `db:"last_failure_time"`
+	LockedUntil     *time.Time `db:"locked_until"`
+}
+
+func (s *DBStore) GetLoginThrottles(ctx context.Context, req GetLoginThrottlesRequest) ([]models.LoginThrottle, error) {
+	var throttles []dbLoginThrottle
+	if err := s.db().SelectContext(ctx, &throttles, `
+		SELECT
+			key, failures, last_failure_time, locked_until
+		FROM
+			login_throttles
+		WHERE
+			key = ANY($1)
+	`, pq.StringArray(req.Keys)); err != nil {
+		return nil, err
+	}
+
+	var out []models.LoginThrottle
+	for _, throttle := range throttles {
+		out = append(out, throttle.model())
+	}
+
+	return out, nil
+}
+
+// RecordLoginFailure counts a failed attempt against key. Failures older than
+// req.Window are forgotten, so that the count restarts at one.
+func (s *DBStore) RecordLoginFailure(ctx context.Context, req RecordLoginFailureRequest) (models.LoginThrottle, error) {
+	now := time.Now()
+
+	var throttle dbLoginThrottle
+	if err := s.writer(ctx).GetContext(ctx, &throttle, `
+		INSERT INTO login_throttles
+			(key, failures, last_failure_time, locked_until)
+		VALUES
+			($1, 1, $2, NULL)
+		ON CONFLICT (key) DO UPDATE
+		SET
+			failures = CASE
+				WHEN login_throttles.last_failure_time < $3 THEN 1
+				ELSE login_throttles.failures + 1
+			END,
+			last_failure_time = $2
+		RETURNING
+			key, failures, last_failure_time, locked_until
+	`, req.Key, now, now.Add(-req.Window)); err != nil {
+		return models.LoginThrottle{}, err
+	}
+
+	return throttle.model(), nil
+}
+
+func (s *DBStore) LockLogin(ctx context.Context, req LockLoginRequest) error {
+	_, err := s.writer(ctx).ExecContext(ctx, `
+		UPDATE login_throttles
+		SET
+			locked_until = $2
+		WHERE
+			key = $1
+	`, req.Key, req.Until)
+
+	return err
+}
+
+func (s *DBStore) ResetLoginThrottles(ctx context.Context, req ResetLoginThrottlesRequest) error {
+	_, err := s.writer(ctx).ExecContext(ctx, `
+		DELETE FROM login_throttles
+		WHERE
+			key = ANY($1)
+	`, pq.StringArray(req.Keys))
+
+	return err
+}
+
+func (t dbLoginThrottle) model() models.LoginThrottle {
+	return models.LoginThrottle{
+		Key:             t.Key,
+		Failures:        t.Failures,
+		LastFailureTime: t.LastFailureTime,
+		LockedUntil:     t.LockedUntil,
+	}
+}
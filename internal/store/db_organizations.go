@@ -0,0 +1,435 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+type dbOrganization struct {
+	ID          uuid.UUID  `db:"id"`
+	CreateTime  time.Time  `db:"create_time"`
+	UpdateTime  time.Time  `db:"update_time"`
+	DeleteTime  *time.Time `db:"delete_time"`
+	DisplayName string     `db:"display_name"`
+	Version     int64      `db:"version"`
+}
+
+const organizationColumns = `
+	id, create_time, update_time, delete_time, display_name, version
+`
+
+func (o dbOrganization) model(admins []uuid.UUID) models.Organization {
+	organization := models.Organization{
+		Name:        fmt.Sprintf("organizations/%s", o.ID),
+		CreateTime:  o.CreateTime,
+		UpdateTime:  o.UpdateTime,
+		DeleteTime:  o.DeleteTime,
+		Etag:        etag(o.Version),
+		DisplayName: o.DisplayName,
+	}
+
+	for _, admin := range admins {
+		organization.Admins = append(organization.Admins, fmt.Sprintf("principals/%s", admin))
+	}
+
+	return organization
+}
+
+type dbOrganizationPasswordPolicy struct {
+	OrganizationID   uuid.UUID `db:"organization_id"`
+	UpdateTime       time.Time `db:"update_time"`
+	MinLength        int       `db:"min_length"`
+	RequireUppercase bool      `db:"require_uppercase"`
+	RequireLowercase bool      `db:"require_lowercase"`
+	RequireDigit     bool      `db:"require_digit"`
+	RequireSymbol    bool      `db:"require_symbol"`
+	HistorySize      int       `db:"history_size"`
+	MaxAgeSeconds    int64     `db:"max_age_seconds"`
+}
+
+func (p dbOrganizationPasswordPolicy) model() models.PasswordPolicy {
+	return models.PasswordPolicy{
+		Name:             fmt.Sprintf("organizations/%s/passwordPolicy", p.OrganizationID),
+		UpdateTime:       p.UpdateTime,
+		MinLength:        p.MinLength,
+		RequireUppercase: p.RequireUppercase,
+		RequireLowercase: p.RequireLowercase,
+		RequireDigit:     p.RequireDigit,
+		RequireSymbol:    p.RequireSymbol,
+		HistorySize:      p.HistorySize,
+		MaxAge:           time.Duration(p.MaxAgeSeconds) * time.Second,
+	}
+}
+
+// organizationID returns the ID in an organization name, or an invalid
+// NullUUID if name isn't an organization name.
+func organizationID(name string) uuid.NullUUID {
+	if !strings.HasPrefix(name, "organizations/") {
+		return uuid.NullUUID{}
+	}
+
+	return nullUUID(strings.TrimPrefix(name, "organizations/"))
+}
+
+func (s *DBStore) CreateOrganization(ctx context.Context, req CreateOrganizationRequest) (models.Organization, error) {
+	now := time.Now()
+
+	admin := principalID(req.Admin)
+	if !admin.Valid {
+		return models.Organization{}, status.Errorf(codes.NotFound, "principal not found: %s", req.Admin)
+	}
+
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	defer tx.Rollback()
+
+	var organization dbOrganization
+	if err := tx.GetContext(ctx, &organization, fmt.Sprintf(`
+		INSERT INTO organizations
+			(id, create_time, update_time, delete_time, display_name)
+		VALUES
+			($1, $2, $2, NULL, $3)
+		RETURNING
+			%s
+	`, organizationColumns), uuid.NewV4(), now, req.Organization.DisplayName); err != nil {
+		return models.Organization{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO organization_admins
+			(organization_id, principal_id, create_time)
+		VALUES
+			($1, $2, $3)
+	`, organization.ID, admin, now); err != nil {
+		return models.Organization{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Organization{}, err
+	}
+
+	return organization.model([]uuid.UUID{admin.UUID}), nil
+}
+
+func (s *DBStore) GetOrganization(ctx context.Context, req GetOrganizationRequest) (models.Organization, error) {
+	return getOrganization(ctx, s.db(), req.Name, false)
+}
+
+func (s *DBStore) UpdateOrganization(ctx context.Context, req UpdateOrganizationRequest) (models.Organization, error) {
+	name := req.Organization.Name
+
+	updateDisplayName := len(req.UpdateMask) == 0
+	for _, path := range req.UpdateMask {
+		if path == "display_name" {
+			updateDisplayName = true
+		}
+	}
+
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE organizations
+		SET
+			display_name = CASE WHEN $2 THEN $3 ELSE display_name END,
+			update_time = $4,
+			version = version + 1
+		WHERE
+			id = $1 AND delete_time IS NULL AND ($5 = '' OR version::text = $5)
+	`, organizationID(name), updateDisplayName, req.Organization.DisplayName, time.Now(),
+		req.Organization.Etag)
+
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return models.Organization{}, err
+	} else if n == 0 {
+		return models.Organization{}, etagOrNotFound(ctx, tx, name, req.Organization.Etag,
+			status.Errorf(codes.NotFound, "organization not found: %s", name), `
+			SELECT 1 FROM organizations WHERE id = $1 AND delete_time IS NULL
+		`, organizationID(name))
+	}
+
+	organization, err := getOrganization(ctx, tx, name, false)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+func (s *DBStore) AddOrganizationAdmin(ctx context.Context, req AddOrganizationAdminRequest) (models.Organization, error) {
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := getOrganization(ctx, tx, req.Name, true); err != nil {
+		return models.Organization{}, err
+	}
+
+	var principals []uuid.UUID
+	if err := tx.SelectContext(ctx, &principals, `
+		SELECT id FROM principals WHERE id = $1 AND delete_time IS NULL
+	`, principalID(req.Principal)); err != nil {
+		return models.Organization{}, err
+	}
+
+	if len(principals) == 0 {
+		return models.Organization{}, status.Errorf(codes.NotFound, "principal not found: %s", req.Principal)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO organization_admins
+			(organization_id, principal_id, create_time)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`, organizationID(req.Name), principals[0], time.Now()); err != nil {
+		return models.Organization{}, err
+	}
+
+	organization, err := getOrganization(ctx, tx, req.Name, false)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+// RemoveOrganizationAdmin removes an admin from an organization. The last
+// admin can't be removed, since nobody could then manage the organization.
+func (s *DBStore) RemoveOrganizationAdmin(ctx context.Context, req RemoveOrganizationAdminRequest) (models.Organization, error) {
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	defer tx.Rollback()
+
+	organization, err := getOrganization(ctx, tx, req.Name, true)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	isAdmin := false
+	for _, admin := range organization.Admins {
+		isAdmin = isAdmin || admin == req.Principal
+	}
+
+	if !isAdmin {
+		return models.Organization{}, status.Errorf(codes.NotFound, "principal is not an admin of %s: %s", req.Name, req.Principal)
+	}
+
+	if len(organization.Admins) == 1 {
+		return models.Organization{}, status.Errorf(codes.FailedPrecondition, "cannot remove the last admin of %s", req.Name)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM organization_admins WHERE organization_id = $1 AND principal_id = $2
+	`, organizationID(req.Name), principalID(req.Principal)); err != nil {
+		return models.Organization{}, err
+	}
+
+	if organization, err = getOrganization(ctx, tx, req.Name, false); err != nil {
+		return models.Organization{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+func (s *DBStore) IsOrganizationAdmin(ctx context.Context, req IsOrganizationAdminRequest) (bool, error) {
+	var isAdmin bool
+	if err := s.db().GetContext(ctx, &isAdmin, `
+		SELECT EXISTS (
+			SELECT
+				1
+			FROM
+				organization_admins, organizations, principals
+			WHERE
+				organization_admins.organization_id = organizations.id AND
+				organization_admins.principal_id = principals.id AND
+				organizations.id = $1 AND principals.id = $2 AND
+				organizations.delete_time IS NULL AND principals.delete_time IS NULL
+		)
+	`, organizationID(req.Organization), principalID(req.Principal)); err != nil {
+		return false, err
+	}
+
+	return isAdmin, nil
+}
+
+func (s *DBStore) GetOrganizationPasswordPolicy(ctx context.Context, req GetOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	var policy dbOrganizationPasswordPolicy
+	if err := s.db().GetContext(ctx, &policy, `
+		SELECT
+			organization_id, update_time, min_length, require_uppercase, require_lowercase,
+			require_digit, require_symbol, history_size, max_age_seconds
+		FROM
+			organization_password_policies
+		WHERE
+			organization_id = $1
+	`, organizationID(req.Organization)); err != nil {
+		if err == sql.ErrNoRows {
+			return models.PasswordPolicy{}, status.Error(codes.NotFound, "password policy not found")
+		}
+
+		return models.PasswordPolicy{}, err
+	}
+
+	return policy.model(), nil
+}
+
+func (s *DBStore) UpdateOrganizationPasswordPolicy(ctx context.Context, req UpdateOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	p := req.PasswordPolicy
+
+	var policy dbOrganizationPasswordPolicy
+	if err := s.writer(ctx).GetContext(ctx, &policy, `
+		INSERT INTO organization_password_policies
+			(organization_id, update_time, min_length, require_uppercase, require_lowercase,
+			 require_digit, require_symbol, history_size, max_age_seconds)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (organization_id) DO UPDATE
+		SET
+			update_time = EXCLUDED.update_time,
+			min_length = EXCLUDED.min_length,
+			require_uppercase = EXCLUDED.require_uppercase,
+			require_lowercase = EXCLUDED.require_lowercase,
+			require_digit = EXCLUDED.require_digit,
+			require_symbol = EXCLUDED.require_symbol,
+			history_size = EXCLUDED.history_size,
+			max_age_seconds = EXCLUDED.max_age_seconds
+		RETURNING
+			organization_id, update_time, min_length, require_uppercase, require_lowercase,
+			require_digit, require_symbol, history_size, max_age_seconds
+	`, organizationID(req.Organization), time.Now(), p.MinLength, p.RequireUppercase,
+		p.RequireLowercase, p.RequireDigit, p.RequireSymbol, p.HistorySize,
+		int64(p.MaxAge/time.Second)); err != nil {
+		return models.PasswordPolicy{}, err
+	}
+
+	return policy.model(), nil
+}
+
+func (s *DBStore) MoveAccount(ctx context.Context, req MoveAccountRequest) (models.Account, error) {
+	name := fmt.Sprintf("accounts/%s", req.AccountID)
+
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	defer tx.Rollback()
+
+	var destination uuid.NullUUID
+	if req.Organization != "" {
+		if _, err := getOrganization(ctx, tx, req.Organization, true); err != nil {
+			return models.Account{}, err
+		}
+
+		destination = organizationID(req.Organization)
+	}
+
+	var account dbAccount
+	if err := tx.GetContext(ctx, &account, fmt.Sprintf(`
+		UPDATE accounts
+		SET
+			organization_id = $2, update_time = $3, version = version + 1
+		WHERE
+			id = $1 AND delete_time IS NULL
+		RETURNING
+			%s
+	`, accountColumns), req.AccountID, destination, time.Now()); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Account{}, status.Errorf(codes.NotFound, "account not found: %s", name)
+		}
+
+		return models.Account{}, err
+	}
+
+	if err := enqueueWebhookEvent(ctx, tx, req.AccountID, models.WebhookEventAccountUpdated, name); err != nil {
+		return models.Account{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Account{}, err
+	}
+
+	return account.model(), nil
+}
+
+// getOrganization reads a live organization and its admins, locking the
+// organization's row if forUpdate is set.
+func getOrganization(ctx context.Context, q sqlx.QueryerContext, name string, forUpdate bool) (models.Organization, error) {
+	lock := ""
+	if forUpdate {
+		lock = "FOR UPDATE"
+	}
+
+	var organization dbOrganization
+	if err := sqlx.GetContext(ctx, q, &organization, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			organizations
+		WHERE
+			id = $1 AND delete_time IS NULL
+		%s
+	`, organizationColumns, lock), organizationID(name)); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Organization{}, status.Errorf(codes.NotFound, "organization not found: %s", name)
+		}
+
+		return models.Organization{}, err
+	}
+
+	var admins []uuid.UUID
+	if err := sqlx.SelectContext(ctx, q, &admins, `
+		SELECT
+			principal_id
+		FROM
+			organization_admins
+		WHERE
+			organization_id = $1
+		ORDER BY
+			create_time, principal_id
+	`, organization.ID); err != nil {
+		return models.Organization{}, err
+	}
+
+	return organization.model(admins), nil
+}
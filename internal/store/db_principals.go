@@ -0,0 +1,303 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+type dbPrincipal struct {
+	ID          uuid.UUID  `db:"id"`
+	CreateTime  time.Time  `db:"create_time"`
+	UpdateTime  time.Time  `db:"update_time"`
+	DeleteTime  *time.Time `db:"delete_time"`
+	DisplayName string     `db:"display_name"`
+	Email       string     `db:"email"`
+	Version     int64      `db:"version"`
+}
+
+const principalColumns = `
+	id, create_time, update_time, delete_time, display_name, email, version
+`
+
+func (p dbPrincipal) model() models.Principal {
+	return models.Principal{
+		Name:        fmt.Sprintf("principals/%s", p.ID),
+		CreateTime:  p.CreateTime,
+		UpdateTime:  p.UpdateTime,
+		DeleteTime:  p.DeleteTime,
+		Etag:        etag(p.Version),
+		DisplayName: p.DisplayName,
+		Email:       p.Email,
+	}
+}
+
+type dbAccountMembership struct {
+	dbAccount
+	UserSlug string `db:"user_slug"`
+}
+
+// principalID returns the ID in a principal name, or an invalid NullUUID if
+// name isn't a principal name.
+func principalID(name string) uuid.NullUUID {
+	if !strings.HasPrefix(name, "principals/") {
+		return uuid.NullUUID{}
+	}
+
+	return nullUUID(strings.TrimPrefix(name, "principals/"))
+}
+
+func (s *DBStore) CreatePrincipal(ctx context.Context, req CreatePrincipalRequest) (models.Principal, error) {
+	now := time.Now()
+
+	passwordHash, err := s.hasher().Hash(req.Password)
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	defer tx.Rollback()
+
+	var principal dbPrincipal
+	if err := tx.GetContext(ctx, &principal, fmt.Sprintf(`
+		INSERT INTO principals
+			(id, create_time, update_time, delete_time, display_name, email)
+		VALUES
+			($1, $2, $2, NULL, $3, $4)
+		RETURNING
+			%s
+	`, principalColumns), uuid.NewV4(), now, req.Principal.DisplayName, req.Principal.Email); err != nil {
+		return models.Principal{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO identities
+			(id, principal_id, create_time, update_time, delete_time, auth_method, password_hash)
+		VALUES
+			($1, $2, $3, $3, NULL, 'password', $4)
+	`, uuid.NewV4(), principal.ID, now, passwordHash); err != nil {
+		return models.Principal{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Principal{}, err
+	}
+
+	return principal.model(), nil
+}
+
+func (s *DBStore) GetPrincipal(ctx context.Context, req GetPrincipalRequest) (models.Principal, error) {
+	var principal dbPrincipal
+	if err := s.db().GetContext(ctx, &principal, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			principals
+		WHERE
+			id = $1 AND delete_time IS NULL
+	`, principalColumns), principalID(req.Name)); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Principal{}, status.Errorf(codes.NotFound, "principal not found: %s", req.Name)
+		}
+
+		return models.Principal{}, err
+	}
+
+	return principal.model(), nil
+}
+
+// CheckPrincipalPassword checks a principal's password the same way
+// CheckPassword checks a user's.
+func (s *DBStore) CheckPrincipalPassword(ctx context.Context, req CheckPrincipalPasswordRequest) (CheckPasswordResponse, error) {
+	var identity dbIdentity
+	if err := s.db().GetContext(ctx, &identity, `
+		SELECT
+			identities.id, identities.update_time, identities.password_hash
+		FROM
+			identities, principals
+		WHERE
+			identities.principal_id = principals.id AND identities.auth_method = 'password' AND
+			principals.id = $1 AND principals.delete_time IS NULL AND identities.delete_time IS NULL
+	`, principalID(req.Principal)); err != nil {
+		if err != sql.ErrNoRows {
+			return CheckPasswordResponse{}, err
+		}
+
+		s.hasher().Verify(s.missingUserHash(), req.Password)
+		return CheckPasswordResponse{}, nil
+	}
+
+	valid, err := s.hasher().Verify(identity.PasswordHash, req.Password)
+	if err != nil {
+		return CheckPasswordResponse{}, err
+	}
+
+	if valid && s.hasher().NeedsRehash(identity.PasswordHash) {
+		s.rehash(ctx, identity, req.Password)
+	}
+
+	return CheckPasswordResponse{
+		Valid:              valid,
+		PasswordUpdateTime: identity.UpdateTime,
+	}, nil
+}
+
+// ListAccountMemberships lists the live accounts in which a principal is
+// linked to a live user.
+func (s *DBStore) ListAccountMemberships(ctx context.Context, req ListAccountMembershipsRequest) ([]models.AccountMembership, error) {
+	var memberships []dbAccountMembership
+	if err := s.reader(ctx).SelectContext(ctx, &memberships, fmt.Sprintf(`
+		SELECT
+			%s, members.slug AS user_slug
+		FROM
+			accounts
+			JOIN (
+				SELECT account_id, slug FROM users WHERE principal_id = $1 AND delete_time IS NULL
+			) AS members ON members.account_id = accounts.id
+		WHERE
+			accounts.delete_time IS NULL
+		ORDER BY
+			accounts.create_time, accounts.id
+	`, accountColumns), principalID(req.Principal)); err != nil {
+		return nil, err
+	}
+
+	var out []models.AccountMembership
+	for _, membership := range memberships {
+		out = append(out, models.AccountMembership{
+			Account: membership.dbAccount.model(),
+			User:    fmt.Sprintf("users/%s", membership.UserSlug),
+		})
+	}
+
+	return out, nil
+}
+
+// LinkPrincipal links a user to a principal. A principal can be linked to at
+// most one user in each account, and a user to at most one principal.
+func (s *DBStore) LinkPrincipal(ctx context.Context, req LinkPrincipalRequest) (models.User, error) {
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	id := principalID(req.Principal)
+	if !id.Valid {
+		return models.User{}, status.Errorf(codes.NotFound, "principal not found: %s", req.Principal)
+	}
+
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	defer tx.Rollback()
+
+	var users []dbUser
+	if err := tx.SelectContext(ctx, &users, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			users
+		WHERE
+			account_id = $1 AND slug = $2 AND delete_time IS NULL
+		FOR UPDATE
+	`, userColumns), req.AccountID, slug); err != nil {
+		return models.User{}, err
+	}
+
+	if len(users) == 0 {
+		return models.User{}, status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+	}
+
+	user := users[0]
+	if user.PrincipalID.Valid {
+		if user.PrincipalID.UUID == id.UUID {
+			return user.model(), nil
+		}
+
+		return models.User{}, status.Errorf(codes.FailedPrecondition, "user is already linked to a principal: %s", req.Name)
+	}
+
+	// Lock the principal, so that two users in the same account can't be
+	// linked to it concurrently.
+	var locked []uuid.UUID
+	if err := tx.SelectContext(ctx, &locked, `
+		SELECT id FROM principals WHERE id = $1 AND delete_time IS NULL FOR UPDATE
+	`, id); err != nil {
+		return models.User{}, err
+	}
+
+	if len(locked) == 0 {
+		return models.User{}, status.Errorf(codes.NotFound, "principal not found: %s", req.Principal)
+	}
+
+	var linked bool
+	if err := tx.GetContext(ctx, &linked, `
+		SELECT EXISTS (
+			SELECT 1 FROM users WHERE account_id = $1 AND principal_id = $2 AND delete_time IS NULL
+		)
+	`, req.AccountID, id); err != nil {
+		return models.User{}, err
+	}
+
+	if linked {
+		return models.User{}, status.Errorf(codes.AlreadyExists, "principal is already linked to a user in this account: %s", req.Principal)
+	}
+
+	if err := tx.GetContext(ctx, &user, fmt.Sprintf(`
+		UPDATE users
+		SET
+			principal_id = $2, update_time = $3, version = version + 1
+		WHERE
+			id = $1
+		RETURNING
+			%s
+	`, userColumns), user.ID, id, time.Now()); err != nil {
+		return models.User{}, alreadyExists(err, "principal is already linked to a user in this account: %s", req.Principal)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.User{}, err
+	}
+
+	return user.model(), nil
+}
+
+func (s *DBStore) UnlinkPrincipal(ctx context.Context, req UnlinkPrincipalRequest) (models.User, error) {
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var user dbUser
+	if err := s.writer(ctx).GetContext(ctx, &user, fmt.Sprintf(`
+		UPDATE users
+		SET
+			principal_id = NULL, update_time = $3, version = version + 1
+		WHERE
+			account_id = $1 AND slug = $2 AND delete_time IS NULL
+		RETURNING
+			%s
+	`, userColumns), req.AccountID, slug, time.Now()); err != nil {
+		if err == sql.ErrNoRows {
+			return models.User{}, status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+		}
+
+		return models.User{}, err
+	}
+
+	return user.model(), nil
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type readSessionKey struct{}
+
+type readSession struct {
+	wrote int32
+}
+
+// WithReadSession returns a context for one request's calls to a DBStore.
+// Reads that DBStore can serve from its replica only go to the replica in
+// such a context, and only until the request writes to the primary, so that
+// a request always reads its own writes. Reads in other contexts, such as
+// background jobs, always go to the primary.
+func WithReadSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readSessionKey{}, &readSession{})
+}
+
+// reader is what DBStore runs reads that tolerate replication lag against:
+// the replica, unless there isn't one, the read is part of a transaction, or
+// the request has already written to the primary.
+func (s *DBStore) reader(ctx context.Context) dbQueryer {
+	if s.Replica == nil || s.tx != nil {
+		return s.db()
+	}
+
+	session, ok := ctx.Value(readSessionKey{}).(*readSession)
+	if !ok || atomic.LoadInt32(&session.wrote) != 0 {
+		return s.db()
+	}
+
+	return s.Replica
+}
+
+// writer is what DBStore runs writes outside of transactions against. It
+// records the write in the request's read session.
+func (s *DBStore) writer(ctx context.Context) dbQueryer {
+	recordWrite(ctx)
+	return s.db()
+}
+
+func recordWrite(ctx context.Context) {
+	if session, ok := ctx.Value(readSessionKey{}).(*readSession); ok {
+		atomic.StoreInt32(&session.wrote, 1)
+	}
+}
@@ -0,0 +1,81 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/json-multiplex/iam-service/internal/migrate"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+	"github.com/json-multiplex/iam-service/internal/store"
+	"github.com/json-multiplex/iam-service/internal/store/storetest"
+	"github.com/json-multiplex/iam-service/migrations"
+)
+
+// TestDBStore runs the conformance suite against the Postgres database in
+// IAM_TEST_DB_ADDR. Each subtest migrates a schema of its own, which it drops
+// when it's done.
+func TestDBStore(t *testing.T) {
+	addr := os.Getenv("IAM_TEST_DB_ADDR")
+	if addr == "" {
+		t.Skip("IAM_TEST_DB_ADDR is not set")
+	}
+
+	all, err := migrate.Load(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storetest.Run(t, func(t *testing.T) store.Store {
+		ctx := context.Background()
+		schema := "iam_test_" + strings.Replace(uuid.NewV4().String(), "-", "", -1)
+
+		admin, err := sqlx.Open("postgres", addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() { admin.Close() })
+
+		if _, err := admin.ExecContext(ctx, fmt.Sprintf("CREATE SCHEMA %s", schema)); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() { admin.Exec(fmt.Sprintf("DROP SCHEMA %s CASCADE", schema)) })
+
+		db, err := sqlx.Open("postgres", withSearchPath(addr, schema))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() { db.Close() })
+
+		migrator := &migrate.Migrator{DB: db.DB, Migrations: all}
+		if _, err := migrator.Up(ctx); err != nil {
+			t.Fatalf("migrating: %v", err)
+		}
+
+		return &store.DBStore{DB: db, Hasher: passhash.Bcrypt{Cost: 4}}
+	})
+}
+
+// withSearchPath adds a search_path parameter to a URL or key=value
+// connection string.
+func withSearchPath(addr, schema string) string {
+	if !strings.HasPrefix(addr, "postgres://") && !strings.HasPrefix(addr, "postgresql://") {
+		return addr + " search_path=" + schema
+	}
+
+	sep := "?"
+	if strings.Contains(addr, "?") {
+		sep = "&"
+	}
+
+	return addr + sep + "search_path=" + schema
+}
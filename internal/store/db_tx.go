@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// maxTxAttempts is how many times WithTx runs a transaction that fails to
+// serialize before giving up.
+const maxTxAttempts = 5
+
+// dbQueryer is what DBStore runs queries against: the database, or the
+// transaction of a Store passed to WithTx.
+type dbQueryer interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// dbTx is a transaction begun by a DBStore method. Within WithTx, it is a
+// savepoint in the enclosing transaction instead.
+type dbTx interface {
+	dbQueryer
+	Commit() error
+	Rollback() error
+}
+
+type savepoint struct {
+	*sqlx.Tx
+	name string
+	done bool
+}
+
+var savepoints uint64
+
+func (p *savepoint) Commit() error {
+	p.done = true
+	_, err := p.Exec("RELEASE SAVEPOINT " + p.name)
+	return err
+}
+
+func (p *savepoint) Rollback() error {
+	if p.done {
+		return sql.ErrTxDone
+	}
+
+	p.done = true
+	_, err := p.Exec("ROLLBACK TO SAVEPOINT " + p.name)
+	return err
+}
+
+func (s *DBStore) db() dbQueryer {
+	if s.tx != nil {
+		return s.tx
+	}
+
+	return s.DB
+}
+
+func (s *DBStore) begin(ctx context.Context, opts *sql.TxOptions) (dbTx, error) {
+	recordWrite(ctx)
+	if s.tx == nil {
+		return s.DB.BeginTxx(ctx, opts)
+	}
+
+	p := &savepoint{
+		Tx:   s.tx,
+		name: fmt.Sprintf("store_%d", atomic.AddUint64(&savepoints, 1)),
+	}
+
+	if _, err := s.tx.ExecContext(ctx, "SAVEPOINT "+p.name); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// WithTx calls fn with a Store whose operations all run in a single
+// serializable transaction, committed if fn returns nil and rolled back
+// otherwise. If the transaction fails to serialize, it is retried from the
+// start, so fn may be called more than once. Calls to WithTx on the Store
+// passed to fn run in the same transaction.
+func (s *DBStore) WithTx(ctx context.Context, fn func(Store) error) error {
+	return s.withTx(ctx, func(tx *DBStore) error {
+		return fn(tx)
+	})
+}
+
+func (s *DBStore) withTx(ctx context.Context, fn func(*DBStore) error) error {
+	if s.tx != nil {
+		tx, err := s.begin(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		defer tx.Rollback()
+
+		if err := fn(s); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := s.runTx(ctx, fn)
+		if !isSerializationFailure(err) || attempt == maxTxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * 10 * time.Millisecond):
+		}
+	}
+}
+
+func (s *DBStore) runTx(ctx context.Context, fn func(*DBStore) error) error {
+	recordWrite(ctx)
+	tx, err := s.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(&DBStore{DB: s.DB, Hasher: s.Hasher, tx: tx}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isSerializationFailure reports whether err means that a transaction was
+// aborted because of concurrent transactions, and can be retried.
+func isSerializationFailure(err error) bool {
+	if pqErr, ok := errors.Cause(err).(*pq.Error); ok {
+		return pqErr.Code == "40001" || pqErr.Code == "40P01"
+	}
+
+	return false
+}
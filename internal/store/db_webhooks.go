@@ -0,0 +1,357 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+type dbWebhook struct {
+	ID         uuid.UUID      `db:"id"`
+	CreateTime time.Time      `db:"create_time"`
+	UpdateTime time.Time      `db:"update_time"`
+	DeleteTime *time.Time     `db:"delete_time"`
+	URL        string         `db:"url"`
+	EventTypes pq.StringArray `db:"event_types"`
+	Secret     string         `db:"secret"`
+}
+
+type dbWebhookDelivery struct {
+	ID         uuid.UUID `db:"id"`
+	WebhookID  uuid.UUID `db:"webhook_id"`
+	CreateTime time.Time `db:"create_time"`
+	EventType  string    `db:"event_type"`
+	Payload    []byte    `db:"payload"`
+	Attempts   int       `db:"attempts"`
+	LastError  string    `db:"last_error"`
+	URL        string    `db:"url"`
+	Secret     string    `db:"secret"`
+}
+
+type webhookPayload struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Account    string    `json:"account"`
+	Resource   string    `json:"resource"`
+	CreateTime time.Time `json:"create_time"`
+}
+
+func (s *DBStore) ListWebhooks(ctx context.Context, req ListWebhooksRequest) (ListWebhooksResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListWebhooksResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	var webhooks []dbWebhook
+	if err := s.reader(ctx).SelectContext(ctx, &webhooks, `
+		SELECT
+			id, create_time, update_time, delete_time, url, event_types, secret
+		FROM
+			webhooks
+		WHERE
+			account_id = $1 AND delete_time IS NULL
+		ORDER BY
+			create_time, id
+		LIMIT $2 OFFSET $3
+	`, req.AccountID, limit+1, offset); err != nil {
+		return ListWebhooksResponse{}, err
+	}
+
+	var res ListWebhooksResponse
+	if len(webhooks) > limit {
+		webhooks = webhooks[:limit]
+		res.NextPageToken = encodePageToken(offset + limit)
+	}
+
+	for _, webhook := range webhooks {
+		res.Webhooks = append(res.Webhooks, webhook.model())
+	}
+
+	return res, nil
+}
+
+func (s *DBStore) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (models.Webhook, error) {
+	id := uuid.NewV4()
+	now := time.Now()
+
+	if _, err := s.writer(ctx).ExecContext(ctx, `
+		INSERT INTO webhooks
+			(id, account_id, create_time, update_time, delete_time, url, event_types, secret)
+		VALUES
+			($1, $2, $3, $3, NULL, $4, $5, $6)
+	`, id, req.AccountID, now, req.Webhook.URL, pq.StringArray(req.Webhook.EventTypes),
+		req.Webhook.Secret); err != nil {
+		return models.Webhook{}, err
+	}
+
+	return models.Webhook{
+		Name:       fmt.Sprintf("webhooks/%s", id),
+		CreateTime: now,
+		UpdateTime: now,
+		URL:        req.Webhook.URL,
+		EventTypes: req.Webhook.EventTypes,
+		Secret:     req.Webhook.Secret,
+	}, nil
+}
+
+func (s *DBStore) DeleteWebhook(ctx context.Context, req DeleteWebhookRequest) error {
+	now := time.Now()
+
+	id, err := nameID(req.Name, "webhooks")
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.begin(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE webhooks
+		SET
+			delete_time = $3, update_time = $3
+		WHERE
+			account_id = $1 AND id = $2 AND delete_time IS NULL
+	`, req.AccountID, id, now)
+
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "webhook not found: %s", req.Name)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET
+			state = 'dead', last_error = 'webhook deleted', update_time = $2
+		WHERE
+			webhook_id = $1 AND state = 'pending'
+	`, id, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *DBStore) EnqueueWebhookEvent(ctx context.Context, req EnqueueWebhookEventRequest) error {
+	accountID := nullUUID(req.AccountID)
+	if !accountID.Valid {
+		return nil
+	}
+
+	return enqueueWebhookEvent(ctx, s.writer(ctx), req.AccountID, req.EventType, req.Resource)
+}
+
+// enqueueWebhookEvent writes a pending delivery for every webhook in the
+// account subscribed to eventType. Callers pass the transaction of the
+// mutation that caused the event, so that the event is published if and only
+// if the mutation commits.
+func enqueueWebhookEvent(ctx context.Context, tx sqlx.ExtContext, accountID, eventType, resource string) error {
+	now := time.Now()
+
+	var webhookIDs []uuid.UUID
+	if err := sqlx.SelectContext(ctx, tx, &webhookIDs, `
+		SELECT
+			id
+		FROM
+			webhooks
+		WHERE
+			account_id = $1 AND $2 = ANY(event_types) AND delete_time IS NULL
+	`, accountID, eventType); err != nil {
+		return err
+	}
+
+	for _, webhookID := range webhookIDs {
+		id := uuid.NewV4()
+
+		payload, err := json.Marshal(webhookPayload{
+			ID:         fmt.Sprintf("webhooks/%s/deliveries/%s", webhookID, id),
+			Type:       eventType,
+			Account:    fmt.Sprintf("accounts/%s", accountID),
+			Resource:   resource,
+			CreateTime: now,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries
+				(id, webhook_id, create_time, update_time, event_type, payload, state, attempts,
+				 next_attempt_time, last_error)
+			VALUES
+				($1, $2, $3, $3, $4, $5, 'pending', 0, $3, '')
+		`, id, webhookID, now, eventType, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *DBStore) ClaimWebhookDeliveries(ctx context.Context, req ClaimWebhookDeliveriesRequest) ([]models.WebhookDelivery, error) {
+	now := time.Now()
+
+	// Push the next attempt out by the lease duration while delivering, so that
+	// other replicas skip these rows until the lease expires.
+	var deliveries []dbWebhookDelivery
+	if err := s.writer(ctx).SelectContext(ctx, &deliveries, `
+		UPDATE webhook_deliveries
+		SET
+			next_attempt_time = $2, update_time = $3
+		FROM
+			webhooks
+		WHERE
+			webhook_deliveries.id IN (
+				SELECT
+					id
+				FROM
+					webhook_deliveries
+				WHERE
+					state = 'pending' AND next_attempt_time <= $3
+				ORDER BY
+					next_attempt_time
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			) AND
+			webhooks.id = webhook_deliveries.webhook_id
+		RETURNING
+			webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.create_time,
+			webhook_deliveries.event_type, webhook_deliveries.payload, webhook_deliveries.attempts,
+			webhook_deliveries.last_error, webhooks.url, webhooks.secret
+	`, req.Limit, now.Add(req.LeaseDuration), now); err != nil {
+		return nil, err
+	}
+
+	var out []models.WebhookDelivery
+	for _, delivery := range deliveries {
+		out = append(out, delivery.model())
+	}
+
+	return out, nil
+}
+
+func (s *DBStore) CompleteWebhookDelivery(ctx context.Context, req CompleteWebhookDeliveryRequest) error {
+	segments := strings.Split(req.Name, "/")
+	id := segments[3]
+
+	_, err := s.writer(ctx).ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET
+			state = 'delivered', attempts = attempts + 1, last_error = '', update_time = $2
+		WHERE
+			id = $1
+	`, id, time.Now())
+
+	return err
+}
+
+func (s *DBStore) FailWebhookDelivery(ctx context.Context, req FailWebhookDeliveryRequest) error {
+	segments := strings.Split(req.Name, "/")
+	id := segments[3]
+
+	state := "pending"
+	if req.Dead {
+		state = "dead"
+	}
+
+	_, err := s.writer(ctx).ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET
+			state = $2, attempts = attempts + 1, last_error = $3, next_attempt_time = $4,
+			update_time = $5
+		WHERE
+			id = $1
+	`, id, state, req.Error, req.NextAttemptTime, time.Now())
+
+	return err
+}
+
+func (s *DBStore) ListDeadLetters(ctx context.Context, req ListDeadLettersRequest) (ListDeadLettersResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListDeadLettersResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	webhookID, err := nameID(req.Parent, "webhooks")
+	if err != nil {
+		return ListDeadLettersResponse{}, err
+	}
+
+	var deliveries []dbWebhookDelivery
+	if err := s.reader(ctx).SelectContext(ctx, &deliveries, `
+		SELECT
+			webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.create_time,
+			webhook_deliveries.event_type, webhook_deliveries.payload, webhook_deliveries.attempts,
+			webhook_deliveries.last_error, webhooks.url, webhooks.secret
+		FROM
+			webhook_deliveries, webhooks
+		WHERE
+			webhook_deliveries.webhook_id = webhooks.id AND
+			webhooks.account_id = $1 AND webhooks.id = $2 AND webhook_deliveries.state = 'dead'
+		ORDER BY
+			webhook_deliveries.create_time, webhook_deliveries.id
+		LIMIT $3 OFFSET $4
+	`, req.AccountID, webhookID, limit+1, offset); err != nil {
+		return ListDeadLettersResponse{}, err
+	}
+
+	var res ListDeadLettersResponse
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+		res.NextPageToken = encodePageToken(offset + limit)
+	}
+
+	for _, delivery := range deliveries {
+		res.DeadLetters = append(res.DeadLetters, delivery.model())
+	}
+
+	return res, nil
+}
+
+func (w dbWebhook) model() models.Webhook {
+	return models.Webhook{
+		Name:       fmt.Sprintf("webhooks/%s", w.ID),
+		CreateTime: w.CreateTime,
+		UpdateTime: w.UpdateTime,
+		DeleteTime: w.DeleteTime,
+		URL:        w.URL,
+		EventTypes: w.EventTypes,
+		Secret:     w.Secret,
+	}
+}
+
+func (d dbWebhookDelivery) model() models.WebhookDelivery {
+	return models.WebhookDelivery{
+		Name:       fmt.Sprintf("webhooks/%s/deliveries/%s", d.WebhookID, d.ID),
+		CreateTime: d.CreateTime,
+		EventType:  d.EventType,
+		Payload:    d.Payload,
+		Attempts:   d.Attempts,
+		LastError:  d.LastError,
+		URL:        d.URL,
+		Secret:     d.Secret,
+	}
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonMap reads and writes a JSONB object of strings.
+type jsonMap map[string]string
+
+func (m jsonMap) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(map[string]string(m))
+}
+
+func (m *jsonMap) Scan(src interface{}) error {
+	var b []byte
+	switch src := src.(type) {
+	case []byte:
+		b = src
+	case string:
+		b = []byte(src)
+	case nil:
+		*m = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into jsonMap", src)
+	}
+
+	return json.Unmarshal(b, (*map[string]string)(m))
+}
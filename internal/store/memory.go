@@ -0,0 +1,685 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/filter"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+)
+
+// MemoryStore is a Store that keeps its data in memory, for tests and local
+// development. It behaves like DBStore, except that nothing is persisted and
+// transactions are serialized rather than run concurrently. The zero value is
+// an empty store.
+type MemoryStore struct {
+	Hasher passhash.Hasher
+
+	missingUserHashOnce sync.Once
+	missingUserHashed   string
+
+	mu   sync.RWMutex
+	data *memoryData
+
+	// tx is set on the Store that WithTx passes to its function.
+	tx *memoryData
+}
+
+// memoryData holds a MemoryStore's tables. Rows are stored by value and never
+// modified in place, so that a copy of the tables can be changed without
+// affecting the original.
+type memoryData struct {
+	accounts                     map[uuid.UUID]dbAccount
+	users                        map[uuid.UUID]dbUser
+	identities                   map[uuid.UUID]memoryIdentity
+	passwordHistory              []memoryPasswordHistory
+	passwordPolicies             map[uuid.UUID]dbPasswordPolicy
+	principals                   map[uuid.UUID]dbPrincipal
+	organizations                map[uuid.UUID]dbOrganization
+	organizationAdmins           []memoryOrganizationAdmin
+	organizationPasswordPolicies map[uuid.UUID]dbOrganizationPasswordPolicy
+	auditEvents                  []dbAuditEvent
+	auditCheckpoints             []dbAuditCheckpoint
+	webhooks                     map[uuid.UUID]memoryWebhook
+	webhookDeliveries            map[uuid.UUID]memoryWebhookDelivery
+	loginThrottles               map[string]dbLoginThrottle
+	userTokens                   map[uuid.UUID]memoryUserToken
+	invitations                  map[uuid.UUID]memoryInvitation
+}
+
+// memoryIdentity is a password identity, belonging to either a user or a
+// principal.
+type memoryIdentity struct {
+	dbIdentity
+	UserID      uuid.NullUUID
+	PrincipalID uuid.NullUUID
+}
+
+type memoryPasswordHistory struct {
+	IdentityID   uuid.UUID
+	CreateTime   time.Time
+	PasswordHash string
+}
+
+// NewMemoryStore returns an empty MemoryStore that hashes passwords with
+// hasher.
+func NewMemoryStore(hasher passhash.Hasher) *MemoryStore {
+	return &MemoryStore{Hasher: hasher}
+}
+
+func (s *MemoryStore) CheckPassword(ctx context.Context, req CheckPasswordRequest) (CheckPasswordResponse, error) {
+	account, userSlug, err := checkPasswordNames(req)
+	if err != nil {
+		return CheckPasswordResponse{}, err
+	}
+
+	accountID := nullUUID(account).UUID
+
+	var identity memoryIdentity
+	var found bool
+	s.read(func(d *memoryData) error {
+		account, ok := d.accounts[accountID]
+		if !ok {
+			return nil
+		}
+
+		user, ok := d.liveUser(accountID, userSlug)
+		if !ok || (account.DeleteTime != nil && !user.IsRoot) {
+			return nil
+		}
+
+		identity, found = d.passwordIdentity(func(i memoryIdentity) bool {
+			return i.UserID.Valid && i.UserID.UUID == user.ID
+		})
+
+		return nil
+	})
+
+	if !found {
+		// Compare against a throwaway hash anyway, so that a missing user takes
+		// as long to reject as a wrong password.
+		s.hasher().Verify(s.missingUserHash(), req.Password)
+		return CheckPasswordResponse{}, nil
+	}
+
+	return s.verifyPassword(identity, req.Password)
+}
+
+func (s *MemoryStore) verifyPassword(identity memoryIdentity, password string) (CheckPasswordResponse, error) {
+	valid, err := s.hasher().Verify(identity.PasswordHash, password)
+	if err != nil {
+		return CheckPasswordResponse{}, err
+	}
+
+	if valid && s.hasher().NeedsRehash(identity.PasswordHash) {
+		s.rehash(identity, password)
+	}
+
+	return CheckPasswordResponse{
+		Valid:              valid,
+		PasswordUpdateTime: identity.UpdateTime,
+	}, nil
+}
+
+// rehash upgrades an identity's stored hash like DBStore.rehash does.
+func (s *MemoryStore) rehash(identity memoryIdentity, password string) {
+	passwordHash, err := s.hasher().Hash(password)
+	if err != nil {
+		log.Printf("error rehashing password: %v", err)
+		return
+	}
+
+	s.write(func(d *memoryData) error {
+		if current, ok := d.identities[identity.ID]; ok && current.PasswordHash == identity.PasswordHash {
+			current.PasswordHash = passwordHash
+			d.identities[identity.ID] = current
+		}
+
+		return nil
+	})
+}
+
+func (s *MemoryStore) hasher() passhash.Hasher {
+	if s.Hasher == nil {
+		return passhash.Default
+	}
+
+	return s.Hasher
+}
+
+func (s *MemoryStore) missingUserHash() string {
+	s.missingUserHashOnce.Do(func() {
+		s.missingUserHashed, _ = s.hasher().Hash("missing user")
+	})
+
+	return s.missingUserHashed
+}
+
+func (s *MemoryStore) GetAccount(ctx context.Context, req GetAccountRequest) (models.Account, error) {
+	var account models.Account
+	err := s.read(func(d *memoryData) error {
+		a, ok := d.accounts[nullUUID(req.AccountID).UUID]
+		if !ok || (a.DeleteTime != nil && !req.ShowDeleted) {
+			return status.Errorf(codes.NotFound, "account not found: accounts/%s", req.AccountID)
+		}
+
+		account = d.accountModel(a)
+		return nil
+	})
+
+	return account, err
+}
+
+// CreateAccount creates an account along with its root user and the root
+// user's password identity, in one transaction.
+func (s *MemoryStore) CreateAccount(ctx context.Context, req CreateAccountRequest) (models.Account, error) {
+	var account models.Account
+	err := s.withTx(func(tx *MemoryStore) error {
+		accountID := uuid.NewV4()
+		now := time.Now()
+
+		tx.tx.accounts[accountID] = dbAccount{
+			ID:          accountID,
+			CreateTime:  now,
+			UpdateTime:  now,
+			DisplayName: req.Account.DisplayName,
+			Version:     1,
+		}
+
+		user, err := tx.CreateUser(ctx, CreateUserRequest{
+			AccountID: accountID.String(),
+			User:      req.Root,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateIdentity(ctx, CreateIdentityRequest{
+			AccountID: accountID.String(),
+			Parent:    user.Name,
+			Identity: models.Identity{
+				AuthMethod: models.AuthMethodPassword,
+				Password:   req.RootPassword,
+			},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		account = tx.tx.accountModel(tx.tx.accounts[accountID])
+		return nil
+	})
+
+	return account, err
+}
+
+func (s *MemoryStore) UpdateAccount(ctx context.Context, req UpdateAccountRequest) (models.Account, error) {
+	now := time.Now()
+
+	updateDisplayName := len(req.UpdateMask) == 0
+	for _, path := range req.UpdateMask {
+		if path == "display_name" {
+			updateDisplayName = true
+		}
+	}
+
+	var account models.Account
+	err := s.write(func(d *memoryData) error {
+		a, ok := d.accounts[nullUUID(req.AccountID).UUID]
+		if !ok || a.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "account not found: %s", req.Account.Name)
+		}
+
+		if req.Account.Etag != "" && req.Account.Etag != etag(a.Version) {
+			return errEtagMismatch(req.Account.Name)
+		}
+
+		if updateDisplayName {
+			a.DisplayName = req.Account.DisplayName
+		}
+
+		a.UpdateTime = now
+		a.Version++
+		d.accounts[a.ID] = a
+
+		name := fmt.Sprintf("accounts/%s", a.ID)
+		if err := d.enqueueWebhookEvent(a.ID.String(), models.WebhookEventAccountUpdated, name); err != nil {
+			return err
+		}
+
+		account = d.accountModel(a)
+		return nil
+	})
+
+	return account, err
+}
+
+func (s *MemoryStore) GetUser(ctx context.Context, req GetUserRequest) (models.User, error) {
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var user models.User
+	err = s.read(func(d *memoryData) error {
+		u, ok := d.latestUser(nullUUID(req.AccountID).UUID, slug, func(u dbUser) bool {
+			return req.ShowDeleted || u.DeleteTime == nil
+		})
+
+		if !ok {
+			return status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+		}
+
+		user = u.model()
+		return nil
+	})
+
+	return user, err
+}
+
+func (s *MemoryStore) ListUsers(ctx context.Context, req ListUsersRequest) (ListUsersResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListUsersResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+	accountID := nullUUID(req.AccountID).UUID
+
+	var res ListUsersResponse
+	err = s.read(func(d *memoryData) error {
+		var users []dbUser
+		for _, user := range d.sortedUsers() {
+			if user.AccountID == accountID && (req.ShowDeleted || user.DeleteTime == nil) {
+				users = append(users, user)
+			}
+		}
+
+		values := make([]filter.Values, len(users))
+		for i, user := range users {
+			values[i] = userValues(user)
+		}
+
+		matched, err := filterRows(userFilterSchema, req.Filter, req.OrderBy, values)
+		if err != nil {
+			return err
+		}
+
+		var start, end int
+		start, end, res.NextPageToken = pageBounds(len(matched), offset, limit)
+		for _, i := range matched[start:end] {
+			res.Users = append(res.Users, users[i].model())
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// UpdateUser sets the fields of the user named in UpdateMask. Changing the
+// email address clears email_verified.
+func (s *MemoryStore) UpdateUser(ctx context.Context, req UpdateUserRequest) (models.User, error) {
+	slug, err := nameID(req.User.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var updates []func(*dbUser)
+	for _, path := range req.UpdateMask {
+		switch path {
+		case "display_name":
+			updates = append(updates, func(u *dbUser) { u.DisplayName = req.User.DisplayName })
+		case "email":
+			updates = append(updates, func(u *dbUser) {
+				u.EmailVerified = u.EmailVerified && u.Email == req.User.Email
+				u.Email = req.User.Email
+			})
+		case "phone":
+			updates = append(updates, func(u *dbUser) { u.Phone = req.User.Phone })
+		case "locale":
+			updates = append(updates, func(u *dbUser) { u.Locale = req.User.Locale })
+		case "labels":
+			updates = append(updates, func(u *dbUser) { u.Labels = copyMap(req.User.Labels) })
+		case "annotations":
+			updates = append(updates, func(u *dbUser) { u.Annotations = copyMap(req.User.Annotations) })
+		default:
+			return models.User{}, status.Errorf(codes.InvalidArgument, "cannot update field: %s", path)
+		}
+	}
+
+	var user models.User
+	err = s.write(func(d *memoryData) error {
+		u, ok := d.liveUser(nullUUID(req.AccountID).UUID, slug)
+		if !ok {
+			return status.Errorf(codes.NotFound, "user not found: %s", req.User.Name)
+		}
+
+		if req.User.Etag != "" && req.User.Etag != etag(u.Version) {
+			return errEtagMismatch(req.User.Name)
+		}
+
+		for _, update := range updates {
+			update(&u)
+		}
+
+		u.UpdateTime = time.Now()
+		u.Version++
+		d.users[u.ID] = u
+
+		user = u.model()
+		return nil
+	})
+
+	return user, err
+}
+
+func (s *MemoryStore) CreateUser(ctx context.Context, req CreateUserRequest) (models.User, error) {
+	now := time.Now()
+
+	slug, err := nameID(req.User.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var user models.User
+	err = s.write(func(d *memoryData) error {
+		accountID := nullUUID(req.AccountID).UUID
+		if _, ok := d.accounts[accountID]; !ok {
+			return status.Errorf(codes.NotFound, "account not found: accounts/%s", req.AccountID)
+		}
+
+		if _, ok := d.liveUser(accountID, slug); ok {
+			return status.Errorf(codes.AlreadyExists, "user already exists: %s", req.User.Name)
+		}
+
+		u := dbUser{
+			ID:          uuid.NewV4(),
+			AccountID:   accountID,
+			Slug:        slug,
+			DisplayName: req.User.DisplayName,
+			IsRoot:      req.User.IsRoot,
+			Email:       req.User.Email,
+			Phone:       req.User.Phone,
+			Locale:      req.User.Locale,
+			Labels:      copyMap(req.User.Labels),
+			Annotations: copyMap(req.User.Annotations),
+			Version:     1,
+			CreateTime:  now,
+			UpdateTime:  now,
+		}
+
+		d.users[u.ID] = u
+
+		if err := d.enqueueWebhookEvent(req.AccountID, models.WebhookEventUserCreated, req.User.Name); err != nil {
+			return err
+		}
+
+		user = u.model()
+		return nil
+	})
+
+	return user, err
+}
+
+func (s *MemoryStore) DeleteUser(ctx context.Context, req DeleteUserRequest) error {
+	now := time.Now()
+
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return err
+	}
+
+	return s.write(func(d *memoryData) error {
+		u, ok := d.liveUser(nullUUID(req.AccountID).UUID, slug)
+		if !ok {
+			return status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+		}
+
+		if req.Etag != "" && req.Etag != etag(u.Version) {
+			return errEtagMismatch(req.Name)
+		}
+
+		u.DeleteTime = &now
+		u.UpdateTime = now
+		u.Version++
+		d.users[u.ID] = u
+
+		return d.enqueueWebhookEvent(req.AccountID, models.WebhookEventUserDeleted, req.Name)
+	})
+}
+
+func (s *MemoryStore) ListIdentities(ctx context.Context, req ListIdentitiesRequest) (ListIdentitiesResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListIdentitiesResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	slug, err := nameID(req.Parent, "users")
+	if err != nil {
+		return ListIdentitiesResponse{}, err
+	}
+
+	var res ListIdentitiesResponse
+	err = s.read(func(d *memoryData) error {
+		var identities []memoryIdentity
+		if user, ok := d.liveUser(nullUUID(req.AccountID).UUID, slug); ok {
+			for _, identity := range d.sortedIdentities() {
+				if identity.UserID.Valid && identity.UserID.UUID == user.ID &&
+					(req.ShowDeleted || identity.DeleteTime == nil) {
+					identities = append(identities, identity)
+				}
+			}
+		}
+
+		values := make([]filter.Values, len(identities))
+		for i, identity := range identities {
+			values[i] = identityValues(identity)
+		}
+
+		matched, err := filterRows(identityFilterSchema, req.Filter, req.OrderBy, values)
+		if err != nil {
+			return err
+		}
+
+		var start, end int
+		start, end, res.NextPageToken = pageBounds(len(matched), offset, limit)
+		for _, i := range matched[start:end] {
+			identity := identities[i]
+			res.Identities = append(res.Identities, models.Identity{
+				Name:       fmt.Sprintf("%s/identities/%s", req.Parent, identity.ID),
+				CreateTime: identity.CreateTime,
+				UpdateTime: identity.UpdateTime,
+				DeleteTime: identity.DeleteTime,
+				Etag:       etag(identity.Version),
+				AuthMethod: models.AuthMethodPassword,
+			})
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (s *MemoryStore) CreateIdentity(ctx context.Context, req CreateIdentityRequest) (models.Identity, error) {
+	now := time.Now()
+
+	slug, err := nameID(req.Parent, "users")
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	passwordHash, err := s.hasher().Hash(req.Identity.Password)
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	var identity models.Identity
+	err = s.write(func(d *memoryData) error {
+		user, ok := d.liveUser(nullUUID(req.AccountID).UUID, slug)
+		if !ok {
+			return status.Errorf(codes.NotFound, "user not found: %s", req.Parent)
+		}
+
+		id := d.insertIdentity(uuid.NullUUID{UUID: user.ID, Valid: true}, uuid.NullUUID{}, passwordHash, now, now)
+
+		name := fmt.Sprintf("%s/identities/%s", req.Parent, id)
+		if err := d.enqueueWebhookEvent(req.AccountID, models.WebhookEventIdentityCreated, name); err != nil {
+			return err
+		}
+
+		identity = models.Identity{
+			Name:       name,
+			CreateTime: now,
+			UpdateTime: now,
+			DeleteTime: nil,
+			Etag:       etag(1),
+			AuthMethod: models.AuthMethodPassword,
+		}
+
+		return nil
+	})
+
+	return identity, err
+}
+
+func (d *memoryData) insertIdentity(userID, principalID uuid.NullUUID, passwordHash string, createTime, now time.Time) uuid.UUID {
+	id := uuid.NewV4()
+	d.identities[id] = memoryIdentity{
+		dbIdentity: dbIdentity{
+			ID:           id,
+			CreateTime:   createTime,
+			UpdateTime:   now,
+			PasswordHash: passwordHash,
+			Version:      1,
+		},
+		UserID:      userID,
+		PrincipalID: principalID,
+	}
+
+	return id
+}
+
+func (d *memoryData) accountModel(a dbAccount) models.Account {
+	for _, user := range d.sortedUsers() {
+		if user.AccountID == a.ID && user.IsRoot && user.DeleteTime == nil {
+			a.RootSlug.String, a.RootSlug.Valid = user.Slug, true
+			break
+		}
+	}
+
+	return a.model()
+}
+
+func (d *memoryData) liveUser(accountID uuid.UUID, slug string) (dbUser, bool) {
+	return d.latestUser(accountID, slug, func(u dbUser) bool {
+		return u.DeleteTime == nil
+	})
+}
+
+// latestUser returns the live user in an account with a slug for which ok
+// returns true or, failing that, the most recently deleted one.
+func (d *memoryData) latestUser(accountID uuid.UUID, slug string, ok func(dbUser) bool) (dbUser, bool) {
+	var latest dbUser
+	var found bool
+	for _, user := range d.users {
+		if user.AccountID != accountID || user.Slug != slug || !ok(user) {
+			continue
+		}
+
+		if !found || user.DeleteTime == nil ||
+			(latest.DeleteTime != nil && user.DeleteTime.After(*latest.DeleteTime)) {
+			latest, found = user, true
+		}
+	}
+
+	return latest, found
+}
+
+// passwordIdentity returns the earliest created live password identity for
+// which ok returns true.
+func (d *memoryData) passwordIdentity(ok func(memoryIdentity) bool) (memoryIdentity, bool) {
+	for _, identity := range d.sortedIdentities() {
+		if identity.DeleteTime == nil && identity.PasswordHash != "" && ok(identity) {
+			return identity, true
+		}
+	}
+
+	return memoryIdentity{}, false
+}
+
+func (d *memoryData) sortedUsers() []dbUser {
+	users := make([]dbUser, 0, len(d.users))
+	for _, user := range d.users {
+		users = append(users, user)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return createdBefore(users[i].CreateTime, users[i].ID, users[j].CreateTime, users[j].ID)
+	})
+
+	return users
+}
+
+func (d *memoryData) sortedIdentities() []memoryIdentity {
+	identities := make([]memoryIdentity, 0, len(d.identities))
+	for _, identity := range d.identities {
+		identities = append(identities, identity)
+	}
+
+	sort.Slice(identities, func(i, j int) bool {
+		return createdBefore(identities[i].CreateTime, identities[i].ID, identities[j].CreateTime, identities[j].ID)
+	})
+
+	return identities
+}
+
+// createdBefore orders rows by create_time and then id, the order that
+// DBStore lists rows in by default.
+func createdBefore(aTime time.Time, aID uuid.UUID, bTime time.Time, bID uuid.UUID) bool {
+	if !aTime.Equal(bTime) {
+		return aTime.Before(bTime)
+	}
+
+	return bytes.Compare(aID.Bytes(), bID.Bytes()) < 0
+}
+
+// pageBounds returns the range of n listed rows in the page at offset, and the
+// token of the next page.
+func pageBounds(n, offset, limit int) (int, int, string) {
+	if offset > n {
+		offset = n
+	}
+
+	if n-offset > limit {
+		return offset, offset + limit, encodePageToken(offset + limit)
+	}
+
+	return offset, n, ""
+}
+
+// copyMap copies a map for storing in a row. Like a JSONB column read back
+// from Postgres, the copy of a nil map is empty rather than nil.
+func copyMap(m map[string]string) jsonMap {
+	out := make(jsonMap, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+
+	return out
+}
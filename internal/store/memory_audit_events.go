@@ -0,0 +1,274 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit/chain"
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *MemoryStore) CreateAuditEvent(ctx context.Context, req CreateAuditEventRequest) (models.AuditEvent, error) {
+	id := uuid.NewV4()
+
+	// Truncate like DBStore does, so that events hash the same in both.
+	now := time.Now().Truncate(time.Microsecond)
+
+	var accountID uuid.NullUUID
+	if segments := strings.Split(req.AuditEvent.Account, "/"); len(segments) == 2 {
+		accountID = nullUUID(segments[1])
+	}
+
+	outcome := auditOutcomeSuccess
+	if req.AuditEvent.Outcome == models.AuditOutcomeFailure {
+		outcome = auditOutcomeFailure
+	}
+
+	var event models.AuditEvent
+	err := s.write(func(d *memoryData) error {
+		var head dbAuditEvent
+		for _, e := range d.auditEvents {
+			if e.AccountID == accountID && e.Sequence.Valid && e.Sequence.Int64 > head.Sequence.Int64 {
+				head = e
+			}
+		}
+
+		event = models.AuditEvent{
+			Name:       fmt.Sprintf("auditEvents/%s", id),
+			CreateTime: now,
+			Actor:      req.AuditEvent.Actor,
+			Resource:   req.AuditEvent.Resource,
+			Method:     req.AuditEvent.Method,
+			SourceIP:   req.AuditEvent.SourceIP,
+			Outcome:    models.AuditOutcomeSuccess,
+			Sequence:   head.Sequence.Int64 + 1,
+			PrevHash:   head.Hash,
+		}
+
+		if accountID.Valid {
+			event.Account = fmt.Sprintf("accounts/%s", accountID.UUID)
+		}
+
+		if outcome == auditOutcomeFailure {
+			event.Outcome = models.AuditOutcomeFailure
+		}
+
+		event.Hash = chain.Hash(event.PrevHash, event)
+
+		d.auditEvents = append(d.auditEvents, dbAuditEvent{
+			ID:         id,
+			CreateTime: now,
+			AccountID:  accountID,
+			Actor:      event.Actor,
+			Resource:   event.Resource,
+			Method:     event.Method,
+			SourceIP:   event.SourceIP,
+			Outcome:    outcome,
+			Sequence:   sql.NullInt64{Int64: event.Sequence, Valid: true},
+			PrevHash:   event.PrevHash,
+			Hash:       event.Hash,
+		})
+
+		return nil
+	})
+
+	return event, err
+}
+
+func (s *MemoryStore) ListAuditEvents(ctx context.Context, req ListAuditEventsRequest) (ListAuditEventsResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListAuditEventsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+	accountID := nullUUID(req.AccountID)
+
+	outcome := ""
+	switch req.Filter.Outcome {
+	case models.AuditOutcomeSuccess:
+		outcome = auditOutcomeSuccess
+	case models.AuditOutcomeFailure:
+		outcome = auditOutcomeFailure
+	}
+
+	matches := func(e dbAuditEvent) bool {
+		return accountID.Valid && e.AccountID == accountID &&
+			(req.StartTime.IsZero() || !e.CreateTime.Before(req.StartTime)) &&
+			(req.EndTime.IsZero() || e.CreateTime.Before(req.EndTime)) &&
+			(req.Filter.Actor == "" || e.Actor == req.Filter.Actor) &&
+			(req.Filter.Resource == "" || e.Resource == req.Filter.Resource) &&
+			(req.Filter.Method == "" || e.Method == req.Filter.Method) &&
+			(req.Filter.SourceIP == "" || e.SourceIP == req.Filter.SourceIP) &&
+			(outcome == "" || e.Outcome == outcome)
+	}
+
+	var res ListAuditEventsResponse
+	err = s.read(func(d *memoryData) error {
+		var events []dbAuditEvent
+		for _, e := range d.auditEvents {
+			if matches(e) {
+				events = append(events, e)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return createdBefore(events[i].CreateTime, events[i].ID, events[j].CreateTime, events[j].ID)
+		})
+
+		var start, end int
+		start, end, res.NextPageToken = pageBounds(len(events), offset, limit)
+		for _, e := range events[start:end] {
+			res.AuditEvents = append(res.AuditEvents, e.model())
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (s *MemoryStore) ListAuditChainHeads(ctx context.Context) ([]models.AuditEvent, error) {
+	var out []models.AuditEvent
+	err := s.read(func(d *memoryData) error {
+		heads := map[uuid.NullUUID]dbAuditEvent{}
+		for _, e := range d.auditEvents {
+			if head, ok := heads[e.AccountID]; e.Sequence.Valid && (!ok || e.Sequence.Int64 > head.Sequence.Int64) {
+				heads[e.AccountID] = e
+			}
+		}
+
+		var events []dbAuditEvent
+		for _, e := range heads {
+			events = append(events, e)
+		}
+
+		// Order by account, with the chain of events outside any account last,
+		// as Postgres orders nulls.
+		sort.Slice(events, func(i, j int) bool {
+			a, b := events[i].AccountID, events[j].AccountID
+			if a.Valid != b.Valid {
+				return a.Valid
+			}
+
+			return bytes.Compare(a.UUID.Bytes(), b.UUID.Bytes()) < 0
+		})
+
+		for _, e := range events {
+			out = append(out, e.model())
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+func (s *MemoryStore) ListAuditChain(ctx context.Context, req ListAuditChainRequest) ([]models.AuditEvent, error) {
+	accountID := nullUUID(req.AccountID)
+
+	var out []models.AuditEvent
+	err := s.read(func(d *memoryData) error {
+		var events []dbAuditEvent
+		for _, e := range d.auditEvents {
+			if e.AccountID == accountID && e.Sequence.Valid && e.Sequence.Int64 > req.AfterSequence {
+				events = append(events, e)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Sequence.Int64 < events[j].Sequence.Int64
+		})
+
+		if limit := pageSize(req.PageSize); len(events) > limit {
+			events = events[:limit]
+		}
+
+		for _, e := range events {
+			out = append(out, e.model())
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+func (s *MemoryStore) CreateAuditCheckpoint(ctx context.Context, req CreateAuditCheckpointRequest) (models.AuditCheckpoint, error) {
+	id := uuid.NewV4()
+	now := time.Now()
+
+	var accountID uuid.NullUUID
+	if segments := strings.Split(req.AuditCheckpoint.Account, "/"); len(segments) == 2 {
+		accountID = nullUUID(segments[1])
+	}
+
+	err := s.write(func(d *memoryData) error {
+		d.auditCheckpoints = append(d.auditCheckpoints, dbAuditCheckpoint{
+			ID:         id,
+			CreateTime: now,
+			AccountID:  accountID,
+			Sequence:   req.AuditCheckpoint.Sequence,
+			Hash:       req.AuditCheckpoint.Hash,
+			Signature:  req.AuditCheckpoint.Signature,
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return models.AuditCheckpoint{}, err
+	}
+
+	checkpoint := req.AuditCheckpoint
+	checkpoint.Name = fmt.Sprintf("auditCheckpoints/%s", id)
+	checkpoint.CreateTime = now
+	return checkpoint, nil
+}
+
+func (s *MemoryStore) ListAuditCheckpoints(ctx context.Context, req ListAuditCheckpointsRequest) ([]models.AuditCheckpoint, error) {
+	accountID := nullUUID(req.AccountID)
+
+	var out []models.AuditCheckpoint
+	err := s.read(func(d *memoryData) error {
+		var checkpoints []dbAuditCheckpoint
+		for _, c := range d.auditCheckpoints {
+			if c.AccountID == accountID {
+				checkpoints = append(checkpoints, c)
+			}
+		}
+
+		sort.SliceStable(checkpoints, func(i, j int) bool {
+			return checkpoints[i].Sequence < checkpoints[j].Sequence
+		})
+
+		for _, c := range checkpoints {
+			checkpoint := models.AuditCheckpoint{
+				Name:       fmt.Sprintf("auditCheckpoints/%s", c.ID),
+				CreateTime: c.CreateTime,
+				Sequence:   c.Sequence,
+				Hash:       c.Hash,
+				Signature:  c.Signature,
+			}
+
+			if c.AccountID.Valid {
+				checkpoint.Account = fmt.Sprintf("accounts/%s", c.AccountID.UUID)
+			}
+
+			out = append(out, checkpoint)
+		}
+
+		return nil
+	})
+
+	return out, err
+}
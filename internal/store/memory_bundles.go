@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *MemoryStore) ExportAccount(ctx context.Context, req ExportAccountRequest) (models.AccountBundle, error) {
+	var bundle models.AccountBundle
+	err := s.read(func(d *memoryData) error {
+		accountID := nullUUID(req.AccountID).UUID
+
+		account, ok := d.accounts[accountID]
+		if !ok || account.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "account not found: accounts/%s", req.AccountID)
+		}
+
+		bundle = models.AccountBundle{
+			Version:    models.AccountBundleVersion,
+			ExportTime: time.Now(),
+			Account:    d.accountModel(account),
+		}
+
+		if policy, ok := d.passwordPolicies[accountID]; ok {
+			p := policy.model()
+			bundle.PasswordPolicy = &p
+		}
+
+		byUser := map[uuid.UUID][]memoryIdentity{}
+		for _, identity := range d.sortedIdentities() {
+			if identity.UserID.Valid && identity.DeleteTime == nil && identity.PasswordHash != "" {
+				byUser[identity.UserID.UUID] = append(byUser[identity.UserID.UUID], identity)
+			}
+		}
+
+		for _, user := range d.sortedUsers() {
+			if user.AccountID != accountID || user.DeleteTime != nil {
+				continue
+			}
+
+			bundled := models.BundledUser{User: user.model()}
+			for _, identity := range byUser[user.ID] {
+				bundled.Identities = append(bundled.Identities, models.BundledIdentity{
+					Identity: models.Identity{
+						Name:       fmt.Sprintf("users/%s/identities/%s", user.Slug, identity.ID),
+						CreateTime: identity.CreateTime,
+						UpdateTime: identity.UpdateTime,
+						Etag:       etag(identity.Version),
+						AuthMethod: models.AuthMethodPassword,
+					},
+					PasswordHash: identity.PasswordHash,
+				})
+			}
+
+			bundle.Users = append(bundle.Users, bundled)
+		}
+
+		return nil
+	})
+
+	return bundle, err
+}
+
+// ImportAccount restores a bundle like DBStore.ImportAccount does.
+func (s *MemoryStore) ImportAccount(ctx context.Context, req ImportAccountRequest) (ImportAccountResponse, error) {
+	now := time.Now()
+
+	var res ImportAccountResponse
+	err := s.write(func(d *memoryData) error {
+		accountID := req.AccountID
+		if req.CreateAccount {
+			if accountID == "" {
+				accountID = uuid.NewV4().String()
+			}
+
+			id := nullUUID(accountID)
+			if !id.Valid {
+				return status.Errorf(codes.InvalidArgument, "invalid account id: %s", accountID)
+			}
+
+			if _, ok := d.accounts[id.UUID]; ok {
+				return status.Errorf(codes.AlreadyExists, "account already exists: accounts/%s", accountID)
+			}
+
+			d.accounts[id.UUID] = dbAccount{
+				ID:          id.UUID,
+				CreateTime:  importTime(req.Bundle.Account.CreateTime, now),
+				UpdateTime:  now,
+				DisplayName: req.Bundle.Account.DisplayName,
+				Version:     1,
+			}
+		} else if account, ok := d.accounts[nullUUID(accountID).UUID]; !ok || account.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "account not found: accounts/%s", accountID)
+		}
+
+		id := nullUUID(accountID).UUID
+
+		if req.Bundle.PasswordPolicy != nil && (req.CreateAccount || req.Conflict == models.ImportConflictOverwrite) {
+			d.upsertPasswordPolicy(id, *req.Bundle.PasswordPolicy)
+		}
+
+		taken := map[string]uuid.UUID{}
+		for _, user := range d.users {
+			if user.AccountID == id && user.DeleteTime == nil {
+				taken[user.Slug] = user.ID
+			}
+		}
+
+		res = ImportAccountResponse{Renamed: map[string]string{}}
+		for _, bundled := range req.Bundle.Users {
+			user := bundled.User
+			user.IsRoot = user.IsRoot && req.CreateAccount
+			slug := strings.TrimPrefix(user.Name, "users/")
+
+			if existingID, ok := taken[slug]; ok {
+				switch req.Conflict {
+				case models.ImportConflictSkip:
+					res.Skipped = append(res.Skipped, user.Name)
+					continue
+				case models.ImportConflictOverwrite:
+					d.overwriteUser(existingID, user, bundled.Identities, now)
+					res.Overwritten = append(res.Overwritten, user.Name)
+					continue
+				case models.ImportConflictRename:
+					slug = freeSlug(slug, taken)
+					res.Renamed[user.Name] = fmt.Sprintf("users/%s", slug)
+				default:
+					return status.Errorf(codes.AlreadyExists, "user already exists: %s", user.Name)
+				}
+			}
+
+			userID := uuid.NewV4()
+			d.users[userID] = dbUser{
+				ID:            userID,
+				AccountID:     id,
+				Slug:          slug,
+				DisplayName:   user.DisplayName,
+				IsRoot:        user.IsRoot,
+				Email:         user.Email,
+				EmailVerified: user.EmailVerified,
+				Phone:         user.Phone,
+				Locale:        user.Locale,
+				Labels:        copyMap(user.Labels),
+				Annotations:   copyMap(user.Annotations),
+				Version:       1,
+				CreateTime:    importTime(user.CreateTime, now),
+				UpdateTime:    now,
+			}
+
+			d.insertBundledIdentities(userID, bundled.Identities, now)
+
+			name := fmt.Sprintf("users/%s", slug)
+			if err := d.enqueueWebhookEvent(accountID, models.WebhookEventUserCreated, name); err != nil {
+				return err
+			}
+
+			taken[slug] = userID
+			res.Created = append(res.Created, name)
+		}
+
+		res.Account = d.accountModel(d.accounts[id])
+		return nil
+	})
+
+	return res, err
+}
+
+// overwriteUser replaces an existing user's profile and identities with
+// bundled ones. Whether the user is root is left alone.
+func (d *memoryData) overwriteUser(id uuid.UUID, user models.User, identities []models.BundledIdentity, now time.Time) {
+	u := d.users[id]
+	u.UpdateTime = now
+	u.Version++
+	u.DisplayName = user.DisplayName
+	u.Email = user.Email
+	u.EmailVerified = user.EmailVerified
+	u.Phone = user.Phone
+	u.Locale = user.Locale
+	u.Labels = copyMap(user.Labels)
+	u.Annotations = copyMap(user.Annotations)
+	d.users[id] = u
+
+	for _, identity := range d.identities {
+		if identity.UserID.Valid && identity.UserID.UUID == id && identity.DeleteTime == nil {
+			identity.DeleteTime = &now
+			identity.UpdateTime = now
+			identity.Version++
+			d.identities[identity.ID] = identity
+		}
+	}
+
+	d.insertBundledIdentities(id, identities, now)
+}
+
+func (d *memoryData) insertBundledIdentities(userID uuid.UUID, identities []models.BundledIdentity, now time.Time) {
+	for _, identity := range identities {
+		d.insertIdentity(uuid.NullUUID{UUID: userID, Valid: true}, uuid.NullUUID{}, identity.PasswordHash,
+			importTime(identity.Identity.CreateTime, now), now)
+	}
+}
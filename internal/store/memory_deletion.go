@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *MemoryStore) DeleteAccount(ctx context.Context, req DeleteAccountRequest) error {
+	now := time.Now()
+	name := fmt.Sprintf("accounts/%s", req.AccountID)
+
+	return s.write(func(d *memoryData) error {
+		account, ok := d.accounts[nullUUID(req.AccountID).UUID]
+		if !ok || account.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "account not found: %s", name)
+		}
+
+		if req.Etag != "" && req.Etag != etag(account.Version) {
+			return errEtagMismatch(name)
+		}
+
+		account.DeleteTime = &now
+		account.UpdateTime = now
+		account.Version++
+		d.accounts[account.ID] = account
+
+		return d.enqueueWebhookEvent(req.AccountID, models.WebhookEventAccountDeleted, name)
+	})
+}
+
+// UndeleteAccount restores an account that was deleted after DeletedAfter.
+func (s *MemoryStore) UndeleteAccount(ctx context.Context, req UndeleteAccountRequest) (models.Account, error) {
+	name := fmt.Sprintf("accounts/%s", req.AccountID)
+
+	var account models.Account
+	err := s.write(func(d *memoryData) error {
+		a, ok := d.accounts[nullUUID(req.AccountID).UUID]
+		if !ok {
+			return status.Errorf(codes.NotFound, "account not found: %s", name)
+		}
+
+		if a.DeleteTime == nil {
+			return status.Errorf(codes.FailedPrecondition, "account is not deleted: %s", name)
+		}
+
+		if !a.DeleteTime.After(req.DeletedAfter) {
+			return status.Errorf(codes.FailedPrecondition, "account can no longer be undeleted: %s", name)
+		}
+
+		a.DeleteTime = nil
+		a.UpdateTime = time.Now()
+		a.Version++
+		d.accounts[a.ID] = a
+
+		if err := d.enqueueWebhookEvent(req.AccountID, models.WebhookEventAccountUndeleted, name); err != nil {
+			return err
+		}
+
+		account = d.accountModel(a)
+		return nil
+	})
+
+	return account, err
+}
+
+// UndeleteUser restores the most recently deleted user with the given name,
+// if it was deleted after DeletedAfter and no other user has taken the name
+// since.
+func (s *MemoryStore) UndeleteUser(ctx context.Context, req UndeleteUserRequest) (models.User, error) {
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var user models.User
+	err = s.write(func(d *memoryData) error {
+		u, ok := d.latestUser(nullUUID(req.AccountID).UUID, slug, func(u dbUser) bool {
+			return u.EraseTime == nil
+		})
+
+		if !ok {
+			return status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+		}
+
+		if u.DeleteTime == nil {
+			return status.Errorf(codes.AlreadyExists, "user already exists: %s", req.Name)
+		}
+
+		if !u.DeleteTime.After(req.DeletedAfter) {
+			return status.Errorf(codes.FailedPrecondition, "user can no longer be undeleted: %s", req.Name)
+		}
+
+		u.DeleteTime = nil
+		u.UpdateTime = time.Now()
+		u.Version++
+		d.users[u.ID] = u
+
+		if err := d.enqueueWebhookEvent(req.AccountID, models.WebhookEventUserUndeleted, req.Name); err != nil {
+			return err
+		}
+
+		user = u.model()
+		return nil
+	})
+
+	return user, err
+}
+
+// PurgeDeleted permanently removes what DBStore.PurgeDeleted does.
+func (s *MemoryStore) PurgeDeleted(ctx context.Context, req PurgeDeletedRequest) (PurgeDeletedResponse, error) {
+	purged := func(deleteTime *time.Time) bool {
+		return deleteTime != nil && deleteTime.Before(req.DeletedBefore)
+	}
+
+	var res PurgeDeletedResponse
+	err := s.write(func(d *memoryData) error {
+		accounts := map[uuid.UUID]bool{}
+		for id, account := range d.accounts {
+			if purged(account.DeleteTime) {
+				accounts[id] = true
+			}
+		}
+
+		users := map[uuid.UUID]bool{}
+		for id, user := range d.users {
+			if purged(user.DeleteTime) || accounts[user.AccountID] {
+				users[id] = true
+			}
+		}
+
+		identities := map[uuid.UUID]bool{}
+		for id, identity := range d.identities {
+			if purged(identity.DeleteTime) || (identity.UserID.Valid && users[identity.UserID.UUID]) {
+				identities[id] = true
+			}
+		}
+
+		var history []memoryPasswordHistory
+		for _, h := range d.passwordHistory {
+			if !identities[h.IdentityID] {
+				history = append(history, h)
+			}
+		}
+
+		d.passwordHistory = history
+
+		for id := range identities {
+			delete(d.identities, id)
+		}
+
+		for id, token := range d.userTokens {
+			if users[token.UserID] {
+				delete(d.userTokens, id)
+			}
+		}
+
+		for id, invitation := range d.invitations {
+			if accounts[invitation.AccountID] || (invitation.UserID.Valid && users[invitation.UserID.UUID]) {
+				delete(d.invitations, id)
+			}
+		}
+
+		for id := range users {
+			delete(d.users, id)
+		}
+
+		for id, webhook := range d.webhooks {
+			if !accounts[webhook.AccountID] {
+				continue
+			}
+
+			for deliveryID, delivery := range d.webhookDeliveries {
+				if delivery.WebhookID == id {
+					delete(d.webhookDeliveries, deliveryID)
+				}
+			}
+
+			delete(d.webhooks, id)
+		}
+
+		for id := range accounts {
+			delete(d.passwordPolicies, id)
+			delete(d.accounts, id)
+		}
+
+		res = PurgeDeletedResponse{
+			Accounts:   int64(len(accounts)),
+			Users:      int64(len(users)),
+			Identities: int64(len(identities)),
+		}
+
+		return nil
+	})
+
+	return res, err
+}
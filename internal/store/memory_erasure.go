@@ -0,0 +1,202 @@
+package store
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit/chain"
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+// EraseUser scrubs a user like DBStore.EraseUser does.
+func (s *MemoryStore) EraseUser(ctx context.Context, req EraseUserRequest) (EraseUserResponse, error) {
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return EraseUserResponse{}, err
+	}
+	now := time.Now()
+
+	var res EraseUserResponse
+	err = s.write(func(d *memoryData) error {
+		accountID := nullUUID(req.AccountID).UUID
+
+		user, ok := d.latestUser(accountID, slug, func(u dbUser) bool {
+			return u.EraseTime == nil
+		})
+
+		if !ok {
+			return status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+		}
+
+		if req.Etag != "" && req.Etag != etag(user.Version) {
+			return errEtagMismatch(req.Name)
+		}
+
+		if user.IsRoot {
+			return status.Errorf(codes.FailedPrecondition, "cannot erase root user: %s", req.Name)
+		}
+
+		pseudonym := fmt.Sprintf("users/erased-%s", hex.EncodeToString(user.ID.Bytes()))
+
+		user.Slug = strings.TrimPrefix(pseudonym, "users/")
+		user.UpdateTime = now
+		if user.DeleteTime == nil {
+			user.DeleteTime = &now
+		}
+
+		user.EraseTime = &now
+		user.Version++
+		user.DisplayName = ""
+		user.Email = ""
+		user.EmailVerified = false
+		user.Phone = ""
+		user.Locale = ""
+		user.Labels = jsonMap{}
+		user.Annotations = jsonMap{}
+		user.PrincipalID.Valid = false
+		d.users[user.ID] = user
+
+		identities := map[uuid.UUID]bool{}
+		for _, identity := range d.identities {
+			if !identity.UserID.Valid || identity.UserID.UUID != user.ID {
+				continue
+			}
+
+			identities[identity.ID] = true
+
+			identity.PasswordHash = ""
+			identity.UpdateTime = now
+			if identity.DeleteTime == nil {
+				identity.DeleteTime = &now
+			}
+
+			identity.Version++
+			d.identities[identity.ID] = identity
+		}
+
+		res.Identities = int64(len(identities))
+
+		var history []memoryPasswordHistory
+		for _, h := range d.passwordHistory {
+			if !identities[h.IdentityID] {
+				history = append(history, h)
+			}
+		}
+
+		d.passwordHistory = history
+
+		for id, token := range d.userTokens {
+			if token.UserID == user.ID {
+				delete(d.userTokens, id)
+			}
+		}
+
+		for id, invitation := range d.invitations {
+			if invitation.UserID.Valid && invitation.UserID.UUID == user.ID {
+				invitation.Slug = user.Slug
+				invitation.Email = ""
+				invitation.DisplayName = ""
+				invitation.Labels = jsonMap{}
+				invitation.UpdateTime = now
+				d.invitations[id] = invitation
+			}
+		}
+
+		res.AuditEvents = d.pseudonymizeAuditEvents(req.AccountID, req.Name, pseudonym, user.CreateTime)
+
+		if err := d.pseudonymizeWebhookDeliveries(req.AccountID, req.Name, pseudonym); err != nil {
+			return err
+		}
+
+		if err := d.enqueueWebhookEvent(req.AccountID, models.WebhookEventUserErased, pseudonym); err != nil {
+			return err
+		}
+
+		res.User = user.model()
+		return nil
+	})
+
+	return res, err
+}
+
+// pseudonymizeAuditEvents rewrites audit events like the DBStore function of
+// the same name.
+func (d *memoryData) pseudonymizeAuditEvents(accountID, name, pseudonym string, since time.Time) int64 {
+	id := nullUUID(accountID)
+
+	replace := func(value string) (string, bool) {
+		if value == name || strings.HasPrefix(value, name+"/") {
+			return pseudonym + strings.TrimPrefix(value, name), true
+		}
+
+		return value, false
+	}
+
+	var n int64
+	for i, event := range d.auditEvents {
+		if !id.Valid || event.AccountID != id || event.CreateTime.Before(since) {
+			continue
+		}
+
+		actor, actorReplaced := replace(event.Actor)
+		resource, resourceReplaced := replace(event.Resource)
+		if !actorReplaced && !resourceReplaced {
+			continue
+		}
+
+		if actorReplaced && event.ActorDigest == nil {
+			event.ActorDigest = chain.FieldDigest(event.Actor)
+		}
+
+		if resourceReplaced && event.ResourceDigest == nil {
+			event.ResourceDigest = chain.FieldDigest(event.Resource)
+		}
+
+		event.Actor, event.Resource = actor, resource
+		d.auditEvents[i] = event
+		n++
+	}
+
+	return n
+}
+
+// pseudonymizeWebhookDeliveries replaces name with pseudonym in the resource
+// of the payloads of an account's webhook deliveries.
+func (d *memoryData) pseudonymizeWebhookDeliveries(accountID, name, pseudonym string) error {
+	id := nullUUID(accountID).UUID
+
+	for deliveryID, delivery := range d.webhookDeliveries {
+		if webhook, ok := d.webhooks[delivery.WebhookID]; !ok || webhook.AccountID != id {
+			continue
+		}
+
+		var payload webhookPayload
+		if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+			return err
+		}
+
+		if payload.Resource != name && !strings.HasPrefix(payload.Resource, name+"/") {
+			continue
+		}
+
+		payload.Resource = pseudonym + strings.TrimPrefix(payload.Resource, name)
+
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		delivery.Payload = b
+		d.webhookDeliveries[deliveryID] = delivery
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/filter"
+)
+
+func userValues(u dbUser) filter.Values {
+	return func(field string) interface{} {
+		switch field {
+		case "create_time":
+			return u.CreateTime
+		case "update_time":
+			return u.UpdateTime
+		case "is_root":
+			return u.IsRoot
+		case "display_name":
+			return u.DisplayName
+		case "email":
+			return u.Email
+		case "email_verified":
+			return u.EmailVerified
+		case "phone":
+			return u.Phone
+		case "locale":
+			return u.Locale
+		case "labels":
+			return map[string]string(u.Labels)
+		}
+
+		return nil
+	}
+}
+
+func identityValues(i memoryIdentity) filter.Values {
+	return func(field string) interface{} {
+		switch field {
+		case "create_time":
+			return i.CreateTime
+		case "update_time":
+			return i.UpdateTime
+		case "auth_method":
+			return "password"
+		}
+
+		return nil
+	}
+}
+
+// filterRows is the in-memory counterpart of filterSQL. It returns the
+// indexes of the rows matching a list request's filter, ordered by its
+// order_by. values holds each row's fields, in the tiebreak order.
+func filterRows(schema filter.Schema, expr filter.Expr, orders []filter.Order, values []filter.Values) ([]int, error) {
+	match := func(filter.Values) bool { return true }
+	if expr != nil {
+		var err error
+		if match, err = filter.Matcher(expr, schema); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+		}
+	}
+
+	compare, err := filter.Comparer(orders, schema)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+
+	var matched []int
+	for i, v := range values {
+		if match(v) {
+			matched = append(matched, i)
+		}
+	}
+
+	sort.SliceStable(matched, func(a, b int) bool {
+		return compare(values[matched[a]], values[matched[b]]) < 0
+	})
+
+	return matched, nil
+}
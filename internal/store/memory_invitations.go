@@ -0,0 +1,213 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+type memoryInvitation struct {
+	dbInvitation
+	UserID uuid.NullUUID
+}
+
+func (s *MemoryStore) ListInvitations(ctx context.Context, req ListInvitationsRequest) (ListInvitationsResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListInvitationsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+	accountID := nullUUID(req.AccountID).UUID
+
+	var res ListInvitationsResponse
+	err = s.read(func(d *memoryData) error {
+		var invitations []memoryInvitation
+		for _, invitation := range d.invitations {
+			if invitation.AccountID == accountID && invitation.DeleteTime == nil {
+				invitations = append(invitations, invitation)
+			}
+		}
+
+		sort.Slice(invitations, func(i, j int) bool {
+			return createdBefore(invitations[i].CreateTime, invitations[i].ID, invitations[j].CreateTime, invitations[j].ID)
+		})
+
+		var start, end int
+		start, end, res.NextPageToken = pageBounds(len(invitations), offset, limit)
+		for _, invitation := range invitations[start:end] {
+			res.Invitations = append(res.Invitations, invitation.model())
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (s *MemoryStore) CreateInvitation(ctx context.Context, req CreateInvitationRequest) (models.Invitation, error) {
+	now := time.Now()
+
+	slug, err := nameID(req.Invitation.User.Name, "users")
+	if err != nil {
+		return models.Invitation{}, err
+	}
+
+	var invitation models.Invitation
+	err = s.write(func(d *memoryData) error {
+		accountID := nullUUID(req.AccountID).UUID
+		if _, ok := d.accounts[accountID]; !ok {
+			return status.Errorf(codes.NotFound, "account not found: accounts/%s", req.AccountID)
+		}
+
+		for _, existing := range d.invitations {
+			if bytes.Equal(existing.CodeHash, req.Invitation.CodeHash) {
+				return status.Error(codes.AlreadyExists, "invitation code already exists")
+			}
+		}
+
+		row := memoryInvitation{
+			dbInvitation: dbInvitation{
+				ID:          uuid.NewV4(),
+				AccountID:   accountID,
+				CreateTime:  now,
+				UpdateTime:  now,
+				ExpireTime:  req.Invitation.ExpireTime,
+				Email:       req.Invitation.Email,
+				Slug:        slug,
+				DisplayName: req.Invitation.User.DisplayName,
+				Labels:      copyMap(req.Invitation.User.Labels),
+				Inviter:     req.Invitation.Inviter,
+				CodeHash:    req.Invitation.CodeHash,
+			},
+		}
+
+		d.invitations[row.ID] = row
+		invitation = row.model()
+		return nil
+	})
+
+	return invitation, err
+}
+
+// DeleteInvitation revokes an invitation that hasn't been accepted yet.
+func (s *MemoryStore) DeleteInvitation(ctx context.Context, req DeleteInvitationRequest) error {
+	now := time.Now()
+	id := invitationID(req.AccountID, req.Name)
+
+	return s.write(func(d *memoryData) error {
+		invitation, ok := d.invitations[id.UUID]
+		if !id.Valid || !ok || invitation.AccountID != nullUUID(req.AccountID).UUID ||
+			invitation.DeleteTime != nil || invitation.AcceptTime != nil {
+			return status.Errorf(codes.NotFound, "invitation not found: %s", req.Name)
+		}
+
+		invitation.DeleteTime = &now
+		invitation.UpdateTime = now
+		d.invitations[invitation.ID] = invitation
+		return nil
+	})
+}
+
+// GetPendingInvitation returns the invitation with a code, provided it can
+// still be accepted.
+func (s *MemoryStore) GetPendingInvitation(ctx context.Context, req GetPendingInvitationRequest) (models.Invitation, error) {
+	var invitation models.Invitation
+	err := s.read(func(d *memoryData) error {
+		pending, ok := d.pendingInvitation(req.CodeHash, time.Now())
+		if !ok {
+			return status.Error(codes.NotFound, "invitation not found")
+		}
+
+		invitation = pending.model()
+		return nil
+	})
+
+	return invitation, err
+}
+
+// AcceptInvitation consumes an invitation, creating its user with a password
+// identity. The user's email is marked as verified, since the code was sent
+// to it.
+func (s *MemoryStore) AcceptInvitation(ctx context.Context, req AcceptInvitationRequest) (models.User, error) {
+	now := time.Now()
+
+	passwordHash, err := s.hasher().Hash(req.Password)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var user models.User
+	err = s.write(func(d *memoryData) error {
+		invitation, ok := d.pendingInvitation(req.CodeHash, now)
+		if !ok {
+			return status.Error(codes.NotFound, "invitation not found")
+		}
+
+		name := fmt.Sprintf("users/%s", invitation.Slug)
+		if _, ok := d.liveUser(invitation.AccountID, invitation.Slug); ok {
+			return status.Errorf(codes.AlreadyExists, "user already exists: %s", name)
+		}
+
+		u := dbUser{
+			ID:            uuid.NewV4(),
+			AccountID:     invitation.AccountID,
+			Slug:          invitation.Slug,
+			DisplayName:   invitation.DisplayName,
+			Email:         invitation.Email,
+			EmailVerified: true,
+			Labels:        copyMap(invitation.Labels),
+			Annotations:   jsonMap{},
+			Version:       1,
+			CreateTime:    now,
+			UpdateTime:    now,
+		}
+
+		d.users[u.ID] = u
+
+		identityID := d.insertIdentity(uuid.NullUUID{UUID: u.ID, Valid: true}, uuid.NullUUID{}, passwordHash, now, now)
+
+		invitation.AcceptTime = &now
+		invitation.UpdateTime = now
+		invitation.UserID = uuid.NullUUID{UUID: u.ID, Valid: true}
+		d.invitations[invitation.ID] = invitation
+
+		accountID := invitation.AccountID.String()
+		if err := d.enqueueWebhookEvent(accountID, models.WebhookEventUserCreated, name); err != nil {
+			return err
+		}
+
+		identity := fmt.Sprintf("%s/identities/%s", name, identityID)
+		if err := d.enqueueWebhookEvent(accountID, models.WebhookEventIdentityCreated, identity); err != nil {
+			return err
+		}
+
+		user = u.model()
+		return nil
+	})
+
+	return user, err
+}
+
+func (d *memoryData) pendingInvitation(codeHash []byte, now time.Time) (memoryInvitation, bool) {
+	for _, invitation := range d.invitations {
+		if !bytes.Equal(invitation.CodeHash, codeHash) || invitation.AcceptTime != nil ||
+			invitation.DeleteTime != nil || !invitation.ExpireTime.After(now) {
+			continue
+		}
+
+		if account, ok := d.accounts[invitation.AccountID]; ok && account.DeleteTime == nil {
+			return invitation, true
+		}
+	}
+
+	return memoryInvitation{}, false
+}
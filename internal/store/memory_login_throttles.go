@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *MemoryStore) GetLoginThrottles(ctx context.Context, req GetLoginThrottlesRequest) ([]models.LoginThrottle, error) {
+	var out []models.LoginThrottle
+	err := s.read(func(d *memoryData) error {
+		seen := map[string]bool{}
+		for _, key := range req.Keys {
+			if throttle, ok := d.loginThrottles[key]; ok && !seen[key] {
+				seen[key] = true
+				out = append(out, throttle.model())
+			}
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+// RecordLoginFailure counts a failed attempt against key. Failures older than
+// req.Window are forgotten, so that the count restarts at one.
+func (s *MemoryStore) RecordLoginFailure(ctx context.Context, req RecordLoginFailureRequest) (models.LoginThrottle, error) {
+	now := time.Now()
+
+	var throttle models.LoginThrottle
+	err := s.write(func(d *memoryData) error {
+		t, ok := d.loginThrottles[req.Key]
+		if !ok || t.LastFailureTime.Before(now.Add(-req.Window)) {
+			t.Key, t.Failures = req.Key, 1
+		} else {
+			t.Failures++
+		}
+
+		t.LastFailureTime = now
+		d.loginThrottles[req.Key] = t
+
+		throttle = t.model()
+		return nil
+	})
+
+	return throttle, err
+}
+
+func (s *MemoryStore) LockLogin(ctx context.Context, req LockLoginRequest) error {
+	return s.write(func(d *memoryData) error {
+		if t, ok := d.loginThrottles[req.Key]; ok {
+			until := req.Until
+			t.LockedUntil = &until
+			d.loginThrottles[req.Key] = t
+		}
+
+		return nil
+	})
+}
+
+func (s *MemoryStore) ResetLoginThrottles(ctx context.Context, req ResetLoginThrottlesRequest) error {
+	return s.write(func(d *memoryData) error {
+		for _, key := range req.Keys {
+			delete(d.loginThrottles, key)
+		}
+
+		return nil
+	})
+}
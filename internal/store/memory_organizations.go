@@ -0,0 +1,318 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+type memoryOrganizationAdmin struct {
+	OrganizationID uuid.UUID
+	PrincipalID    uuid.UUID
+	CreateTime     time.Time
+}
+
+func (s *MemoryStore) CreateOrganization(ctx context.Context, req CreateOrganizationRequest) (models.Organization, error) {
+	now := time.Now()
+
+	admin := principalID(req.Admin)
+	if !admin.Valid {
+		return models.Organization{}, status.Errorf(codes.NotFound, "principal not found: %s", req.Admin)
+	}
+
+	var organization models.Organization
+	err := s.write(func(d *memoryData) error {
+		if _, ok := d.principals[admin.UUID]; !ok {
+			return status.Errorf(codes.NotFound, "principal not found: %s", req.Admin)
+		}
+
+		o := dbOrganization{
+			ID:          uuid.NewV4(),
+			CreateTime:  now,
+			UpdateTime:  now,
+			DisplayName: req.Organization.DisplayName,
+			Version:     1,
+		}
+
+		d.organizations[o.ID] = o
+		d.organizationAdmins = append(d.organizationAdmins, memoryOrganizationAdmin{
+			OrganizationID: o.ID,
+			PrincipalID:    admin.UUID,
+			CreateTime:     now,
+		})
+
+		organization = o.model([]uuid.UUID{admin.UUID})
+		return nil
+	})
+
+	return organization, err
+}
+
+func (s *MemoryStore) GetOrganization(ctx context.Context, req GetOrganizationRequest) (models.Organization, error) {
+	var organization models.Organization
+	err := s.read(func(d *memoryData) error {
+		var err error
+		organization, err = d.organization(req.Name)
+		return err
+	})
+
+	return organization, err
+}
+
+func (s *MemoryStore) UpdateOrganization(ctx context.Context, req UpdateOrganizationRequest) (models.Organization, error) {
+	name := req.Organization.Name
+
+	updateDisplayName := len(req.UpdateMask) == 0
+	for _, path := range req.UpdateMask {
+		if path == "display_name" {
+			updateDisplayName = true
+		}
+	}
+
+	var organization models.Organization
+	err := s.write(func(d *memoryData) error {
+		o, ok := d.organizations[organizationID(name).UUID]
+		if !ok || o.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "organization not found: %s", name)
+		}
+
+		if req.Organization.Etag != "" && req.Organization.Etag != etag(o.Version) {
+			return errEtagMismatch(name)
+		}
+
+		if updateDisplayName {
+			o.DisplayName = req.Organization.DisplayName
+		}
+
+		o.UpdateTime = time.Now()
+		o.Version++
+		d.organizations[o.ID] = o
+
+		var err error
+		organization, err = d.organization(name)
+		return err
+	})
+
+	return organization, err
+}
+
+func (s *MemoryStore) AddOrganizationAdmin(ctx context.Context, req AddOrganizationAdminRequest) (models.Organization, error) {
+	var organization models.Organization
+	err := s.write(func(d *memoryData) error {
+		current, err := d.organization(req.Name)
+		if err != nil {
+			return err
+		}
+
+		id := principalID(req.Principal)
+		if principal, ok := d.principals[id.UUID]; !id.Valid || !ok || principal.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "principal not found: %s", req.Principal)
+		}
+
+		isAdmin := false
+		for _, admin := range current.Admins {
+			isAdmin = isAdmin || admin == req.Principal
+		}
+
+		if !isAdmin {
+			d.organizationAdmins = append(d.organizationAdmins, memoryOrganizationAdmin{
+				OrganizationID: organizationID(req.Name).UUID,
+				PrincipalID:    id.UUID,
+				CreateTime:     time.Now(),
+			})
+		}
+
+		organization, err = d.organization(req.Name)
+		return err
+	})
+
+	return organization, err
+}
+
+// RemoveOrganizationAdmin removes an admin from an organization. The last
+// admin can't be removed, since nobody could then manage the organization.
+func (s *MemoryStore) RemoveOrganizationAdmin(ctx context.Context, req RemoveOrganizationAdminRequest) (models.Organization, error) {
+	var organization models.Organization
+	err := s.write(func(d *memoryData) error {
+		current, err := d.organization(req.Name)
+		if err != nil {
+			return err
+		}
+
+		isAdmin := false
+		for _, admin := range current.Admins {
+			isAdmin = isAdmin || admin == req.Principal
+		}
+
+		if !isAdmin {
+			return status.Errorf(codes.NotFound, "principal is not an admin of %s: %s", req.Name, req.Principal)
+		}
+
+		if len(current.Admins) == 1 {
+			return status.Errorf(codes.FailedPrecondition, "cannot remove the last admin of %s", req.Name)
+		}
+
+		orgID, adminID := organizationID(req.Name).UUID, principalID(req.Principal).UUID
+
+		var admins []memoryOrganizationAdmin
+		for _, admin := range d.organizationAdmins {
+			if admin.OrganizationID != orgID || admin.PrincipalID != adminID {
+				admins = append(admins, admin)
+			}
+		}
+
+		d.organizationAdmins = admins
+
+		organization, err = d.organization(req.Name)
+		return err
+	})
+
+	return organization, err
+}
+
+func (s *MemoryStore) IsOrganizationAdmin(ctx context.Context, req IsOrganizationAdminRequest) (bool, error) {
+	orgID, adminID := organizationID(req.Organization), principalID(req.Principal)
+
+	var isAdmin bool
+	err := s.read(func(d *memoryData) error {
+		organization, ok := d.organizations[orgID.UUID]
+		if !orgID.Valid || !ok || organization.DeleteTime != nil {
+			return nil
+		}
+
+		principal, ok := d.principals[adminID.UUID]
+		if !adminID.Valid || !ok || principal.DeleteTime != nil {
+			return nil
+		}
+
+		for _, admin := range d.organizationAdmins {
+			isAdmin = isAdmin || (admin.OrganizationID == organization.ID && admin.PrincipalID == principal.ID)
+		}
+
+		return nil
+	})
+
+	return isAdmin, err
+}
+
+func (s *MemoryStore) GetOrganizationPasswordPolicy(ctx context.Context, req GetOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	var policy models.PasswordPolicy
+	err := s.read(func(d *memoryData) error {
+		id := organizationID(req.Organization)
+
+		p, ok := d.organizationPasswordPolicies[id.UUID]
+		if !id.Valid || !ok {
+			return status.Error(codes.NotFound, "password policy not found")
+		}
+
+		policy = p.model()
+		return nil
+	})
+
+	return policy, err
+}
+
+func (s *MemoryStore) UpdateOrganizationPasswordPolicy(ctx context.Context, req UpdateOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	p := req.PasswordPolicy
+
+	var policy models.PasswordPolicy
+	err := s.write(func(d *memoryData) error {
+		id := organizationID(req.Organization)
+		if _, ok := d.organizations[id.UUID]; !id.Valid || !ok {
+			return status.Errorf(codes.NotFound, "organization not found: %s", req.Organization)
+		}
+
+		row := dbOrganizationPasswordPolicy{
+			OrganizationID:   id.UUID,
+			UpdateTime:       time.Now(),
+			MinLength:        p.MinLength,
+			RequireUppercase: p.RequireUppercase,
+			RequireLowercase: p.RequireLowercase,
+			RequireDigit:     p.RequireDigit,
+			RequireSymbol:    p.RequireSymbol,
+			HistorySize:      p.HistorySize,
+			MaxAgeSeconds:    int64(p.MaxAge / time.Second),
+		}
+
+		d.organizationPasswordPolicies[id.UUID] = row
+		policy = row.model()
+		return nil
+	})
+
+	return policy, err
+}
+
+func (s *MemoryStore) MoveAccount(ctx context.Context, req MoveAccountRequest) (models.Account, error) {
+	name := fmt.Sprintf("accounts/%s", req.AccountID)
+
+	var account models.Account
+	err := s.write(func(d *memoryData) error {
+		var destination uuid.NullUUID
+		if req.Organization != "" {
+			if _, err := d.organization(req.Organization); err != nil {
+				return err
+			}
+
+			destination = organizationID(req.Organization)
+		}
+
+		a, ok := d.accounts[nullUUID(req.AccountID).UUID]
+		if !ok || a.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "account not found: %s", name)
+		}
+
+		a.OrganizationID = destination
+		a.UpdateTime = time.Now()
+		a.Version++
+		d.accounts[a.ID] = a
+
+		if err := d.enqueueWebhookEvent(req.AccountID, models.WebhookEventAccountUpdated, name); err != nil {
+			return err
+		}
+
+		account = d.accountModel(a)
+		return nil
+	})
+
+	return account, err
+}
+
+// organization reads a live organization and its admins.
+func (d *memoryData) organization(name string) (models.Organization, error) {
+	id := organizationID(name)
+
+	organization, ok := d.organizations[id.UUID]
+	if !id.Valid || !ok || organization.DeleteTime != nil {
+		return models.Organization{}, status.Errorf(codes.NotFound, "organization not found: %s", name)
+	}
+
+	var admins []memoryOrganizationAdmin
+	for _, admin := range d.organizationAdmins {
+		if admin.OrganizationID == organization.ID {
+			admins = append(admins, admin)
+		}
+	}
+
+	sort.Slice(admins, func(i, j int) bool {
+		if !admins[i].CreateTime.Equal(admins[j].CreateTime) {
+			return admins[i].CreateTime.Before(admins[j].CreateTime)
+		}
+
+		return bytes.Compare(admins[i].PrincipalID.Bytes(), admins[j].PrincipalID.Bytes()) < 0
+	})
+
+	var principals []uuid.UUID
+	for _, admin := range admins {
+		principals = append(principals, admin.PrincipalID)
+	}
+
+	return organization.model(principals), nil
+}
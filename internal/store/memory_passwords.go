@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *MemoryStore) GetPasswordPolicy(ctx context.Context, req GetPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	var policy models.PasswordPolicy
+	err := s.read(func(d *memoryData) error {
+		p, ok := d.passwordPolicies[nullUUID(req.AccountID).UUID]
+		if !ok {
+			return status.Error(codes.NotFound, "password policy not found")
+		}
+
+		policy = p.model()
+		return nil
+	})
+
+	return policy, err
+}
+
+func (s *MemoryStore) UpdatePasswordPolicy(ctx context.Context, req UpdatePasswordPolicyRequest) (models.PasswordPolicy, error) {
+	var policy models.PasswordPolicy
+	err := s.write(func(d *memoryData) error {
+		id := nullUUID(req.AccountID).UUID
+		if _, ok := d.accounts[id]; !ok {
+			return status.Errorf(codes.NotFound, "account not found: accounts/%s", req.AccountID)
+		}
+
+		policy = d.upsertPasswordPolicy(id, req.PasswordPolicy)
+		return nil
+	})
+
+	return policy, err
+}
+
+func (d *memoryData) upsertPasswordPolicy(accountID uuid.UUID, p models.PasswordPolicy) models.PasswordPolicy {
+	row := dbPasswordPolicy{
+		AccountID:        accountID,
+		UpdateTime:       time.Now(),
+		MinLength:        p.MinLength,
+		RequireUppercase: p.RequireUppercase,
+		RequireLowercase: p.RequireLowercase,
+		RequireDigit:     p.RequireDigit,
+		RequireSymbol:    p.RequireSymbol,
+		HistorySize:      p.HistorySize,
+		MaxAgeSeconds:    int64(p.MaxAge / time.Second),
+	}
+
+	d.passwordPolicies[accountID] = row
+	return row.model()
+}
+
+// UpdateIdentity replaces the password of a password identity, keeping the
+// previous hash in the identity's password history.
+func (s *MemoryStore) UpdateIdentity(ctx context.Context, req UpdateIdentityRequest) (models.Identity, error) {
+	now := time.Now()
+
+	segments := strings.Split(req.Identity.Name, "/")
+	if len(segments) != 4 {
+		return models.Identity{}, status.Errorf(codes.NotFound, "identity not found: %s", req.Identity.Name)
+	}
+
+	slug := segments[1]
+	id := nullUUID(segments[3])
+	if !id.Valid {
+		return models.Identity{}, status.Errorf(codes.NotFound, "identity not found: %s", req.Identity.Name)
+	}
+
+	passwordHash, err := s.hasher().Hash(req.Identity.Password)
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	var identity models.Identity
+	err = s.write(func(d *memoryData) error {
+		user, ok := d.liveUser(nullUUID(req.AccountID).UUID, slug)
+		current, found := d.identities[id.UUID]
+		if !ok || !found || !current.UserID.Valid || current.UserID.UUID != user.ID || current.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "identity not found: %s", req.Identity.Name)
+		}
+
+		if req.Identity.Etag != "" && req.Identity.Etag != etag(current.Version) {
+			return errEtagMismatch(req.Identity.Name)
+		}
+
+		d.replacePassword(current, passwordHash, now)
+
+		identity = models.Identity{
+			Name:       fmt.Sprintf("users/%s/identities/%s", slug, current.ID),
+			CreateTime: current.CreateTime,
+			UpdateTime: now,
+			Etag:       etag(current.Version + 1),
+			AuthMethod: models.AuthMethodPassword,
+		}
+
+		return nil
+	})
+
+	return identity, err
+}
+
+// replacePassword moves identity's current hash into its password history
+// and stores passwordHash in its place.
+func (d *memoryData) replacePassword(identity memoryIdentity, passwordHash string, now time.Time) {
+	d.passwordHistory = append(d.passwordHistory, memoryPasswordHistory{
+		IdentityID:   identity.ID,
+		CreateTime:   now,
+		PasswordHash: identity.PasswordHash,
+	})
+
+	identity.PasswordHash = passwordHash
+	identity.UpdateTime = now
+	identity.Version++
+	d.identities[identity.ID] = identity
+}
+
+// PasswordReused reports whether password matches the identity's current
+// password or any of the HistorySize-1 passwords before it.
+func (s *MemoryStore) PasswordReused(ctx context.Context, req PasswordReusedRequest) (bool, error) {
+	if req.HistorySize <= 0 {
+		return false, nil
+	}
+
+	segments := strings.Split(req.Identity, "/")
+	if len(segments) != 4 {
+		return false, nil
+	}
+
+	id := nullUUID(segments[3])
+	if !id.Valid {
+		return false, nil
+	}
+
+	var hashes []string
+	s.read(func(d *memoryData) error {
+		identity, ok := d.identities[id.UUID]
+		if !ok || !identity.UserID.Valid {
+			return nil
+		}
+
+		user, ok := d.users[identity.UserID.UUID]
+		if !ok || user.AccountID != nullUUID(req.AccountID).UUID || user.Slug != segments[1] {
+			return nil
+		}
+
+		hashes = d.recentPasswordHashes(identity, req.HistorySize)
+		return nil
+	})
+
+	for _, hash := range hashes {
+		if ok, err := s.hasher().Verify(hash, req.Password); err == nil && ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// recentPasswordHashes returns the identity's current password hash followed
+// by its most recent previous ones, n hashes in total.
+func (d *memoryData) recentPasswordHashes(identity memoryIdentity, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	hashes := []memoryPasswordHistory{{
+		IdentityID:   identity.ID,
+		CreateTime:   identity.UpdateTime,
+		PasswordHash: identity.PasswordHash,
+	}}
+
+	for _, h := range d.passwordHistory {
+		if h.IdentityID == identity.ID {
+			hashes = append(hashes, h)
+		}
+	}
+
+	sort.SliceStable(hashes, func(i, j int) bool {
+		return hashes[i].CreateTime.After(hashes[j].CreateTime)
+	})
+
+	if len(hashes) > n {
+		hashes = hashes[:n]
+	}
+
+	var out []string
+	for _, h := range hashes {
+		out = append(out, h.PasswordHash)
+	}
+
+	return out
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *MemoryStore) CreatePrincipal(ctx context.Context, req CreatePrincipalRequest) (models.Principal, error) {
+	now := time.Now()
+
+	passwordHash, err := s.hasher().Hash(req.Password)
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	var principal models.Principal
+	err = s.write(func(d *memoryData) error {
+		p := dbPrincipal{
+			ID:          uuid.NewV4(),
+			CreateTime:  now,
+			UpdateTime:  now,
+			DisplayName: req.Principal.DisplayName,
+			Email:       req.Principal.Email,
+			Version:     1,
+		}
+
+		d.principals[p.ID] = p
+		d.insertIdentity(uuid.NullUUID{}, uuid.NullUUID{UUID: p.ID, Valid: true}, passwordHash, now, now)
+
+		principal = p.model()
+		return nil
+	})
+
+	return principal, err
+}
+
+func (s *MemoryStore) GetPrincipal(ctx context.Context, req GetPrincipalRequest) (models.Principal, error) {
+	var principal models.Principal
+	err := s.read(func(d *memoryData) error {
+		id := principalID(req.Name)
+
+		p, ok := d.principals[id.UUID]
+		if !id.Valid || !ok || p.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "principal not found: %s", req.Name)
+		}
+
+		principal = p.model()
+		return nil
+	})
+
+	return principal, err
+}
+
+// CheckPrincipalPassword checks a principal's password the same way
+// CheckPassword checks a user's.
+func (s *MemoryStore) CheckPrincipalPassword(ctx context.Context, req CheckPrincipalPasswordRequest) (CheckPasswordResponse, error) {
+	id := principalID(req.Principal)
+
+	var identity memoryIdentity
+	var found bool
+	s.read(func(d *memoryData) error {
+		if p, ok := d.principals[id.UUID]; !id.Valid || !ok || p.DeleteTime != nil {
+			return nil
+		}
+
+		identity, found = d.passwordIdentity(func(i memoryIdentity) bool {
+			return i.PrincipalID == id
+		})
+
+		return nil
+	})
+
+	if !found {
+		s.hasher().Verify(s.missingUserHash(), req.Password)
+		return CheckPasswordResponse{}, nil
+	}
+
+	return s.verifyPassword(identity, req.Password)
+}
+
+// ListAccountMemberships lists the live accounts in which a principal is
+// linked to a live user.
+func (s *MemoryStore) ListAccountMemberships(ctx context.Context, req ListAccountMembershipsRequest) ([]models.AccountMembership, error) {
+	id := principalID(req.Principal)
+
+	var out []models.AccountMembership
+	err := s.read(func(d *memoryData) error {
+		if !id.Valid {
+			return nil
+		}
+
+		var accounts []dbAccount
+		slugs := map[uuid.UUID]string{}
+		for _, user := range d.users {
+			if user.PrincipalID != id || user.DeleteTime != nil {
+				continue
+			}
+
+			if account, ok := d.accounts[user.AccountID]; ok && account.DeleteTime == nil {
+				accounts = append(accounts, account)
+				slugs[account.ID] = user.Slug
+			}
+		}
+
+		sort.Slice(accounts, func(i, j int) bool {
+			return createdBefore(accounts[i].CreateTime, accounts[i].ID, accounts[j].CreateTime, accounts[j].ID)
+		})
+
+		for _, account := range accounts {
+			out = append(out, models.AccountMembership{
+				Account: d.accountModel(account),
+				User:    fmt.Sprintf("users/%s", slugs[account.ID]),
+			})
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+// LinkPrincipal links a user to a principal. A principal can be linked to at
+// most one user in each account, and a user to at most one principal.
+func (s *MemoryStore) LinkPrincipal(ctx context.Context, req LinkPrincipalRequest) (models.User, error) {
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	id := principalID(req.Principal)
+	if !id.Valid {
+		return models.User{}, status.Errorf(codes.NotFound, "principal not found: %s", req.Principal)
+	}
+
+	var user models.User
+	err = s.write(func(d *memoryData) error {
+		accountID := nullUUID(req.AccountID).UUID
+
+		u, ok := d.liveUser(accountID, slug)
+		if !ok {
+			return status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+		}
+
+		if u.PrincipalID.Valid {
+			if u.PrincipalID.UUID == id.UUID {
+				user = u.model()
+				return nil
+			}
+
+			return status.Errorf(codes.FailedPrecondition, "user is already linked to a principal: %s", req.Name)
+		}
+
+		if p, ok := d.principals[id.UUID]; !ok || p.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "principal not found: %s", req.Principal)
+		}
+
+		for _, other := range d.users {
+			if other.AccountID == accountID && other.PrincipalID == id && other.DeleteTime == nil {
+				return status.Errorf(codes.AlreadyExists, "principal is already linked to a user in this account: %s", req.Principal)
+			}
+		}
+
+		u.PrincipalID = id
+		u.UpdateTime = time.Now()
+		u.Version++
+		d.users[u.ID] = u
+
+		user = u.model()
+		return nil
+	})
+
+	return user, err
+}
+
+func (s *MemoryStore) UnlinkPrincipal(ctx context.Context, req UnlinkPrincipalRequest) (models.User, error) {
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var user models.User
+	err = s.write(func(d *memoryData) error {
+		u, ok := d.liveUser(nullUUID(req.AccountID).UUID, slug)
+		if !ok {
+			return status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+		}
+
+		u.PrincipalID = uuid.NullUUID{}
+		u.UpdateTime = time.Now()
+		u.Version++
+		d.users[u.ID] = u
+
+		user = u.model()
+		return nil
+	})
+
+	return user, err
+}
@@ -0,0 +1,15 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/json-multiplex/iam-service/internal/passhash"
+	"github.com/json-multiplex/iam-service/internal/store"
+	"github.com/json-multiplex/iam-service/internal/store/storetest"
+)
+
+func TestMemoryStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		return store.NewMemoryStore(passhash.Bcrypt{Cost: 4})
+	})
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// read calls fn with the store's tables, which fn must not modify.
+func (s *MemoryStore) read(fn func(*memoryData) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+
+	s.mu.RLock()
+	data := s.data
+	s.mu.RUnlock()
+
+	if data == nil {
+		data = newMemoryData()
+	}
+
+	return fn(data)
+}
+
+// write calls fn with a copy of the store's tables, which replaces them if fn
+// returns nil. Each write is therefore atomic, like a DBStore method running
+// in its own transaction or savepoint.
+func (s *MemoryStore) write(fn func(*memoryData) error) error {
+	if s.tx != nil {
+		data := s.tx.clone()
+		if err := fn(data); err != nil {
+			return err
+		}
+
+		*s.tx = *data
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data == nil {
+		s.data = newMemoryData()
+	}
+
+	data := s.data.clone()
+	if err := fn(data); err != nil {
+		return err
+	}
+
+	s.data = data
+	return nil
+}
+
+// WithTx calls fn with a Store whose operations all see and change a private
+// copy of the data, which replaces the store's data if fn returns nil. Other
+// writes wait for fn to return, so transactions never conflict and fn is
+// called exactly once. fn must only use the Store it is passed, since using
+// the original one would wait for fn itself.
+func (s *MemoryStore) WithTx(ctx context.Context, fn func(Store) error) error {
+	return s.withTx(func(tx *MemoryStore) error {
+		return fn(tx)
+	})
+}
+
+func (s *MemoryStore) withTx(fn func(*MemoryStore) error) error {
+	return s.write(func(data *memoryData) error {
+		return fn(&MemoryStore{Hasher: s.Hasher, tx: data})
+	})
+}
+
+func newMemoryData() *memoryData {
+	return &memoryData{
+		accounts:                     map[uuid.UUID]dbAccount{},
+		users:                        map[uuid.UUID]dbUser{},
+		identities:                   map[uuid.UUID]memoryIdentity{},
+		passwordPolicies:             map[uuid.UUID]dbPasswordPolicy{},
+		principals:                   map[uuid.UUID]dbPrincipal{},
+		organizations:                map[uuid.UUID]dbOrganization{},
+		organizationPasswordPolicies: map[uuid.UUID]dbOrganizationPasswordPolicy{},
+		webhooks:                     map[uuid.UUID]memoryWebhook{},
+		webhookDeliveries:            map[uuid.UUID]memoryWebhookDelivery{},
+		loginThrottles:               map[string]dbLoginThrottle{},
+		userTokens:                   map[uuid.UUID]memoryUserToken{},
+		invitations:                  map[uuid.UUID]memoryInvitation{},
+	}
+}
+
+func (d *memoryData) clone() *memoryData {
+	out := newMemoryData()
+
+	for k, v := range d.accounts {
+		out.accounts[k] = v
+	}
+
+	for k, v := range d.users {
+		out.users[k] = v
+	}
+
+	for k, v := range d.identities {
+		out.identities[k] = v
+	}
+
+	for k, v := range d.passwordPolicies {
+		out.passwordPolicies[k] = v
+	}
+
+	for k, v := range d.principals {
+		out.principals[k] = v
+	}
+
+	for k, v := range d.organizations {
+		out.organizations[k] = v
+	}
+
+	for k, v := range d.organizationPasswordPolicies {
+		out.organizationPasswordPolicies[k] = v
+	}
+
+	for k, v := range d.webhooks {
+		out.webhooks[k] = v
+	}
+
+	for k, v := range d.webhookDeliveries {
+		out.webhookDeliveries[k] = v
+	}
+
+	for k, v := range d.loginThrottles {
+		out.loginThrottles[k] = v
+	}
+
+	for k, v := range d.userTokens {
+		out.userTokens[k] = v
+	}
+
+	for k, v := range d.invitations {
+		out.invitations[k] = v
+	}
+
+	out.passwordHistory = append(out.passwordHistory, d.passwordHistory...)
+	out.organizationAdmins = append(out.organizationAdmins, d.organizationAdmins...)
+	out.auditEvents = append(out.auditEvents, d.auditEvents...)
+	out.auditCheckpoints = append(out.auditCheckpoints, d.auditCheckpoints...)
+
+	return out
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+type memoryUserToken struct {
+	dbUserToken
+	ConsumeTime *time.Time
+}
+
+func (s *MemoryStore) CreateUserToken(ctx context.Context, req CreateUserTokenRequest) error {
+	slug, err := nameID(req.UserToken.User, "users")
+	if err != nil {
+		return err
+	}
+
+	return s.write(func(d *memoryData) error {
+		user, ok := d.liveUser(nullUUID(req.AccountID).UUID, slug)
+		if !ok {
+			return status.Errorf(codes.NotFound, "user not found: %s", req.UserToken.User)
+		}
+
+		token := memoryUserToken{
+			dbUserToken: dbUserToken{
+				ID:         uuid.NewV4(),
+				UserID:     user.ID,
+				Purpose:    userTokenPurpose(req.UserToken.Purpose),
+				TokenHash:  req.UserToken.TokenHash,
+				Email:      req.UserToken.Email,
+				CreateTime: time.Now(),
+				ExpireTime: req.UserToken.ExpireTime,
+			},
+		}
+
+		d.userTokens[token.ID] = token
+		return nil
+	})
+}
+
+func (s *MemoryStore) GetUserToken(ctx context.Context, req GetUserTokenRequest) (models.UserToken, error) {
+	var token models.UserToken
+	err := s.read(func(d *memoryData) error {
+		t, ok := d.usableUserToken(req.TokenHash, req.Purpose, time.Now())
+		if !ok {
+			return status.Error(codes.NotFound, "token not found")
+		}
+
+		user, ok := d.users[t.UserID]
+		if !ok || user.DeleteTime != nil {
+			return status.Error(codes.NotFound, "token not found")
+		}
+
+		t.AccountID, t.Slug = user.AccountID, user.Slug
+		token = t.model()
+		return nil
+	})
+
+	return token, err
+}
+
+// ResetPassword consumes a password reset token and replaces the password of
+// the token's user.
+func (s *MemoryStore) ResetPassword(ctx context.Context, req ResetPasswordRequest) error {
+	now := time.Now()
+
+	return s.write(func(d *memoryData) error {
+		userID, _, err := d.consumeUserToken(req.TokenHash, models.UserTokenPurposePasswordReset, now)
+		if err != nil {
+			return err
+		}
+
+		identity, ok := d.passwordIdentity(func(i memoryIdentity) bool {
+			return i.UserID.Valid && i.UserID.UUID == userID
+		})
+
+		if !ok {
+			return status.Error(codes.FailedPrecondition, "user has no password identity")
+		}
+
+		for _, hash := range d.recentPasswordHashes(identity, req.HistorySize) {
+			if ok, err := s.hasher().Verify(hash, req.Password); err == nil && ok {
+				return ErrPasswordReused
+			}
+		}
+
+		passwordHash, err := s.hasher().Hash(req.Password)
+		if err != nil {
+			return err
+		}
+
+		d.replacePassword(identity, passwordHash, now)
+		return nil
+	})
+}
+
+// VerifyEmail consumes an email verification token and marks the token's
+// user's email as verified, provided it hasn't changed since the token was
+// issued.
+func (s *MemoryStore) VerifyEmail(ctx context.Context, req VerifyEmailRequest) error {
+	now := time.Now()
+
+	return s.write(func(d *memoryData) error {
+		userID, email, err := d.consumeUserToken(req.TokenHash, models.UserTokenPurposeEmailVerification, now)
+		if err != nil {
+			return err
+		}
+
+		user, ok := d.users[userID]
+		if !ok || user.Email != email || user.DeleteTime != nil {
+			return status.Error(codes.FailedPrecondition, "email address has changed since the token was issued")
+		}
+
+		user.EmailVerified = true
+		user.UpdateTime = now
+		user.Version++
+		d.users[user.ID] = user
+		return nil
+	})
+}
+
+func (d *memoryData) consumeUserToken(tokenHash []byte, purpose models.UserTokenPurpose, now time.Time) (uuid.UUID, string, error) {
+	token, ok := d.usableUserToken(tokenHash, purpose, now)
+	if !ok {
+		return uuid.UUID{}, "", status.Error(codes.NotFound, "token not found")
+	}
+
+	token.ConsumeTime = &now
+	d.userTokens[token.ID] = token
+
+	return token.UserID, token.Email, nil
+}
+
+// usableUserToken finds an unconsumed, unexpired token.
+func (d *memoryData) usableUserToken(tokenHash []byte, purpose models.UserTokenPurpose, now time.Time) (memoryUserToken, bool) {
+	for _, token := range d.userTokens {
+		if bytes.Equal(token.TokenHash, tokenHash) && token.Purpose == userTokenPurpose(purpose) &&
+			token.ConsumeTime == nil && token.ExpireTime.After(now) {
+			return token, true
+		}
+	}
+
+	return memoryUserToken{}, false
+}
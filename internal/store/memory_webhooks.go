@@ -0,0 +1,317 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+type memoryWebhook struct {
+	dbWebhook
+	AccountID uuid.UUID
+}
+
+type memoryWebhookDelivery struct {
+	dbWebhookDelivery
+	State           string
+	NextAttemptTime time.Time
+	UpdateTime      time.Time
+}
+
+func (s *MemoryStore) ListWebhooks(ctx context.Context, req ListWebhooksRequest) (ListWebhooksResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListWebhooksResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+	accountID := nullUUID(req.AccountID).UUID
+
+	var res ListWebhooksResponse
+	err = s.read(func(d *memoryData) error {
+		var webhooks []memoryWebhook
+		for _, webhook := range d.webhooks {
+			if webhook.AccountID == accountID && webhook.DeleteTime == nil {
+				webhooks = append(webhooks, webhook)
+			}
+		}
+
+		sort.Slice(webhooks, func(i, j int) bool {
+			return createdBefore(webhooks[i].CreateTime, webhooks[i].ID, webhooks[j].CreateTime, webhooks[j].ID)
+		})
+
+		var start, end int
+		start, end, res.NextPageToken = pageBounds(len(webhooks), offset, limit)
+		for _, webhook := range webhooks[start:end] {
+			res.Webhooks = append(res.Webhooks, webhook.model())
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (s *MemoryStore) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (models.Webhook, error) {
+	now := time.Now()
+
+	var webhook models.Webhook
+	err := s.write(func(d *memoryData) error {
+		accountID := nullUUID(req.AccountID)
+		if _, ok := d.accounts[accountID.UUID]; !accountID.Valid || !ok {
+			return status.Errorf(codes.NotFound, "account not found: accounts/%s", req.AccountID)
+		}
+
+		w := memoryWebhook{
+			dbWebhook: dbWebhook{
+				ID:         uuid.NewV4(),
+				CreateTime: now,
+				UpdateTime: now,
+				URL:        req.Webhook.URL,
+				EventTypes: pq.StringArray(append([]string(nil), req.Webhook.EventTypes...)),
+				Secret:     req.Webhook.Secret,
+			},
+			AccountID: accountID.UUID,
+		}
+
+		d.webhooks[w.ID] = w
+		webhook = w.model()
+		return nil
+	})
+
+	return webhook, err
+}
+
+func (s *MemoryStore) DeleteWebhook(ctx context.Context, req DeleteWebhookRequest) error {
+	now := time.Now()
+
+	webhookID, err := nameID(req.Name, "webhooks")
+	if err != nil {
+		return err
+	}
+
+	id := nullUUID(webhookID)
+
+	return s.write(func(d *memoryData) error {
+		webhook, ok := d.webhooks[id.UUID]
+		if !id.Valid || !ok || webhook.AccountID != nullUUID(req.AccountID).UUID || webhook.DeleteTime != nil {
+			return status.Errorf(codes.NotFound, "webhook not found: %s", req.Name)
+		}
+
+		webhook.DeleteTime = &now
+		webhook.UpdateTime = now
+		d.webhooks[webhook.ID] = webhook
+
+		for deliveryID, delivery := range d.webhookDeliveries {
+			if delivery.WebhookID == webhook.ID && delivery.State == "pending" {
+				delivery.State = "dead"
+				delivery.LastError = "webhook deleted"
+				delivery.UpdateTime = now
+				d.webhookDeliveries[deliveryID] = delivery
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *MemoryStore) EnqueueWebhookEvent(ctx context.Context, req EnqueueWebhookEventRequest) error {
+	accountID := nullUUID(req.AccountID)
+	if !accountID.Valid {
+		return nil
+	}
+
+	return s.write(func(d *memoryData) error {
+		return d.enqueueWebhookEvent(req.AccountID, req.EventType, req.Resource)
+	})
+}
+
+// enqueueWebhookEvent writes a pending delivery for every webhook in the
+// account subscribed to eventType.
+func (d *memoryData) enqueueWebhookEvent(accountID, eventType, resource string) error {
+	now := time.Now()
+	id := nullUUID(accountID)
+
+	for _, webhook := range d.webhooks {
+		if webhook.AccountID != id.UUID || webhook.DeleteTime != nil {
+			continue
+		}
+
+		subscribed := false
+		for _, t := range webhook.EventTypes {
+			subscribed = subscribed || t == eventType
+		}
+
+		if !subscribed {
+			continue
+		}
+
+		deliveryID := uuid.NewV4()
+
+		payload, err := json.Marshal(webhookPayload{
+			ID:         fmt.Sprintf("webhooks/%s/deliveries/%s", webhook.ID, deliveryID),
+			Type:       eventType,
+			Account:    fmt.Sprintf("accounts/%s", accountID),
+			Resource:   resource,
+			CreateTime: now,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		d.webhookDeliveries[deliveryID] = memoryWebhookDelivery{
+			dbWebhookDelivery: dbWebhookDelivery{
+				ID:         deliveryID,
+				WebhookID:  webhook.ID,
+				CreateTime: now,
+				EventType:  eventType,
+				Payload:    payload,
+			},
+			State:           "pending",
+			NextAttemptTime: now,
+			UpdateTime:      now,
+		}
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) ClaimWebhookDeliveries(ctx context.Context, req ClaimWebhookDeliveriesRequest) ([]models.WebhookDelivery, error) {
+	now := time.Now()
+
+	var out []models.WebhookDelivery
+	err := s.write(func(d *memoryData) error {
+		var due []memoryWebhookDelivery
+		for _, delivery := range d.webhookDeliveries {
+			if delivery.State == "pending" && !delivery.NextAttemptTime.After(now) {
+				due = append(due, delivery)
+			}
+		}
+
+		sort.Slice(due, func(i, j int) bool {
+			return due[i].NextAttemptTime.Before(due[j].NextAttemptTime)
+		})
+
+		if req.Limit >= 0 && len(due) > req.Limit {
+			due = due[:req.Limit]
+		}
+
+		// Push the next attempt out by the lease duration while delivering, as
+		// DBStore does.
+		for _, delivery := range due {
+			delivery.NextAttemptTime = now.Add(req.LeaseDuration)
+			delivery.UpdateTime = now
+			d.webhookDeliveries[delivery.ID] = delivery
+
+			out = append(out, d.deliveryModel(delivery))
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+func (s *MemoryStore) CompleteWebhookDelivery(ctx context.Context, req CompleteWebhookDeliveryRequest) error {
+	segments := strings.Split(req.Name, "/")
+	id := nullUUID(segments[3])
+
+	return s.write(func(d *memoryData) error {
+		if delivery, ok := d.webhookDeliveries[id.UUID]; id.Valid && ok {
+			delivery.State = "delivered"
+			delivery.Attempts++
+			delivery.LastError = ""
+			delivery.UpdateTime = time.Now()
+			d.webhookDeliveries[delivery.ID] = delivery
+		}
+
+		return nil
+	})
+}
+
+func (s *MemoryStore) FailWebhookDelivery(ctx context.Context, req FailWebhookDeliveryRequest) error {
+	segments := strings.Split(req.Name, "/")
+	id := nullUUID(segments[3])
+
+	state := "pending"
+	if req.Dead {
+		state = "dead"
+	}
+
+	return s.write(func(d *memoryData) error {
+		if delivery, ok := d.webhookDeliveries[id.UUID]; id.Valid && ok {
+			delivery.State = state
+			delivery.Attempts++
+			delivery.LastError = req.Error
+			delivery.NextAttemptTime = req.NextAttemptTime
+			delivery.UpdateTime = time.Now()
+			d.webhookDeliveries[delivery.ID] = delivery
+		}
+
+		return nil
+	})
+}
+
+func (s *MemoryStore) ListDeadLetters(ctx context.Context, req ListDeadLettersRequest) (ListDeadLettersResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListDeadLettersResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	id, err := nameID(req.Parent, "webhooks")
+	if err != nil {
+		return ListDeadLettersResponse{}, err
+	}
+
+	webhookID := nullUUID(id)
+
+	var res ListDeadLettersResponse
+	err = s.read(func(d *memoryData) error {
+		webhook, ok := d.webhooks[webhookID.UUID]
+		if !webhookID.Valid || !ok || webhook.AccountID != nullUUID(req.AccountID).UUID {
+			return nil
+		}
+
+		var deliveries []memoryWebhookDelivery
+		for _, delivery := range d.webhookDeliveries {
+			if delivery.WebhookID == webhook.ID && delivery.State == "dead" {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+
+		sort.Slice(deliveries, func(i, j int) bool {
+			return createdBefore(deliveries[i].CreateTime, deliveries[i].ID, deliveries[j].CreateTime, deliveries[j].ID)
+		})
+
+		var start, end int
+		start, end, res.NextPageToken = pageBounds(len(deliveries), offset, limit)
+		for _, delivery := range deliveries[start:end] {
+			res.DeadLetters = append(res.DeadLetters, d.deliveryModel(delivery))
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// deliveryModel fills in the URL and secret of the delivery's webhook.
+func (d *memoryData) deliveryModel(delivery memoryWebhookDelivery) models.WebhookDelivery {
+	webhook := d.webhooks[delivery.WebhookID]
+	delivery.URL, delivery.Secret = webhook.URL, webhook.Secret
+
+	return delivery.model()
+}
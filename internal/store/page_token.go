@@ -0,0 +1,47 @@
+package store
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+func pageSize(size int32) int {
+	if size <= 0 {
+		return defaultPageSize
+	}
+
+	if size > maxPageSize {
+		return maxPageSize
+	}
+
+	return int(size)
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid page token")
+	}
+
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid page token")
+	}
+
+	return offset, nil
+}
+
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
@@ -0,0 +1,282 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit/chain"
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *SQLiteStore) CreateAuditEvent(ctx context.Context, req CreateAuditEventRequest) (models.AuditEvent, error) {
+	id := uuid.NewV4()
+	now := time.Now()
+
+	var accountID uuid.NullUUID
+	if segments := strings.Split(req.AuditEvent.Account, "/"); len(segments) == 2 {
+		accountID = nullUUID(segments[1])
+	}
+
+	outcome := auditOutcomeSuccess
+	if req.AuditEvent.Outcome == models.AuditOutcomeFailure {
+		outcome = auditOutcomeFailure
+	}
+
+	// Write transactions hold SQLite's database lock from the start, so two
+	// events can't claim the same predecessor.
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	defer tx.Rollback()
+
+	var head dbAuditEvent
+	err = tx.GetContext(ctx, &head, `
+		SELECT
+			sequence, hash
+		FROM
+			audit_events
+		WHERE
+			account_id IS ?1 AND sequence IS NOT NULL
+		ORDER BY
+			sequence DESC
+		LIMIT 1
+	`, accountID)
+
+	if err != nil && err != sql.ErrNoRows {
+		return models.AuditEvent{}, err
+	}
+
+	event := models.AuditEvent{
+		Name:       fmt.Sprintf("auditEvents/%s", id),
+		CreateTime: now,
+		Actor:      req.AuditEvent.Actor,
+		Resource:   req.AuditEvent.Resource,
+		Method:     req.AuditEvent.Method,
+		SourceIP:   req.AuditEvent.SourceIP,
+		Outcome:    models.AuditOutcomeSuccess,
+		Sequence:   head.Sequence.Int64 + 1,
+		PrevHash:   head.Hash,
+	}
+
+	if accountID.Valid {
+		event.Account = fmt.Sprintf("accounts/%s", accountID.UUID)
+	}
+
+	if outcome == auditOutcomeFailure {
+		event.Outcome = models.AuditOutcomeFailure
+	}
+
+	event.Hash = chain.Hash(event.PrevHash, event)
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO audit_events
+			(id, create_time, account_id, actor, resource, method, source_ip, outcome,
+			 sequence, prev_hash, hash)
+		VALUES
+			(?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11)
+	`, id, now, accountID, event.Actor, event.Resource, event.Method, event.SourceIP,
+		outcome, event.Sequence, event.PrevHash, event.Hash); err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	return event, nil
+}
+
+func (s *SQLiteStore) ListAuditEvents(ctx context.Context, req ListAuditEventsRequest) (ListAuditEventsResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListAuditEventsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	conditions := []string{"account_id = ?1"}
+	args := []interface{}{req.AccountID}
+
+	addCondition := func(column string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s ?%d", column, len(args)))
+	}
+
+	if !req.StartTime.IsZero() {
+		addCondition("create_time >=", req.StartTime)
+	}
+
+	if !req.EndTime.IsZero() {
+		addCondition("create_time <", req.EndTime)
+	}
+
+	if req.Filter.Actor != "" {
+		addCondition("actor =", req.Filter.Actor)
+	}
+
+	if req.Filter.Resource != "" {
+		addCondition("resource =", req.Filter.Resource)
+	}
+
+	if req.Filter.Method != "" {
+		addCondition("method =", req.Filter.Method)
+	}
+
+	if req.Filter.SourceIP != "" {
+		addCondition("source_ip =", req.Filter.SourceIP)
+	}
+
+	switch req.Filter.Outcome {
+	case models.AuditOutcomeSuccess:
+		addCondition("outcome =", auditOutcomeSuccess)
+	case models.AuditOutcomeFailure:
+		addCondition("outcome =", auditOutcomeFailure)
+	}
+
+	args = append(args, limit+1, offset)
+
+	var events []dbAuditEvent
+	if err := s.db().SelectContext(ctx, &events, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			audit_events
+		WHERE
+			%s
+		ORDER BY
+			create_time, id
+		LIMIT ?%d OFFSET ?%d
+	`, auditEventColumns, strings.Join(conditions, " AND "), len(args)-1, len(args)), args...); err != nil {
+		return ListAuditEventsResponse{}, err
+	}
+
+	var res ListAuditEventsResponse
+	if len(events) > limit {
+		events = events[:limit]
+		res.NextPageToken = encodePageToken(offset + limit)
+	}
+
+	for _, event := range events {
+		res.AuditEvents = append(res.AuditEvents, event.model())
+	}
+
+	return res, nil
+}
+
+func (s *SQLiteStore) ListAuditChainHeads(ctx context.Context) ([]models.AuditEvent, error) {
+	var events []dbAuditEvent
+	if err := s.db().SelectContext(ctx, &events, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			audit_events AS events
+		WHERE
+			sequence = (
+				SELECT MAX(sequence) FROM audit_events WHERE account_id IS events.account_id
+			)
+		ORDER BY
+			account_id IS NULL, account_id
+	`, auditEventColumns)); err != nil {
+		return nil, err
+	}
+
+	var out []models.AuditEvent
+	for _, event := range events {
+		out = append(out, event.model())
+	}
+
+	return out, nil
+}
+
+func (s *SQLiteStore) ListAuditChain(ctx context.Context, req ListAuditChainRequest) ([]models.AuditEvent, error) {
+	var events []dbAuditEvent
+	if err := s.db().SelectContext(ctx, &events, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			audit_events
+		WHERE
+			account_id IS ?1 AND sequence > ?2
+		ORDER BY
+			sequence
+		LIMIT ?3
+	`, auditEventColumns), nullUUID(req.AccountID), req.AfterSequence, pageSize(req.PageSize)); err != nil {
+		return nil, err
+	}
+
+	var out []models.AuditEvent
+	for _, event := range events {
+		out = append(out, event.model())
+	}
+
+	return out, nil
+}
+
+func (s *SQLiteStore) CreateAuditCheckpoint(ctx context.Context, req CreateAuditCheckpointRequest) (models.AuditCheckpoint, error) {
+	id := uuid.NewV4()
+	now := time.Now()
+
+	var accountID uuid.NullUUID
+	if segments := strings.Split(req.AuditCheckpoint.Account, "/"); len(segments) == 2 {
+		accountID = nullUUID(segments[1])
+	}
+
+	if _, err := s.db().ExecContext(ctx, `
+		INSERT INTO audit_checkpoints
+			(id, create_time, account_id, sequence, hash, signature)
+		VALUES
+			(?1, ?2, ?3, ?4, ?5, ?6)
+	`, id, now, accountID, req.AuditCheckpoint.Sequence, req.AuditCheckpoint.Hash,
+		req.AuditCheckpoint.Signature); err != nil {
+		return models.AuditCheckpoint{}, err
+	}
+
+	checkpoint := req.AuditCheckpoint
+	checkpoint.Name = fmt.Sprintf("auditCheckpoints/%s", id)
+	checkpoint.CreateTime = now
+	return checkpoint, nil
+}
+
+func (s *SQLiteStore) ListAuditCheckpoints(ctx context.Context, req ListAuditCheckpointsRequest) ([]models.AuditCheckpoint, error) {
+	var checkpoints []dbAuditCheckpoint
+	if err := s.db().SelectContext(ctx, &checkpoints, `
+		SELECT
+			id, create_time, account_id, sequence, hash, signature
+		FROM
+			audit_checkpoints
+		WHERE
+			account_id IS ?1
+		ORDER BY
+			sequence
+	`, nullUUID(req.AccountID)); err != nil {
+		return nil, err
+	}
+
+	var out []models.AuditCheckpoint
+	for _, c := range checkpoints {
+		checkpoint := models.AuditCheckpoint{
+			Name:       fmt.Sprintf("auditCheckpoints/%s", c.ID),
+			CreateTime: c.CreateTime,
+			Sequence:   c.Sequence,
+			Hash:       c.Hash,
+			Signature:  c.Signature,
+		}
+
+		if c.AccountID.Valid {
+			checkpoint.Account = fmt.Sprintf("accounts/%s", c.AccountID.UUID)
+		}
+
+		out = append(out, checkpoint)
+	}
+
+	return out, nil
+}
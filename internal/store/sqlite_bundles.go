@@ -0,0 +1,307 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+// ExportAccount reads an account's settings, users and password identities
+// from a single snapshot. Deleted users and identities are left out, as is
+// password history.
+func (s *SQLiteStore) ExportAccount(ctx context.Context, req ExportAccountRequest) (models.AccountBundle, error) {
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return models.AccountBundle{}, err
+	}
+
+	defer tx.Rollback()
+
+	var account dbAccount
+	if err := tx.GetContext(ctx, &account, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			accounts
+		WHERE
+			id = ?1 AND delete_time IS NULL
+	`, accountColumns), req.AccountID); err != nil {
+		if err == sql.ErrNoRows {
+			return models.AccountBundle{}, status.Errorf(codes.NotFound, "account not found: accounts/%s", req.AccountID)
+		}
+
+		return models.AccountBundle{}, err
+	}
+
+	bundle := models.AccountBundle{
+		Version:    models.AccountBundleVersion,
+		ExportTime: time.Now(),
+		Account:    account.model(),
+	}
+
+	var policy dbPasswordPolicy
+	if err := tx.GetContext(ctx, &policy, `
+		SELECT
+			account_id, update_time, min_length, require_uppercase, require_lowercase,
+			require_digit, require_symbol, history_size, max_age_seconds
+		FROM
+			password_policies
+		WHERE
+			account_id = ?1
+	`, req.AccountID); err == nil {
+		p := policy.model()
+		bundle.PasswordPolicy = &p
+	} else if err != sql.ErrNoRows {
+		return models.AccountBundle{}, err
+	}
+
+	var users []dbUser
+	if err := tx.SelectContext(ctx, &users, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			users
+		WHERE
+			account_id = ?1 AND delete_time IS NULL
+		ORDER BY
+			create_time, id
+	`, userColumns), req.AccountID); err != nil {
+		return models.AccountBundle{}, err
+	}
+
+	var identities []dbBundledIdentity
+	if err := tx.SelectContext(ctx, &identities, `
+		SELECT
+			identities.id, identities.user_id, identities.create_time, identities.update_time,
+			identities.password_hash, identities.version
+		FROM
+			identities, users
+		WHERE
+			identities.user_id = users.id AND users.account_id = ?1 AND
+			identities.auth_method = 'password' AND identities.password_hash IS NOT NULL AND
+			users.delete_time IS NULL AND identities.delete_time IS NULL
+		ORDER BY
+			identities.create_time, identities.id
+	`, req.AccountID); err != nil {
+		return models.AccountBundle{}, err
+	}
+
+	byUser := map[uuid.UUID][]dbBundledIdentity{}
+	for _, identity := range identities {
+		byUser[identity.UserID] = append(byUser[identity.UserID], identity)
+	}
+
+	for _, user := range users {
+		bundled := models.BundledUser{User: user.model()}
+		for _, identity := range byUser[user.ID] {
+			bundled.Identities = append(bundled.Identities, models.BundledIdentity{
+				Identity: models.Identity{
+					Name:       fmt.Sprintf("users/%s/identities/%s", user.Slug, identity.ID),
+					CreateTime: identity.CreateTime,
+					UpdateTime: identity.UpdateTime,
+					Etag:       etag(identity.Version),
+					AuthMethod: models.AuthMethodPassword,
+				},
+				PasswordHash: identity.PasswordHash,
+			})
+		}
+
+		bundle.Users = append(bundle.Users, bundled)
+	}
+
+	return bundle, nil
+}
+
+// ImportAccount restores a bundle in a single transaction. Users and
+// identities get new IDs, so a bundle can be imported into the deployment it
+// came from. Imported users are only root if the import creates the account.
+// The bundle's password policy replaces the account's when the account is
+// created or Conflict is ImportConflictOverwrite.
+func (s *SQLiteStore) ImportAccount(ctx context.Context, req ImportAccountRequest) (ImportAccountResponse, error) {
+	now := time.Now()
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return ImportAccountResponse{}, err
+	}
+
+	defer tx.Rollback()
+
+	accountID := req.AccountID
+	if req.CreateAccount {
+		if accountID == "" {
+			accountID = uuid.NewV4().String()
+		}
+
+		if !nullUUID(accountID).Valid {
+			return ImportAccountResponse{}, status.Errorf(codes.InvalidArgument, "invalid account id: %s", accountID)
+		}
+
+		var exists bool
+		if err := tx.GetContext(ctx, &exists, `
+			SELECT EXISTS (SELECT 1 FROM accounts WHERE id = ?1)
+		`, accountID); err != nil {
+			return ImportAccountResponse{}, err
+		}
+
+		if exists {
+			return ImportAccountResponse{}, status.Errorf(codes.AlreadyExists, "account already exists: accounts/%s", accountID)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO accounts
+				(id, create_time, update_time, delete_time, display_name)
+			VALUES
+				(?1, ?2, ?3, NULL, ?4)
+		`, accountID, importTime(req.Bundle.Account.CreateTime, now), now, req.Bundle.Account.DisplayName); err != nil {
+			return ImportAccountResponse{}, sqliteAlreadyExists(err, "account already exists: accounts/%s", accountID)
+		}
+	} else {
+		var locked []uuid.UUID
+		if err := tx.SelectContext(ctx, &locked, `
+			SELECT id FROM accounts WHERE id = ?1 AND delete_time IS NULL
+		`, accountID); err != nil {
+			return ImportAccountResponse{}, err
+		}
+
+		if len(locked) == 0 {
+			return ImportAccountResponse{}, status.Errorf(codes.NotFound, "account not found: accounts/%s", accountID)
+		}
+	}
+
+	if req.Bundle.PasswordPolicy != nil && (req.CreateAccount || req.Conflict == models.ImportConflictOverwrite) {
+		if _, err := sqliteUpsertPasswordPolicy(ctx, tx, accountID, *req.Bundle.PasswordPolicy); err != nil {
+			return ImportAccountResponse{}, err
+		}
+	}
+
+	var existing []dbUser
+	if err := tx.SelectContext(ctx, &existing, `
+		SELECT id, slug FROM users WHERE account_id = ?1 AND delete_time IS NULL
+	`, accountID); err != nil {
+		return ImportAccountResponse{}, err
+	}
+
+	taken := map[string]uuid.UUID{}
+	for _, user := range existing {
+		taken[user.Slug] = user.ID
+	}
+
+	res := ImportAccountResponse{Renamed: map[string]string{}}
+	for _, bundled := range req.Bundle.Users {
+		user := bundled.User
+		user.IsRoot = user.IsRoot && req.CreateAccount
+		slug := strings.TrimPrefix(user.Name, "users/")
+
+		if existingID, ok := taken[slug]; ok {
+			switch req.Conflict {
+			case models.ImportConflictSkip:
+				res.Skipped = append(res.Skipped, user.Name)
+				continue
+			case models.ImportConflictOverwrite:
+				if err := sqliteOverwriteUser(ctx, tx, existingID, user, bundled.Identities, now); err != nil {
+					return ImportAccountResponse{}, err
+				}
+
+				res.Overwritten = append(res.Overwritten, user.Name)
+				continue
+			case models.ImportConflictRename:
+				slug = freeSlug(slug, taken)
+				res.Renamed[user.Name] = fmt.Sprintf("users/%s", slug)
+			default:
+				return ImportAccountResponse{}, status.Errorf(codes.AlreadyExists, "user already exists: %s", user.Name)
+			}
+		}
+
+		id := uuid.NewV4()
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO users
+				(id, account_id, create_time, update_time, delete_time, slug, display_name, is_root,
+				 email, email_verified, phone, locale, labels, annotations)
+			VALUES
+				(?1, ?2, ?3, ?4, NULL, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13)
+		`, id, accountID, importTime(user.CreateTime, now), now, slug, user.DisplayName, user.IsRoot,
+			user.Email, user.EmailVerified, user.Phone, user.Locale, jsonMap(user.Labels),
+			jsonMap(user.Annotations)); err != nil {
+			return ImportAccountResponse{}, sqliteAlreadyExists(err, "user already exists: users/%s", slug)
+		}
+
+		if err := sqliteInsertBundledIdentities(ctx, tx, id, bundled.Identities, now); err != nil {
+			return ImportAccountResponse{}, err
+		}
+
+		name := fmt.Sprintf("users/%s", slug)
+		if err := sqliteEnqueueWebhookEvent(ctx, tx, accountID, models.WebhookEventUserCreated, name); err != nil {
+			return ImportAccountResponse{}, err
+		}
+
+		taken[slug] = id
+		res.Created = append(res.Created, name)
+	}
+
+	var account dbAccount
+	if err := tx.GetContext(ctx, &account, fmt.Sprintf(`
+		SELECT %s FROM accounts WHERE id = ?1
+	`, accountColumns), accountID); err != nil {
+		return ImportAccountResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ImportAccountResponse{}, err
+	}
+
+	res.Account = account.model()
+	return res, nil
+}
+
+// sqliteOverwriteUser replaces an existing user's profile and identities with
+// bundled ones. Whether the user is root is left alone.
+func sqliteOverwriteUser(ctx context.Context, tx dbQueryer, id uuid.UUID, user models.User, identities []models.BundledIdentity, now time.Time) error {
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET
+			update_time = ?2, version = version + 1, display_name = ?3, email = ?4,
+			email_verified = ?5, phone = ?6, locale = ?7, labels = ?8, annotations = ?9
+		WHERE
+			id = ?1
+	`, id, now, user.DisplayName, user.Email, user.EmailVerified, user.Phone, user.Locale,
+		jsonMap(user.Labels), jsonMap(user.Annotations)); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE identities
+		SET
+			delete_time = ?2, update_time = ?2, version = version + 1
+		WHERE
+			user_id = ?1 AND delete_time IS NULL
+	`, id, now); err != nil {
+		return err
+	}
+
+	return sqliteInsertBundledIdentities(ctx, tx, id, identities, now)
+}
+
+func sqliteInsertBundledIdentities(ctx context.Context, tx dbQueryer, userID uuid.UUID, identities []models.BundledIdentity, now time.Time) error {
+	for _, identity := range identities {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO identities
+				(id, user_id, create_time, update_time, delete_time, auth_method, password_hash)
+			VALUES
+				(?1, ?2, ?3, ?4, NULL, 'password', ?5)
+		`, uuid.NewV4(), userID, importTime(identity.Identity.CreateTime, now), now,
+			identity.PasswordHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *SQLiteStore) DeleteAccount(ctx context.Context, req DeleteAccountRequest) error {
+	now := time.Now()
+	name := fmt.Sprintf("accounts/%s", req.AccountID)
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE accounts
+		SET
+			delete_time = ?2, update_time = ?2, version = version + 1
+		WHERE
+			id = ?1 AND delete_time IS NULL AND (?3 = '' OR CAST(version AS TEXT) = ?3)
+	`, req.AccountID, now, req.Etag)
+
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return etagOrNotFound(ctx, tx, name, req.Etag,
+			status.Errorf(codes.NotFound, "account not found: %s", name), `
+			SELECT 1 FROM accounts WHERE id = ?1 AND delete_time IS NULL
+		`, req.AccountID)
+	}
+
+	if err := sqliteEnqueueWebhookEvent(ctx, tx, req.AccountID, models.WebhookEventAccountDeleted, name); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UndeleteAccount restores an account that was deleted after DeletedAfter.
+func (s *SQLiteStore) UndeleteAccount(ctx context.Context, req UndeleteAccountRequest) (models.Account, error) {
+	name := fmt.Sprintf("accounts/%s", req.AccountID)
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	defer tx.Rollback()
+
+	var deleteTime *time.Time
+	if err := tx.GetContext(ctx, &deleteTime, `
+		SELECT delete_time FROM accounts WHERE id = ?1
+	`, req.AccountID); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Account{}, status.Errorf(codes.NotFound, "account not found: %s", name)
+		}
+
+		return models.Account{}, err
+	}
+
+	if deleteTime == nil {
+		return models.Account{}, status.Errorf(codes.FailedPrecondition, "account is not deleted: %s", name)
+	}
+
+	if !deleteTime.After(req.DeletedAfter) {
+		return models.Account{}, status.Errorf(codes.FailedPrecondition, "account can no longer be undeleted: %s", name)
+	}
+
+	var account dbAccount
+	if err := tx.GetContext(ctx, &account, fmt.Sprintf(`
+		UPDATE accounts
+		SET
+			delete_time = NULL, update_time = ?2, version = version + 1
+		WHERE
+			id = ?1
+		RETURNING
+			%s
+	`, accountColumns), req.AccountID, time.Now()); err != nil {
+		return models.Account{}, err
+	}
+
+	if err := sqliteEnqueueWebhookEvent(ctx, tx, req.AccountID, models.WebhookEventAccountUndeleted, name); err != nil {
+		return models.Account{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Account{}, err
+	}
+
+	return account.model(), nil
+}
+
+// UndeleteUser restores the most recently deleted user with the given name,
+// if it was deleted after DeletedAfter and no other user has taken the name
+// since.
+func (s *SQLiteStore) UndeleteUser(ctx context.Context, req UndeleteUserRequest) (models.User, error) {
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return models.User{}, err
+	}
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	defer tx.Rollback()
+
+	var deleted []dbUser
+	if err := tx.SelectContext(ctx, &deleted, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			users
+		WHERE
+			account_id = ?1 AND slug = ?2 AND erase_time IS NULL
+		ORDER BY
+			delete_time IS NOT NULL, delete_time DESC
+		LIMIT 1
+	`, userColumns), req.AccountID, slug); err != nil {
+		return models.User{}, err
+	}
+
+	if len(deleted) == 0 {
+		return models.User{}, status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+	}
+
+	user := deleted[0]
+	if user.DeleteTime == nil {
+		return models.User{}, status.Errorf(codes.AlreadyExists, "user already exists: %s", req.Name)
+	}
+
+	if !user.DeleteTime.After(req.DeletedAfter) {
+		return models.User{}, status.Errorf(codes.FailedPrecondition, "user can no longer be undeleted: %s", req.Name)
+	}
+
+	if err := tx.GetContext(ctx, &user, fmt.Sprintf(`
+		UPDATE users
+		SET
+			delete_time = NULL, update_time = ?2, version = version + 1
+		WHERE
+			id = ?1
+		RETURNING
+			%s
+	`, userColumns), user.ID, time.Now()); err != nil {
+		return models.User{}, sqliteAlreadyExists(err, "user already exists: %s", req.Name)
+	}
+
+	if err := sqliteEnqueueWebhookEvent(ctx, tx, req.AccountID, models.WebhookEventUserUndeleted, req.Name); err != nil {
+		return models.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.User{}, err
+	}
+
+	return user.model(), nil
+}
+
+const (
+	sqlitePurgedAccounts = `
+		SELECT id FROM accounts WHERE delete_time < ?1
+	`
+
+	sqlitePurgedUsers = `
+		SELECT id FROM users WHERE delete_time < ?1 OR account_id IN (` + sqlitePurgedAccounts + `)
+	`
+
+	sqlitePurgedIdentities = `
+		SELECT id FROM identities WHERE delete_time < ?1 OR user_id IN (` + sqlitePurgedUsers + `)
+	`
+)
+
+// PurgeDeleted permanently removes accounts, users and identities deleted
+// before DeletedBefore, along with their password hashes and history, tokens,
+// invitations, webhooks and policies. Audit events are kept.
+func (s *SQLiteStore) PurgeDeleted(ctx context.Context, req PurgeDeletedRequest) (PurgeDeletedResponse, error) {
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return PurgeDeletedResponse{}, err
+	}
+
+	defer tx.Rollback()
+
+	var res PurgeDeletedResponse
+	for _, stmt := range []struct {
+		query string
+		count *int64
+	}{
+		{query: `DELETE FROM password_history WHERE identity_id IN (` + sqlitePurgedIdentities + `)`},
+		{query: `DELETE FROM identities WHERE id IN (` + sqlitePurgedIdentities + `)`, count: &res.Identities},
+		{query: `DELETE FROM user_tokens WHERE user_id IN (` + sqlitePurgedUsers + `)`},
+		{query: `
+			DELETE FROM invitations
+			WHERE account_id IN (` + sqlitePurgedAccounts + `) OR user_id IN (` + sqlitePurgedUsers + `)
+		`},
+		{query: `DELETE FROM users WHERE id IN (` + sqlitePurgedUsers + `)`, count: &res.Users},
+		{query: `
+			DELETE FROM webhook_deliveries
+			WHERE webhook_id IN (SELECT id FROM webhooks WHERE account_id IN (` + sqlitePurgedAccounts + `))
+		`},
+		{query: `DELETE FROM webhooks WHERE account_id IN (` + sqlitePurgedAccounts + `)`},
+		{query: `DELETE FROM password_policies WHERE account_id IN (` + sqlitePurgedAccounts + `)`},
+		{query: `DELETE FROM accounts WHERE id IN (` + sqlitePurgedAccounts + `)`, count: &res.Accounts},
+	} {
+		result, err := tx.ExecContext(ctx, stmt.query, req.DeletedBefore)
+		if err != nil {
+			return PurgeDeletedResponse{}, err
+		}
+
+		if stmt.count != nil {
+			if *stmt.count, err = result.RowsAffected(); err != nil {
+				return PurgeDeletedResponse{}, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PurgeDeletedResponse{}, err
+	}
+
+	return res, nil
+}
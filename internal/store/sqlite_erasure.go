@@ -0,0 +1,198 @@
+package store
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/audit/chain"
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+// EraseUser scrubs a user's profile and destroys their identities' secrets,
+// renaming the user to a pseudonym derived from its ID. References to the
+// user in audit events and webhook deliveries are rewritten to the
+// pseudonym, so that they still refer to the same user. The original audit
+// values are kept only as digests, which keeps the audit chain verifiable.
+//
+// The user is the live one with the given name or, failing that, the most
+// recently deleted one that hasn't been erased. Root users can't be erased.
+func (s *SQLiteStore) EraseUser(ctx context.Context, req EraseUserRequest) (EraseUserResponse, error) {
+	slug, err := nameID(req.Name, "users")
+	if err != nil {
+		return EraseUserResponse{}, err
+	}
+	now := time.Now()
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return EraseUserResponse{}, err
+	}
+
+	defer tx.Rollback()
+
+	var users []dbUser
+	if err := tx.SelectContext(ctx, &users, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			users
+		WHERE
+			account_id = ?1 AND slug = ?2 AND erase_time IS NULL
+		ORDER BY
+			delete_time IS NOT NULL, delete_time DESC
+		LIMIT 1
+	`, userColumns), req.AccountID, slug); err != nil {
+		return EraseUserResponse{}, err
+	}
+
+	if len(users) == 0 {
+		return EraseUserResponse{}, status.Errorf(codes.NotFound, "user not found: %s", req.Name)
+	}
+
+	user := users[0]
+	if req.Etag != "" && req.Etag != etag(user.Version) {
+		return EraseUserResponse{}, errEtagMismatch(req.Name)
+	}
+
+	if user.IsRoot {
+		return EraseUserResponse{}, status.Errorf(codes.FailedPrecondition, "cannot erase root user: %s", req.Name)
+	}
+
+	pseudonym := fmt.Sprintf("users/erased-%s", hex.EncodeToString(user.ID.Bytes()))
+
+	if err := tx.GetContext(ctx, &user, fmt.Sprintf(`
+		UPDATE users
+		SET
+			slug = ?2, update_time = ?3, delete_time = COALESCE(delete_time, ?3), erase_time = ?3,
+			version = version + 1, display_name = '', email = '', email_verified = FALSE,
+			phone = '', locale = '', labels = '{}', annotations = '{}', principal_id = NULL
+		WHERE
+			id = ?1
+		RETURNING
+			%s
+	`, userColumns), user.ID, strings.TrimPrefix(pseudonym, "users/"), now); err != nil {
+		return EraseUserResponse{}, err
+	}
+
+	var res EraseUserResponse
+	for _, stmt := range []struct {
+		query string
+		count *int64
+	}{
+		{query: `
+			DELETE FROM password_history
+			WHERE identity_id IN (SELECT id FROM identities WHERE user_id = ?1)
+		`},
+		{query: `
+			UPDATE identities
+			SET
+				password_hash = NULL, update_time = ?2, delete_time = COALESCE(delete_time, ?2),
+				version = version + 1
+			WHERE
+				user_id = ?1
+		`, count: &res.Identities},
+		{query: `DELETE FROM user_tokens WHERE user_id = ?1`},
+		{query: `
+			UPDATE invitations
+			SET
+				slug = (SELECT slug FROM users WHERE id = ?1), email = '', display_name = '',
+				labels = '{}', update_time = ?2
+			WHERE
+				user_id = ?1
+		`},
+	} {
+		result, err := tx.ExecContext(ctx, stmt.query, user.ID, now)
+		if err != nil {
+			return EraseUserResponse{}, err
+		}
+
+		if stmt.count != nil {
+			if *stmt.count, err = result.RowsAffected(); err != nil {
+				return EraseUserResponse{}, err
+			}
+		}
+	}
+
+	res.AuditEvents, err = sqlitePseudonymizeAuditEvents(ctx, tx, req.AccountID, req.Name, pseudonym, user.CreateTime)
+	if err != nil {
+		return EraseUserResponse{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET
+			payload = json_set(payload, '$.resource',
+				?3 || substr(json_extract(payload, '$.resource'), length(?2) + 1))
+		WHERE
+			webhook_id IN (SELECT id FROM webhooks WHERE account_id = ?1) AND
+			(json_extract(payload, '$.resource') = ?2 OR
+				substr(json_extract(payload, '$.resource'), 1, length(?2) + 1) = ?2 || '/')
+	`, req.AccountID, req.Name, pseudonym); err != nil {
+		return EraseUserResponse{}, err
+	}
+
+	if err := sqliteEnqueueWebhookEvent(ctx, tx, req.AccountID, models.WebhookEventUserErased, pseudonym); err != nil {
+		return EraseUserResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return EraseUserResponse{}, err
+	}
+
+	res.User = user.model()
+	return res, nil
+}
+
+// sqlitePseudonymizeAuditEvents is pseudonymizeAuditEvents for SQLite.
+func sqlitePseudonymizeAuditEvents(ctx context.Context, tx dbQueryer, accountID, name, pseudonym string, since time.Time) (int64, error) {
+	var events []dbAuditEvent
+	if err := tx.SelectContext(ctx, &events, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			audit_events
+		WHERE
+			account_id = ?1 AND create_time >= ?2 AND
+			(actor = ?3 OR resource = ?3 OR substr(resource, 1, length(?3) + 1) = ?3 || '/')
+	`, auditEventColumns), accountID, since, name); err != nil {
+		return 0, err
+	}
+
+	replace := func(value string) (string, bool) {
+		if value == name || strings.HasPrefix(value, name+"/") {
+			return pseudonym + strings.TrimPrefix(value, name), true
+		}
+
+		return value, false
+	}
+
+	for _, event := range events {
+		actor, ok := replace(event.Actor)
+		if ok && event.ActorDigest == nil {
+			event.ActorDigest = chain.FieldDigest(event.Actor)
+		}
+
+		resource, ok := replace(event.Resource)
+		if ok && event.ResourceDigest == nil {
+			event.ResourceDigest = chain.FieldDigest(event.Resource)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE audit_events
+			SET
+				actor = ?2, resource = ?3, actor_digest = ?4, resource_digest = ?5
+			WHERE
+				id = ?1
+		`, event.ID, actor, resource, event.ActorDigest, event.ResourceDigest); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(events)), nil
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sqliteAlreadyExists is alreadyExists for SQLiteStore.
+func sqliteAlreadyExists(err error, format string, args ...interface{}) error {
+	if sqliteErr, ok := errors.Cause(err).(sqlite3.Error); ok && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return status.Errorf(codes.AlreadyExists, format, args...)
+	}
+
+	return err
+}
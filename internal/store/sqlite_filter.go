@@ -0,0 +1,19 @@
+package store
+
+import (
+	"github.com/json-multiplex/iam-service/internal/filter"
+)
+
+// sqliteIdentityFilterSchema is identityFilterSchema for SQLite, where
+// auth_method is already text.
+var sqliteIdentityFilterSchema = filter.Schema{
+	"create_time": {Column: "identities.create_time", Type: filter.Timestamp},
+	"update_time": {Column: "identities.update_time", Type: filter.Timestamp},
+	"auth_method": {Column: "identities.auth_method", Type: filter.String},
+}
+
+// sqliteFilterSQL is filterSQL for SQLiteStore. userFilterSchema works for
+// both databases.
+func sqliteFilterSQL(schema filter.Schema, expr filter.Expr, orders []filter.Order, conditions []string, args []interface{}, tiebreak string) ([]string, []interface{}, string, error) {
+	return compileFilter(filter.SQLite, schema, expr, orders, conditions, args, tiebreak)
+}
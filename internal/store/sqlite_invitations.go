@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *SQLiteStore) ListInvitations(ctx context.Context, req ListInvitationsRequest) (ListInvitationsResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListInvitationsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	var invitations []dbInvitation
+	if err := s.db().SelectContext(ctx, &invitations, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			invitations
+		WHERE
+			account_id = ?1 AND delete_time IS NULL
+		ORDER BY
+			create_time, id
+		LIMIT ?2 OFFSET ?3
+	`, invitationColumns), req.AccountID, limit+1, offset); err != nil {
+		return ListInvitationsResponse{}, err
+	}
+
+	var res ListInvitationsResponse
+	if len(invitations) > limit {
+		invitations = invitations[:limit]
+		res.NextPageToken = encodePageToken(offset + limit)
+	}
+
+	for _, invitation := range invitations {
+		res.Invitations = append(res.Invitations, invitation.model())
+	}
+
+	return res, nil
+}
+
+func (s *SQLiteStore) CreateInvitation(ctx context.Context, req CreateInvitationRequest) (models.Invitation, error) {
+	id := uuid.NewV4()
+	now := time.Now()
+
+	slug, err := nameID(req.Invitation.User.Name, "users")
+	if err != nil {
+		return models.Invitation{}, err
+	}
+
+	var invitation dbInvitation
+	if err := s.db().GetContext(ctx, &invitation, fmt.Sprintf(`
+		INSERT INTO invitations
+			(id, account_id, create_time, update_time, delete_time, expire_time, accept_time,
+			 email, slug, display_name, labels, inviter, code_hash, user_id)
+		VALUES
+			(?1, ?2, ?3, ?3, NULL, ?4, NULL, ?5, ?6, ?7, ?8, ?9, ?10, NULL)
+		RETURNING
+			%s
+	`, invitationColumns), id, req.AccountID, now, req.Invitation.ExpireTime,
+		req.Invitation.Email, slug, req.Invitation.User.DisplayName,
+		jsonMap(req.Invitation.User.Labels), req.Invitation.Inviter,
+		req.Invitation.CodeHash); err != nil {
+		return models.Invitation{}, err
+	}
+
+	return invitation.model(), nil
+}
+
+// DeleteInvitation revokes an invitation that hasn't been accepted yet.
+func (s *SQLiteStore) DeleteInvitation(ctx context.Context, req DeleteInvitationRequest) error {
+	res, err := s.db().ExecContext(ctx, `
+		UPDATE invitations
+		SET
+			delete_time = ?3, update_time = ?3
+		WHERE
+			account_id = ?1 AND id = ?2 AND delete_time IS NULL AND accept_time IS NULL
+	`, req.AccountID, invitationID(req.AccountID, req.Name), time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "invitation not found: %s", req.Name)
+	}
+
+	return nil
+}
+
+// GetPendingInvitation returns the invitation with a code, provided it can
+// still be accepted.
+func (s *SQLiteStore) GetPendingInvitation(ctx context.Context, req GetPendingInvitationRequest) (models.Invitation, error) {
+	var invitation dbInvitation
+	if err := s.db().GetContext(ctx, &invitation, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			invitations
+		WHERE
+			code_hash = ?1 AND accept_time IS NULL AND delete_time IS NULL AND expire_time > ?2 AND
+			account_id IN (SELECT id FROM accounts WHERE delete_time IS NULL)
+	`, invitationColumns), req.CodeHash, time.Now()); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Invitation{}, status.Error(codes.NotFound, "invitation not found")
+		}
+
+		return models.Invitation{}, err
+	}
+
+	return invitation.model(), nil
+}
+
+// AcceptInvitation consumes an invitation, creating its user with a password
+// identity. The user's email is marked as verified, since the code was sent
+// to it.
+func (s *SQLiteStore) AcceptInvitation(ctx context.Context, req AcceptInvitationRequest) (models.User, error) {
+	now := time.Now()
+
+	passwordHash, err := s.hasher().Hash(req.Password)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	defer tx.Rollback()
+
+	var invitation dbInvitation
+	if err := tx.GetContext(ctx, &invitation, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			invitations
+		WHERE
+			code_hash = ?1 AND accept_time IS NULL AND delete_time IS NULL AND expire_time > ?2 AND
+			account_id IN (SELECT id FROM accounts WHERE delete_time IS NULL)
+	`, invitationColumns), req.CodeHash, now); err != nil {
+		if err == sql.ErrNoRows {
+			return models.User{}, status.Error(codes.NotFound, "invitation not found")
+		}
+
+		return models.User{}, err
+	}
+
+	name := fmt.Sprintf("users/%s", invitation.Slug)
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `
+		SELECT EXISTS (
+			SELECT 1 FROM users WHERE account_id = ?1 AND slug = ?2 AND delete_time IS NULL
+		)
+	`, invitation.AccountID, invitation.Slug); err != nil {
+		return models.User{}, err
+	}
+
+	if exists {
+		return models.User{}, status.Errorf(codes.AlreadyExists, "user already exists: %s", name)
+	}
+
+	var user dbUser
+	if err := tx.GetContext(ctx, &user, fmt.Sprintf(`
+		INSERT INTO users
+			(id, account_id, create_time, update_time, delete_time, slug, display_name, is_root,
+			 email, email_verified, phone, locale, labels, annotations)
+		VALUES
+			(?1, ?2, ?3, ?3, NULL, ?4, ?5, FALSE, ?6, TRUE, '', '', ?7, '{}')
+		RETURNING
+			%s
+	`, userColumns), uuid.NewV4(), invitation.AccountID, now, invitation.Slug,
+		invitation.DisplayName, invitation.Email, invitation.Labels); err != nil {
+		return models.User{}, sqliteAlreadyExists(err, "user already exists: %s", name)
+	}
+
+	identityID := uuid.NewV4()
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO identities
+			(id, user_id, create_time, update_time, delete_time, auth_method, password_hash)
+		VALUES
+			(?1, ?2, ?3, ?3, NULL, 'password', ?4)
+	`, identityID, user.ID, now, passwordHash); err != nil {
+		return models.User{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE invitations
+		SET
+			accept_time = ?2, update_time = ?2, user_id = ?3
+		WHERE
+			id = ?1
+	`, invitation.ID, now, user.ID); err != nil {
+		return models.User{}, err
+	}
+
+	accountID := invitation.AccountID.String()
+	if err := sqliteEnqueueWebhookEvent(ctx, tx, accountID, models.WebhookEventUserCreated, name); err != nil {
+		return models.User{}, err
+	}
+
+	identity := fmt.Sprintf("%s/identities/%s", name, identityID)
+	if err := sqliteEnqueueWebhookEvent(ctx, tx, accountID, models.WebhookEventIdentityCreated, identity); err != nil {
+		return models.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.User{}, err
+	}
+
+	return user.model(), nil
+}
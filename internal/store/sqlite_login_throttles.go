@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *SQLiteStore) GetLoginThrottles(ctx context.Context, req GetLoginThrottlesRequest) ([]models.LoginThrottle, error) {
+	var throttles []dbLoginThrottle
+	if err := s.db().SelectContext(ctx, &throttles, `
+		SELECT
+			key, failures, last_failure_time, locked_until
+		FROM
+			login_throttles
+		WHERE
+			key IN (SELECT value FROM json_each(?1))
+	`, sqliteArray(req.Keys)); err != nil {
+		return nil, err
+	}
+
+	var out []models.LoginThrottle
+	for _, throttle := range throttles {
+		out = append(out, throttle.model())
+	}
+
+	return out, nil
+}
+
+// RecordLoginFailure counts a failed attempt against key. Failures older than
+// req.Window are forgotten, so that the count restarts at one.
+func (s *SQLiteStore) RecordLoginFailure(ctx context.Context, req RecordLoginFailureRequest) (models.LoginThrottle, error) {
+	now := time.Now()
+
+	var throttle dbLoginThrottle
+	if err := s.db().GetContext(ctx, &throttle, `
+		INSERT INTO login_throttles
+			(key, failures, last_failure_time, locked_until)
+		VALUES
+			(?1, 1, ?2, NULL)
+		ON CONFLICT (key) DO UPDATE
+		SET
+			failures = CASE
+				WHEN login_throttles.last_failure_time < ?3 THEN 1
+				ELSE login_throttles.failures + 1
+			END,
+			last_failure_time = ?2
+		RETURNING
+			key, failures, last_failure_time, locked_until
+	`, req.Key, now, now.Add(-req.Window)); err != nil {
+		return models.LoginThrottle{}, err
+	}
+
+	return throttle.model(), nil
+}
+
+func (s *SQLiteStore) LockLogin(ctx context.Context, req LockLoginRequest) error {
+	_, err := s.db().ExecContext(ctx, `
+		UPDATE login_throttles
+		SET
+			locked_until = ?2
+		WHERE
+			key = ?1
+	`, req.Key, req.Until)
+
+	return err
+}
+
+func (s *SQLiteStore) ResetLoginThrottles(ctx context.Context, req ResetLoginThrottlesRequest) error {
+	_, err := s.db().ExecContext(ctx, `
+		DELETE FROM login_throttles
+		WHERE
+			key IN (SELECT value FROM json_each(?1))
+	`, sqliteArray(req.Keys))
+
+	return err
+}
+
+// sqliteArray encodes values as a JSON array, for queries to expand with
+// json_each in place of Postgres's ANY.
+func sqliteArray(values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+
+	b, _ := json.Marshal(values)
+	return string(b)
+}
@@ -0,0 +1,250 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *SQLiteStore) GetPasswordPolicy(ctx context.Context, req GetPasswordPolicyRequest) (models.PasswordPolicy, error) {
+	var policy dbPasswordPolicy
+	if err := s.db().GetContext(ctx, &policy, `
+		SELECT
+			account_id, update_time, min_length, require_uppercase, require_lowercase,
+			require_digit, require_symbol, history_size, max_age_seconds
+		FROM
+			password_policies
+		WHERE
+			account_id = ?1
+	`, req.AccountID); err != nil {
+		if err == sql.ErrNoRows {
+			return models.PasswordPolicy{}, status.Error(codes.NotFound, "password policy not found")
+		}
+
+		return models.PasswordPolicy{}, err
+	}
+
+	return policy.model(), nil
+}
+
+func (s *SQLiteStore) UpdatePasswordPolicy(ctx context.Context, req UpdatePasswordPolicyRequest) (models.PasswordPolicy, error) {
+	return sqliteUpsertPasswordPolicy(ctx, s.db(), req.AccountID, req.PasswordPolicy)
+}
+
+// sqliteUpsertPasswordPolicy is upsertPasswordPolicy for SQLite.
+func sqliteUpsertPasswordPolicy(ctx context.Context, q sqlx.QueryerContext, accountID string, p models.PasswordPolicy) (models.PasswordPolicy, error) {
+	var policy dbPasswordPolicy
+	if err := sqlx.GetContext(ctx, q, &policy, `
+		INSERT INTO password_policies
+			(account_id, update_time, min_length, require_uppercase, require_lowercase,
+			 require_digit, require_symbol, history_size, max_age_seconds)
+		VALUES
+			(?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+		ON CONFLICT (account_id) DO UPDATE
+		SET
+			update_time = EXCLUDED.update_time,
+			min_length = EXCLUDED.min_length,
+			require_uppercase = EXCLUDED.require_uppercase,
+			require_lowercase = EXCLUDED.require_lowercase,
+			require_digit = EXCLUDED.require_digit,
+			require_symbol = EXCLUDED.require_symbol,
+			history_size = EXCLUDED.history_size,
+			max_age_seconds = EXCLUDED.max_age_seconds
+		RETURNING
+			account_id, update_time, min_length, require_uppercase, require_lowercase,
+			require_digit, require_symbol, history_size, max_age_seconds
+	`, accountID, time.Now(), p.MinLength, p.RequireUppercase, p.RequireLowercase,
+		p.RequireDigit, p.RequireSymbol, p.HistorySize, int64(p.MaxAge/time.Second)); err != nil {
+		return models.PasswordPolicy{}, err
+	}
+
+	return policy.model(), nil
+}
+
+// UpdateIdentity replaces the password of a password identity, keeping the
+// previous hash in the identity's password history.
+func (s *SQLiteStore) UpdateIdentity(ctx context.Context, req UpdateIdentityRequest) (models.Identity, error) {
+	now := time.Now()
+
+	segments := strings.Split(req.Identity.Name, "/")
+	if len(segments) != 4 {
+		return models.Identity{}, status.Errorf(codes.NotFound, "identity not found: %s", req.Identity.Name)
+	}
+
+	slug := segments[1]
+	id := nullUUID(segments[3])
+	if !id.Valid {
+		return models.Identity{}, status.Errorf(codes.NotFound, "identity not found: %s", req.Identity.Name)
+	}
+
+	passwordHash, err := s.hasher().Hash(req.Identity.Password)
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	defer tx.Rollback()
+
+	var identity dbIdentity
+	if err := tx.GetContext(ctx, &identity, `
+		SELECT
+			identities.id, identities.create_time, identities.update_time, identities.password_hash,
+			identities.version
+		FROM
+			identities, users
+		WHERE
+			identities.user_id = users.id AND identities.auth_method = 'password' AND
+			users.account_id = ?1 AND users.slug = ?2 AND identities.id = ?3 AND
+			users.delete_time IS NULL AND identities.delete_time IS NULL
+	`, req.AccountID, slug, id); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Identity{}, status.Errorf(codes.NotFound, "identity not found: %s", req.Identity.Name)
+		}
+
+		return models.Identity{}, err
+	}
+
+	if req.Identity.Etag != "" && req.Identity.Etag != etag(identity.Version) {
+		return models.Identity{}, errEtagMismatch(req.Identity.Name)
+	}
+
+	if err := sqliteReplacePassword(ctx, tx, identity, passwordHash, now); err != nil {
+		return models.Identity{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Identity{}, err
+	}
+
+	return models.Identity{
+		Name:       fmt.Sprintf("users/%s/identities/%s", slug, identity.ID),
+		CreateTime: identity.CreateTime,
+		UpdateTime: now,
+		Etag:       etag(identity.Version + 1),
+		AuthMethod: models.AuthMethodPassword,
+	}, nil
+}
+
+// sqliteReplacePassword is replacePassword for SQLite.
+func sqliteReplacePassword(ctx context.Context, tx dbQueryer, identity dbIdentity, passwordHash string, now time.Time) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO password_history
+			(id, identity_id, create_time, password_hash)
+		VALUES
+			(?1, ?2, ?3, ?4)
+	`, uuid.NewV4(), identity.ID, now, identity.PasswordHash); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE identities
+		SET
+			password_hash = ?2, update_time = ?3, version = version + 1
+		WHERE
+			id = ?1
+	`, identity.ID, passwordHash, now)
+
+	return err
+}
+
+// PasswordReused reports whether password matches the identity's current
+// password or any of the HistorySize-1 passwords before it.
+func (s *SQLiteStore) PasswordReused(ctx context.Context, req PasswordReusedRequest) (bool, error) {
+	if req.HistorySize <= 0 {
+		return false, nil
+	}
+
+	segments := strings.Split(req.Identity, "/")
+	if len(segments) != 4 {
+		return false, nil
+	}
+
+	id := nullUUID(segments[3])
+	if !id.Valid {
+		return false, nil
+	}
+
+	var identityID uuid.UUID
+	if err := s.db().GetContext(ctx, &identityID, `
+		SELECT
+			identities.id
+		FROM
+			identities, users
+		WHERE
+			identities.user_id = users.id AND users.account_id = ?1 AND users.slug = ?2 AND
+			identities.id = ?3
+	`, req.AccountID, segments[1], id); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	hashes, err := sqliteRecentPasswordHashes(ctx, s.db(), identityID, req.HistorySize)
+	if err != nil {
+		return false, err
+	}
+
+	for _, hash := range hashes {
+		if ok, err := s.hasher().Verify(hash, req.Password); err == nil && ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// sqliteRecentPasswordHashes is recentPasswordHashes for SQLite, whose
+// compound queries can't order or limit their parts.
+func sqliteRecentPasswordHashes(ctx context.Context, q sqlx.QueryerContext, identityID uuid.UUID, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	var hashes []struct {
+		PasswordHash string    `db:"password_hash"`
+		CreateTime   time.Time `db:"create_time"`
+	}
+
+	if err := sqlx.SelectContext(ctx, q, &hashes, `
+		SELECT
+			password_hash, update_time AS create_time
+		FROM
+			identities
+		WHERE
+			id = ?1
+		UNION ALL
+		SELECT
+			password_hash, create_time
+		FROM
+			password_history
+		WHERE
+			identity_id = ?1
+		ORDER BY
+			create_time DESC
+		LIMIT ?2
+	`, identityID, n); err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, hash := range hashes {
+		out = append(out, hash.PasswordHash)
+	}
+
+	return out, nil
+}
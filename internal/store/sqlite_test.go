@@ -0,0 +1,42 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/json-multiplex/iam-service/internal/migrate"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+	"github.com/json-multiplex/iam-service/internal/store"
+	"github.com/json-multiplex/iam-service/internal/store/storetest"
+	sqlitemigrations "github.com/json-multiplex/iam-service/migrations/sqlite"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	all, err := migrate.Load(sqlitemigrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storetest.Run(t, func(t *testing.T) store.Store {
+		// Use the same options as the server; see sqliteDSN in cmd/iam.
+		path := filepath.Join(t.TempDir(), "iam.db")
+		db, err := sqlx.Open("sqlite3", fmt.Sprintf("file:%s?_foreign_keys=1&_busy_timeout=5000&_txlock=immediate", path))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() { db.Close() })
+
+		migrator := &migrate.Migrator{DB: db.DB, Migrations: all, SQLite: true}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			t.Fatalf("migrating: %v", err)
+		}
+
+		return &store.SQLiteStore{DB: db, Hasher: passhash.Bcrypt{Cost: 4}}
+	})
+}
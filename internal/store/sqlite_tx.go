@@ -0,0 +1,176 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+)
+
+// sqliteQueryer is a dbQueryer that converts arguments to the forms SQLite
+// stores and compares correctly. Times are converted to UTC, since SQLite
+// compares them as text, and JSON maps are written as text rather than as
+// blobs.
+type sqliteQueryer struct {
+	dbQueryer
+}
+
+func sqliteArgs(args []interface{}) []interface{} {
+	out := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case time.Time:
+			out[i] = arg.UTC()
+		case *time.Time:
+			if arg != nil {
+				out[i] = arg.UTC()
+			}
+		case jsonMap:
+			b, _ := arg.Value()
+			out[i] = string(b.([]byte))
+		default:
+			out[i] = arg
+		}
+	}
+
+	return out
+}
+
+func (q sqliteQueryer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return q.dbQueryer.ExecContext(ctx, query, sqliteArgs(args)...)
+}
+
+func (q sqliteQueryer) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return q.dbQueryer.QueryContext(ctx, query, sqliteArgs(args)...)
+}
+
+func (q sqliteQueryer) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	return q.dbQueryer.QueryxContext(ctx, query, sqliteArgs(args)...)
+}
+
+func (q sqliteQueryer) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	return q.dbQueryer.QueryRowxContext(ctx, query, sqliteArgs(args)...)
+}
+
+func (q sqliteQueryer) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return q.dbQueryer.GetContext(ctx, dest, query, sqliteArgs(args)...)
+}
+
+func (q sqliteQueryer) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return q.dbQueryer.SelectContext(ctx, dest, query, sqliteArgs(args)...)
+}
+
+type sqliteTx struct {
+	sqliteQueryer
+	tx dbTx
+}
+
+func (t sqliteTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t sqliteTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func (s *SQLiteStore) db() dbQueryer {
+	if s.tx != nil {
+		return sqliteQueryer{s.tx}
+	}
+
+	return sqliteQueryer{s.DB}
+}
+
+func (s *SQLiteStore) begin(ctx context.Context) (dbTx, error) {
+	if s.tx == nil {
+		tx, err := s.DB.BeginTxx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return sqliteTx{sqliteQueryer{tx}, tx}, nil
+	}
+
+	p := &savepoint{
+		Tx:   s.tx,
+		name: fmt.Sprintf("store_%d", atomic.AddUint64(&savepoints, 1)),
+	}
+
+	if _, err := s.tx.ExecContext(ctx, "SAVEPOINT "+p.name); err != nil {
+		return nil, err
+	}
+
+	return sqliteTx{sqliteQueryer{p}, p}, nil
+}
+
+// WithTx calls fn with a Store whose operations all run in a single
+// transaction, committed if fn returns nil and rolled back otherwise. SQLite
+// runs one write transaction at a time, so transactions don't conflict, but
+// one that finds the database locked for longer than the busy timeout is
+// retried from the start, so fn may be called more than once. Calls to WithTx
+// on the Store passed to fn run in the same transaction.
+func (s *SQLiteStore) WithTx(ctx context.Context, fn func(Store) error) error {
+	return s.withTx(ctx, func(tx *SQLiteStore) error {
+		return fn(tx)
+	})
+}
+
+func (s *SQLiteStore) withTx(ctx context.Context, fn func(*SQLiteStore) error) error {
+	if s.tx != nil {
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return err
+		}
+
+		defer tx.Rollback()
+
+		if err := fn(s); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := s.runTx(ctx, fn)
+		if !isSQLiteBusy(err) || attempt == maxTxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * 10 * time.Millisecond):
+		}
+	}
+}
+
+func (s *SQLiteStore) runTx(ctx context.Context, fn func(*SQLiteStore) error) error {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(&SQLiteStore{DB: s.DB, Hasher: s.Hasher, tx: tx}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isSQLiteBusy reports whether err means that the database was locked by
+// another connection, and the transaction can be retried.
+func isSQLiteBusy(err error) bool {
+	if sqliteErr, ok := errors.Cause(err).(sqlite3.Error); ok {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	return false
+}
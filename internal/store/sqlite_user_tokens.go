@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *SQLiteStore) CreateUserToken(ctx context.Context, req CreateUserTokenRequest) error {
+	slug, err := nameID(req.UserToken.User, "users")
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db().ExecContext(ctx, `
+		INSERT INTO user_tokens
+			(id, user_id, purpose, token_hash, email, create_time, expire_time, consume_time)
+		SELECT
+			?1, id, ?4, ?5, ?6, ?7, ?8, NULL
+		FROM
+			users
+		WHERE
+			account_id = ?2 AND slug = ?3 AND delete_time IS NULL
+	`, uuid.NewV4(), req.AccountID, slug, userTokenPurpose(req.UserToken.Purpose),
+		req.UserToken.TokenHash, req.UserToken.Email, time.Now(), req.UserToken.ExpireTime)
+
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "user not found: %s", req.UserToken.User)
+	}
+
+	return nil
+}
+
+func (s *SQLiteStore) GetUserToken(ctx context.Context, req GetUserTokenRequest) (models.UserToken, error) {
+	var token dbUserToken
+	if err := s.db().GetContext(ctx, &token, `
+		SELECT
+			user_tokens.id, user_tokens.user_id, users.account_id, users.slug, user_tokens.purpose,
+			user_tokens.token_hash, user_tokens.email, user_tokens.create_time,
+			user_tokens.expire_time
+		FROM
+			user_tokens, users
+		WHERE
+			user_tokens.user_id = users.id AND users.delete_time IS NULL AND
+			user_tokens.token_hash = ?1 AND user_tokens.purpose = ?2 AND
+			user_tokens.consume_time IS NULL AND user_tokens.expire_time > ?3
+	`, req.TokenHash, userTokenPurpose(req.Purpose), time.Now()); err != nil {
+		if err == sql.ErrNoRows {
+			return models.UserToken{}, status.Error(codes.NotFound, "token not found")
+		}
+
+		return models.UserToken{}, err
+	}
+
+	return token.model(), nil
+}
+
+// ResetPassword consumes a password reset token and replaces the password of
+// the token's user.
+func (s *SQLiteStore) ResetPassword(ctx context.Context, req ResetPasswordRequest) error {
+	now := time.Now()
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	userID, _, err := sqliteConsumeUserToken(ctx, tx, req.TokenHash, models.UserTokenPurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	var identity dbIdentity
+	if err := tx.GetContext(ctx, &identity, `
+		SELECT
+			id, update_time, password_hash
+		FROM
+			identities
+		WHERE
+			user_id = ?1 AND auth_method = 'password' AND delete_time IS NULL
+		ORDER BY
+			create_time
+		LIMIT 1
+	`, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return status.Error(codes.FailedPrecondition, "user has no password identity")
+		}
+
+		return err
+	}
+
+	hashes, err := sqliteRecentPasswordHashes(ctx, tx, identity.ID, req.HistorySize)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		if ok, err := s.hasher().Verify(hash, req.Password); err == nil && ok {
+			return ErrPasswordReused
+		}
+	}
+
+	passwordHash, err := s.hasher().Hash(req.Password)
+	if err != nil {
+		return err
+	}
+
+	if err := sqliteReplacePassword(ctx, tx, identity, passwordHash, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// VerifyEmail consumes an email verification token and marks the token's
+// user's email as verified, provided it hasn't changed since the token was
+// issued.
+func (s *SQLiteStore) VerifyEmail(ctx context.Context, req VerifyEmailRequest) error {
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	userID, email, err := sqliteConsumeUserToken(ctx, tx, req.TokenHash, models.UserTokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET
+			email_verified = TRUE, update_time = ?3, version = version + 1
+		WHERE
+			id = ?1 AND email = ?2 AND delete_time IS NULL
+	`, userID, email, time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Error(codes.FailedPrecondition, "email address has changed since the token was issued")
+	}
+
+	return tx.Commit()
+}
+
+// sqliteConsumeUserToken is consumeUserToken for SQLite.
+func sqliteConsumeUserToken(ctx context.Context, tx dbQueryer, tokenHash []byte, purpose models.UserTokenPurpose) (uuid.UUID, string, error) {
+	now := time.Now()
+
+	var token dbUserToken
+	if err := tx.GetContext(ctx, &token, `
+		UPDATE user_tokens
+		SET
+			consume_time = ?3
+		WHERE
+			token_hash = ?1 AND purpose = ?2 AND consume_time IS NULL AND expire_time > ?3
+		RETURNING
+			user_id, email
+	`, tokenHash, userTokenPurpose(purpose), now); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.UUID{}, "", status.Error(codes.NotFound, "token not found")
+		}
+
+		return uuid.UUID{}, "", err
+	}
+
+	return token.UserID, token.Email, nil
+}
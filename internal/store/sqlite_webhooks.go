@@ -0,0 +1,323 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+)
+
+func (s *SQLiteStore) ListWebhooks(ctx context.Context, req ListWebhooksRequest) (ListWebhooksResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListWebhooksResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	var webhooks []dbWebhook
+	if err := s.db().SelectContext(ctx, &webhooks, `
+		SELECT
+			id, create_time, update_time, delete_time, url, event_types, secret
+		FROM
+			webhooks
+		WHERE
+			account_id = ?1 AND delete_time IS NULL
+		ORDER BY
+			create_time, id
+		LIMIT ?2 OFFSET ?3
+	`, req.AccountID, limit+1, offset); err != nil {
+		return ListWebhooksResponse{}, err
+	}
+
+	var res ListWebhooksResponse
+	if len(webhooks) > limit {
+		webhooks = webhooks[:limit]
+		res.NextPageToken = encodePageToken(offset + limit)
+	}
+
+	for _, webhook := range webhooks {
+		res.Webhooks = append(res.Webhooks, webhook.model())
+	}
+
+	return res, nil
+}
+
+func (s *SQLiteStore) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (models.Webhook, error) {
+	id := uuid.NewV4()
+	now := time.Now()
+
+	if _, err := s.db().ExecContext(ctx, `
+		INSERT INTO webhooks
+			(id, account_id, create_time, update_time, delete_time, url, event_types, secret)
+		VALUES
+			(?1, ?2, ?3, ?3, NULL, ?4, ?5, ?6)
+	`, id, req.AccountID, now, req.Webhook.URL, pq.StringArray(req.Webhook.EventTypes),
+		req.Webhook.Secret); err != nil {
+		return models.Webhook{}, err
+	}
+
+	return models.Webhook{
+		Name:       fmt.Sprintf("webhooks/%s", id),
+		CreateTime: now,
+		UpdateTime: now,
+		URL:        req.Webhook.URL,
+		EventTypes: req.Webhook.EventTypes,
+		Secret:     req.Webhook.Secret,
+	}, nil
+}
+
+func (s *SQLiteStore) DeleteWebhook(ctx context.Context, req DeleteWebhookRequest) error {
+	now := time.Now()
+
+	id, err := nameID(req.Name, "webhooks")
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE webhooks
+		SET
+			delete_time = ?3, update_time = ?3
+		WHERE
+			account_id = ?1 AND id = ?2 AND delete_time IS NULL
+	`, req.AccountID, id, now)
+
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "webhook not found: %s", req.Name)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET
+			state = 'dead', last_error = 'webhook deleted', update_time = ?2
+		WHERE
+			webhook_id = ?1 AND state = 'pending'
+	`, id, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteStore) EnqueueWebhookEvent(ctx context.Context, req EnqueueWebhookEventRequest) error {
+	accountID := nullUUID(req.AccountID)
+	if !accountID.Valid {
+		return nil
+	}
+
+	return sqliteEnqueueWebhookEvent(ctx, s.db(), req.AccountID, req.EventType, req.Resource)
+}
+
+// sqliteEnqueueWebhookEvent is enqueueWebhookEvent for SQLite. Event types
+// are stored in Postgres's array format, so subscriptions are checked here
+// rather than in the query.
+func sqliteEnqueueWebhookEvent(ctx context.Context, tx sqlx.ExtContext, accountID, eventType, resource string) error {
+	now := time.Now()
+
+	var webhooks []dbWebhook
+	if err := sqlx.SelectContext(ctx, tx, &webhooks, `
+		SELECT
+			id, event_types
+		FROM
+			webhooks
+		WHERE
+			account_id = ?1 AND delete_time IS NULL
+	`, accountID); err != nil {
+		return err
+	}
+
+	var webhookIDs []uuid.UUID
+	for _, webhook := range webhooks {
+		for _, t := range webhook.EventTypes {
+			if t == eventType {
+				webhookIDs = append(webhookIDs, webhook.ID)
+				break
+			}
+		}
+	}
+
+	for _, webhookID := range webhookIDs {
+		id := uuid.NewV4()
+
+		payload, err := json.Marshal(webhookPayload{
+			ID:         fmt.Sprintf("webhooks/%s/deliveries/%s", webhookID, id),
+			Type:       eventType,
+			Account:    fmt.Sprintf("accounts/%s", accountID),
+			Resource:   resource,
+			CreateTime: now,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries
+				(id, webhook_id, create_time, update_time, event_type, payload, state, attempts,
+				 next_attempt_time, last_error)
+			VALUES
+				(?1, ?2, ?3, ?3, ?4, ?5, 'pending', 0, ?3, '')
+		`, id, webhookID, now, eventType, string(payload)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SQLiteStore) ClaimWebhookDeliveries(ctx context.Context, req ClaimWebhookDeliveriesRequest) ([]models.WebhookDelivery, error) {
+	now := time.Now()
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	var deliveries []dbWebhookDelivery
+	if err := tx.SelectContext(ctx, &deliveries, `
+		SELECT
+			webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.create_time,
+			webhook_deliveries.event_type, webhook_deliveries.payload, webhook_deliveries.attempts,
+			webhook_deliveries.last_error, webhooks.url, webhooks.secret
+		FROM
+			webhook_deliveries, webhooks
+		WHERE
+			webhook_deliveries.webhook_id = webhooks.id AND
+			webhook_deliveries.state = 'pending' AND webhook_deliveries.next_attempt_time <= ?2
+		ORDER BY
+			webhook_deliveries.next_attempt_time
+		LIMIT ?1
+	`, req.Limit, now); err != nil {
+		return nil, err
+	}
+
+	// Push the next attempt out by the lease duration while delivering, so that
+	// other replicas skip these rows until the lease expires.
+	for _, delivery := range deliveries {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE webhook_deliveries
+			SET
+				next_attempt_time = ?2, update_time = ?3
+			WHERE
+				id = ?1
+		`, delivery.ID, now.Add(req.LeaseDuration), now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	var out []models.WebhookDelivery
+	for _, delivery := range deliveries {
+		out = append(out, delivery.model())
+	}
+
+	return out, nil
+}
+
+func (s *SQLiteStore) CompleteWebhookDelivery(ctx context.Context, req CompleteWebhookDeliveryRequest) error {
+	segments := strings.Split(req.Name, "/")
+	id := segments[3]
+
+	_, err := s.db().ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET
+			state = 'delivered', attempts = attempts + 1, last_error = '', update_time = ?2
+		WHERE
+			id = ?1
+	`, id, time.Now())
+
+	return err
+}
+
+func (s *SQLiteStore) FailWebhookDelivery(ctx context.Context, req FailWebhookDeliveryRequest) error {
+	segments := strings.Split(req.Name, "/")
+	id := segments[3]
+
+	state := "pending"
+	if req.Dead {
+		state = "dead"
+	}
+
+	_, err := s.db().ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET
+			state = ?2, attempts = attempts + 1, last_error = ?3, next_attempt_time = ?4,
+			update_time = ?5
+		WHERE
+			id = ?1
+	`, id, state, req.Error, req.NextAttemptTime, time.Now())
+
+	return err
+}
+
+func (s *SQLiteStore) ListDeadLetters(ctx context.Context, req ListDeadLettersRequest) (ListDeadLettersResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return ListDeadLettersResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := pageSize(req.PageSize)
+
+	webhookID, err := nameID(req.Parent, "webhooks")
+	if err != nil {
+		return ListDeadLettersResponse{}, err
+	}
+
+	var deliveries []dbWebhookDelivery
+	if err := s.db().SelectContext(ctx, &deliveries, `
+		SELECT
+			webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.create_time,
+			webhook_deliveries.event_type, webhook_deliveries.payload, webhook_deliveries.attempts,
+			webhook_deliveries.last_error, webhooks.url, webhooks.secret
+		FROM
+			webhook_deliveries, webhooks
+		WHERE
+			webhook_deliveries.webhook_id = webhooks.id AND
+			webhooks.account_id = ?1 AND webhooks.id = ?2 AND webhook_deliveries.state = 'dead'
+		ORDER BY
+			webhook_deliveries.create_time, webhook_deliveries.id
+		LIMIT ?3 OFFSET ?4
+	`, req.AccountID, webhookID, limit+1, offset); err != nil {
+		return ListDeadLettersResponse{}, err
+	}
+
+	var res ListDeadLettersResponse
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+		res.NextPageToken = encodePageToken(offset + limit)
+	}
+
+	for _, delivery := range deliveries {
+		res.DeadLetters = append(res.DeadLetters, delivery.model())
+	}
+
+	return res, nil
+}
@@ -2,7 +2,9 @@ package store
 
 import (
 	"context"
+	"time"
 
+	"github.com/json-multiplex/iam-service/internal/filter"
 	"github.com/json-multiplex/iam-service/internal/models"
 )
 
@@ -12,15 +14,88 @@ type CheckPasswordRequest struct {
 	Password string
 }
 
+type CheckPasswordResponse struct {
+	Valid              bool
+	PasswordUpdateTime time.Time
+}
+
 type CreateAccountRequest struct {
 	Account      models.Account
 	Root         models.User
 	RootPassword string
 }
 
+type GetAccountRequest struct {
+	AccountID   string
+	ShowDeleted bool
+}
+
+type UpdateAccountRequest struct {
+	AccountID  string
+	Account    models.Account
+	UpdateMask []string
+}
+
+type DeleteAccountRequest struct {
+	AccountID string
+	Etag      string
+}
+
+type ExportAccountRequest struct {
+	AccountID string
+}
+
+type ImportAccountRequest struct {
+	// AccountID is the account to import into. If CreateAccount is set, a new
+	// account is created with this ID, or a generated one if it is empty.
+	AccountID     string
+	CreateAccount bool
+	Bundle        models.AccountBundle
+	Conflict      models.ImportConflict
+}
+
+type ImportAccountResponse struct {
+	Account     models.Account
+	Created     []string
+	Overwritten []string
+	Skipped     []string
+
+	// Renamed maps the bundled names of renamed users to their new names.
+	Renamed map[string]string
+}
+
+type UndeleteAccountRequest struct {
+	AccountID    string
+	DeletedAfter time.Time
+}
+
 type GetUserRequest struct {
 	AccountID string
 	Name      string
+
+	// ShowDeleted also returns the user if it was deleted. If several deleted
+	// users had the name, the most recently deleted one is returned.
+	ShowDeleted bool
+}
+
+type ListUsersRequest struct {
+	AccountID   string
+	ShowDeleted bool
+	Filter      filter.Expr
+	OrderBy     []filter.Order
+	PageSize    int32
+	PageToken   string
+}
+
+type ListUsersResponse struct {
+	Users         []models.User
+	NextPageToken string
+}
+
+type UpdateUserRequest struct {
+	AccountID  string
+	User       models.User
+	UpdateMask []string
 }
 
 type CreateUserRequest struct {
@@ -28,16 +103,389 @@ type CreateUserRequest struct {
 	User      models.User
 }
 
+type GetPasswordPolicyRequest struct {
+	AccountID string
+}
+
+type UpdatePasswordPolicyRequest struct {
+	AccountID      string
+	PasswordPolicy models.PasswordPolicy
+}
+
+type DeleteUserRequest struct {
+	AccountID string
+	Name      string
+	Etag      string
+}
+
+type UndeleteUserRequest struct {
+	AccountID    string
+	Name         string
+	DeletedAfter time.Time
+}
+
+type EraseUserRequest struct {
+	AccountID string
+	Name      string
+	Etag      string
+}
+
+type EraseUserResponse struct {
+	User        models.User
+	Identities  int64
+	AuditEvents int64
+}
+
+type CreatePrincipalRequest struct {
+	Principal models.Principal
+	Password  string
+}
+
+type GetPrincipalRequest struct {
+	Name string
+}
+
+type CheckPrincipalPasswordRequest struct {
+	Principal string
+	Password  string
+}
+
+type ListAccountMembershipsRequest struct {
+	Principal string
+}
+
+type LinkPrincipalRequest struct {
+	AccountID string
+	Name      string
+	Principal string
+}
+
+type UnlinkPrincipalRequest struct {
+	AccountID string
+	Name      string
+}
+
+type CreateOrganizationRequest struct {
+	Organization models.Organization
+
+	// Admin is the principal that becomes the organization's first admin.
+	Admin string
+}
+
+type GetOrganizationRequest struct {
+	Name string
+}
+
+type UpdateOrganizationRequest struct {
+	Organization models.Organization
+	UpdateMask   []string
+}
+
+type AddOrganizationAdminRequest struct {
+	Name      string
+	Principal string
+}
+
+type RemoveOrganizationAdminRequest struct {
+	Name      string
+	Principal string
+}
+
+type IsOrganizationAdminRequest struct {
+	Organization string
+	Principal    string
+}
+
+type GetOrganizationPasswordPolicyRequest struct {
+	Organization string
+}
+
+type UpdateOrganizationPasswordPolicyRequest struct {
+	Organization   string
+	PasswordPolicy models.PasswordPolicy
+}
+
+type MoveAccountRequest struct {
+	AccountID string
+
+	// Organization is the organization to move the account to, or empty to
+	// remove it from its organization.
+	Organization string
+}
+
+type PurgeDeletedRequest struct {
+	DeletedBefore time.Time
+}
+
+type PurgeDeletedResponse struct {
+	Accounts   int64
+	Users      int64
+	Identities int64
+}
+
+type ListIdentitiesRequest struct {
+	AccountID   string
+	Parent      string
+	ShowDeleted bool
+	Filter      filter.Expr
+	OrderBy     []filter.Order
+	PageSize    int32
+	PageToken   string
+}
+
+type ListIdentitiesResponse struct {
+	Identities    []models.Identity
+	NextPageToken string
+}
+
 type CreateIdentityRequest struct {
 	AccountID string
 	Identity  models.Identity
 	Parent    string
 }
 
+type CreateAuditEventRequest struct {
+	AuditEvent models.AuditEvent
+}
+
+type AuditEventFilter struct {
+	Actor    string
+	Resource string
+	Method   string
+	SourceIP string
+	Outcome  models.AuditOutcome
+}
+
+type UpdateIdentityRequest struct {
+	AccountID string
+	Identity  models.Identity
+}
+
+type PasswordReusedRequest struct {
+	AccountID   string
+	Identity    string
+	Password    string
+	HistorySize int
+}
+
+type ListAuditEventsRequest struct {
+	AccountID string
+	StartTime time.Time
+	EndTime   time.Time
+	Filter    AuditEventFilter
+	PageSize  int32
+	PageToken string
+}
+
+type ListAuditEventsResponse struct {
+	AuditEvents   []models.AuditEvent
+	NextPageToken string
+}
+
+type ListAuditChainRequest struct {
+	AccountID     string
+	AfterSequence int64
+	PageSize      int32
+}
+
+type CreateAuditCheckpointRequest struct {
+	AuditCheckpoint models.AuditCheckpoint
+}
+
+type ListAuditCheckpointsRequest struct {
+	AccountID string
+}
+
+type ListWebhooksRequest struct {
+	AccountID string
+	PageSize  int32
+	PageToken string
+}
+
+type ListWebhooksResponse struct {
+	Webhooks      []models.Webhook
+	NextPageToken string
+}
+
+type CreateWebhookRequest struct {
+	AccountID string
+	Webhook   models.Webhook
+}
+
+type DeleteWebhookRequest struct {
+	AccountID string
+	Name      string
+}
+
+type EnqueueWebhookEventRequest struct {
+	AccountID string
+	EventType string
+	Resource  string
+}
+
+type ClaimWebhookDeliveriesRequest struct {
+	Limit         int
+	LeaseDuration time.Duration
+}
+
+type CompleteWebhookDeliveryRequest struct {
+	Name string
+}
+
+type FailWebhookDeliveryRequest struct {
+	Name            string
+	Error           string
+	NextAttemptTime time.Time
+	Dead            bool
+}
+
+type ListDeadLettersRequest struct {
+	AccountID string
+	Parent    string
+	PageSize  int32
+	PageToken string
+}
+
+type ListDeadLettersResponse struct {
+	DeadLetters   []models.WebhookDelivery
+	NextPageToken string
+}
+
+type GetLoginThrottlesRequest struct {
+	Keys []string
+}
+
+type RecordLoginFailureRequest struct {
+	Key    string
+	Window time.Duration
+}
+
+type LockLoginRequest struct {
+	Key   string
+	Until time.Time
+}
+
+type ResetLoginThrottlesRequest struct {
+	Keys []string
+}
+
+type CreateUserTokenRequest struct {
+	AccountID string
+	UserToken models.UserToken
+}
+
+type GetUserTokenRequest struct {
+	Purpose   models.UserTokenPurpose
+	TokenHash []byte
+}
+
+type ResetPasswordRequest struct {
+	TokenHash   []byte
+	Password    string
+	HistorySize int
+}
+
+type VerifyEmailRequest struct {
+	TokenHash []byte
+}
+
+type ListInvitationsRequest struct {
+	AccountID string
+	PageSize  int32
+	PageToken string
+}
+
+type ListInvitationsResponse struct {
+	Invitations   []models.Invitation
+	NextPageToken string
+}
+
+type CreateInvitationRequest struct {
+	AccountID  string
+	Invitation models.Invitation
+}
+
+type DeleteInvitationRequest struct {
+	AccountID string
+	Name      string
+}
+
+type GetPendingInvitationRequest struct {
+	CodeHash []byte
+}
+
+type AcceptInvitationRequest struct {
+	CodeHash []byte
+	Password string
+}
+
 type Store interface {
-	CheckPassword(context.Context, CheckPasswordRequest) (bool, error)
+	// WithTx calls fn with a Store whose operations run in one transaction,
+	// retrying it if it conflicts with concurrent transactions.
+	WithTx(ctx context.Context, fn func(Store) error) error
+
+	CheckPassword(context.Context, CheckPasswordRequest) (CheckPasswordResponse, error)
+	GetAccount(context.Context, GetAccountRequest) (models.Account, error)
 	CreateAccount(context.Context, CreateAccountRequest) (models.Account, error)
+	UpdateAccount(context.Context, UpdateAccountRequest) (models.Account, error)
+	DeleteAccount(context.Context, DeleteAccountRequest) error
+	UndeleteAccount(context.Context, UndeleteAccountRequest) (models.Account, error)
+	ExportAccount(context.Context, ExportAccountRequest) (models.AccountBundle, error)
+	ImportAccount(context.Context, ImportAccountRequest) (ImportAccountResponse, error)
+	GetPasswordPolicy(context.Context, GetPasswordPolicyRequest) (models.PasswordPolicy, error)
+	UpdatePasswordPolicy(context.Context, UpdatePasswordPolicyRequest) (models.PasswordPolicy, error)
 	GetUser(context.Context, GetUserRequest) (models.User, error)
+	ListUsers(context.Context, ListUsersRequest) (ListUsersResponse, error)
 	CreateUser(context.Context, CreateUserRequest) (models.User, error)
+	UpdateUser(context.Context, UpdateUserRequest) (models.User, error)
+	DeleteUser(context.Context, DeleteUserRequest) error
+	UndeleteUser(context.Context, UndeleteUserRequest) (models.User, error)
+	EraseUser(context.Context, EraseUserRequest) (EraseUserResponse, error)
+	PurgeDeleted(context.Context, PurgeDeletedRequest) (PurgeDeletedResponse, error)
+	CreatePrincipal(context.Context, CreatePrincipalRequest) (models.Principal, error)
+	GetPrincipal(context.Context, GetPrincipalRequest) (models.Principal, error)
+	CheckPrincipalPassword(context.Context, CheckPrincipalPasswordRequest) (CheckPasswordResponse, error)
+	ListAccountMemberships(context.Context, ListAccountMembershipsRequest) ([]models.AccountMembership, error)
+	LinkPrincipal(context.Context, LinkPrincipalRequest) (models.User, error)
+	UnlinkPrincipal(context.Context, UnlinkPrincipalRequest) (models.User, error)
+	CreateOrganization(context.Context, CreateOrganizationRequest) (models.Organization, error)
+	GetOrganization(context.Context, GetOrganizationRequest) (models.Organization, error)
+	UpdateOrganization(context.Context, UpdateOrganizationRequest) (models.Organization, error)
+	AddOrganizationAdmin(context.Context, AddOrganizationAdminRequest) (models.Organization, error)
+	RemoveOrganizationAdmin(context.Context, RemoveOrganizationAdminRequest) (models.Organization, error)
+	IsOrganizationAdmin(context.Context, IsOrganizationAdminRequest) (bool, error)
+	GetOrganizationPasswordPolicy(context.Context, GetOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error)
+	UpdateOrganizationPasswordPolicy(context.Context, UpdateOrganizationPasswordPolicyRequest) (models.PasswordPolicy, error)
+	MoveAccount(context.Context, MoveAccountRequest) (models.Account, error)
+	ListIdentities(context.Context, ListIdentitiesRequest) (ListIdentitiesResponse, error)
 	CreateIdentity(context.Context, CreateIdentityRequest) (models.Identity, error)
+	UpdateIdentity(context.Context, UpdateIdentityRequest) (models.Identity, error)
+	PasswordReused(context.Context, PasswordReusedRequest) (bool, error)
+	CreateAuditEvent(context.Context, CreateAuditEventRequest) (models.AuditEvent, error)
+	ListAuditEvents(context.Context, ListAuditEventsRequest) (ListAuditEventsResponse, error)
+	ListAuditChainHeads(context.Context) ([]models.AuditEvent, error)
+	ListAuditChain(context.Context, ListAuditChainRequest) ([]models.AuditEvent, error)
+	CreateAuditCheckpoint(context.Context, CreateAuditCheckpointRequest) (models.AuditCheckpoint, error)
+	ListAuditCheckpoints(context.Context, ListAuditCheckpointsRequest) ([]models.AuditCheckpoint, error)
+	ListWebhooks(context.Context, ListWebhooksRequest) (ListWebhooksResponse, error)
+	CreateWebhook(context.Context, CreateWebhookRequest) (models.Webhook, error)
+	DeleteWebhook(context.Context, DeleteWebhookRequest) error
+	EnqueueWebhookEvent(context.Context, EnqueueWebhookEventRequest) error
+	ClaimWebhookDeliveries(context.Context, ClaimWebhookDeliveriesRequest) ([]models.WebhookDelivery, error)
+	CompleteWebhookDelivery(context.Context, CompleteWebhookDeliveryRequest) error
+	FailWebhookDelivery(context.Context, FailWebhookDeliveryRequest) error
+	ListDeadLetters(context.Context, ListDeadLettersRequest) (ListDeadLettersResponse, error)
+	GetLoginThrottles(context.Context, GetLoginThrottlesRequest) ([]models.LoginThrottle, error)
+	RecordLoginFailure(context.Context, RecordLoginFailureRequest) (models.LoginThrottle, error)
+	LockLogin(context.Context, LockLoginRequest) error
+	ResetLoginThrottles(context.Context, ResetLoginThrottlesRequest) error
+	CreateUserToken(context.Context, CreateUserTokenRequest) error
+	GetUserToken(context.Context, GetUserTokenRequest) (models.UserToken, error)
+	ResetPassword(context.Context, ResetPasswordRequest) error
+	VerifyEmail(context.Context, VerifyEmailRequest) error
+	ListInvitations(context.Context, ListInvitationsRequest) (ListInvitationsResponse, error)
+	CreateInvitation(context.Context, CreateInvitationRequest) (models.Invitation, error)
+	DeleteInvitation(context.Context, DeleteInvitationRequest) error
+	GetPendingInvitation(context.Context, GetPendingInvitationRequest) (models.Invitation, error)
+	AcceptInvitation(context.Context, AcceptInvitationRequest) (models.User, error)
 }
@@ -0,0 +1,502 @@
+// Package storetest is a conformance suite for store.Store implementations.
+// Each implementation's tests call Run with a function that returns an empty
+// store, so that DBStore, SQLiteStore and MemoryStore are held to the same
+// behavior and service tests can use MemoryStore in place of Postgres.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/json-multiplex/iam-service/internal/filter"
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+// Run runs the suite. newStore is called once per subtest and must return a
+// store that no other subtest uses.
+func Run(t *testing.T, newStore func(t *testing.T) store.Store) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s store.Store)
+	}{
+		{"CheckPassword", testCheckPassword},
+		{"CheckPasswordMalformedNames", testCheckPasswordMalformedNames},
+		{"MalformedNames", testMalformedNames},
+		{"UserSlugUnique", testUserSlugUnique},
+		{"DeleteUndeleteUser", testDeleteUndeleteUser},
+		{"CreateIdentityRecreatedUser", testCreateIdentityRecreatedUser},
+		{"ListUsersPagination", testListUsersPagination},
+		{"ListUsersFilter", testListUsersFilter},
+		{"EtagMismatch", testEtagMismatch},
+		{"WithTxRollback", testWithTxRollback},
+		{"Invitations", testInvitations},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.fn(t, newStore(t))
+		})
+	}
+}
+
+func testCheckPassword(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	account := createAccount(t, s)
+
+	for _, tt := range []struct {
+		user, password string
+		valid          bool
+	}{
+		{"users/root", "password", true},
+		{"users/root", "wrong", false},
+		{"users/missing", "password", false},
+	} {
+		res, err := s.CheckPassword(ctx, store.CheckPasswordRequest{
+			Account:  account.Name,
+			User:     tt.user,
+			Password: tt.password,
+		})
+
+		if err != nil {
+			t.Fatalf("CheckPassword(%s, %q): %v", tt.user, tt.password, err)
+		}
+
+		if res.Valid != tt.valid {
+			t.Errorf("CheckPassword(%s, %q) = %v, want %v", tt.user, tt.password, res.Valid, tt.valid)
+		}
+	}
+}
+
+func testCheckPasswordMalformedNames(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	account := createAccount(t, s)
+
+	for _, tt := range []struct {
+		account, user string
+		code          codes.Code
+	}{
+		{account.Name, "root", codes.InvalidArgument},
+		{account.Name, "users/", codes.InvalidArgument},
+		{account.Name, "", codes.InvalidArgument},
+		{"accounts", "users/root", codes.InvalidArgument},
+		{"", "users/root", codes.InvalidArgument},
+		{"accounts/not-a-uuid", "users/root", codes.OK},
+	} {
+		_, err := s.CheckPassword(ctx, store.CheckPasswordRequest{
+			Account:  tt.account,
+			User:     tt.user,
+			Password: "password",
+		})
+
+		if got := status.Code(err); got != tt.code {
+			t.Errorf("CheckPassword(%q, %q) = %v, want %v", tt.account, tt.user, err, tt.code)
+		}
+	}
+}
+
+func testMalformedNames(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	accountID := accountID(createAccount(t, s))
+
+	calls := []struct {
+		name string
+		call func(name string) error
+	}{
+		{"GetUser", func(name string) error {
+			_, err := s.GetUser(ctx, store.GetUserRequest{AccountID: accountID, Name: name})
+			return err
+		}},
+		{"CreateUser", func(name string) error {
+			_, err := s.CreateUser(ctx, store.CreateUserRequest{AccountID: accountID, User: models.User{Name: name}})
+			return err
+		}},
+		{"UpdateUser", func(name string) error {
+			_, err := s.UpdateUser(ctx, store.UpdateUserRequest{
+				AccountID:  accountID,
+				User:       models.User{Name: name, DisplayName: "Renamed"},
+				UpdateMask: []string{"display_name"},
+			})
+
+			return err
+		}},
+		{"DeleteUser", func(name string) error {
+			return s.DeleteUser(ctx, store.DeleteUserRequest{AccountID: accountID, Name: name})
+		}},
+		{"ListIdentities", func(name string) error {
+			_, err := s.ListIdentities(ctx, store.ListIdentitiesRequest{AccountID: accountID, Parent: name})
+			return err
+		}},
+		{"CreateIdentity", func(name string) error {
+			_, err := s.CreateIdentity(ctx, store.CreateIdentityRequest{
+				AccountID: accountID,
+				Parent:    name,
+				Identity:  models.Identity{AuthMethod: models.AuthMethodPassword, Password: "password"},
+			})
+
+			return err
+		}},
+	}
+
+	for _, call := range calls {
+		for _, name := range []string{"", "root", "users/", "users/root/identities", "accounts/root"} {
+			if err := call.call(name); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("%s(%q) = %v, want InvalidArgument", call.name, name, err)
+			}
+		}
+	}
+}
+
+func testUserSlugUnique(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	accountID := accountID(createAccount(t, s))
+
+	createUser(t, s, accountID, "users/ann", nil)
+
+	_, err := s.CreateUser(ctx, store.CreateUserRequest{
+		AccountID: accountID,
+		User:      models.User{Name: "users/ann"},
+	})
+
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("CreateUser with a taken name: got %v, want AlreadyExists", err)
+	}
+
+	// Names only need to be unique among live users.
+	if err := s.DeleteUser(ctx, store.DeleteUserRequest{AccountID: accountID, Name: "users/ann"}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	createUser(t, s, accountID, "users/ann", nil)
+}
+
+func testDeleteUndeleteUser(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	accountID := accountID(createAccount(t, s))
+
+	createUser(t, s, accountID, "users/ann", nil)
+
+	if err := s.DeleteUser(ctx, store.DeleteUserRequest{AccountID: accountID, Name: "users/ann"}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	if _, err := s.GetUser(ctx, store.GetUserRequest{AccountID: accountID, Name: "users/ann"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetUser after delete: got %v, want NotFound", err)
+	}
+
+	deleted, err := s.GetUser(ctx, store.GetUserRequest{AccountID: accountID, Name: "users/ann", ShowDeleted: true})
+	if err != nil {
+		t.Fatalf("GetUser with ShowDeleted: %v", err)
+	}
+
+	if deleted.DeleteTime == nil {
+		t.Errorf("GetUser with ShowDeleted: DeleteTime is nil")
+	}
+
+	user, err := s.UndeleteUser(ctx, store.UndeleteUserRequest{
+		AccountID:    accountID,
+		Name:         "users/ann",
+		DeletedAfter: time.Now().Add(-time.Hour),
+	})
+
+	if err != nil {
+		t.Fatalf("UndeleteUser: %v", err)
+	}
+
+	if user.DeleteTime != nil {
+		t.Errorf("UndeleteUser: DeleteTime = %v, want nil", user.DeleteTime)
+	}
+
+	if _, err := s.GetUser(ctx, store.GetUserRequest{AccountID: accountID, Name: "users/ann"}); err != nil {
+		t.Errorf("GetUser after undelete: %v", err)
+	}
+}
+
+func testCreateIdentityRecreatedUser(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	account := createAccount(t, s)
+	accountID := accountID(account)
+
+	createIdentity := func(password string) {
+		t.Helper()
+
+		if _, err := s.CreateIdentity(ctx, store.CreateIdentityRequest{
+			AccountID: accountID,
+			Parent:    "users/ann",
+			Identity:  models.Identity{AuthMethod: models.AuthMethodPassword, Password: password},
+		}); err != nil {
+			t.Fatalf("CreateIdentity: %v", err)
+		}
+	}
+
+	createUser(t, s, accountID, "users/ann", nil)
+	createIdentity("old-password")
+
+	if err := s.DeleteUser(ctx, store.DeleteUserRequest{AccountID: accountID, Name: "users/ann"}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	// The identity must go to the new user, not the deleted one.
+	createUser(t, s, accountID, "users/ann", nil)
+	createIdentity("new-password")
+
+	res, err := s.ListIdentities(ctx, store.ListIdentitiesRequest{AccountID: accountID, Parent: "users/ann"})
+	if err != nil {
+		t.Fatalf("ListIdentities: %v", err)
+	}
+
+	if len(res.Identities) != 1 {
+		t.Errorf("ListIdentities: got %d identities, want 1", len(res.Identities))
+	}
+
+	check, err := s.CheckPassword(ctx, store.CheckPasswordRequest{Account: account.Name, User: "users/ann", Password: "new-password"})
+	if err != nil {
+		t.Fatalf("CheckPassword: %v", err)
+	}
+
+	if !check.Valid {
+		t.Error("CheckPassword with the new user's password: not valid")
+	}
+}
+
+func testListUsersPagination(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	accountID := accountID(createAccount(t, s))
+
+	want := []string{"users/root"}
+	for i := 0; i < 4; i++ {
+		name := fmt.Sprintf("users/user-%d", i)
+		createUser(t, s, accountID, name, nil)
+		want = append(want, name)
+	}
+
+	var got []string
+	var pages int
+	req := store.ListUsersRequest{AccountID: accountID, PageSize: 2}
+	for {
+		res, err := s.ListUsers(ctx, req)
+		if err != nil {
+			t.Fatalf("ListUsers: %v", err)
+		}
+
+		pages++
+		for _, user := range res.Users {
+			got = append(got, user.Name)
+		}
+
+		if res.NextPageToken == "" {
+			break
+		}
+
+		req.PageToken = res.NextPageToken
+	}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ListUsers = %v, want %v", got, want)
+	}
+
+	if pages != 3 {
+		t.Errorf("ListUsers returned %d pages, want 3", pages)
+	}
+}
+
+func testListUsersFilter(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	accountID := accountID(createAccount(t, s))
+
+	createUser(t, s, accountID, "users/ann", map[string]string{"team": "red"})
+	createUser(t, s, accountID, "users/bob", map[string]string{"team": "blue"})
+	createUser(t, s, accountID, "users/cat", map[string]string{"team": "red"})
+
+	expr, err := filter.Parse(`labels.team = "red"`)
+	if err != nil {
+		t.Fatalf("filter.Parse: %v", err)
+	}
+
+	orderBy, err := filter.ParseOrderBy("create_time desc")
+	if err != nil {
+		t.Fatalf("filter.ParseOrderBy: %v", err)
+	}
+
+	res, err := s.ListUsers(ctx, store.ListUsersRequest{AccountID: accountID, Filter: expr, OrderBy: orderBy})
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+
+	var got []string
+	for _, user := range res.Users {
+		got = append(got, user.Name)
+	}
+
+	if want := "users/cat,users/ann"; strings.Join(got, ",") != want {
+		t.Errorf("ListUsers = %v, want %s", got, want)
+	}
+
+	unknown, err := filter.Parse(`shoe_size = "9"`)
+	if err != nil {
+		t.Fatalf("filter.Parse: %v", err)
+	}
+
+	_, err = s.ListUsers(ctx, store.ListUsersRequest{AccountID: accountID, Filter: unknown})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListUsers with an unknown field: got %v, want InvalidArgument", err)
+	}
+}
+
+func testEtagMismatch(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	accountID := accountID(createAccount(t, s))
+
+	user := createUser(t, s, accountID, "users/ann", nil)
+
+	user.DisplayName = "Ann"
+	updated, err := s.UpdateUser(ctx, store.UpdateUserRequest{
+		AccountID:  accountID,
+		User:       user,
+		UpdateMask: []string{"display_name"},
+	})
+
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	if updated.Etag == user.Etag {
+		t.Errorf("UpdateUser didn't change the etag")
+	}
+
+	// user still has the etag from before the update.
+	user.DisplayName = "Annie"
+	_, err = s.UpdateUser(ctx, store.UpdateUserRequest{
+		AccountID:  accountID,
+		User:       user,
+		UpdateMask: []string{"display_name"},
+	})
+
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("UpdateUser with a stale etag: got %v, want Aborted", err)
+	}
+
+	err = s.DeleteUser(ctx, store.DeleteUserRequest{AccountID: accountID, Name: "users/ann", Etag: user.Etag})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("DeleteUser with a stale etag: got %v, want Aborted", err)
+	}
+}
+
+func testWithTxRollback(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	accountID := accountID(createAccount(t, s))
+
+	errRollback := errors.New("rollback")
+	err := s.WithTx(ctx, func(tx store.Store) error {
+		createUser(t, tx, accountID, "users/ann", nil)
+		return errRollback
+	})
+
+	if err != errRollback {
+		t.Fatalf("WithTx = %v, want %v", err, errRollback)
+	}
+
+	if _, err := s.GetUser(ctx, store.GetUserRequest{AccountID: accountID, Name: "users/ann"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetUser after rollback: got %v, want NotFound", err)
+	}
+
+	err = s.WithTx(ctx, func(tx store.Store) error {
+		createUser(t, tx, accountID, "users/ann", nil)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+
+	if _, err := s.GetUser(ctx, store.GetUserRequest{AccountID: accountID, Name: "users/ann"}); err != nil {
+		t.Errorf("GetUser after commit: %v", err)
+	}
+}
+
+func testInvitations(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	account := createAccount(t, s)
+	accountID := accountID(account)
+
+	codeHash := []byte("code hash")
+	if _, err := s.CreateInvitation(ctx, store.CreateInvitationRequest{
+		AccountID: accountID,
+		Invitation: models.Invitation{
+			ExpireTime: time.Now().Add(time.Hour),
+			Email:      "ann@example.com",
+			User:       models.User{Name: "users/ann", DisplayName: "Ann"},
+			Inviter:    "users/root",
+			CodeHash:   codeHash,
+		},
+	}); err != nil {
+		t.Fatalf("CreateInvitation: %v", err)
+	}
+
+	if _, err := s.GetPendingInvitation(ctx, store.GetPendingInvitationRequest{CodeHash: codeHash}); err != nil {
+		t.Fatalf("GetPendingInvitation: %v", err)
+	}
+
+	user, err := s.AcceptInvitation(ctx, store.AcceptInvitationRequest{CodeHash: codeHash, Password: "password"})
+	if err != nil {
+		t.Fatalf("AcceptInvitation: %v", err)
+	}
+
+	if user.Name != "users/ann" || user.Email != "ann@example.com" || !user.EmailVerified {
+		t.Errorf("AcceptInvitation = %+v, want users/ann with a verified email", user)
+	}
+
+	res, err := s.CheckPassword(ctx, store.CheckPasswordRequest{Account: account.Name, User: "users/ann", Password: "password"})
+	if err != nil || !res.Valid {
+		t.Errorf("CheckPassword after AcceptInvitation = %v, %v, want valid", res.Valid, err)
+	}
+
+	// Invitations are single use.
+	_, err = s.AcceptInvitation(ctx, store.AcceptInvitationRequest{CodeHash: codeHash, Password: "password"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("AcceptInvitation twice: got %v, want NotFound", err)
+	}
+}
+
+func createAccount(t *testing.T, s store.Store) models.Account {
+	t.Helper()
+
+	account, err := s.CreateAccount(context.Background(), store.CreateAccountRequest{
+		Account:      models.Account{DisplayName: "Test"},
+		Root:         models.User{Name: "users/root", IsRoot: true},
+		RootPassword: "password",
+	})
+
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+
+	return account
+}
+
+func createUser(t *testing.T, s store.Store, accountID, name string, labels map[string]string) models.User {
+	t.Helper()
+
+	user, err := s.CreateUser(context.Background(), store.CreateUserRequest{
+		AccountID: accountID,
+		User:      models.User{Name: name, Labels: labels},
+	})
+
+	if err != nil {
+		t.Fatalf("CreateUser(%s): %v", name, err)
+	}
+
+	return user
+}
+
+func accountID(account models.Account) string {
+	return strings.TrimPrefix(account.Name, "accounts/")
+}
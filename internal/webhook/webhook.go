@@ -0,0 +1,151 @@
+// Package webhook delivers IAM lifecycle events from the webhook outbox to
+// subscribers.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+const (
+	HeaderEvent     = "X-IAM-Event"
+	HeaderDelivery  = "X-IAM-Delivery"
+	HeaderTimestamp = "X-IAM-Timestamp"
+	HeaderSignature = "X-IAM-Signature"
+)
+
+const (
+	minBackoff = 10 * time.Second
+	maxBackoff = time.Hour
+)
+
+type Dispatcher struct {
+	Store       store.Store
+	Client      *http.Client
+	MaxAttempts int
+	BatchSize   int
+}
+
+// Dispatch attempts every delivery that is currently due. Failed deliveries
+// are rescheduled with exponential backoff, and moved to the dead-letter list
+// once they have used up MaxAttempts.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	deliveries, err := d.Store.ClaimWebhookDeliveries(ctx, store.ClaimWebhookDeliveriesRequest{
+		Limit:         d.BatchSize,
+		LeaseDuration: d.Client.Timeout + time.Minute,
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "error claiming webhook deliveries")
+	}
+
+	for _, delivery := range deliveries {
+		if err := d.deliver(ctx, delivery); err != nil {
+			attempts := delivery.Attempts + 1
+			if err := d.Store.FailWebhookDelivery(ctx, store.FailWebhookDeliveryRequest{
+				Name:            delivery.Name,
+				Error:           err.Error(),
+				NextAttemptTime: time.Now().Add(Backoff(attempts)),
+				Dead:            attempts >= d.MaxAttempts,
+			}); err != nil {
+				return errors.Wrap(err, "error recording failed webhook delivery")
+			}
+
+			continue
+		}
+
+		if err := d.Store.CompleteWebhookDelivery(ctx, store.CompleteWebhookDeliveryRequest{
+			Name: delivery.Name,
+		}); err != nil {
+			return errors.Wrap(err, "error recording webhook delivery")
+		}
+	}
+
+	return nil
+}
+
+// Run calls Dispatch every interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.Dispatch(ctx); err != nil {
+				log.Printf("error dispatching webhooks: %v", err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery models.WebhookDelivery) error {
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.Name)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %s", res.Status)
+	}
+
+	return nil
+}
+
+// Sign returns the signature sent in the X-IAM-Signature header. Receivers
+// should recompute it over the X-IAM-Timestamp header and the raw body, and
+// reject stale timestamps to prevent replays.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns how long to wait before the attempt after the given number
+// of failed attempts.
+func Backoff(attempts int) time.Duration {
+	backoff := minBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return backoff
+}
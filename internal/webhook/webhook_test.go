@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/json-multiplex/iam-service/internal/models"
+	"github.com/json-multiplex/iam-service/internal/passhash"
+	"github.com/json-multiplex/iam-service/internal/store"
+)
+
+// dueNow makes failed deliveries due again immediately, so that retries can
+// be tested without waiting out the backoff.
+type dueNow struct {
+	store.Store
+}
+
+func (s dueNow) FailWebhookDelivery(ctx context.Context, req store.FailWebhookDeliveryRequest) error {
+	req.NextAttemptTime = time.Now()
+	return s.Store.FailWebhookDelivery(ctx, req)
+}
+
+// receiver records the deliveries it gets, failing the first failures of them.
+type receiver struct {
+	failures int
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	if len(r.requests) <= r.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		maxAttempts   int
+		wantAttempts  int
+		wantDelivered bool
+	}{
+		{name: "delivered", failures: 0, maxAttempts: 3, wantAttempts: 1, wantDelivered: true},
+		{name: "retried", failures: 2, maxAttempts: 3, wantAttempts: 3, wantDelivered: true},
+		{name: "dead-lettered", failures: 5, maxAttempts: 3, wantAttempts: 3, wantDelivered: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			rcv := &receiver{failures: tt.failures}
+			server := httptest.NewServer(rcv)
+			defer server.Close()
+
+			st := store.NewMemoryStore(passhash.Bcrypt{Cost: 4})
+			account, err := st.CreateAccount(ctx, store.CreateAccountRequest{
+				Account:      models.Account{DisplayName: "Test"},
+				Root:         models.User{Name: "users/root", IsRoot: true},
+				RootPassword: "password",
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			accountID := strings.TrimPrefix(account.Name, "accounts/")
+			webhook, err := st.CreateWebhook(ctx, store.CreateWebhookRequest{
+				AccountID: accountID,
+				Webhook: models.Webhook{
+					URL:        server.URL,
+					EventTypes: []string{models.WebhookEventUserDeleted},
+					Secret:     "s3cret",
+				},
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := st.EnqueueWebhookEvent(ctx, store.EnqueueWebhookEventRequest{
+				AccountID: accountID,
+				EventType: models.WebhookEventUserDeleted,
+				Resource:  "users/bob",
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			d := Dispatcher{
+				Store:       dueNow{st},
+				Client:      &http.Client{Timeout: 5 * time.Second},
+				MaxAttempts: tt.maxAttempts,
+				BatchSize:   10,
+			}
+
+			for i := 0; i < tt.maxAttempts+2; i++ {
+				if err := d.Dispatch(ctx); err != nil {
+					t.Fatalf("Dispatch: %v", err)
+				}
+			}
+
+			if len(rcv.requests) != tt.wantAttempts {
+				t.Fatalf("got %d attempts, want %d", len(rcv.requests), tt.wantAttempts)
+			}
+
+			for i, req := range rcv.requests {
+				timestamp, err := strconv.ParseInt(req.Header.Get(HeaderTimestamp), 10, 64)
+				if err != nil {
+					t.Fatalf("invalid %s: %v", HeaderTimestamp, err)
+				}
+
+				if got, want := req.Header.Get(HeaderSignature), Sign("s3cret", timestamp, rcv.bodies[i]); got != want {
+					t.Errorf("attempt %d: signature %q, want %q", i+1, got, want)
+				}
+
+				if got := req.Header.Get(HeaderEvent); got != models.WebhookEventUserDeleted {
+					t.Errorf("attempt %d: event %q, want %q", i+1, got, models.WebhookEventUserDeleted)
+				}
+			}
+
+			deadLetters, err := st.ListDeadLetters(ctx, store.ListDeadLettersRequest{
+				AccountID: accountID,
+				Parent:    webhook.Name,
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dead := len(deadLetters.DeadLetters) == 1; dead == tt.wantDelivered {
+				t.Errorf("got dead letters %+v, want delivered %v", deadLetters.DeadLetters, tt.wantDelivered)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		secret    string
+		timestamp int64
+		payload   string
+		other     string
+	}{
+		{secret: "a", timestamp: 1, payload: "{}", other: "{ }"},
+		{secret: "b", timestamp: 1554120000, payload: `{"type":"user.created"}`, other: `{"type":"user.deleted"}`},
+	}
+
+	for _, tt := range tests {
+		sig := Sign(tt.secret, tt.timestamp, []byte(tt.payload))
+		if !strings.HasPrefix(sig, "sha256=") || len(sig) != len("sha256=")+64 {
+			t.Errorf("Sign() = %q, want sha256= and a hex SHA-256 MAC", sig)
+		}
+
+		if sig == Sign(tt.secret+"x", tt.timestamp, []byte(tt.payload)) ||
+			sig == Sign(tt.secret, tt.timestamp+1, []byte(tt.payload)) ||
+			sig == Sign(tt.secret, tt.timestamp, []byte(tt.other)) {
+			t.Errorf("Sign(%q, %d, %q) doesn't depend on all of its inputs", tt.secret, tt.timestamp, tt.payload)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 10 * time.Second},
+		{attempts: 2, want: 20 * time.Second},
+		{attempts: 4, want: 80 * time.Second},
+		{attempts: 9, want: 2560 * time.Second},
+		{attempts: 10, want: time.Hour},
+		{attempts: 100, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+CREATE TYPE audit_outcome AS ENUM('success', 'failure');
+
+CREATE TABLE audit_events (
+  id UUID NOT NULL PRIMARY KEY,
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  account_id UUID,
+  actor TEXT NOT NULL,
+  resource TEXT NOT NULL,
+  method TEXT NOT NULL,
+  source_ip TEXT NOT NULL,
+  outcome audit_outcome NOT NULL
+);
+
+CREATE INDEX audit_events_account_id_create_time_idx ON audit_events(account_id, create_time);
@@ -0,0 +1,6 @@
+DROP TABLE audit_checkpoints;
+DROP INDEX audit_events_chain_idx;
+ALTER TABLE audit_events
+  DROP COLUMN hash,
+  DROP COLUMN prev_hash,
+  DROP COLUMN sequence;
@@ -0,0 +1,17 @@
+ALTER TABLE audit_events
+  ADD COLUMN sequence BIGINT,
+  ADD COLUMN prev_hash BYTEA,
+  ADD COLUMN hash BYTEA;
+
+CREATE UNIQUE INDEX audit_events_chain_idx ON audit_events(
+  COALESCE(account_id, '00000000-0000-0000-0000-000000000000'), sequence
+) WHERE sequence IS NOT NULL;
+
+CREATE TABLE audit_checkpoints (
+  id UUID NOT NULL PRIMARY KEY,
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  account_id UUID,
+  sequence BIGINT NOT NULL,
+  hash BYTEA NOT NULL,
+  signature BYTEA NOT NULL
+);
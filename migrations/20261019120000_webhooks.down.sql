@@ -0,0 +1,3 @@
+DROP TABLE webhook_deliveries;
+DROP TYPE webhook_delivery_state;
+DROP TABLE webhooks;
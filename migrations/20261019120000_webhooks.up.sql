@@ -0,0 +1,30 @@
+CREATE TABLE webhooks (
+  id UUID NOT NULL PRIMARY KEY,
+  account_id UUID NOT NULL REFERENCES accounts(id),
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  update_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  delete_time TIMESTAMP WITH TIME ZONE,
+  url TEXT NOT NULL,
+  event_types TEXT[] NOT NULL,
+  secret TEXT NOT NULL
+);
+
+CREATE INDEX webhooks_account_id_idx ON webhooks(account_id);
+
+CREATE TYPE webhook_delivery_state AS ENUM('pending', 'delivered', 'dead');
+
+CREATE TABLE webhook_deliveries (
+  id UUID NOT NULL PRIMARY KEY,
+  webhook_id UUID NOT NULL REFERENCES webhooks(id),
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  update_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  state webhook_delivery_state NOT NULL,
+  attempts INTEGER NOT NULL,
+  next_attempt_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  last_error TEXT NOT NULL
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries(next_attempt_time) WHERE state = 'pending';
+CREATE INDEX webhook_deliveries_webhook_id_state_idx ON webhook_deliveries(webhook_id, state);
@@ -0,0 +1,6 @@
+CREATE TABLE login_throttles (
+  key TEXT NOT NULL PRIMARY KEY,
+  failures INTEGER NOT NULL,
+  last_failure_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  locked_until TIMESTAMP WITH TIME ZONE
+);
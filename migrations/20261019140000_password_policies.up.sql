@@ -0,0 +1,20 @@
+CREATE TABLE password_policies (
+  account_id UUID NOT NULL PRIMARY KEY REFERENCES accounts(id),
+  update_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  min_length INTEGER NOT NULL,
+  require_uppercase BOOLEAN NOT NULL,
+  require_lowercase BOOLEAN NOT NULL,
+  require_digit BOOLEAN NOT NULL,
+  require_symbol BOOLEAN NOT NULL,
+  history_size INTEGER NOT NULL,
+  max_age_seconds BIGINT NOT NULL
+);
+
+CREATE TABLE password_history (
+  id UUID NOT NULL PRIMARY KEY,
+  identity_id UUID NOT NULL REFERENCES identities(id),
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  password_hash TEXT NOT NULL
+);
+
+CREATE INDEX password_history_identity_id_idx ON password_history(identity_id, create_time);
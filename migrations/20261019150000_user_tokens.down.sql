@@ -0,0 +1,4 @@
+DROP TABLE user_tokens;
+DROP TYPE user_token_purpose;
+ALTER TABLE users DROP COLUMN email_verified;
+ALTER TABLE users DROP COLUMN email;
@@ -0,0 +1,17 @@
+ALTER TABLE users ADD COLUMN email TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TYPE user_token_purpose AS ENUM('password_reset', 'email_verification');
+
+CREATE TABLE user_tokens (
+  id UUID NOT NULL PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id),
+  purpose user_token_purpose NOT NULL,
+  token_hash BYTEA NOT NULL UNIQUE,
+  email TEXT NOT NULL,
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  expire_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  consume_time TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX user_tokens_user_id_idx ON user_tokens(user_id);
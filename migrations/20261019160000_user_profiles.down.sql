@@ -0,0 +1,6 @@
+DROP INDEX users_labels_idx;
+
+ALTER TABLE users DROP COLUMN annotations;
+ALTER TABLE users DROP COLUMN labels;
+ALTER TABLE users DROP COLUMN locale;
+ALTER TABLE users DROP COLUMN phone;
@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN phone TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN locale TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN labels JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE users ADD COLUMN annotations JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX users_labels_idx ON users USING GIN (labels);
@@ -0,0 +1,3 @@
+ALTER TABLE identities DROP COLUMN version;
+ALTER TABLE users DROP COLUMN version;
+ALTER TABLE accounts DROP COLUMN version;
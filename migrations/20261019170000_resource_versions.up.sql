@@ -0,0 +1,3 @@
+ALTER TABLE accounts ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE identities ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,5 @@
+ALTER TABLE audit_events
+  DROP COLUMN resource_digest,
+  DROP COLUMN actor_digest;
+
+ALTER TABLE users DROP COLUMN erase_time;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN erase_time TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE audit_events
+  ADD COLUMN actor_digest BYTEA,
+  ADD COLUMN resource_digest BYTEA;
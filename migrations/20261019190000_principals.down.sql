@@ -0,0 +1,12 @@
+DELETE FROM password_history WHERE identity_id IN (SELECT id FROM identities WHERE principal_id IS NOT NULL);
+DELETE FROM identities WHERE principal_id IS NOT NULL;
+
+ALTER TABLE identities
+  DROP CONSTRAINT identities_owner_check,
+  DROP COLUMN principal_id,
+  ALTER COLUMN user_id SET NOT NULL;
+
+DROP INDEX users_principal_id_idx;
+ALTER TABLE users DROP COLUMN principal_id;
+
+DROP TABLE principals;
@@ -0,0 +1,18 @@
+CREATE TABLE principals (
+  id UUID NOT NULL PRIMARY KEY,
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  update_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  delete_time TIMESTAMP WITH TIME ZONE,
+  display_name TEXT NOT NULL,
+  email TEXT NOT NULL,
+  version BIGINT NOT NULL DEFAULT 1
+);
+
+ALTER TABLE users ADD COLUMN principal_id UUID REFERENCES principals(id);
+
+CREATE INDEX users_principal_id_idx ON users(principal_id) WHERE principal_id IS NOT NULL;
+
+ALTER TABLE identities
+  ALTER COLUMN user_id DROP NOT NULL,
+  ADD COLUMN principal_id UUID REFERENCES principals(id),
+  ADD CONSTRAINT identities_owner_check CHECK ((user_id IS NULL) <> (principal_id IS NULL));
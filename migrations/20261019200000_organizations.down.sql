@@ -0,0 +1,6 @@
+DROP INDEX accounts_organization_id_idx;
+ALTER TABLE accounts DROP COLUMN organization_id;
+
+DROP TABLE organization_password_policies;
+DROP TABLE organization_admins;
+DROP TABLE organizations;
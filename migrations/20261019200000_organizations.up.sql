@@ -0,0 +1,32 @@
+CREATE TABLE organizations (
+  id UUID NOT NULL PRIMARY KEY,
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  update_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  delete_time TIMESTAMP WITH TIME ZONE,
+  display_name TEXT NOT NULL,
+  require_mfa BOOLEAN NOT NULL DEFAULT FALSE,
+  version BIGINT NOT NULL DEFAULT 1
+);
+
+CREATE TABLE organization_admins (
+  organization_id UUID NOT NULL REFERENCES organizations(id),
+  principal_id UUID NOT NULL REFERENCES principals(id),
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (organization_id, principal_id)
+);
+
+CREATE TABLE organization_password_policies (
+  organization_id UUID NOT NULL PRIMARY KEY REFERENCES organizations(id),
+  update_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  min_length INTEGER NOT NULL,
+  require_uppercase BOOLEAN NOT NULL,
+  require_lowercase BOOLEAN NOT NULL,
+  require_digit BOOLEAN NOT NULL,
+  require_symbol BOOLEAN NOT NULL,
+  history_size INTEGER NOT NULL,
+  max_age_seconds BIGINT NOT NULL
+);
+
+ALTER TABLE accounts ADD COLUMN organization_id UUID REFERENCES organizations(id);
+
+CREATE INDEX accounts_organization_id_idx ON accounts(organization_id) WHERE organization_id IS NOT NULL;
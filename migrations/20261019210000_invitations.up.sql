@@ -0,0 +1,18 @@
+CREATE TABLE invitations (
+  id UUID NOT NULL PRIMARY KEY,
+  account_id UUID NOT NULL REFERENCES accounts(id),
+  create_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  update_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  delete_time TIMESTAMP WITH TIME ZONE,
+  expire_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  accept_time TIMESTAMP WITH TIME ZONE,
+  email TEXT NOT NULL,
+  slug TEXT NOT NULL,
+  display_name TEXT NOT NULL,
+  labels JSONB NOT NULL DEFAULT '{}',
+  inviter TEXT NOT NULL,
+  code_hash BYTEA NOT NULL UNIQUE,
+  user_id UUID REFERENCES users(id)
+);
+
+CREATE INDEX invitations_account_id_idx ON invitations(account_id);
@@ -0,0 +1,8 @@
+DROP INDEX organization_admins_principal_id_idx;
+
+DROP INDEX identities_principal_id_idx;
+DROP INDEX identities_user_id_idx;
+
+DROP INDEX users_account_id_create_time_idx;
+DROP INDEX users_account_id_principal_id_idx;
+DROP INDEX users_account_id_slug_idx;
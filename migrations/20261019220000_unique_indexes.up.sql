@@ -0,0 +1,57 @@
+-- Before this migration, live users could share a slug, or a principal could
+-- be linked to several live users in one account. Which of them to keep is for
+-- an operator to decide, so refuse to migrate until the duplicates are gone.
+-- This query lists them:
+--
+--   SELECT account_id, slug, principal_id, id, create_time
+--   FROM users u
+--   WHERE delete_time IS NULL AND (
+--     EXISTS (SELECT 1 FROM users d WHERE d.delete_time IS NULL AND d.id <> u.id
+--       AND d.account_id = u.account_id AND d.slug = u.slug) OR
+--     EXISTS (SELECT 1 FROM users d WHERE d.delete_time IS NULL AND d.id <> u.id
+--       AND d.account_id = u.account_id AND d.principal_id = u.principal_id))
+--   ORDER BY account_id, slug, create_time;
+--
+-- Then delete or rename the extra users through the API, or unlink their
+-- principals, and run the migration again.
+DO $$
+DECLARE
+  duplicates text;
+BEGIN
+  SELECT string_agg(format('account %s: %s (%s)', account_id, conflict, ids), E'\n')
+  INTO duplicates
+  FROM (
+    SELECT account_id, format('%s live users with slug %L', count(*), slug) AS conflict,
+      string_agg(id::text, ', ' ORDER BY create_time) AS ids
+    FROM users
+    WHERE delete_time IS NULL
+    GROUP BY account_id, slug
+    HAVING count(*) > 1
+    UNION ALL
+    SELECT account_id, format('%s live users linked to principal %s', count(*), principal_id),
+      string_agg(id::text, ', ' ORDER BY create_time)
+    FROM users
+    WHERE delete_time IS NULL AND principal_id IS NOT NULL
+    GROUP BY account_id, principal_id
+    HAVING count(*) > 1
+    ORDER BY 1, 2
+    LIMIT 100
+  ) AS conflicts;
+
+  IF duplicates IS NOT NULL THEN
+    RAISE EXCEPTION 'live users conflict with the new unique indexes'
+      USING DETAIL = duplicates,
+            HINT = 'See 20261019220000_unique_indexes.up.sql for a query listing every conflicting user, and remove the duplicates before migrating.';
+  END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX users_account_id_slug_idx ON users(account_id, slug) WHERE delete_time IS NULL;
+CREATE UNIQUE INDEX users_account_id_principal_id_idx ON users(account_id, principal_id)
+  WHERE principal_id IS NOT NULL AND delete_time IS NULL;
+CREATE INDEX users_account_id_create_time_idx ON users(account_id, create_time);
+
+CREATE INDEX identities_user_id_idx ON identities(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX identities_principal_id_idx ON identities(principal_id) WHERE principal_id IS NOT NULL;
+
+CREATE INDEX organization_admins_principal_id_idx ON organization_admins(principal_id);
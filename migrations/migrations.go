@@ -0,0 +1,9 @@
+// Package migrations embeds the database schema migrations, so that the iam
+// binary can apply them. Each migration is a pair of files named
+// {version}_{name}.up.sql and {version}_{name}.down.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,16 @@
+DROP TABLE invitations;
+DROP TABLE user_tokens;
+DROP TABLE login_throttles;
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
+DROP TABLE audit_checkpoints;
+DROP TABLE audit_events;
+DROP TABLE password_history;
+DROP TABLE password_policies;
+DROP TABLE identities;
+DROP TABLE users;
+DROP TABLE accounts;
+DROP TABLE organization_password_policies;
+DROP TABLE organization_admins;
+DROP TABLE organizations;
+DROP TABLE principals;
@@ -0,0 +1,218 @@
+-- SQLite has no UUID, enum, array or JSONB types. UUIDs are stored as their
+-- canonical text, enums as text with a CHECK constraint, and JSON as text.
+-- Timestamps are UTC text, which the store always writes in the same format
+-- so that they compare correctly as strings.
+
+CREATE TABLE principals (
+  id TEXT NOT NULL PRIMARY KEY,
+  create_time TIMESTAMP NOT NULL,
+  update_time TIMESTAMP NOT NULL,
+  delete_time TIMESTAMP,
+  display_name TEXT NOT NULL,
+  email TEXT NOT NULL,
+  version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE organizations (
+  id TEXT NOT NULL PRIMARY KEY,
+  create_time TIMESTAMP NOT NULL,
+  update_time TIMESTAMP NOT NULL,
+  delete_time TIMESTAMP,
+  display_name TEXT NOT NULL,
+  require_mfa BOOLEAN NOT NULL DEFAULT FALSE,
+  version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE organization_admins (
+  organization_id TEXT NOT NULL REFERENCES organizations(id),
+  principal_id TEXT NOT NULL REFERENCES principals(id),
+  create_time TIMESTAMP NOT NULL,
+  PRIMARY KEY (organization_id, principal_id)
+);
+
+CREATE INDEX organization_admins_principal_id_idx ON organization_admins(principal_id);
+
+CREATE TABLE organization_password_policies (
+  organization_id TEXT NOT NULL PRIMARY KEY REFERENCES organizations(id),
+  update_time TIMESTAMP NOT NULL,
+  min_length INTEGER NOT NULL,
+  require_uppercase BOOLEAN NOT NULL,
+  require_lowercase BOOLEAN NOT NULL,
+  require_digit BOOLEAN NOT NULL,
+  require_symbol BOOLEAN NOT NULL,
+  history_size INTEGER NOT NULL,
+  max_age_seconds INTEGER NOT NULL
+);
+
+CREATE TABLE accounts (
+  id TEXT NOT NULL PRIMARY KEY,
+  create_time TIMESTAMP NOT NULL,
+  update_time TIMESTAMP NOT NULL,
+  delete_time TIMESTAMP,
+  display_name TEXT NOT NULL,
+  version INTEGER NOT NULL DEFAULT 1,
+  organization_id TEXT REFERENCES organizations(id)
+);
+
+CREATE INDEX accounts_organization_id_idx ON accounts(organization_id) WHERE organization_id IS NOT NULL;
+
+CREATE TABLE users (
+  id TEXT NOT NULL PRIMARY KEY,
+  slug TEXT NOT NULL,
+  account_id TEXT NOT NULL REFERENCES accounts(id),
+  create_time TIMESTAMP NOT NULL,
+  update_time TIMESTAMP NOT NULL,
+  delete_time TIMESTAMP,
+  is_root BOOLEAN NOT NULL,
+  display_name TEXT NOT NULL,
+  email TEXT NOT NULL DEFAULT '',
+  email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+  phone TEXT NOT NULL DEFAULT '',
+  locale TEXT NOT NULL DEFAULT '',
+  labels TEXT NOT NULL DEFAULT '{}',
+  annotations TEXT NOT NULL DEFAULT '{}',
+  version INTEGER NOT NULL DEFAULT 1,
+  erase_time TIMESTAMP,
+  principal_id TEXT REFERENCES principals(id)
+);
+
+CREATE UNIQUE INDEX users_account_id_slug_idx ON users(account_id, slug) WHERE delete_time IS NULL;
+CREATE UNIQUE INDEX users_account_id_principal_id_idx ON users(account_id, principal_id)
+  WHERE principal_id IS NOT NULL AND delete_time IS NULL;
+CREATE INDEX users_account_id_create_time_idx ON users(account_id, create_time);
+CREATE INDEX users_principal_id_idx ON users(principal_id) WHERE principal_id IS NOT NULL;
+
+CREATE TABLE identities (
+  id TEXT NOT NULL PRIMARY KEY,
+  user_id TEXT REFERENCES users(id),
+  principal_id TEXT REFERENCES principals(id),
+  create_time TIMESTAMP NOT NULL,
+  update_time TIMESTAMP NOT NULL,
+  delete_time TIMESTAMP,
+  auth_method TEXT NOT NULL CHECK (auth_method IN ('password')),
+  password_hash TEXT,
+  version INTEGER NOT NULL DEFAULT 1,
+  CHECK ((user_id IS NULL) <> (principal_id IS NULL))
+);
+
+CREATE INDEX identities_user_id_idx ON identities(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX identities_principal_id_idx ON identities(principal_id) WHERE principal_id IS NOT NULL;
+
+CREATE TABLE password_policies (
+  account_id TEXT NOT NULL PRIMARY KEY REFERENCES accounts(id),
+  update_time TIMESTAMP NOT NULL,
+  min_length INTEGER NOT NULL,
+  require_uppercase BOOLEAN NOT NULL,
+  require_lowercase BOOLEAN NOT NULL,
+  require_digit BOOLEAN NOT NULL,
+  require_symbol BOOLEAN NOT NULL,
+  history_size INTEGER NOT NULL,
+  max_age_seconds INTEGER NOT NULL
+);
+
+CREATE TABLE password_history (
+  id TEXT NOT NULL PRIMARY KEY,
+  identity_id TEXT NOT NULL REFERENCES identities(id),
+  create_time TIMESTAMP NOT NULL,
+  password_hash TEXT NOT NULL
+);
+
+CREATE INDEX password_history_identity_id_idx ON password_history(identity_id, create_time);
+
+CREATE TABLE audit_events (
+  id TEXT NOT NULL PRIMARY KEY,
+  create_time TIMESTAMP NOT NULL,
+  account_id TEXT,
+  actor TEXT NOT NULL,
+  resource TEXT NOT NULL,
+  method TEXT NOT NULL,
+  source_ip TEXT NOT NULL,
+  outcome TEXT NOT NULL CHECK (outcome IN ('success', 'failure')),
+  sequence INTEGER,
+  prev_hash BLOB,
+  hash BLOB,
+  actor_digest BLOB,
+  resource_digest BLOB
+);
+
+CREATE INDEX audit_events_account_id_create_time_idx ON audit_events(account_id, create_time);
+CREATE UNIQUE INDEX audit_events_chain_idx ON audit_events(
+  COALESCE(account_id, '00000000-0000-0000-0000-000000000000'), sequence
+) WHERE sequence IS NOT NULL;
+
+CREATE TABLE audit_checkpoints (
+  id TEXT NOT NULL PRIMARY KEY,
+  create_time TIMESTAMP NOT NULL,
+  account_id TEXT,
+  sequence INTEGER NOT NULL,
+  hash BLOB NOT NULL,
+  signature BLOB NOT NULL
+);
+
+CREATE TABLE webhooks (
+  id TEXT NOT NULL PRIMARY KEY,
+  account_id TEXT NOT NULL REFERENCES accounts(id),
+  create_time TIMESTAMP NOT NULL,
+  update_time TIMESTAMP NOT NULL,
+  delete_time TIMESTAMP,
+  url TEXT NOT NULL,
+  event_types TEXT NOT NULL,
+  secret TEXT NOT NULL
+);
+
+CREATE INDEX webhooks_account_id_idx ON webhooks(account_id);
+
+CREATE TABLE webhook_deliveries (
+  id TEXT NOT NULL PRIMARY KEY,
+  webhook_id TEXT NOT NULL REFERENCES webhooks(id),
+  create_time TIMESTAMP NOT NULL,
+  update_time TIMESTAMP NOT NULL,
+  event_type TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  state TEXT NOT NULL CHECK (state IN ('pending', 'delivered', 'dead')),
+  attempts INTEGER NOT NULL,
+  next_attempt_time TIMESTAMP NOT NULL,
+  last_error TEXT NOT NULL
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries(next_attempt_time) WHERE state = 'pending';
+CREATE INDEX webhook_deliveries_webhook_id_state_idx ON webhook_deliveries(webhook_id, state);
+
+CREATE TABLE login_throttles (
+  key TEXT NOT NULL PRIMARY KEY,
+  failures INTEGER NOT NULL,
+  last_failure_time TIMESTAMP NOT NULL,
+  locked_until TIMESTAMP
+);
+
+CREATE TABLE user_tokens (
+  id TEXT NOT NULL PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(id),
+  purpose TEXT NOT NULL CHECK (purpose IN ('password_reset', 'email_verification')),
+  token_hash BLOB NOT NULL UNIQUE,
+  email TEXT NOT NULL,
+  create_time TIMESTAMP NOT NULL,
+  expire_time TIMESTAMP NOT NULL,
+  consume_time TIMESTAMP
+);
+
+CREATE INDEX user_tokens_user_id_idx ON user_tokens(user_id);
+
+CREATE TABLE invitations (
+  id TEXT NOT NULL PRIMARY KEY,
+  account_id TEXT NOT NULL REFERENCES accounts(id),
+  create_time TIMESTAMP NOT NULL,
+  update_time TIMESTAMP NOT NULL,
+  delete_time TIMESTAMP,
+  expire_time TIMESTAMP NOT NULL,
+  accept_time TIMESTAMP,
+  email TEXT NOT NULL,
+  slug TEXT NOT NULL,
+  display_name TEXT NOT NULL,
+  labels TEXT NOT NULL DEFAULT '{}',
+  inviter TEXT NOT NULL,
+  code_hash BLOB NOT NULL UNIQUE,
+  user_id TEXT REFERENCES users(id)
+);
+
+CREATE INDEX invitations_account_id_idx ON invitations(account_id);
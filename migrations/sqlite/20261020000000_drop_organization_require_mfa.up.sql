@@ -0,0 +1,3 @@
+-- require_mfa was never enforced, so drop it until multi-factor authentication
+-- exists.
+ALTER TABLE organizations DROP COLUMN require_mfa;
@@ -0,0 +1,9 @@
+// Package sqlite embeds the schema migrations for SQLite databases. They
+// follow the same naming as the Postgres migrations in the parent directory,
+// but start from the schema those migrations had built up to.
+package sqlite
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS